@@ -260,6 +260,7 @@ type DeviceInfo struct {
 	IsOnline      bool                   `protobuf:"varint,7,opt,name=is_online,json=isOnline,proto3" json:"is_online,omitempty"`
 	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	PushProvider  string                 `protobuf:"bytes,10,opt,name=push_provider,json=pushProvider,proto3" json:"push_provider,omitempty"` // apns, fcm, webpush
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -357,6 +358,13 @@ func (x *DeviceInfo) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *DeviceInfo) GetPushProvider() string {
+	if x != nil {
+		return x.PushProvider
+	}
+	return ""
+}
+
 // Token 信息
 type TokenInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -524,6 +532,7 @@ type RegisterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
 	Data          *RegisterData          `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Violations    []*PasswordViolation   `protobuf:"bytes,3,rep,name=violations,proto3" json:"violations,omitempty"` // 密码不符合策略时返回
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -572,6 +581,66 @@ func (x *RegisterResponse) GetData() *RegisterData {
 	return nil
 }
 
+func (x *RegisterResponse) GetViolations() []*PasswordViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+// 密码策略违规项
+type PasswordViolation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // too_short, too_long, missing_lowercase, missing_uppercase, missing_digit, missing_symbol, blocklisted, reused
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PasswordViolation) Reset() {
+	*x = PasswordViolation{}
+	mi := &file_auth_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasswordViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasswordViolation) ProtoMessage() {}
+
+func (x *PasswordViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasswordViolation.ProtoReflect.Descriptor instead.
+func (*PasswordViolation) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PasswordViolation) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *PasswordViolation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type RegisterData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *UserInfo              `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -583,7 +652,7 @@ type RegisterData struct {
 
 func (x *RegisterData) Reset() {
 	*x = RegisterData{}
-	mi := &file_auth_proto_msgTypes[6]
+	mi := &file_auth_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -595,7 +664,7 @@ func (x *RegisterData) String() string {
 func (*RegisterData) ProtoMessage() {}
 
 func (x *RegisterData) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[6]
+	mi := &file_auth_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -608,7 +677,7 @@ func (x *RegisterData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterData.ProtoReflect.Descriptor instead.
 func (*RegisterData) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{6}
+	return file_auth_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RegisterData) GetUser() *UserInfo {
@@ -651,7 +720,7 @@ type LoginRequest struct {
 
 func (x *LoginRequest) Reset() {
 	*x = LoginRequest{}
-	mi := &file_auth_proto_msgTypes[7]
+	mi := &file_auth_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -663,7 +732,7 @@ func (x *LoginRequest) String() string {
 func (*LoginRequest) ProtoMessage() {}
 
 func (x *LoginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[7]
+	mi := &file_auth_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -676,7 +745,7 @@ func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
 func (*LoginRequest) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{7}
+	return file_auth_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *LoginRequest) GetCredential() isLoginRequest_Credential {
@@ -765,16 +834,18 @@ func (*LoginRequest_Username) isLoginRequest_Credential() {}
 
 // 登录响应
 type LoginResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Data          *LoginData             `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState   `protogen:"open.v1"`
+	Response       *Response                `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Data           *LoginData               `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	TwoFactor      *TwoFactorChallenge      `protobuf:"bytes,3,opt,name=two_factor,json=twoFactor,proto3" json:"two_factor,omitempty"`                // 账号启用两步验证时返回, 此时data只包含user
+	DeviceApproval *DeviceApprovalChallenge `protobuf:"bytes,4,opt,name=device_approval,json=deviceApproval,proto3" json:"device_approval,omitempty"` // 新设备需要已登录设备批准时返回, 此时data只包含user
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *LoginResponse) Reset() {
 	*x = LoginResponse{}
-	mi := &file_auth_proto_msgTypes[8]
+	mi := &file_auth_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -786,7 +857,7 @@ func (x *LoginResponse) String() string {
 func (*LoginResponse) ProtoMessage() {}
 
 func (x *LoginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[8]
+	mi := &file_auth_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -799,7 +870,7 @@ func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
 func (*LoginResponse) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{8}
+	return file_auth_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *LoginResponse) GetResponse() *Response {
@@ -816,6 +887,73 @@ func (x *LoginResponse) GetData() *LoginData {
 	return nil
 }
 
+func (x *LoginResponse) GetTwoFactor() *TwoFactorChallenge {
+	if x != nil {
+		return x.TwoFactor
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetDeviceApproval() *DeviceApprovalChallenge {
+	if x != nil {
+		return x.DeviceApproval
+	}
+	return nil
+}
+
+// 两步验证挑战
+type TwoFactorChallenge struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeToken string                 `protobuf:"bytes,1,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"`
+	ExpiresIn      int64                  `protobuf:"varint,2,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TwoFactorChallenge) Reset() {
+	*x = TwoFactorChallenge{}
+	mi := &file_auth_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TwoFactorChallenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TwoFactorChallenge) ProtoMessage() {}
+
+func (x *TwoFactorChallenge) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TwoFactorChallenge.ProtoReflect.Descriptor instead.
+func (*TwoFactorChallenge) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TwoFactorChallenge) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+func (x *TwoFactorChallenge) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
 type LoginData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *UserInfo              `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -827,7 +965,7 @@ type LoginData struct {
 
 func (x *LoginData) Reset() {
 	*x = LoginData{}
-	mi := &file_auth_proto_msgTypes[9]
+	mi := &file_auth_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -839,7 +977,7 @@ func (x *LoginData) String() string {
 func (*LoginData) ProtoMessage() {}
 
 func (x *LoginData) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[9]
+	mi := &file_auth_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -852,7 +990,7 @@ func (x *LoginData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginData.ProtoReflect.Descriptor instead.
 func (*LoginData) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{9}
+	return file_auth_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *LoginData) GetUser() *UserInfo {
@@ -876,29 +1014,33 @@ func (x *LoginData) GetToken() *TokenInfo {
 	return nil
 }
 
-// 刷新Token请求
-type RefreshTokenRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+// 发送验证码请求
+type SendCodeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Target:
+	//
+	//	*SendCodeRequest_Phone
+	//	*SendCodeRequest_Email
+	Target        isSendCodeRequest_Target `protobuf_oneof:"target"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RefreshTokenRequest) Reset() {
-	*x = RefreshTokenRequest{}
-	mi := &file_auth_proto_msgTypes[10]
+func (x *SendCodeRequest) Reset() {
+	*x = SendCodeRequest{}
+	mi := &file_auth_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshTokenRequest) String() string {
+func (x *SendCodeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshTokenRequest) ProtoMessage() {}
+func (*SendCodeRequest) ProtoMessage() {}
 
-func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[10]
+func (x *SendCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -909,42 +1051,76 @@ func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
-func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use SendCodeRequest.ProtoReflect.Descriptor instead.
+func (*SendCodeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *RefreshTokenRequest) GetRefreshToken() string {
+func (x *SendCodeRequest) GetTarget() isSendCodeRequest_Target {
 	if x != nil {
-		return x.RefreshToken
+		return x.Target
+	}
+	return nil
+}
+
+func (x *SendCodeRequest) GetPhone() string {
+	if x != nil {
+		if x, ok := x.Target.(*SendCodeRequest_Phone); ok {
+			return x.Phone
+		}
 	}
 	return ""
 }
 
-// 刷新Token响应
-type RefreshTokenResponse struct {
+func (x *SendCodeRequest) GetEmail() string {
+	if x != nil {
+		if x, ok := x.Target.(*SendCodeRequest_Email); ok {
+			return x.Email
+		}
+	}
+	return ""
+}
+
+type isSendCodeRequest_Target interface {
+	isSendCodeRequest_Target()
+}
+
+type SendCodeRequest_Phone struct {
+	Phone string `protobuf:"bytes,1,opt,name=phone,proto3,oneof"`
+}
+
+type SendCodeRequest_Email struct {
+	Email string `protobuf:"bytes,2,opt,name=email,proto3,oneof"`
+}
+
+func (*SendCodeRequest_Phone) isSendCodeRequest_Target() {}
+
+func (*SendCodeRequest_Email) isSendCodeRequest_Target() {}
+
+// 发送验证码响应
+type SendCodeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Token         *TokenInfo             `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Data          *SendCodeData          `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RefreshTokenResponse) Reset() {
-	*x = RefreshTokenResponse{}
-	mi := &file_auth_proto_msgTypes[11]
+func (x *SendCodeResponse) Reset() {
+	*x = SendCodeResponse{}
+	mi := &file_auth_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshTokenResponse) String() string {
+func (x *SendCodeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshTokenResponse) ProtoMessage() {}
+func (*SendCodeResponse) ProtoMessage() {}
 
-func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[11]
+func (x *SendCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -955,48 +1131,48 @@ func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
-func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use SendCodeResponse.ProtoReflect.Descriptor instead.
+func (*SendCodeResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *RefreshTokenResponse) GetResponse() *Response {
+func (x *SendCodeResponse) GetResponse() *Response {
 	if x != nil {
 		return x.Response
 	}
 	return nil
 }
 
-func (x *RefreshTokenResponse) GetToken() *TokenInfo {
+func (x *SendCodeResponse) GetData() *SendCodeData {
 	if x != nil {
-		return x.Token
+		return x.Data
 	}
 	return nil
 }
 
-// 注销请求
-type LogoutRequest struct {
+type SendCodeData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeviceToken   string                 `protobuf:"bytes,1,opt,name=device_token,json=deviceToken,proto3" json:"device_token,omitempty"`
+	ExpiresIn     int64                  `protobuf:"varint,1,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`       // 验证码有效期(秒)
+	ResendAfter   int64                  `protobuf:"varint,2,opt,name=resend_after,json=resendAfter,proto3" json:"resend_after,omitempty"` // 可重新发送的等待时间(秒)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LogoutRequest) Reset() {
-	*x = LogoutRequest{}
-	mi := &file_auth_proto_msgTypes[12]
+func (x *SendCodeData) Reset() {
+	*x = SendCodeData{}
+	mi := &file_auth_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogoutRequest) String() string {
+func (x *SendCodeData) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogoutRequest) ProtoMessage() {}
+func (*SendCodeData) ProtoMessage() {}
 
-func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[12]
+func (x *SendCodeData) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1007,41 +1183,57 @@ func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
-func (*LogoutRequest) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use SendCodeData.ProtoReflect.Descriptor instead.
+func (*SendCodeData) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *LogoutRequest) GetDeviceToken() string {
+func (x *SendCodeData) GetExpiresIn() int64 {
 	if x != nil {
-		return x.DeviceToken
+		return x.ExpiresIn
 	}
-	return ""
+	return 0
 }
 
-// 注销响应
-type LogoutResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+func (x *SendCodeData) GetResendAfter() int64 {
+	if x != nil {
+		return x.ResendAfter
+	}
+	return 0
+}
+
+// 验证码登录请求
+type VerifyCodeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Target:
+	//
+	//	*VerifyCodeRequest_Phone
+	//	*VerifyCodeRequest_Email
+	Target        isVerifyCodeRequest_Target `protobuf_oneof:"target"`
+	Code          string                     `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	DeviceToken   string                     `protobuf:"bytes,4,opt,name=device_token,json=deviceToken,proto3" json:"device_token,omitempty"`
+	DeviceType    DeviceType                 `protobuf:"varint,5,opt,name=device_type,json=deviceType,proto3,enum=telegramlite.auth.DeviceType" json:"device_type,omitempty"`
+	DeviceName    string                     `protobuf:"bytes,6,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`
+	Username      string                     `protobuf:"bytes,7,opt,name=username,proto3" json:"username,omitempty"` // 注册时使用, 为空则自动生成
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LogoutResponse) Reset() {
-	*x = LogoutResponse{}
-	mi := &file_auth_proto_msgTypes[13]
+func (x *VerifyCodeRequest) Reset() {
+	*x = VerifyCodeRequest{}
+	mi := &file_auth_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogoutResponse) String() string {
+func (x *VerifyCodeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogoutResponse) ProtoMessage() {}
+func (*VerifyCodeRequest) ProtoMessage() {}
 
-func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[13]
+func (x *VerifyCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1052,41 +1244,5081 @@ func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
-func (*LogoutResponse) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use VerifyCodeRequest.ProtoReflect.Descriptor instead.
+func (*VerifyCodeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *LogoutResponse) GetResponse() *Response {
+func (x *VerifyCodeRequest) GetTarget() isVerifyCodeRequest_Target {
 	if x != nil {
-		return x.Response
+		return x.Target
 	}
 	return nil
 }
 
-// 验证Token请求 (内部服务调用)
-type VerifyTokenRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *VerifyTokenRequest) Reset() {
-	*x = VerifyTokenRequest{}
-	mi := &file_auth_proto_msgTypes[14]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *VerifyCodeRequest) GetPhone() string {
+	if x != nil {
+		if x, ok := x.Target.(*VerifyCodeRequest_Phone); ok {
+			return x.Phone
+		}
+	}
+	return ""
 }
 
-func (x *VerifyTokenRequest) String() string {
+func (x *VerifyCodeRequest) GetEmail() string {
+	if x != nil {
+		if x, ok := x.Target.(*VerifyCodeRequest_Email); ok {
+			return x.Email
+		}
+	}
+	return ""
+}
+
+func (x *VerifyCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *VerifyCodeRequest) GetDeviceToken() string {
+	if x != nil {
+		return x.DeviceToken
+	}
+	return ""
+}
+
+func (x *VerifyCodeRequest) GetDeviceType() DeviceType {
+	if x != nil {
+		return x.DeviceType
+	}
+	return DeviceType_DEVICE_TYPE_UNSPECIFIED
+}
+
+func (x *VerifyCodeRequest) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+func (x *VerifyCodeRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type isVerifyCodeRequest_Target interface {
+	isVerifyCodeRequest_Target()
+}
+
+type VerifyCodeRequest_Phone struct {
+	Phone string `protobuf:"bytes,1,opt,name=phone,proto3,oneof"`
+}
+
+type VerifyCodeRequest_Email struct {
+	Email string `protobuf:"bytes,2,opt,name=email,proto3,oneof"`
+}
+
+func (*VerifyCodeRequest_Phone) isVerifyCodeRequest_Target() {}
+
+func (*VerifyCodeRequest_Email) isVerifyCodeRequest_Target() {}
+
+// 验证码登录响应
+type VerifyCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Data          *LoginData             `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	IsNewUser     bool                   `protobuf:"varint,3,opt,name=is_new_user,json=isNewUser,proto3" json:"is_new_user,omitempty"`
+	TwoFactor     *TwoFactorChallenge    `protobuf:"bytes,4,opt,name=two_factor,json=twoFactor,proto3" json:"two_factor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyCodeResponse) Reset() {
+	*x = VerifyCodeResponse{}
+	mi := &file_auth_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyCodeResponse) ProtoMessage() {}
+
+func (x *VerifyCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyCodeResponse.ProtoReflect.Descriptor instead.
+func (*VerifyCodeResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *VerifyCodeResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *VerifyCodeResponse) GetData() *LoginData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *VerifyCodeResponse) GetIsNewUser() bool {
+	if x != nil {
+		return x.IsNewUser
+	}
+	return false
+}
+
+func (x *VerifyCodeResponse) GetTwoFactor() *TwoFactorChallenge {
+	if x != nil {
+		return x.TwoFactor
+	}
+	return nil
+}
+
+// 申请重置密码请求
+type RequestPasswordResetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Target:
+	//
+	//	*RequestPasswordResetRequest_Phone
+	//	*RequestPasswordResetRequest_Email
+	Target        isRequestPasswordResetRequest_Target `protobuf_oneof:"target"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetRequest) Reset() {
+	*x = RequestPasswordResetRequest{}
+	mi := &file_auth_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetRequest) ProtoMessage() {}
+
+func (x *RequestPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RequestPasswordResetRequest) GetTarget() isRequestPasswordResetRequest_Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *RequestPasswordResetRequest) GetPhone() string {
+	if x != nil {
+		if x, ok := x.Target.(*RequestPasswordResetRequest_Phone); ok {
+			return x.Phone
+		}
+	}
+	return ""
+}
+
+func (x *RequestPasswordResetRequest) GetEmail() string {
+	if x != nil {
+		if x, ok := x.Target.(*RequestPasswordResetRequest_Email); ok {
+			return x.Email
+		}
+	}
+	return ""
+}
+
+type isRequestPasswordResetRequest_Target interface {
+	isRequestPasswordResetRequest_Target()
+}
+
+type RequestPasswordResetRequest_Phone struct {
+	Phone string `protobuf:"bytes,1,opt,name=phone,proto3,oneof"`
+}
+
+type RequestPasswordResetRequest_Email struct {
+	Email string `protobuf:"bytes,2,opt,name=email,proto3,oneof"`
+}
+
+func (*RequestPasswordResetRequest_Phone) isRequestPasswordResetRequest_Target() {}
+
+func (*RequestPasswordResetRequest_Email) isRequestPasswordResetRequest_Target() {}
+
+// 申请重置密码响应 (账号不存在时同样返回成功)
+type RequestPasswordResetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetResponse) Reset() {
+	*x = RequestPasswordResetResponse{}
+	mi := &file_auth_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetResponse) ProtoMessage() {}
+
+func (x *RequestPasswordResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetResponse.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RequestPasswordResetResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// 重置密码请求
+type ResetPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
+	mi := &file_auth_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordRequest) ProtoMessage() {}
+
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ResetPasswordRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ResetPasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+// 重置密码响应
+type ResetPasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Violations    []*PasswordViolation   `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordResponse) Reset() {
+	*x = ResetPasswordResponse{}
+	mi := &file_auth_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordResponse) ProtoMessage() {}
+
+func (x *ResetPasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordResponse.ProtoReflect.Descriptor instead.
+func (*ResetPasswordResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ResetPasswordResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ResetPasswordResponse) GetViolations() []*PasswordViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+// 修改密码请求
+type ChangePasswordRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken         string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	OldPassword         string                 `protobuf:"bytes,2,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
+	NewPassword         string                 `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	RevokeOtherSessions bool                   `protobuf:"varint,4,opt,name=revoke_other_sessions,json=revokeOtherSessions,proto3" json:"revoke_other_sessions,omitempty"` // 同时终止除当前设备外的所有会话
+	Code                string                 `protobuf:"bytes,5,opt,name=code,proto3" json:"code,omitempty"`                                                             // 未设置密码的账号使用登录验证码设置密码
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_auth_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ChangePasswordRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetOldPassword() string {
+	if x != nil {
+		return x.OldPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetRevokeOtherSessions() bool {
+	if x != nil {
+		return x.RevokeOtherSessions
+	}
+	return false
+}
+
+func (x *ChangePasswordRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// 修改密码响应
+type ChangePasswordResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Response        *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Violations      []*PasswordViolation   `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`
+	RevokedSessions int32                  `protobuf:"varint,3,opt,name=revoked_sessions,json=revokedSessions,proto3" json:"revoked_sessions,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChangePasswordResponse) Reset() {
+	*x = ChangePasswordResponse{}
+	mi := &file_auth_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordResponse) ProtoMessage() {}
+
+func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
+func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ChangePasswordResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ChangePasswordResponse) GetViolations() []*PasswordViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+func (x *ChangePasswordResponse) GetRevokedSessions() int32 {
+	if x != nil {
+		return x.RevokedSessions
+	}
+	return 0
+}
+
+// 完成两步验证登录请求
+type VerifySecondFactorRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeToken string                 `protobuf:"bytes,1,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"`
+	Code           string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // TOTP验证码或恢复码
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VerifySecondFactorRequest) Reset() {
+	*x = VerifySecondFactorRequest{}
+	mi := &file_auth_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifySecondFactorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifySecondFactorRequest) ProtoMessage() {}
+
+func (x *VerifySecondFactorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifySecondFactorRequest.ProtoReflect.Descriptor instead.
+func (*VerifySecondFactorRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *VerifySecondFactorRequest) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+func (x *VerifySecondFactorRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// 完成两步验证登录响应
+type VerifySecondFactorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Data          *LoginData             `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifySecondFactorResponse) Reset() {
+	*x = VerifySecondFactorResponse{}
+	mi := &file_auth_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifySecondFactorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifySecondFactorResponse) ProtoMessage() {}
+
+func (x *VerifySecondFactorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifySecondFactorResponse.ProtoReflect.Descriptor instead.
+func (*VerifySecondFactorResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *VerifySecondFactorResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *VerifySecondFactorResponse) GetData() *LoginData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// 获取两步验证状态请求
+type GetTwoFactorStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTwoFactorStatusRequest) Reset() {
+	*x = GetTwoFactorStatusRequest{}
+	mi := &file_auth_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTwoFactorStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTwoFactorStatusRequest) ProtoMessage() {}
+
+func (x *GetTwoFactorStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTwoFactorStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetTwoFactorStatusRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetTwoFactorStatusRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+// 获取两步验证状态响应
+type GetTwoFactorStatusResponse struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Response               *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Enabled                bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	EnabledAt              *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=enabled_at,json=enabledAt,proto3" json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int64                  `protobuf:"varint,4,opt,name=recovery_codes_remaining,json=recoveryCodesRemaining,proto3" json:"recovery_codes_remaining,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetTwoFactorStatusResponse) Reset() {
+	*x = GetTwoFactorStatusResponse{}
+	mi := &file_auth_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTwoFactorStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTwoFactorStatusResponse) ProtoMessage() {}
+
+func (x *GetTwoFactorStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTwoFactorStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetTwoFactorStatusResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetTwoFactorStatusResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *GetTwoFactorStatusResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GetTwoFactorStatusResponse) GetEnabledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EnabledAt
+	}
+	return nil
+}
+
+func (x *GetTwoFactorStatusResponse) GetRecoveryCodesRemaining() int64 {
+	if x != nil {
+		return x.RecoveryCodesRemaining
+	}
+	return 0
+}
+
+// 生成两步验证密钥请求
+type EnrollTwoFactorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTwoFactorRequest) Reset() {
+	*x = EnrollTwoFactorRequest{}
+	mi := &file_auth_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTwoFactorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTwoFactorRequest) ProtoMessage() {}
+
+func (x *EnrollTwoFactorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTwoFactorRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTwoFactorRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *EnrollTwoFactorRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+// 生成两步验证密钥响应 (恢复码只返回这一次)
+type EnrollTwoFactorResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Response        *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Secret          string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	ProvisioningUri string                 `protobuf:"bytes,3,opt,name=provisioning_uri,json=provisioningUri,proto3" json:"provisioning_uri,omitempty"`
+	RecoveryCodes   []string               `protobuf:"bytes,4,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EnrollTwoFactorResponse) Reset() {
+	*x = EnrollTwoFactorResponse{}
+	mi := &file_auth_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTwoFactorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTwoFactorResponse) ProtoMessage() {}
+
+func (x *EnrollTwoFactorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTwoFactorResponse.ProtoReflect.Descriptor instead.
+func (*EnrollTwoFactorResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *EnrollTwoFactorResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *EnrollTwoFactorResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EnrollTwoFactorResponse) GetProvisioningUri() string {
+	if x != nil {
+		return x.ProvisioningUri
+	}
+	return ""
+}
+
+func (x *EnrollTwoFactorResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+// 确认启用两步验证请求
+type ConfirmTwoFactorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTwoFactorRequest) Reset() {
+	*x = ConfirmTwoFactorRequest{}
+	mi := &file_auth_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTwoFactorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTwoFactorRequest) ProtoMessage() {}
+
+func (x *ConfirmTwoFactorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTwoFactorRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTwoFactorRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ConfirmTwoFactorRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *ConfirmTwoFactorRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// 确认启用两步验证响应
+type ConfirmTwoFactorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTwoFactorResponse) Reset() {
+	*x = ConfirmTwoFactorResponse{}
+	mi := &file_auth_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTwoFactorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTwoFactorResponse) ProtoMessage() {}
+
+func (x *ConfirmTwoFactorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTwoFactorResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmTwoFactorResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ConfirmTwoFactorResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// 关闭两步验证请求
+type DisableTwoFactorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"` // TOTP验证码或恢复码
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTwoFactorRequest) Reset() {
+	*x = DisableTwoFactorRequest{}
+	mi := &file_auth_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTwoFactorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTwoFactorRequest) ProtoMessage() {}
+
+func (x *DisableTwoFactorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTwoFactorRequest.ProtoReflect.Descriptor instead.
+func (*DisableTwoFactorRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DisableTwoFactorRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *DisableTwoFactorRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *DisableTwoFactorRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// 关闭两步验证响应
+type DisableTwoFactorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTwoFactorResponse) Reset() {
+	*x = DisableTwoFactorResponse{}
+	mi := &file_auth_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTwoFactorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTwoFactorResponse) ProtoMessage() {}
+
+func (x *DisableTwoFactorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTwoFactorResponse.ProtoReflect.Descriptor instead.
+func (*DisableTwoFactorResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DisableTwoFactorResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// 刷新Token请求
+type RefreshTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	mi := &file_auth_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+// 刷新Token响应
+type RefreshTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Token         *TokenInfo             `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenResponse) Reset() {
+	*x = RefreshTokenResponse{}
+	mi := &file_auth_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenResponse) ProtoMessage() {}
+
+func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
+func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RefreshTokenResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *RefreshTokenResponse) GetToken() *TokenInfo {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+// 注销请求
+type LogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceToken   string                 `protobuf:"bytes,1,opt,name=device_token,json=deviceToken,proto3" json:"device_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_auth_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *LogoutRequest) GetDeviceToken() string {
+	if x != nil {
+		return x.DeviceToken
+	}
+	return ""
+}
+
+// 注销响应
+type LogoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutResponse) Reset() {
+	*x = LogoutResponse{}
+	mi := &file_auth_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutResponse) ProtoMessage() {}
+
+func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
+func (*LogoutResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *LogoutResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// 验证Token请求 (内部服务调用)
+type VerifyTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyTokenRequest) Reset() {
+	*x = VerifyTokenRequest{}
+	mi := &file_auth_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTokenRequest) ProtoMessage() {}
+
+func (x *VerifyTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTokenRequest.ProtoReflect.Descriptor instead.
+func (*VerifyTokenRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *VerifyTokenRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+// 验证Token响应
+type VerifyTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Data          *VerifyTokenData       `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyTokenResponse) Reset() {
+	*x = VerifyTokenResponse{}
+	mi := &file_auth_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTokenResponse) ProtoMessage() {}
+
+func (x *VerifyTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTokenResponse.ProtoReflect.Descriptor instead.
+func (*VerifyTokenResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *VerifyTokenResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *VerifyTokenResponse) GetData() *VerifyTokenData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type VerifyTokenData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId      uint64                 `protobuf:"varint,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	DeviceToken   string                 `protobuf:"bytes,4,opt,name=device_token,json=deviceToken,proto3" json:"device_token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	AccountType   string                 `protobuf:"bytes,6,opt,name=account_type,json=accountType,proto3" json:"account_type,omitempty"` // user 或 bot；机器人token没有设备，device_id为0
+	Scopes        []string               `protobuf:"bytes,7,rep,name=scopes,proto3" json:"scopes,omitempty"`                              // 个人访问token的授权范围，token_kind为personal_access_token时必须校验
+	TokenKind     string                 `protobuf:"bytes,8,opt,name=token_kind,json=tokenKind,proto3" json:"token_kind,omitempty"`       // session、personal_access_token 或 bot
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyTokenData) Reset() {
+	*x = VerifyTokenData{}
+	mi := &file_auth_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyTokenData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTokenData) ProtoMessage() {}
+
+func (x *VerifyTokenData) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTokenData.ProtoReflect.Descriptor instead.
+func (*VerifyTokenData) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *VerifyTokenData) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *VerifyTokenData) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *VerifyTokenData) GetDeviceId() uint64 {
+	if x != nil {
+		return x.DeviceId
+	}
+	return 0
+}
+
+func (x *VerifyTokenData) GetDeviceToken() string {
+	if x != nil {
+		return x.DeviceToken
+	}
+	return ""
+}
+
+func (x *VerifyTokenData) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *VerifyTokenData) GetAccountType() string {
+	if x != nil {
+		return x.AccountType
+	}
+	return ""
+}
+
+func (x *VerifyTokenData) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *VerifyTokenData) GetTokenKind() string {
+	if x != nil {
+		return x.TokenKind
+	}
+	return ""
+}
+
+// 获取用户信息请求
+type GetUserInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserInfoRequest) Reset() {
+	*x = GetUserInfoRequest{}
+	mi := &file_auth_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserInfoRequest) ProtoMessage() {}
+
+func (x *GetUserInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetUserInfoRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetUserInfoRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+// 获取用户信息响应
+type GetUserInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	User          *UserInfo              `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserInfoResponse) Reset() {
+	*x = GetUserInfoResponse{}
+	mi := &file_auth_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserInfoResponse) ProtoMessage() {}
+
+func (x *GetUserInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetUserInfoResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetUserInfoResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *GetUserInfoResponse) GetUser() *UserInfo {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// 会话信息
+type SessionInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      uint64                 `protobuf:"varint,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	DeviceType    DeviceType             `protobuf:"varint,2,opt,name=device_type,json=deviceType,proto3,enum=telegramlite.auth.DeviceType" json:"device_type,omitempty"`
+	DeviceName    string                 `protobuf:"bytes,3,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`
+	Ip            string                 `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
+	IsOnline      bool                   `protobuf:"varint,5,opt,name=is_online,json=isOnline,proto3" json:"is_online,omitempty"`
+	IsCurrent     bool                   `protobuf:"varint,6,opt,name=is_current,json=isCurrent,proto3" json:"is_current,omitempty"` // 是否为发起请求的设备
+	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionInfo) Reset() {
+	*x = SessionInfo{}
+	mi := &file_auth_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionInfo) ProtoMessage() {}
+
+func (x *SessionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionInfo.ProtoReflect.Descriptor instead.
+func (*SessionInfo) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SessionInfo) GetDeviceId() uint64 {
+	if x != nil {
+		return x.DeviceId
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetDeviceType() DeviceType {
+	if x != nil {
+		return x.DeviceType
+	}
+	return DeviceType_DEVICE_TYPE_UNSPECIFIED
+}
+
+func (x *SessionInfo) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetIsOnline() bool {
+	if x != nil {
+		return x.IsOnline
+	}
+	return false
+}
+
+func (x *SessionInfo) GetIsCurrent() bool {
+	if x != nil {
+		return x.IsCurrent
+	}
+	return false
+}
+
+func (x *SessionInfo) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+func (x *SessionInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// 获取会话列表请求
+type ListSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	mi := &file_auth_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListSessionsRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+// 获取会话列表响应
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Sessions      []*SessionInfo         `protobuf:"bytes,2,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_auth_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListSessionsResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ListSessionsResponse) GetSessions() []*SessionInfo {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+// 终止会话请求
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	DeviceId      uint64                 `protobuf:"varint,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_auth_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RevokeSessionRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *RevokeSessionRequest) GetDeviceId() uint64 {
+	if x != nil {
+		return x.DeviceId
+	}
+	return 0
+}
+
+// 终止会话响应
+type RevokeSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionResponse) Reset() {
+	*x = RevokeSessionResponse{}
+	mi := &file_auth_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionResponse) ProtoMessage() {}
+
+func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionResponse.ProtoReflect.Descriptor instead.
+func (*RevokeSessionResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *RevokeSessionResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// 终止其他会话请求
+type RevokeOtherSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeOtherSessionsRequest) Reset() {
+	*x = RevokeOtherSessionsRequest{}
+	mi := &file_auth_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeOtherSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeOtherSessionsRequest) ProtoMessage() {}
+
+func (x *RevokeOtherSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeOtherSessionsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeOtherSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RevokeOtherSessionsRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+// 终止其他会话响应
+type RevokeOtherSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	RevokedCount  int32                  `protobuf:"varint,2,opt,name=revoked_count,json=revokedCount,proto3" json:"revoked_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeOtherSessionsResponse) Reset() {
+	*x = RevokeOtherSessionsResponse{}
+	mi := &file_auth_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeOtherSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeOtherSessionsResponse) ProtoMessage() {}
+
+func (x *RevokeOtherSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeOtherSessionsResponse.ProtoReflect.Descriptor instead.
+func (*RevokeOtherSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *RevokeOtherSessionsResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *RevokeOtherSessionsResponse) GetRevokedCount() int32 {
+	if x != nil {
+		return x.RevokedCount
+	}
+	return 0
+}
+
+// 吊销Token请求
+type RevokeTokensRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Target:
+	//
+	//	*RevokeTokensRequest_TokenId
+	//	*RevokeTokensRequest_DeviceId
+	//	*RevokeTokensRequest_UserId
+	Target        isRevokeTokensRequest_Target `protobuf_oneof:"target"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeTokensRequest) Reset() {
+	*x = RevokeTokensRequest{}
+	mi := &file_auth_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeTokensRequest) ProtoMessage() {}
+
+func (x *RevokeTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeTokensRequest.ProtoReflect.Descriptor instead.
+func (*RevokeTokensRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *RevokeTokensRequest) GetTarget() isRevokeTokensRequest_Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *RevokeTokensRequest) GetTokenId() string {
+	if x != nil {
+		if x, ok := x.Target.(*RevokeTokensRequest_TokenId); ok {
+			return x.TokenId
+		}
+	}
+	return ""
+}
+
+func (x *RevokeTokensRequest) GetDeviceId() uint64 {
+	if x != nil {
+		if x, ok := x.Target.(*RevokeTokensRequest_DeviceId); ok {
+			return x.DeviceId
+		}
+	}
+	return 0
+}
+
+func (x *RevokeTokensRequest) GetUserId() uint64 {
+	if x != nil {
+		if x, ok := x.Target.(*RevokeTokensRequest_UserId); ok {
+			return x.UserId
+		}
+	}
+	return 0
+}
+
+type isRevokeTokensRequest_Target interface {
+	isRevokeTokensRequest_Target()
+}
+
+type RevokeTokensRequest_TokenId struct {
+	TokenId string `protobuf:"bytes,1,opt,name=token_id,json=tokenId,proto3,oneof"` // 吊销单个访问token (jti)
+}
+
+type RevokeTokensRequest_DeviceId struct {
+	DeviceId uint64 `protobuf:"varint,2,opt,name=device_id,json=deviceId,proto3,oneof"` // 吊销设备的所有token
+}
+
+type RevokeTokensRequest_UserId struct {
+	UserId uint64 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3,oneof"` // 吊销用户的所有token
+}
+
+func (*RevokeTokensRequest_TokenId) isRevokeTokensRequest_Target() {}
+
+func (*RevokeTokensRequest_DeviceId) isRevokeTokensRequest_Target() {}
+
+func (*RevokeTokensRequest_UserId) isRevokeTokensRequest_Target() {}
+
+// 吊销Token响应
+type RevokeTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeTokensResponse) Reset() {
+	*x = RevokeTokensResponse{}
+	mi := &file_auth_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeTokensResponse) ProtoMessage() {}
+
+func (x *RevokeTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeTokensResponse.ProtoReflect.Descriptor instead.
+func (*RevokeTokensResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *RevokeTokensResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// 签名公钥
+type SigningKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kid           string                 `protobuf:"bytes,1,opt,name=kid,proto3" json:"kid,omitempty"`
+	Algorithm     string                 `protobuf:"bytes,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`                  // RS256 / EdDSA
+	PublicKey     []byte                 `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"` // PKIX DER 编码
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SigningKey) Reset() {
+	*x = SigningKey{}
+	mi := &file_auth_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SigningKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SigningKey) ProtoMessage() {}
+
+func (x *SigningKey) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SigningKey.ProtoReflect.Descriptor instead.
+func (*SigningKey) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SigningKey) GetKid() string {
+	if x != nil {
+		return x.Kid
+	}
+	return ""
+}
+
+func (x *SigningKey) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *SigningKey) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *SigningKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SigningKey) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// 获取签名公钥请求
+type GetSigningKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSigningKeysRequest) Reset() {
+	*x = GetSigningKeysRequest{}
+	mi := &file_auth_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSigningKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSigningKeysRequest) ProtoMessage() {}
+
+func (x *GetSigningKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSigningKeysRequest.ProtoReflect.Descriptor instead.
+func (*GetSigningKeysRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{52}
+}
+
+// 获取签名公钥响应
+type GetSigningKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Keys          []*SigningKey          `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"` // 第一个为当前签发密钥
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSigningKeysResponse) Reset() {
+	*x = GetSigningKeysResponse{}
+	mi := &file_auth_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSigningKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSigningKeysResponse) ProtoMessage() {}
+
+func (x *GetSigningKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSigningKeysResponse.ProtoReflect.Descriptor instead.
+func (*GetSigningKeysResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetSigningKeysResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *GetSigningKeysResponse) GetKeys() []*SigningKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// Token吊销事件
+type RevocationEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // 事件ID, 作为下次同步的游标
+	// Types that are valid to be assigned to Target:
+	//
+	//	*RevocationEvent_TokenId
+	//	*RevocationEvent_DeviceId
+	//	*RevocationEvent_UserId
+	Target        isRevocationEvent_Target `protobuf_oneof:"target"`
+	RevokedAt     *timestamppb.Timestamp   `protobuf:"bytes,5,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp   `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // 之后相关token已自然过期, 可丢弃该事件
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevocationEvent) Reset() {
+	*x = RevocationEvent{}
+	mi := &file_auth_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevocationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevocationEvent) ProtoMessage() {}
+
+func (x *RevocationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevocationEvent.ProtoReflect.Descriptor instead.
+func (*RevocationEvent) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RevocationEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RevocationEvent) GetTarget() isRevocationEvent_Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *RevocationEvent) GetTokenId() string {
+	if x != nil {
+		if x, ok := x.Target.(*RevocationEvent_TokenId); ok {
+			return x.TokenId
+		}
+	}
+	return ""
+}
+
+func (x *RevocationEvent) GetDeviceId() uint64 {
+	if x != nil {
+		if x, ok := x.Target.(*RevocationEvent_DeviceId); ok {
+			return x.DeviceId
+		}
+	}
+	return 0
+}
+
+func (x *RevocationEvent) GetUserId() uint64 {
+	if x != nil {
+		if x, ok := x.Target.(*RevocationEvent_UserId); ok {
+			return x.UserId
+		}
+	}
+	return 0
+}
+
+func (x *RevocationEvent) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+func (x *RevocationEvent) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type isRevocationEvent_Target interface {
+	isRevocationEvent_Target()
+}
+
+type RevocationEvent_TokenId struct {
+	TokenId string `protobuf:"bytes,2,opt,name=token_id,json=tokenId,proto3,oneof"` // 单个访问token (jti)
+}
+
+type RevocationEvent_DeviceId struct {
+	DeviceId uint64 `protobuf:"varint,3,opt,name=device_id,json=deviceId,proto3,oneof"` // 设备在revoked_at之前签发的token
+}
+
+type RevocationEvent_UserId struct {
+	UserId uint64 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3,oneof"` // 用户在revoked_at之前签发的token
+}
+
+func (*RevocationEvent_TokenId) isRevocationEvent_Target() {}
+
+func (*RevocationEvent_DeviceId) isRevocationEvent_Target() {}
+
+func (*RevocationEvent_UserId) isRevocationEvent_Target() {}
+
+// 同步Token吊销事件请求
+type GetRevocationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"` // 上次同步返回的游标, 为空时从头同步
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`  // 单次最大条数, 默认1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRevocationsRequest) Reset() {
+	*x = GetRevocationsRequest{}
+	mi := &file_auth_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRevocationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRevocationsRequest) ProtoMessage() {}
+
+func (x *GetRevocationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRevocationsRequest.ProtoReflect.Descriptor instead.
+func (*GetRevocationsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetRevocationsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetRevocationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// 同步Token吊销事件响应
+type GetRevocationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Events        []*RevocationEvent     `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // 下次同步的游标
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRevocationsResponse) Reset() {
+	*x = GetRevocationsResponse{}
+	mi := &file_auth_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRevocationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRevocationsResponse) ProtoMessage() {}
+
+func (x *GetRevocationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRevocationsResponse.ProtoReflect.Descriptor instead.
+func (*GetRevocationsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetRevocationsResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *GetRevocationsResponse) GetEvents() []*RevocationEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetRevocationsResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// 停用账号请求
+type DeactivateAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"` // 账号已设置密码时必填
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`         // 未设置密码的账号使用登录验证码确认
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateAccountRequest) Reset() {
+	*x = DeactivateAccountRequest{}
+	mi := &file_auth_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateAccountRequest) ProtoMessage() {}
+
+func (x *DeactivateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeactivateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *DeactivateAccountRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *DeactivateAccountRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *DeactivateAccountRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// 停用账号响应
+type DeactivateAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateAccountResponse) Reset() {
+	*x = DeactivateAccountResponse{}
+	mi := &file_auth_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateAccountResponse) ProtoMessage() {}
+
+func (x *DeactivateAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateAccountResponse.ProtoReflect.Descriptor instead.
+func (*DeactivateAccountResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *DeactivateAccountResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// 删除账号请求
+type DeleteAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
+	mi := &file_auth_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountRequest) ProtoMessage() {}
+
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *DeleteAccountRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *DeleteAccountRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *DeleteAccountRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// 删除账号响应
+type DeleteAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	PurgeAt       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=purge_at,json=purgeAt,proto3" json:"purge_at,omitempty"` // 账号数据将被清除的时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountResponse) Reset() {
+	*x = DeleteAccountResponse{}
+	mi := &file_auth_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountResponse) ProtoMessage() {}
+
+func (x *DeleteAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAccountResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *DeleteAccountResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *DeleteAccountResponse) GetPurgeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PurgeAt
+	}
+	return nil
+}
+
+// 已清除的账号
+type AccountPurge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // 记录ID, 作为下次同步的游标
+	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PurgedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=purged_at,json=purgedAt,proto3" json:"purged_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountPurge) Reset() {
+	*x = AccountPurge{}
+	mi := &file_auth_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountPurge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountPurge) ProtoMessage() {}
+
+func (x *AccountPurge) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountPurge.ProtoReflect.Descriptor instead.
+func (*AccountPurge) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *AccountPurge) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AccountPurge) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AccountPurge) GetPurgedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PurgedAt
+	}
+	return nil
+}
+
+// 同步已清除账号请求
+type GetAccountPurgesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"` // 上次同步返回的游标, 为空时从头同步
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`  // 单次最大条数, 默认1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountPurgesRequest) Reset() {
+	*x = GetAccountPurgesRequest{}
+	mi := &file_auth_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountPurgesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountPurgesRequest) ProtoMessage() {}
+
+func (x *GetAccountPurgesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountPurgesRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountPurgesRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetAccountPurgesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetAccountPurgesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// 同步已清除账号响应
+type GetAccountPurgesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Purges        []*AccountPurge        `protobuf:"bytes,2,rep,name=purges,proto3" json:"purges,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // 下次同步的游标
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountPurgesResponse) Reset() {
+	*x = GetAccountPurgesResponse{}
+	mi := &file_auth_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountPurgesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountPurgesResponse) ProtoMessage() {}
+
+func (x *GetAccountPurgesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountPurgesResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountPurgesResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetAccountPurgesResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *GetAccountPurgesResponse) GetPurges() []*AccountPurge {
+	if x != nil {
+		return x.Purges
+	}
+	return nil
+}
+
+func (x *GetAccountPurgesResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// 导出用户数据请求
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_auth_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ExportUserDataRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// 导出用户数据响应
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"` // JSON文档: 账号、设备、登录记录和安全设置, 包含format_version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_auth_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ExportUserDataResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// 安全事件
+type AuthEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 账号未知时为0
+	DeviceId      uint64                 `protobuf:"varint,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Event         string                 `protobuf:"bytes,4,opt,name=event,proto3" json:"event,omitempty"`     // login, login_failed, new_device, token_refreshed, logout, password_changed...
+	Outcome       string                 `protobuf:"bytes,5,opt,name=outcome,proto3" json:"outcome,omitempty"` // success, failure
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Ip            string                 `protobuf:"bytes,7,opt,name=ip,proto3" json:"ip,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,8,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Details       string                 `protobuf:"bytes,9,opt,name=details,proto3" json:"details,omitempty"` // JSON
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthEvent) Reset() {
+	*x = AuthEvent{}
+	mi := &file_auth_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthEvent) ProtoMessage() {}
+
+func (x *AuthEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthEvent.ProtoReflect.Descriptor instead.
+func (*AuthEvent) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *AuthEvent) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuthEvent) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AuthEvent) GetDeviceId() uint64 {
+	if x != nil {
+		return x.DeviceId
+	}
+	return 0
+}
+
+func (x *AuthEvent) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *AuthEvent) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *AuthEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AuthEvent) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *AuthEvent) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *AuthEvent) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *AuthEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// 查看安全事件请求
+type ListSecurityEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"` // 上一页返回的游标, 为空时从最新的事件开始
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`  // 默认20, 最大100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecurityEventsRequest) Reset() {
+	*x = ListSecurityEventsRequest{}
+	mi := &file_auth_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecurityEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecurityEventsRequest) ProtoMessage() {}
+
+func (x *ListSecurityEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecurityEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListSecurityEventsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListSecurityEventsRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *ListSecurityEventsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListSecurityEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// 查看安全事件响应
+type ListSecurityEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Events        []*AuthEvent           `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // 下一页的游标, 为空表示没有更多
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecurityEventsResponse) Reset() {
+	*x = ListSecurityEventsResponse{}
+	mi := &file_auth_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecurityEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecurityEventsResponse) ProtoMessage() {}
+
+func (x *ListSecurityEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecurityEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListSecurityEventsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ListSecurityEventsResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ListSecurityEventsResponse) GetEvents() []*AuthEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListSecurityEventsResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// 查询安全事件请求, 未设置的条件不过滤
+type QueryAuthEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=until,proto3" json:"until,omitempty"`
+	Cursor        string                 `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit         int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAuthEventsRequest) Reset() {
+	*x = QueryAuthEventsRequest{}
+	mi := &file_auth_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAuthEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuthEventsRequest) ProtoMessage() {}
+
+func (x *QueryAuthEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAuthEventsRequest.ProtoReflect.Descriptor instead.
+func (*QueryAuthEventsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *QueryAuthEventsRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *QueryAuthEventsRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *QueryAuthEventsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *QueryAuthEventsRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *QueryAuthEventsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *QueryAuthEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// 查询安全事件响应
+type QueryAuthEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Events        []*AuthEvent           `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAuthEventsResponse) Reset() {
+	*x = QueryAuthEventsResponse{}
+	mi := &file_auth_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAuthEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuthEventsResponse) ProtoMessage() {}
+
+func (x *QueryAuthEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAuthEventsResponse.ProtoReflect.Descriptor instead.
+func (*QueryAuthEventsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *QueryAuthEventsResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *QueryAuthEventsResponse) GetEvents() []*AuthEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *QueryAuthEventsResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// 新设备登录审批挑战
+type DeviceApprovalChallenge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApprovalToken string                 `protobuf:"bytes,1,opt,name=approval_token,json=approvalToken,proto3" json:"approval_token,omitempty"` // 只在登录响应中返回, 凭此查询审批结果
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                                    // pending: 等待审批; code_required: 已超时, 可使用验证码
+	FallbackIn    int64                  `protobuf:"varint,3,opt,name=fallback_in,json=fallbackIn,proto3" json:"fallback_in,omitempty"`         // 距可使用验证码的秒数
+	ExpiresIn     int64                  `protobuf:"varint,4,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceApprovalChallenge) Reset() {
+	*x = DeviceApprovalChallenge{}
+	mi := &file_auth_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceApprovalChallenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceApprovalChallenge) ProtoMessage() {}
+
+func (x *DeviceApprovalChallenge) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceApprovalChallenge.ProtoReflect.Descriptor instead.
+func (*DeviceApprovalChallenge) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *DeviceApprovalChallenge) GetApprovalToken() string {
+	if x != nil {
+		return x.ApprovalToken
+	}
+	return ""
+}
+
+func (x *DeviceApprovalChallenge) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DeviceApprovalChallenge) GetFallbackIn() int64 {
+	if x != nil {
+		return x.FallbackIn
+	}
+	return 0
+}
+
+func (x *DeviceApprovalChallenge) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+// 等待审批的新设备登录
+type DeviceApproval struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeviceType    string                 `protobuf:"bytes,2,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`
+	DeviceName    string                 `protobuf:"bytes,3,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`
+	Ip            string                 `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceApproval) Reset() {
+	*x = DeviceApproval{}
+	mi := &file_auth_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceApproval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceApproval) ProtoMessage() {}
+
+func (x *DeviceApproval) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceApproval.ProtoReflect.Descriptor instead.
+func (*DeviceApproval) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *DeviceApproval) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeviceApproval) GetDeviceType() string {
+	if x != nil {
+		return x.DeviceType
+	}
+	return ""
+}
+
+func (x *DeviceApproval) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+func (x *DeviceApproval) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *DeviceApproval) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *DeviceApproval) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DeviceApproval) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// 查询登录审批结果请求
+type GetDeviceApprovalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApprovalToken string                 `protobuf:"bytes,1,opt,name=approval_token,json=approvalToken,proto3" json:"approval_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeviceApprovalRequest) Reset() {
+	*x = GetDeviceApprovalRequest{}
+	mi := &file_auth_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeviceApprovalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceApprovalRequest) ProtoMessage() {}
+
+func (x *GetDeviceApprovalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceApprovalRequest.ProtoReflect.Descriptor instead.
+func (*GetDeviceApprovalRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *GetDeviceApprovalRequest) GetApprovalToken() string {
+	if x != nil {
+		return x.ApprovalToken
+	}
+	return ""
+}
+
+// 查询登录审批结果响应
+type GetDeviceApprovalResponse struct {
+	state          protoimpl.MessageState   `protogen:"open.v1"`
+	Response       *Response                `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Data           *LoginData               `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`                                           // 已批准时返回
+	TwoFactor      *TwoFactorChallenge      `protobuf:"bytes,3,opt,name=two_factor,json=twoFactor,proto3" json:"two_factor,omitempty"`                // 已批准但账号启用两步验证时返回
+	DeviceApproval *DeviceApprovalChallenge `protobuf:"bytes,4,opt,name=device_approval,json=deviceApproval,proto3" json:"device_approval,omitempty"` // 仍在等待时返回
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetDeviceApprovalResponse) Reset() {
+	*x = GetDeviceApprovalResponse{}
+	mi := &file_auth_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeviceApprovalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceApprovalResponse) ProtoMessage() {}
+
+func (x *GetDeviceApprovalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceApprovalResponse.ProtoReflect.Descriptor instead.
+func (*GetDeviceApprovalResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *GetDeviceApprovalResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *GetDeviceApprovalResponse) GetData() *LoginData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GetDeviceApprovalResponse) GetTwoFactor() *TwoFactorChallenge {
+	if x != nil {
+		return x.TwoFactor
+	}
+	return nil
+}
+
+func (x *GetDeviceApprovalResponse) GetDeviceApproval() *DeviceApprovalChallenge {
+	if x != nil {
+		return x.DeviceApproval
+	}
+	return nil
+}
+
+// 发送登录审批验证码请求
+type SendDeviceApprovalCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApprovalToken string                 `protobuf:"bytes,1,opt,name=approval_token,json=approvalToken,proto3" json:"approval_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendDeviceApprovalCodeRequest) Reset() {
+	*x = SendDeviceApprovalCodeRequest{}
+	mi := &file_auth_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendDeviceApprovalCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendDeviceApprovalCodeRequest) ProtoMessage() {}
+
+func (x *SendDeviceApprovalCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendDeviceApprovalCodeRequest.ProtoReflect.Descriptor instead.
+func (*SendDeviceApprovalCodeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *SendDeviceApprovalCodeRequest) GetApprovalToken() string {
+	if x != nil {
+		return x.ApprovalToken
+	}
+	return ""
+}
+
+// 发送登录审批验证码响应
+type SendDeviceApprovalCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Data          *SendCodeData          `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendDeviceApprovalCodeResponse) Reset() {
+	*x = SendDeviceApprovalCodeResponse{}
+	mi := &file_auth_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendDeviceApprovalCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendDeviceApprovalCodeResponse) ProtoMessage() {}
+
+func (x *SendDeviceApprovalCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendDeviceApprovalCodeResponse.ProtoReflect.Descriptor instead.
+func (*SendDeviceApprovalCodeResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *SendDeviceApprovalCodeResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *SendDeviceApprovalCodeResponse) GetData() *SendCodeData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// 使用验证码完成新设备登录请求
+type VerifyDeviceApprovalCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApprovalToken string                 `protobuf:"bytes,1,opt,name=approval_token,json=approvalToken,proto3" json:"approval_token,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyDeviceApprovalCodeRequest) Reset() {
+	*x = VerifyDeviceApprovalCodeRequest{}
+	mi := &file_auth_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyDeviceApprovalCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDeviceApprovalCodeRequest) ProtoMessage() {}
+
+func (x *VerifyDeviceApprovalCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDeviceApprovalCodeRequest.ProtoReflect.Descriptor instead.
+func (*VerifyDeviceApprovalCodeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *VerifyDeviceApprovalCodeRequest) GetApprovalToken() string {
+	if x != nil {
+		return x.ApprovalToken
+	}
+	return ""
+}
+
+func (x *VerifyDeviceApprovalCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// 使用验证码完成新设备登录响应
+type VerifyDeviceApprovalCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Data          *LoginData             `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	TwoFactor     *TwoFactorChallenge    `protobuf:"bytes,3,opt,name=two_factor,json=twoFactor,proto3" json:"two_factor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyDeviceApprovalCodeResponse) Reset() {
+	*x = VerifyDeviceApprovalCodeResponse{}
+	mi := &file_auth_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyDeviceApprovalCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDeviceApprovalCodeResponse) ProtoMessage() {}
+
+func (x *VerifyDeviceApprovalCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDeviceApprovalCodeResponse.ProtoReflect.Descriptor instead.
+func (*VerifyDeviceApprovalCodeResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *VerifyDeviceApprovalCodeResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *VerifyDeviceApprovalCodeResponse) GetData() *LoginData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *VerifyDeviceApprovalCodeResponse) GetTwoFactor() *TwoFactorChallenge {
+	if x != nil {
+		return x.TwoFactor
+	}
+	return nil
+}
+
+// 获取等待审批的登录请求
+type ListDeviceApprovalsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeviceApprovalsRequest) Reset() {
+	*x = ListDeviceApprovalsRequest{}
+	mi := &file_auth_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeviceApprovalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeviceApprovalsRequest) ProtoMessage() {}
+
+func (x *ListDeviceApprovalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeviceApprovalsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeviceApprovalsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ListDeviceApprovalsRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+// 获取等待审批的登录响应
+type ListDeviceApprovalsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Approvals     []*DeviceApproval      `protobuf:"bytes,2,rep,name=approvals,proto3" json:"approvals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeviceApprovalsResponse) Reset() {
+	*x = ListDeviceApprovalsResponse{}
+	mi := &file_auth_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeviceApprovalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeviceApprovalsResponse) ProtoMessage() {}
+
+func (x *ListDeviceApprovalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeviceApprovalsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeviceApprovalsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ListDeviceApprovalsResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ListDeviceApprovalsResponse) GetApprovals() []*DeviceApproval {
+	if x != nil {
+		return x.Approvals
+	}
+	return nil
+}
+
+// 订阅登录审批请求
+type WatchDeviceApprovalsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchDeviceApprovalsRequest) Reset() {
+	*x = WatchDeviceApprovalsRequest{}
+	mi := &file_auth_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchDeviceApprovalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchDeviceApprovalsRequest) ProtoMessage() {}
+
+func (x *WatchDeviceApprovalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDeviceApprovalsRequest.ProtoReflect.Descriptor instead.
+func (*WatchDeviceApprovalsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *WatchDeviceApprovalsRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+// 批准或拒绝新设备登录请求
+type DecideDeviceApprovalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	ApprovalId    uint64                 `protobuf:"varint,2,opt,name=approval_id,json=approvalId,proto3" json:"approval_id,omitempty"`
+	Approve       bool                   `protobuf:"varint,3,opt,name=approve,proto3" json:"approve,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecideDeviceApprovalRequest) Reset() {
+	*x = DecideDeviceApprovalRequest{}
+	mi := &file_auth_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecideDeviceApprovalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecideDeviceApprovalRequest) ProtoMessage() {}
+
+func (x *DecideDeviceApprovalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecideDeviceApprovalRequest.ProtoReflect.Descriptor instead.
+func (*DecideDeviceApprovalRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *DecideDeviceApprovalRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *DecideDeviceApprovalRequest) GetApprovalId() uint64 {
+	if x != nil {
+		return x.ApprovalId
+	}
+	return 0
+}
+
+func (x *DecideDeviceApprovalRequest) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
+// 批准或拒绝新设备登录响应
+type DecideDeviceApprovalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecideDeviceApprovalResponse) Reset() {
+	*x = DecideDeviceApprovalResponse{}
+	mi := &file_auth_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecideDeviceApprovalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecideDeviceApprovalResponse) ProtoMessage() {}
+
+func (x *DecideDeviceApprovalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecideDeviceApprovalResponse.ProtoReflect.Descriptor instead.
+func (*DecideDeviceApprovalResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *DecideDeviceApprovalResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// 申请二维码登录token请求
+type ExportLoginTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceToken   string                 `protobuf:"bytes,1,opt,name=device_token,json=deviceToken,proto3" json:"device_token,omitempty"`
+	DeviceType    DeviceType             `protobuf:"varint,2,opt,name=device_type,json=deviceType,proto3,enum=telegramlite.auth.DeviceType" json:"device_type,omitempty"` // 仅支持网页版和桌面版
+	DeviceName    string                 `protobuf:"bytes,3,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportLoginTokenRequest) Reset() {
+	*x = ExportLoginTokenRequest{}
+	mi := &file_auth_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportLoginTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportLoginTokenRequest) ProtoMessage() {}
+
+func (x *ExportLoginTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportLoginTokenRequest.ProtoReflect.Descriptor instead.
+func (*ExportLoginTokenRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ExportLoginTokenRequest) GetDeviceToken() string {
+	if x != nil {
+		return x.DeviceToken
+	}
+	return ""
+}
+
+func (x *ExportLoginTokenRequest) GetDeviceType() DeviceType {
+	if x != nil {
+		return x.DeviceType
+	}
+	return DeviceType_DEVICE_TYPE_UNSPECIFIED
+}
+
+func (x *ExportLoginTokenRequest) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+// 申请二维码登录token响应
+type ExportLoginTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	QrPayload     string                 `protobuf:"bytes,3,opt,name=qr_payload,json=qrPayload,proto3" json:"qr_payload,omitempty"` // 二维码内容
+	ExpiresIn     int64                  `protobuf:"varint,4,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportLoginTokenResponse) Reset() {
+	*x = ExportLoginTokenResponse{}
+	mi := &file_auth_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportLoginTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportLoginTokenResponse) ProtoMessage() {}
+
+func (x *ExportLoginTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportLoginTokenResponse.ProtoReflect.Descriptor instead.
+func (*ExportLoginTokenResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *ExportLoginTokenResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ExportLoginTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ExportLoginTokenResponse) GetQrPayload() string {
+	if x != nil {
+		return x.QrPayload
+	}
+	return ""
+}
+
+func (x *ExportLoginTokenResponse) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+// 扫码确认登录请求
+type AcceptLoginTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"` // 手机设备的Access Token
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`                                // 二维码中的token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptLoginTokenRequest) Reset() {
+	*x = AcceptLoginTokenRequest{}
+	mi := &file_auth_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptLoginTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptLoginTokenRequest) ProtoMessage() {}
+
+func (x *AcceptLoginTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptLoginTokenRequest.ProtoReflect.Descriptor instead.
+func (*AcceptLoginTokenRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *AcceptLoginTokenRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *AcceptLoginTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// 扫码确认登录响应
+type AcceptLoginTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptLoginTokenResponse) Reset() {
+	*x = AcceptLoginTokenResponse{}
+	mi := &file_auth_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptLoginTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptLoginTokenResponse) ProtoMessage() {}
+
+func (x *AcceptLoginTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptLoginTokenResponse.ProtoReflect.Descriptor instead.
+func (*AcceptLoginTokenResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *AcceptLoginTokenResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// 领取二维码登录结果请求
+type ImportLoginTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	DeviceToken   string                 `protobuf:"bytes,2,opt,name=device_token,json=deviceToken,proto3" json:"device_token,omitempty"`  // 必须与申请token时一致
+	WaitSeconds   int32                  `protobuf:"varint,3,opt,name=wait_seconds,json=waitSeconds,proto3" json:"wait_seconds,omitempty"` // 未确认时的最长等待时间, 最大30秒
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportLoginTokenRequest) Reset() {
+	*x = ImportLoginTokenRequest{}
+	mi := &file_auth_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportLoginTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportLoginTokenRequest) ProtoMessage() {}
+
+func (x *ImportLoginTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportLoginTokenRequest.ProtoReflect.Descriptor instead.
+func (*ImportLoginTokenRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *ImportLoginTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ImportLoginTokenRequest) GetDeviceToken() string {
+	if x != nil {
+		return x.DeviceToken
+	}
+	return ""
+}
+
+func (x *ImportLoginTokenRequest) GetWaitSeconds() int32 {
+	if x != nil {
+		return x.WaitSeconds
+	}
+	return 0
+}
+
+// 领取二维码登录结果响应
+type ImportLoginTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Data          *LoginData             `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`                             // 已确认时返回
+	TwoFactor     *TwoFactorChallenge    `protobuf:"bytes,3,opt,name=two_factor,json=twoFactor,proto3" json:"two_factor,omitempty"`  // 已确认但账号启用两步验证时返回
+	Pending       bool                   `protobuf:"varint,4,opt,name=pending,proto3" json:"pending,omitempty"`                      // 尚未确认, 客户端应继续轮询
+	ExpiresIn     int64                  `protobuf:"varint,5,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"` // 尚未确认时token的剩余有效期
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportLoginTokenResponse) Reset() {
+	*x = ImportLoginTokenResponse{}
+	mi := &file_auth_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportLoginTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportLoginTokenResponse) ProtoMessage() {}
+
+func (x *ImportLoginTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportLoginTokenResponse.ProtoReflect.Descriptor instead.
+func (*ImportLoginTokenResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ImportLoginTokenResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ImportLoginTokenResponse) GetData() *LoginData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportLoginTokenResponse) GetTwoFactor() *TwoFactorChallenge {
+	if x != nil {
+		return x.TwoFactor
+	}
+	return nil
+}
+
+func (x *ImportLoginTokenResponse) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+func (x *ImportLoginTokenResponse) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+// 注册推送token请求
+type RegisterPushTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Provider      string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"` // apns, fcm, webpush
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterPushTokenRequest) Reset() {
+	*x = RegisterPushTokenRequest{}
+	mi := &file_auth_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterPushTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterPushTokenRequest) ProtoMessage() {}
+
+func (x *RegisterPushTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterPushTokenRequest.ProtoReflect.Descriptor instead.
+func (*RegisterPushTokenRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *RegisterPushTokenRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *RegisterPushTokenRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *RegisterPushTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// 注册推送token响应
+type RegisterPushTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterPushTokenResponse) Reset() {
+	*x = RegisterPushTokenResponse{}
+	mi := &file_auth_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterPushTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterPushTokenResponse) ProtoMessage() {}
+
+func (x *RegisterPushTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterPushTokenResponse.ProtoReflect.Descriptor instead.
+func (*RegisterPushTokenResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *RegisterPushTokenResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// 清除推送token请求
+type UnregisterPushTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnregisterPushTokenRequest) Reset() {
+	*x = UnregisterPushTokenRequest{}
+	mi := &file_auth_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnregisterPushTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterPushTokenRequest) ProtoMessage() {}
+
+func (x *UnregisterPushTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterPushTokenRequest.ProtoReflect.Descriptor instead.
+func (*UnregisterPushTokenRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *UnregisterPushTokenRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+// 清除推送token响应
+type UnregisterPushTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnregisterPushTokenResponse) Reset() {
+	*x = UnregisterPushTokenResponse{}
+	mi := &file_auth_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnregisterPushTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterPushTokenResponse) ProtoMessage() {}
+
+func (x *UnregisterPushTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterPushTokenResponse.ProtoReflect.Descriptor instead.
+func (*UnregisterPushTokenResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *UnregisterPushTokenResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// 推送目标
+type PushTarget struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      uint64                 `protobuf:"varint,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	DeviceType    DeviceType             `protobuf:"varint,2,opt,name=device_type,json=deviceType,proto3,enum=telegramlite.auth.DeviceType" json:"device_type,omitempty"`
+	Provider      string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	Token         string                 `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushTarget) Reset() {
+	*x = PushTarget{}
+	mi := &file_auth_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushTarget) ProtoMessage() {}
+
+func (x *PushTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushTarget.ProtoReflect.Descriptor instead.
+func (*PushTarget) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *PushTarget) GetDeviceId() uint64 {
+	if x != nil {
+		return x.DeviceId
+	}
+	return 0
+}
+
+func (x *PushTarget) GetDeviceType() DeviceType {
+	if x != nil {
+		return x.DeviceType
+	}
+	return DeviceType_DEVICE_TYPE_UNSPECIFIED
+}
+
+func (x *PushTarget) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *PushTarget) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// 获取推送目标请求
+type GetPushTargetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPushTargetsRequest) Reset() {
+	*x = GetPushTargetsRequest{}
+	mi := &file_auth_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPushTargetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPushTargetsRequest) ProtoMessage() {}
+
+func (x *GetPushTargetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPushTargetsRequest.ProtoReflect.Descriptor instead.
+func (*GetPushTargetsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *GetPushTargetsRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// 获取推送目标响应
+type GetPushTargetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Targets       []*PushTarget          `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPushTargetsResponse) Reset() {
+	*x = GetPushTargetsResponse{}
+	mi := &file_auth_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPushTargetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPushTargetsResponse) ProtoMessage() {}
+
+func (x *GetPushTargetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPushTargetsResponse.ProtoReflect.Descriptor instead.
+func (*GetPushTargetsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *GetPushTargetsResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *GetPushTargetsResponse) GetTargets() []*PushTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+// 清除失效推送token请求
+type InvalidatePushTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Tokens        []string               `protobuf:"bytes,2,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidatePushTokensRequest) Reset() {
+	*x = InvalidatePushTokensRequest{}
+	mi := &file_auth_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidatePushTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidatePushTokensRequest) ProtoMessage() {}
+
+func (x *InvalidatePushTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidatePushTokensRequest.ProtoReflect.Descriptor instead.
+func (*InvalidatePushTokensRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *InvalidatePushTokensRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *InvalidatePushTokensRequest) GetTokens() []string {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+// 清除失效推送token响应
+type InvalidatePushTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Invalidated   int64                  `protobuf:"varint,2,opt,name=invalidated,proto3" json:"invalidated,omitempty"` // 清除的设备数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidatePushTokensResponse) Reset() {
+	*x = InvalidatePushTokensResponse{}
+	mi := &file_auth_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidatePushTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidatePushTokensResponse) ProtoMessage() {}
+
+func (x *InvalidatePushTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidatePushTokensResponse.ProtoReflect.Descriptor instead.
+func (*InvalidatePushTokensResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *InvalidatePushTokensResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *InvalidatePushTokensResponse) GetInvalidated() int64 {
+	if x != nil {
+		return x.Invalidated
+	}
+	return 0
+}
+
+// 检查用户名请求
+type CheckUsernameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"` // 可选, 提供时当前用户自己的用户名视为可用
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckUsernameRequest) Reset() {
+	*x = CheckUsernameRequest{}
+	mi := &file_auth_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckUsernameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckUsernameRequest) ProtoMessage() {}
+
+func (x *CheckUsernameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckUsernameRequest.ProtoReflect.Descriptor instead.
+func (*CheckUsernameRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *CheckUsernameRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CheckUsernameRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+// 检查用户名响应
+type CheckUsernameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Available     bool                   `protobuf:"varint,2,opt,name=available,proto3" json:"available,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // invalid, reserved, taken, held
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckUsernameResponse) Reset() {
+	*x = CheckUsernameResponse{}
+	mi := &file_auth_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckUsernameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckUsernameResponse) ProtoMessage() {}
+
+func (x *CheckUsernameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckUsernameResponse.ProtoReflect.Descriptor instead.
+func (*CheckUsernameResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *CheckUsernameResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *CheckUsernameResponse) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *CheckUsernameResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// 修改用户名请求
+type ChangeUsernameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeUsernameRequest) Reset() {
+	*x = ChangeUsernameRequest{}
+	mi := &file_auth_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeUsernameRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyTokenRequest) ProtoMessage() {}
+func (*ChangeUsernameRequest) ProtoMessage() {}
 
-func (x *VerifyTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[14]
+func (x *ChangeUsernameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1097,42 +6329,49 @@ func (x *VerifyTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyTokenRequest.ProtoReflect.Descriptor instead.
-func (*VerifyTokenRequest) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ChangeUsernameRequest.ProtoReflect.Descriptor instead.
+func (*ChangeUsernameRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{101}
 }
 
-func (x *VerifyTokenRequest) GetAccessToken() string {
+func (x *ChangeUsernameRequest) GetAccessToken() string {
 	if x != nil {
 		return x.AccessToken
 	}
 	return ""
 }
 
-// 验证Token响应
-type VerifyTokenResponse struct {
+func (x *ChangeUsernameRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// 修改用户名响应
+type ChangeUsernameResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Data          *VerifyTokenData       `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	User          *UserInfo              `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VerifyTokenResponse) Reset() {
-	*x = VerifyTokenResponse{}
-	mi := &file_auth_proto_msgTypes[15]
+func (x *ChangeUsernameResponse) Reset() {
+	*x = ChangeUsernameResponse{}
+	mi := &file_auth_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyTokenResponse) String() string {
+func (x *ChangeUsernameResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyTokenResponse) ProtoMessage() {}
+func (*ChangeUsernameResponse) ProtoMessage() {}
 
-func (x *VerifyTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[15]
+func (x *ChangeUsernameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1143,51 +6382,51 @@ func (x *VerifyTokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyTokenResponse.ProtoReflect.Descriptor instead.
-func (*VerifyTokenResponse) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ChangeUsernameResponse.ProtoReflect.Descriptor instead.
+func (*ChangeUsernameResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{102}
 }
 
-func (x *VerifyTokenResponse) GetResponse() *Response {
+func (x *ChangeUsernameResponse) GetResponse() *Response {
 	if x != nil {
 		return x.Response
 	}
 	return nil
 }
 
-func (x *VerifyTokenResponse) GetData() *VerifyTokenData {
+func (x *ChangeUsernameResponse) GetUser() *UserInfo {
 	if x != nil {
-		return x.Data
+		return x.User
 	}
 	return nil
 }
 
-type VerifyTokenData struct {
+// 用户公开信息
+type PublicUserInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
-	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	DeviceId      uint64                 `protobuf:"varint,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
-	DeviceToken   string                 `protobuf:"bytes,4,opt,name=device_token,json=deviceToken,proto3" json:"device_token,omitempty"`
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	AvatarUrl     string                 `protobuf:"bytes,3,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	AccountType   string                 `protobuf:"bytes,4,opt,name=account_type,json=accountType,proto3" json:"account_type,omitempty"` // user, bot
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VerifyTokenData) Reset() {
-	*x = VerifyTokenData{}
-	mi := &file_auth_proto_msgTypes[16]
+func (x *PublicUserInfo) Reset() {
+	*x = PublicUserInfo{}
+	mi := &file_auth_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyTokenData) String() string {
+func (x *PublicUserInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyTokenData) ProtoMessage() {}
+func (*PublicUserInfo) ProtoMessage() {}
 
-func (x *VerifyTokenData) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[16]
+func (x *PublicUserInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1198,69 +6437,160 @@ func (x *VerifyTokenData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyTokenData.ProtoReflect.Descriptor instead.
-func (*VerifyTokenData) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use PublicUserInfo.ProtoReflect.Descriptor instead.
+func (*PublicUserInfo) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{103}
 }
 
-func (x *VerifyTokenData) GetValid() bool {
+func (x *PublicUserInfo) GetId() uint64 {
 	if x != nil {
-		return x.Valid
+		return x.Id
 	}
-	return false
+	return 0
 }
 
-func (x *VerifyTokenData) GetUserId() uint64 {
+func (x *PublicUserInfo) GetUsername() string {
 	if x != nil {
-		return x.UserId
+		return x.Username
 	}
-	return 0
+	return ""
 }
 
-func (x *VerifyTokenData) GetDeviceId() uint64 {
+func (x *PublicUserInfo) GetAvatarUrl() string {
 	if x != nil {
-		return x.DeviceId
+		return x.AvatarUrl
 	}
-	return 0
+	return ""
 }
 
-func (x *VerifyTokenData) GetDeviceToken() string {
+func (x *PublicUserInfo) GetAccountType() string {
 	if x != nil {
-		return x.DeviceToken
+		return x.AccountType
 	}
 	return ""
 }
 
-func (x *VerifyTokenData) GetExpiresAt() *timestamppb.Timestamp {
+// 解析用户名请求
+type ResolveUsernameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveUsernameRequest) Reset() {
+	*x = ResolveUsernameRequest{}
+	mi := &file_auth_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveUsernameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveUsernameRequest) ProtoMessage() {}
+
+func (x *ResolveUsernameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[104]
 	if x != nil {
-		return x.ExpiresAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveUsernameRequest.ProtoReflect.Descriptor instead.
+func (*ResolveUsernameRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *ResolveUsernameRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// 解析用户名响应
+type ResolveUsernameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	User          *PublicUserInfo        `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveUsernameResponse) Reset() {
+	*x = ResolveUsernameResponse{}
+	mi := &file_auth_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveUsernameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveUsernameResponse) ProtoMessage() {}
+
+func (x *ResolveUsernameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveUsernameResponse.ProtoReflect.Descriptor instead.
+func (*ResolveUsernameResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *ResolveUsernameResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
 	}
 	return nil
 }
 
-// 获取用户信息请求
-type GetUserInfoRequest struct {
+func (x *ResolveUsernameResponse) GetUser() *PublicUserInfo {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// 设备心跳请求
+type HeartbeatRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserInfoRequest) Reset() {
-	*x = GetUserInfoRequest{}
-	mi := &file_auth_proto_msgTypes[17]
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_auth_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserInfoRequest) String() string {
+func (x *HeartbeatRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserInfoRequest) ProtoMessage() {}
+func (*HeartbeatRequest) ProtoMessage() {}
 
-func (x *GetUserInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[17]
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1271,42 +6601,42 @@ func (x *GetUserInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetUserInfoRequest) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{106}
 }
 
-func (x *GetUserInfoRequest) GetAccessToken() string {
+func (x *HeartbeatRequest) GetAccessToken() string {
 	if x != nil {
 		return x.AccessToken
 	}
 	return ""
 }
 
-// 获取用户信息响应
-type GetUserInfoResponse struct {
+// 设备心跳响应
+type HeartbeatResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	User          *UserInfo              `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	Interval      int32                  `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"` // 建议的下次心跳间隔(秒)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserInfoResponse) Reset() {
-	*x = GetUserInfoResponse{}
-	mi := &file_auth_proto_msgTypes[18]
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	mi := &file_auth_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserInfoResponse) String() string {
+func (x *HeartbeatResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserInfoResponse) ProtoMessage() {}
+func (*HeartbeatResponse) ProtoMessage() {}
 
-func (x *GetUserInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[18]
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1317,23 +6647,23 @@ func (x *GetUserInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserInfoResponse.ProtoReflect.Descriptor instead.
-func (*GetUserInfoResponse) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{107}
 }
 
-func (x *GetUserInfoResponse) GetResponse() *Response {
+func (x *HeartbeatResponse) GetResponse() *Response {
 	if x != nil {
 		return x.Response
 	}
 	return nil
 }
 
-func (x *GetUserInfoResponse) GetUser() *UserInfo {
+func (x *HeartbeatResponse) GetInterval() int32 {
 	if x != nil {
-		return x.User
+		return x.Interval
 	}
-	return nil
+	return 0
 }
 
 // 健康检查请求
@@ -1345,7 +6675,7 @@ type HealthRequest struct {
 
 func (x *HealthRequest) Reset() {
 	*x = HealthRequest{}
-	mi := &file_auth_proto_msgTypes[19]
+	mi := &file_auth_proto_msgTypes[108]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1357,7 +6687,7 @@ func (x *HealthRequest) String() string {
 func (*HealthRequest) ProtoMessage() {}
 
 func (x *HealthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[19]
+	mi := &file_auth_proto_msgTypes[108]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1370,7 +6700,7 @@ func (x *HealthRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
 func (*HealthRequest) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{19}
+	return file_auth_proto_rawDescGZIP(), []int{108}
 }
 
 // 健康检查响应
@@ -1384,7 +6714,7 @@ type HealthResponse struct {
 
 func (x *HealthResponse) Reset() {
 	*x = HealthResponse{}
-	mi := &file_auth_proto_msgTypes[20]
+	mi := &file_auth_proto_msgTypes[109]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1396,7 +6726,7 @@ func (x *HealthResponse) String() string {
 func (*HealthResponse) ProtoMessage() {}
 
 func (x *HealthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[20]
+	mi := &file_auth_proto_msgTypes[109]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1409,7 +6739,7 @@ func (x *HealthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
 func (*HealthResponse) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{20}
+	return file_auth_proto_rawDescGZIP(), []int{109}
 }
 
 func (x *HealthResponse) GetResponse() *Response {
@@ -1437,7 +6767,7 @@ type HealthData struct {
 
 func (x *HealthData) Reset() {
 	*x = HealthData{}
-	mi := &file_auth_proto_msgTypes[21]
+	mi := &file_auth_proto_msgTypes[110]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1449,7 +6779,7 @@ func (x *HealthData) String() string {
 func (*HealthData) ProtoMessage() {}
 
 func (x *HealthData) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[21]
+	mi := &file_auth_proto_msgTypes[110]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1462,7 +6792,7 @@ func (x *HealthData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthData.ProtoReflect.Descriptor instead.
 func (*HealthData) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{21}
+	return file_auth_proto_rawDescGZIP(), []int{110}
 }
 
 func (x *HealthData) GetService() string {
@@ -1508,7 +6838,7 @@ const file_auth_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xee\x02\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x93\x03\n" +
 	"\n" +
 	"DeviceInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
@@ -1524,7 +6854,9 @@ const file_auth_proto_rawDesc = "" +
 	"\flast_seen_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"lastSeenAt\x129\n" +
 	"\n" +
-	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x91\x01\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12#\n" +
+	"\rpush_provider\x18\n" +
+	" \x01(\tR\fpushProvider\"\x91\x01\n" +
 	"\tTokenInfo\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
 	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x12\x1d\n" +
@@ -1541,10 +6873,16 @@ const file_auth_proto_rawDesc = "" +
 	"\vdevice_type\x18\x06 \x01(\x0e2\x1d.telegramlite.auth.DeviceTypeR\n" +
 	"deviceType\x12\x1f\n" +
 	"\vdevice_name\x18\a \x01(\tR\n" +
-	"deviceName\"\x80\x01\n" +
+	"deviceName\"\xc6\x01\n" +
 	"\x10RegisterResponse\x127\n" +
 	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x123\n" +
-	"\x04data\x18\x02 \x01(\v2\x1f.telegramlite.auth.RegisterDataR\x04data\"\xaa\x01\n" +
+	"\x04data\x18\x02 \x01(\v2\x1f.telegramlite.auth.RegisterDataR\x04data\x12D\n" +
+	"\n" +
+	"violations\x18\x03 \x03(\v2$.telegramlite.auth.PasswordViolationR\n" +
+	"violations\"A\n" +
+	"\x11PasswordViolation\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xaa\x01\n" +
 	"\fRegisterData\x12/\n" +
 	"\x04user\x18\x01 \x01(\v2\x1b.telegramlite.auth.UserInfoR\x04user\x125\n" +
 	"\x06device\x18\x02 \x01(\v2\x1d.telegramlite.auth.DeviceInfoR\x06device\x122\n" +
@@ -1560,14 +6898,107 @@ const file_auth_proto_rawDesc = "" +
 	"\vdevice_name\x18\a \x01(\tR\n" +
 	"deviceNameB\f\n" +
 	"\n" +
-	"credential\"z\n" +
+	"credential\"\x95\x02\n" +
 	"\rLoginResponse\x127\n" +
 	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x120\n" +
-	"\x04data\x18\x02 \x01(\v2\x1c.telegramlite.auth.LoginDataR\x04data\"\xa7\x01\n" +
+	"\x04data\x18\x02 \x01(\v2\x1c.telegramlite.auth.LoginDataR\x04data\x12D\n" +
+	"\n" +
+	"two_factor\x18\x03 \x01(\v2%.telegramlite.auth.TwoFactorChallengeR\ttwoFactor\x12S\n" +
+	"\x0fdevice_approval\x18\x04 \x01(\v2*.telegramlite.auth.DeviceApprovalChallengeR\x0edeviceApproval\"\\\n" +
+	"\x12TwoFactorChallenge\x12'\n" +
+	"\x0fchallenge_token\x18\x01 \x01(\tR\x0echallengeToken\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x02 \x01(\x03R\texpiresIn\"\xa7\x01\n" +
 	"\tLoginData\x12/\n" +
 	"\x04user\x18\x01 \x01(\v2\x1b.telegramlite.auth.UserInfoR\x04user\x125\n" +
 	"\x06device\x18\x02 \x01(\v2\x1d.telegramlite.auth.DeviceInfoR\x06device\x122\n" +
-	"\x05token\x18\x03 \x01(\v2\x1c.telegramlite.auth.TokenInfoR\x05token\":\n" +
+	"\x05token\x18\x03 \x01(\v2\x1c.telegramlite.auth.TokenInfoR\x05token\"K\n" +
+	"\x0fSendCodeRequest\x12\x16\n" +
+	"\x05phone\x18\x01 \x01(\tH\x00R\x05phone\x12\x16\n" +
+	"\x05email\x18\x02 \x01(\tH\x00R\x05emailB\b\n" +
+	"\x06target\"\x80\x01\n" +
+	"\x10SendCodeResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x123\n" +
+	"\x04data\x18\x02 \x01(\v2\x1f.telegramlite.auth.SendCodeDataR\x04data\"P\n" +
+	"\fSendCodeData\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x01 \x01(\x03R\texpiresIn\x12!\n" +
+	"\fresend_after\x18\x02 \x01(\x03R\vresendAfter\"\x81\x02\n" +
+	"\x11VerifyCodeRequest\x12\x16\n" +
+	"\x05phone\x18\x01 \x01(\tH\x00R\x05phone\x12\x16\n" +
+	"\x05email\x18\x02 \x01(\tH\x00R\x05email\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12!\n" +
+	"\fdevice_token\x18\x04 \x01(\tR\vdeviceToken\x12>\n" +
+	"\vdevice_type\x18\x05 \x01(\x0e2\x1d.telegramlite.auth.DeviceTypeR\n" +
+	"deviceType\x12\x1f\n" +
+	"\vdevice_name\x18\x06 \x01(\tR\n" +
+	"deviceName\x12\x1a\n" +
+	"\busername\x18\a \x01(\tR\busernameB\b\n" +
+	"\x06target\"\xe5\x01\n" +
+	"\x12VerifyCodeResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x120\n" +
+	"\x04data\x18\x02 \x01(\v2\x1c.telegramlite.auth.LoginDataR\x04data\x12\x1e\n" +
+	"\vis_new_user\x18\x03 \x01(\bR\tisNewUser\x12D\n" +
+	"\n" +
+	"two_factor\x18\x04 \x01(\v2%.telegramlite.auth.TwoFactorChallengeR\ttwoFactor\"W\n" +
+	"\x1bRequestPasswordResetRequest\x12\x16\n" +
+	"\x05phone\x18\x01 \x01(\tH\x00R\x05phone\x12\x16\n" +
+	"\x05email\x18\x02 \x01(\tH\x00R\x05emailB\b\n" +
+	"\x06target\"W\n" +
+	"\x1cRequestPasswordResetResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\"O\n" +
+	"\x14ResetPasswordRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"\x96\x01\n" +
+	"\x15ResetPasswordResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x12D\n" +
+	"\n" +
+	"violations\x18\x02 \x03(\v2$.telegramlite.auth.PasswordViolationR\n" +
+	"violations\"\xc8\x01\n" +
+	"\x15ChangePasswordRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12!\n" +
+	"\fold_password\x18\x02 \x01(\tR\voldPassword\x12!\n" +
+	"\fnew_password\x18\x03 \x01(\tR\vnewPassword\x122\n" +
+	"\x15revoke_other_sessions\x18\x04 \x01(\bR\x13revokeOtherSessions\x12\x12\n" +
+	"\x04code\x18\x05 \x01(\tR\x04code\"\xc2\x01\n" +
+	"\x16ChangePasswordResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x12D\n" +
+	"\n" +
+	"violations\x18\x02 \x03(\v2$.telegramlite.auth.PasswordViolationR\n" +
+	"violations\x12)\n" +
+	"\x10revoked_sessions\x18\x03 \x01(\x05R\x0frevokedSessions\"X\n" +
+	"\x19VerifySecondFactorRequest\x12'\n" +
+	"\x0fchallenge_token\x18\x01 \x01(\tR\x0echallengeToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\x87\x01\n" +
+	"\x1aVerifySecondFactorResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x120\n" +
+	"\x04data\x18\x02 \x01(\v2\x1c.telegramlite.auth.LoginDataR\x04data\">\n" +
+	"\x19GetTwoFactorStatusRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"\xe4\x01\n" +
+	"\x1aGetTwoFactorStatusResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x129\n" +
+	"\n" +
+	"enabled_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tenabledAt\x128\n" +
+	"\x18recovery_codes_remaining\x18\x04 \x01(\x03R\x16recoveryCodesRemaining\";\n" +
+	"\x16EnrollTwoFactorRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"\xbc\x01\n" +
+	"\x17EnrollTwoFactorResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\x12)\n" +
+	"\x10provisioning_uri\x18\x03 \x01(\tR\x0fprovisioningUri\x12%\n" +
+	"\x0erecovery_codes\x18\x04 \x03(\tR\rrecoveryCodes\"P\n" +
+	"\x17ConfirmTwoFactorRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"S\n" +
+	"\x18ConfirmTwoFactorResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\"l\n" +
+	"\x17DisableTwoFactorRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\"S\n" +
+	"\x18DisableTwoFactorResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\":\n" +
 	"\x13RefreshTokenRequest\x12#\n" +
 	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\x83\x01\n" +
 	"\x14RefreshTokenResponse\x127\n" +
@@ -1581,19 +7012,294 @@ const file_auth_proto_rawDesc = "" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"\x86\x01\n" +
 	"\x13VerifyTokenResponse\x127\n" +
 	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x126\n" +
-	"\x04data\x18\x02 \x01(\v2\".telegramlite.auth.VerifyTokenDataR\x04data\"\xbb\x01\n" +
+	"\x04data\x18\x02 \x01(\v2\".telegramlite.auth.VerifyTokenDataR\x04data\"\x95\x02\n" +
 	"\x0fVerifyTokenData\x12\x14\n" +
 	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12\x1b\n" +
 	"\tdevice_id\x18\x03 \x01(\x04R\bdeviceId\x12!\n" +
 	"\fdevice_token\x18\x04 \x01(\tR\vdeviceToken\x129\n" +
 	"\n" +
-	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"7\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12!\n" +
+	"\faccount_type\x18\x06 \x01(\tR\vaccountType\x12\x16\n" +
+	"\x06scopes\x18\a \x03(\tR\x06scopes\x12\x1d\n" +
+	"\n" +
+	"token_kind\x18\b \x01(\tR\ttokenKind\"7\n" +
 	"\x12GetUserInfoRequest\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"\x7f\n" +
 	"\x13GetUserInfoResponse\x127\n" +
 	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x12/\n" +
-	"\x04user\x18\x02 \x01(\v2\x1b.telegramlite.auth.UserInfoR\x04user\"\x0f\n" +
+	"\x04user\x18\x02 \x01(\v2\x1b.telegramlite.auth.UserInfoR\x04user\"\xd0\x02\n" +
+	"\vSessionInfo\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\x04R\bdeviceId\x12>\n" +
+	"\vdevice_type\x18\x02 \x01(\x0e2\x1d.telegramlite.auth.DeviceTypeR\n" +
+	"deviceType\x12\x1f\n" +
+	"\vdevice_name\x18\x03 \x01(\tR\n" +
+	"deviceName\x12\x0e\n" +
+	"\x02ip\x18\x04 \x01(\tR\x02ip\x12\x1b\n" +
+	"\tis_online\x18\x05 \x01(\bR\bisOnline\x12\x1d\n" +
+	"\n" +
+	"is_current\x18\x06 \x01(\bR\tisCurrent\x12<\n" +
+	"\flast_seen_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenAt\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"8\n" +
+	"\x13ListSessionsRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"\x8b\x01\n" +
+	"\x14ListSessionsResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x12:\n" +
+	"\bsessions\x18\x02 \x03(\v2\x1e.telegramlite.auth.SessionInfoR\bsessions\"V\n" +
+	"\x14RevokeSessionRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\x04R\bdeviceId\"P\n" +
+	"\x15RevokeSessionResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\"?\n" +
+	"\x1aRevokeOtherSessionsRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"{\n" +
+	"\x1bRevokeOtherSessionsResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x12#\n" +
+	"\rrevoked_count\x18\x02 \x01(\x05R\frevokedCount\"v\n" +
+	"\x13RevokeTokensRequest\x12\x1b\n" +
+	"\btoken_id\x18\x01 \x01(\tH\x00R\atokenId\x12\x1d\n" +
+	"\tdevice_id\x18\x02 \x01(\x04H\x00R\bdeviceId\x12\x19\n" +
+	"\auser_id\x18\x03 \x01(\x04H\x00R\x06userIdB\b\n" +
+	"\x06target\"O\n" +
+	"\x14RevokeTokensResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\"\xd1\x01\n" +
+	"\n" +
+	"SigningKey\x12\x10\n" +
+	"\x03kid\x18\x01 \x01(\tR\x03kid\x12\x1c\n" +
+	"\talgorithm\x18\x02 \x01(\tR\talgorithm\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x03 \x01(\fR\tpublicKey\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x17\n" +
+	"\x15GetSigningKeysRequest\"\x84\x01\n" +
+	"\x16GetSigningKeysResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x121\n" +
+	"\x04keys\x18\x02 \x03(\v2\x1d.telegramlite.auth.SigningKeyR\x04keys\"\xf8\x01\n" +
+	"\x0fRevocationEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\btoken_id\x18\x02 \x01(\tH\x00R\atokenId\x12\x1d\n" +
+	"\tdevice_id\x18\x03 \x01(\x04H\x00R\bdeviceId\x12\x19\n" +
+	"\auser_id\x18\x04 \x01(\x04H\x00R\x06userId\x129\n" +
+	"\n" +
+	"revoked_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAtB\b\n" +
+	"\x06target\"E\n" +
+	"\x15GetRevocationsRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xa5\x01\n" +
+	"\x16GetRevocationsResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x12:\n" +
+	"\x06events\x18\x02 \x03(\v2\".telegramlite.auth.RevocationEventR\x06events\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"m\n" +
+	"\x18DeactivateAccountRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\"T\n" +
+	"\x19DeactivateAccountResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\"i\n" +
+	"\x14DeleteAccountRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\"\x87\x01\n" +
+	"\x15DeleteAccountResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x125\n" +
+	"\bpurge_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\apurgeAt\"p\n" +
+	"\fAccountPurge\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\x127\n" +
+	"\tpurged_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bpurgedAt\"G\n" +
+	"\x17GetAccountPurgesRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xa4\x01\n" +
+	"\x18GetAccountPurgesResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x127\n" +
+	"\x06purges\x18\x02 \x03(\v2\x1f.telegramlite.auth.AccountPurgeR\x06purges\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\"e\n" +
+	"\x16ExportUserDataResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\x9d\x02\n" +
+	"\tAuthEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x03 \x01(\x04R\bdeviceId\x12\x14\n" +
+	"\x05event\x18\x04 \x01(\tR\x05event\x12\x18\n" +
+	"\aoutcome\x18\x05 \x01(\tR\aoutcome\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x0e\n" +
+	"\x02ip\x18\a \x01(\tR\x02ip\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\b \x01(\tR\tuserAgent\x12\x18\n" +
+	"\adetails\x18\t \x01(\tR\adetails\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"l\n" +
+	"\x19ListSecurityEventsRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xa3\x01\n" +
+	"\x1aListSecurityEventsResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x124\n" +
+	"\x06events\x18\x02 \x03(\v2\x1c.telegramlite.auth.AuthEventR\x06events\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"\xd3\x01\n" +
+	"\x16QueryAuthEventsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\"\xa0\x01\n" +
+	"\x17QueryAuthEventsResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x124\n" +
+	"\x06events\x18\x02 \x03(\v2\x1c.telegramlite.auth.AuthEventR\x06events\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"\x98\x01\n" +
+	"\x17DeviceApprovalChallenge\x12%\n" +
+	"\x0eapproval_token\x18\x01 \x01(\tR\rapprovalToken\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1f\n" +
+	"\vfallback_in\x18\x03 \x01(\x03R\n" +
+	"fallbackIn\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x04 \x01(\x03R\texpiresIn\"\x87\x02\n" +
+	"\x0eDeviceApproval\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1f\n" +
+	"\vdevice_type\x18\x02 \x01(\tR\n" +
+	"deviceType\x12\x1f\n" +
+	"\vdevice_name\x18\x03 \x01(\tR\n" +
+	"deviceName\x12\x0e\n" +
+	"\x02ip\x18\x04 \x01(\tR\x02ip\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x05 \x01(\tR\tuserAgent\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"A\n" +
+	"\x18GetDeviceApprovalRequest\x12%\n" +
+	"\x0eapproval_token\x18\x01 \x01(\tR\rapprovalToken\"\xa1\x02\n" +
+	"\x19GetDeviceApprovalResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x120\n" +
+	"\x04data\x18\x02 \x01(\v2\x1c.telegramlite.auth.LoginDataR\x04data\x12D\n" +
+	"\n" +
+	"two_factor\x18\x03 \x01(\v2%.telegramlite.auth.TwoFactorChallengeR\ttwoFactor\x12S\n" +
+	"\x0fdevice_approval\x18\x04 \x01(\v2*.telegramlite.auth.DeviceApprovalChallengeR\x0edeviceApproval\"F\n" +
+	"\x1dSendDeviceApprovalCodeRequest\x12%\n" +
+	"\x0eapproval_token\x18\x01 \x01(\tR\rapprovalToken\"\x8e\x01\n" +
+	"\x1eSendDeviceApprovalCodeResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x123\n" +
+	"\x04data\x18\x02 \x01(\v2\x1f.telegramlite.auth.SendCodeDataR\x04data\"\\\n" +
+	"\x1fVerifyDeviceApprovalCodeRequest\x12%\n" +
+	"\x0eapproval_token\x18\x01 \x01(\tR\rapprovalToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\xd3\x01\n" +
+	" VerifyDeviceApprovalCodeResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x120\n" +
+	"\x04data\x18\x02 \x01(\v2\x1c.telegramlite.auth.LoginDataR\x04data\x12D\n" +
+	"\n" +
+	"two_factor\x18\x03 \x01(\v2%.telegramlite.auth.TwoFactorChallengeR\ttwoFactor\"?\n" +
+	"\x1aListDeviceApprovalsRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"\x97\x01\n" +
+	"\x1bListDeviceApprovalsResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x12?\n" +
+	"\tapprovals\x18\x02 \x03(\v2!.telegramlite.auth.DeviceApprovalR\tapprovals\"@\n" +
+	"\x1bWatchDeviceApprovalsRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"{\n" +
+	"\x1bDecideDeviceApprovalRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1f\n" +
+	"\vapproval_id\x18\x02 \x01(\x04R\n" +
+	"approvalId\x12\x18\n" +
+	"\aapprove\x18\x03 \x01(\bR\aapprove\"W\n" +
+	"\x1cDecideDeviceApprovalResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\"\x9d\x01\n" +
+	"\x17ExportLoginTokenRequest\x12!\n" +
+	"\fdevice_token\x18\x01 \x01(\tR\vdeviceToken\x12>\n" +
+	"\vdevice_type\x18\x02 \x01(\x0e2\x1d.telegramlite.auth.DeviceTypeR\n" +
+	"deviceType\x12\x1f\n" +
+	"\vdevice_name\x18\x03 \x01(\tR\n" +
+	"deviceName\"\xa7\x01\n" +
+	"\x18ExportLoginTokenResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"qr_payload\x18\x03 \x01(\tR\tqrPayload\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x04 \x01(\x03R\texpiresIn\"R\n" +
+	"\x17AcceptLoginTokenRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"S\n" +
+	"\x18AcceptLoginTokenResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\"u\n" +
+	"\x17ImportLoginTokenRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12!\n" +
+	"\fdevice_token\x18\x02 \x01(\tR\vdeviceToken\x12!\n" +
+	"\fwait_seconds\x18\x03 \x01(\x05R\vwaitSeconds\"\x84\x02\n" +
+	"\x18ImportLoginTokenResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x120\n" +
+	"\x04data\x18\x02 \x01(\v2\x1c.telegramlite.auth.LoginDataR\x04data\x12D\n" +
+	"\n" +
+	"two_factor\x18\x03 \x01(\v2%.telegramlite.auth.TwoFactorChallengeR\ttwoFactor\x12\x18\n" +
+	"\apending\x18\x04 \x01(\bR\apending\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x05 \x01(\x03R\texpiresIn\"o\n" +
+	"\x18RegisterPushTokenRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\"T\n" +
+	"\x19RegisterPushTokenResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\"?\n" +
+	"\x1aUnregisterPushTokenRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"V\n" +
+	"\x1bUnregisterPushTokenResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\"\x9b\x01\n" +
+	"\n" +
+	"PushTarget\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\x04R\bdeviceId\x12>\n" +
+	"\vdevice_type\x18\x02 \x01(\x0e2\x1d.telegramlite.auth.DeviceTypeR\n" +
+	"deviceType\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\"0\n" +
+	"\x15GetPushTargetsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\"\x8a\x01\n" +
+	"\x16GetPushTargetsResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x127\n" +
+	"\atargets\x18\x02 \x03(\v2\x1d.telegramlite.auth.PushTargetR\atargets\"Q\n" +
+	"\x1bInvalidatePushTokensRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x16\n" +
+	"\x06tokens\x18\x02 \x03(\tR\x06tokens\"y\n" +
+	"\x1cInvalidatePushTokensResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x12 \n" +
+	"\vinvalidated\x18\x02 \x01(\x03R\vinvalidated\"U\n" +
+	"\x14CheckUsernameRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12!\n" +
+	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\"\x86\x01\n" +
+	"\x15CheckUsernameResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x12\x1c\n" +
+	"\tavailable\x18\x02 \x01(\bR\tavailable\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"V\n" +
+	"\x15ChangeUsernameRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\"\x82\x01\n" +
+	"\x16ChangeUsernameResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x12/\n" +
+	"\x04user\x18\x02 \x01(\v2\x1b.telegramlite.auth.UserInfoR\x04user\"~\n" +
+	"\x0ePublicUserInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x03 \x01(\tR\tavatarUrl\x12!\n" +
+	"\faccount_type\x18\x04 \x01(\tR\vaccountType\"4\n" +
+	"\x16ResolveUsernameRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"\x89\x01\n" +
+	"\x17ResolveUsernameResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x125\n" +
+	"\x04user\x18\x02 \x01(\v2!.telegramlite.auth.PublicUserInfoR\x04user\"5\n" +
+	"\x10HeartbeatRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"h\n" +
+	"\x11HeartbeatResponse\x127\n" +
+	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x12\x1a\n" +
+	"\binterval\x18\x02 \x01(\x05R\binterval\"\x0f\n" +
 	"\rHealthRequest\"|\n" +
 	"\x0eHealthResponse\x127\n" +
 	"\bresponse\x18\x01 \x01(\v2\x1b.telegramlite.auth.ResponseR\bresponse\x121\n" +
@@ -1609,14 +7315,54 @@ const file_auth_proto_rawDesc = "" +
 	"\x0fDEVICE_TYPE_IOS\x10\x01\x12\x17\n" +
 	"\x13DEVICE_TYPE_ANDROID\x10\x02\x12\x13\n" +
 	"\x0fDEVICE_TYPE_WEB\x10\x03\x12\x17\n" +
-	"\x13DEVICE_TYPE_DESKTOP\x10\x042\xe9\x04\n" +
+	"\x13DEVICE_TYPE_DESKTOP\x10\x042\xe1%\n" +
 	"\vAuthService\x12S\n" +
 	"\bRegister\x12\".telegramlite.auth.RegisterRequest\x1a#.telegramlite.auth.RegisterResponse\x12J\n" +
-	"\x05Login\x12\x1f.telegramlite.auth.LoginRequest\x1a .telegramlite.auth.LoginResponse\x12_\n" +
+	"\x05Login\x12\x1f.telegramlite.auth.LoginRequest\x1a .telegramlite.auth.LoginResponse\x12S\n" +
+	"\bSendCode\x12\".telegramlite.auth.SendCodeRequest\x1a#.telegramlite.auth.SendCodeResponse\x12Y\n" +
+	"\n" +
+	"VerifyCode\x12$.telegramlite.auth.VerifyCodeRequest\x1a%.telegramlite.auth.VerifyCodeResponse\x12w\n" +
+	"\x14RequestPasswordReset\x12..telegramlite.auth.RequestPasswordResetRequest\x1a/.telegramlite.auth.RequestPasswordResetResponse\x12b\n" +
+	"\rResetPassword\x12'.telegramlite.auth.ResetPasswordRequest\x1a(.telegramlite.auth.ResetPasswordResponse\x12e\n" +
+	"\x0eChangePassword\x12(.telegramlite.auth.ChangePasswordRequest\x1a).telegramlite.auth.ChangePasswordResponse\x12q\n" +
+	"\x12VerifySecondFactor\x12,.telegramlite.auth.VerifySecondFactorRequest\x1a-.telegramlite.auth.VerifySecondFactorResponse\x12q\n" +
+	"\x12GetTwoFactorStatus\x12,.telegramlite.auth.GetTwoFactorStatusRequest\x1a-.telegramlite.auth.GetTwoFactorStatusResponse\x12h\n" +
+	"\x0fEnrollTwoFactor\x12).telegramlite.auth.EnrollTwoFactorRequest\x1a*.telegramlite.auth.EnrollTwoFactorResponse\x12k\n" +
+	"\x10ConfirmTwoFactor\x12*.telegramlite.auth.ConfirmTwoFactorRequest\x1a+.telegramlite.auth.ConfirmTwoFactorResponse\x12k\n" +
+	"\x10DisableTwoFactor\x12*.telegramlite.auth.DisableTwoFactorRequest\x1a+.telegramlite.auth.DisableTwoFactorResponse\x12_\n" +
 	"\fRefreshToken\x12&.telegramlite.auth.RefreshTokenRequest\x1a'.telegramlite.auth.RefreshTokenResponse\x12M\n" +
 	"\x06Logout\x12 .telegramlite.auth.LogoutRequest\x1a!.telegramlite.auth.LogoutResponse\x12\\\n" +
 	"\vVerifyToken\x12%.telegramlite.auth.VerifyTokenRequest\x1a&.telegramlite.auth.VerifyTokenResponse\x12\\\n" +
-	"\vGetUserInfo\x12%.telegramlite.auth.GetUserInfoRequest\x1a&.telegramlite.auth.GetUserInfoResponse\x12M\n" +
+	"\vGetUserInfo\x12%.telegramlite.auth.GetUserInfoRequest\x1a&.telegramlite.auth.GetUserInfoResponse\x12_\n" +
+	"\fListSessions\x12&.telegramlite.auth.ListSessionsRequest\x1a'.telegramlite.auth.ListSessionsResponse\x12b\n" +
+	"\rRevokeSession\x12'.telegramlite.auth.RevokeSessionRequest\x1a(.telegramlite.auth.RevokeSessionResponse\x12t\n" +
+	"\x13RevokeOtherSessions\x12-.telegramlite.auth.RevokeOtherSessionsRequest\x1a..telegramlite.auth.RevokeOtherSessionsResponse\x12_\n" +
+	"\fRevokeTokens\x12&.telegramlite.auth.RevokeTokensRequest\x1a'.telegramlite.auth.RevokeTokensResponse\x12e\n" +
+	"\x0eGetSigningKeys\x12(.telegramlite.auth.GetSigningKeysRequest\x1a).telegramlite.auth.GetSigningKeysResponse\x12e\n" +
+	"\x0eGetRevocations\x12(.telegramlite.auth.GetRevocationsRequest\x1a).telegramlite.auth.GetRevocationsResponse\x12n\n" +
+	"\x11DeactivateAccount\x12+.telegramlite.auth.DeactivateAccountRequest\x1a,.telegramlite.auth.DeactivateAccountResponse\x12b\n" +
+	"\rDeleteAccount\x12'.telegramlite.auth.DeleteAccountRequest\x1a(.telegramlite.auth.DeleteAccountResponse\x12k\n" +
+	"\x10GetAccountPurges\x12*.telegramlite.auth.GetAccountPurgesRequest\x1a+.telegramlite.auth.GetAccountPurgesResponse\x12e\n" +
+	"\x0eExportUserData\x12(.telegramlite.auth.ExportUserDataRequest\x1a).telegramlite.auth.ExportUserDataResponse\x12q\n" +
+	"\x12ListSecurityEvents\x12,.telegramlite.auth.ListSecurityEventsRequest\x1a-.telegramlite.auth.ListSecurityEventsResponse\x12h\n" +
+	"\x0fQueryAuthEvents\x12).telegramlite.auth.QueryAuthEventsRequest\x1a*.telegramlite.auth.QueryAuthEventsResponse\x12n\n" +
+	"\x11GetDeviceApproval\x12+.telegramlite.auth.GetDeviceApprovalRequest\x1a,.telegramlite.auth.GetDeviceApprovalResponse\x12}\n" +
+	"\x16SendDeviceApprovalCode\x120.telegramlite.auth.SendDeviceApprovalCodeRequest\x1a1.telegramlite.auth.SendDeviceApprovalCodeResponse\x12\x83\x01\n" +
+	"\x18VerifyDeviceApprovalCode\x122.telegramlite.auth.VerifyDeviceApprovalCodeRequest\x1a3.telegramlite.auth.VerifyDeviceApprovalCodeResponse\x12t\n" +
+	"\x13ListDeviceApprovals\x12-.telegramlite.auth.ListDeviceApprovalsRequest\x1a..telegramlite.auth.ListDeviceApprovalsResponse\x12k\n" +
+	"\x14WatchDeviceApprovals\x12..telegramlite.auth.WatchDeviceApprovalsRequest\x1a!.telegramlite.auth.DeviceApproval0\x01\x12w\n" +
+	"\x14DecideDeviceApproval\x12..telegramlite.auth.DecideDeviceApprovalRequest\x1a/.telegramlite.auth.DecideDeviceApprovalResponse\x12k\n" +
+	"\x10ExportLoginToken\x12*.telegramlite.auth.ExportLoginTokenRequest\x1a+.telegramlite.auth.ExportLoginTokenResponse\x12k\n" +
+	"\x10AcceptLoginToken\x12*.telegramlite.auth.AcceptLoginTokenRequest\x1a+.telegramlite.auth.AcceptLoginTokenResponse\x12k\n" +
+	"\x10ImportLoginToken\x12*.telegramlite.auth.ImportLoginTokenRequest\x1a+.telegramlite.auth.ImportLoginTokenResponse\x12n\n" +
+	"\x11RegisterPushToken\x12+.telegramlite.auth.RegisterPushTokenRequest\x1a,.telegramlite.auth.RegisterPushTokenResponse\x12t\n" +
+	"\x13UnregisterPushToken\x12-.telegramlite.auth.UnregisterPushTokenRequest\x1a..telegramlite.auth.UnregisterPushTokenResponse\x12e\n" +
+	"\x0eGetPushTargets\x12(.telegramlite.auth.GetPushTargetsRequest\x1a).telegramlite.auth.GetPushTargetsResponse\x12w\n" +
+	"\x14InvalidatePushTokens\x12..telegramlite.auth.InvalidatePushTokensRequest\x1a/.telegramlite.auth.InvalidatePushTokensResponse\x12b\n" +
+	"\rCheckUsername\x12'.telegramlite.auth.CheckUsernameRequest\x1a(.telegramlite.auth.CheckUsernameResponse\x12e\n" +
+	"\x0eChangeUsername\x12(.telegramlite.auth.ChangeUsernameRequest\x1a).telegramlite.auth.ChangeUsernameResponse\x12h\n" +
+	"\x0fResolveUsername\x12).telegramlite.auth.ResolveUsernameRequest\x1a*.telegramlite.auth.ResolveUsernameResponse\x12V\n" +
+	"\tHeartbeat\x12#.telegramlite.auth.HeartbeatRequest\x1a$.telegramlite.auth.HeartbeatResponse\x12M\n" +
 	"\x06Health\x12 .telegramlite.auth.HealthRequest\x1a!.telegramlite.auth.HealthResponseB;Z9github.com/jacl-coder/telegramlite/auth_service/api/protob\x06proto3"
 
 var (
@@ -1632,83 +7378,333 @@ func file_auth_proto_rawDescGZIP() []byte {
 }
 
 var file_auth_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 111)
 var file_auth_proto_goTypes = []any{
-	(DeviceType)(0),               // 0: telegramlite.auth.DeviceType
-	(*Response)(nil),              // 1: telegramlite.auth.Response
-	(*UserInfo)(nil),              // 2: telegramlite.auth.UserInfo
-	(*DeviceInfo)(nil),            // 3: telegramlite.auth.DeviceInfo
-	(*TokenInfo)(nil),             // 4: telegramlite.auth.TokenInfo
-	(*RegisterRequest)(nil),       // 5: telegramlite.auth.RegisterRequest
-	(*RegisterResponse)(nil),      // 6: telegramlite.auth.RegisterResponse
-	(*RegisterData)(nil),          // 7: telegramlite.auth.RegisterData
-	(*LoginRequest)(nil),          // 8: telegramlite.auth.LoginRequest
-	(*LoginResponse)(nil),         // 9: telegramlite.auth.LoginResponse
-	(*LoginData)(nil),             // 10: telegramlite.auth.LoginData
-	(*RefreshTokenRequest)(nil),   // 11: telegramlite.auth.RefreshTokenRequest
-	(*RefreshTokenResponse)(nil),  // 12: telegramlite.auth.RefreshTokenResponse
-	(*LogoutRequest)(nil),         // 13: telegramlite.auth.LogoutRequest
-	(*LogoutResponse)(nil),        // 14: telegramlite.auth.LogoutResponse
-	(*VerifyTokenRequest)(nil),    // 15: telegramlite.auth.VerifyTokenRequest
-	(*VerifyTokenResponse)(nil),   // 16: telegramlite.auth.VerifyTokenResponse
-	(*VerifyTokenData)(nil),       // 17: telegramlite.auth.VerifyTokenData
-	(*GetUserInfoRequest)(nil),    // 18: telegramlite.auth.GetUserInfoRequest
-	(*GetUserInfoResponse)(nil),   // 19: telegramlite.auth.GetUserInfoResponse
-	(*HealthRequest)(nil),         // 20: telegramlite.auth.HealthRequest
-	(*HealthResponse)(nil),        // 21: telegramlite.auth.HealthResponse
-	(*HealthData)(nil),            // 22: telegramlite.auth.HealthData
-	(*timestamppb.Timestamp)(nil), // 23: google.protobuf.Timestamp
+	(DeviceType)(0),                          // 0: telegramlite.auth.DeviceType
+	(*Response)(nil),                         // 1: telegramlite.auth.Response
+	(*UserInfo)(nil),                         // 2: telegramlite.auth.UserInfo
+	(*DeviceInfo)(nil),                       // 3: telegramlite.auth.DeviceInfo
+	(*TokenInfo)(nil),                        // 4: telegramlite.auth.TokenInfo
+	(*RegisterRequest)(nil),                  // 5: telegramlite.auth.RegisterRequest
+	(*RegisterResponse)(nil),                 // 6: telegramlite.auth.RegisterResponse
+	(*PasswordViolation)(nil),                // 7: telegramlite.auth.PasswordViolation
+	(*RegisterData)(nil),                     // 8: telegramlite.auth.RegisterData
+	(*LoginRequest)(nil),                     // 9: telegramlite.auth.LoginRequest
+	(*LoginResponse)(nil),                    // 10: telegramlite.auth.LoginResponse
+	(*TwoFactorChallenge)(nil),               // 11: telegramlite.auth.TwoFactorChallenge
+	(*LoginData)(nil),                        // 12: telegramlite.auth.LoginData
+	(*SendCodeRequest)(nil),                  // 13: telegramlite.auth.SendCodeRequest
+	(*SendCodeResponse)(nil),                 // 14: telegramlite.auth.SendCodeResponse
+	(*SendCodeData)(nil),                     // 15: telegramlite.auth.SendCodeData
+	(*VerifyCodeRequest)(nil),                // 16: telegramlite.auth.VerifyCodeRequest
+	(*VerifyCodeResponse)(nil),               // 17: telegramlite.auth.VerifyCodeResponse
+	(*RequestPasswordResetRequest)(nil),      // 18: telegramlite.auth.RequestPasswordResetRequest
+	(*RequestPasswordResetResponse)(nil),     // 19: telegramlite.auth.RequestPasswordResetResponse
+	(*ResetPasswordRequest)(nil),             // 20: telegramlite.auth.ResetPasswordRequest
+	(*ResetPasswordResponse)(nil),            // 21: telegramlite.auth.ResetPasswordResponse
+	(*ChangePasswordRequest)(nil),            // 22: telegramlite.auth.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),           // 23: telegramlite.auth.ChangePasswordResponse
+	(*VerifySecondFactorRequest)(nil),        // 24: telegramlite.auth.VerifySecondFactorRequest
+	(*VerifySecondFactorResponse)(nil),       // 25: telegramlite.auth.VerifySecondFactorResponse
+	(*GetTwoFactorStatusRequest)(nil),        // 26: telegramlite.auth.GetTwoFactorStatusRequest
+	(*GetTwoFactorStatusResponse)(nil),       // 27: telegramlite.auth.GetTwoFactorStatusResponse
+	(*EnrollTwoFactorRequest)(nil),           // 28: telegramlite.auth.EnrollTwoFactorRequest
+	(*EnrollTwoFactorResponse)(nil),          // 29: telegramlite.auth.EnrollTwoFactorResponse
+	(*ConfirmTwoFactorRequest)(nil),          // 30: telegramlite.auth.ConfirmTwoFactorRequest
+	(*ConfirmTwoFactorResponse)(nil),         // 31: telegramlite.auth.ConfirmTwoFactorResponse
+	(*DisableTwoFactorRequest)(nil),          // 32: telegramlite.auth.DisableTwoFactorRequest
+	(*DisableTwoFactorResponse)(nil),         // 33: telegramlite.auth.DisableTwoFactorResponse
+	(*RefreshTokenRequest)(nil),              // 34: telegramlite.auth.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),             // 35: telegramlite.auth.RefreshTokenResponse
+	(*LogoutRequest)(nil),                    // 36: telegramlite.auth.LogoutRequest
+	(*LogoutResponse)(nil),                   // 37: telegramlite.auth.LogoutResponse
+	(*VerifyTokenRequest)(nil),               // 38: telegramlite.auth.VerifyTokenRequest
+	(*VerifyTokenResponse)(nil),              // 39: telegramlite.auth.VerifyTokenResponse
+	(*VerifyTokenData)(nil),                  // 40: telegramlite.auth.VerifyTokenData
+	(*GetUserInfoRequest)(nil),               // 41: telegramlite.auth.GetUserInfoRequest
+	(*GetUserInfoResponse)(nil),              // 42: telegramlite.auth.GetUserInfoResponse
+	(*SessionInfo)(nil),                      // 43: telegramlite.auth.SessionInfo
+	(*ListSessionsRequest)(nil),              // 44: telegramlite.auth.ListSessionsRequest
+	(*ListSessionsResponse)(nil),             // 45: telegramlite.auth.ListSessionsResponse
+	(*RevokeSessionRequest)(nil),             // 46: telegramlite.auth.RevokeSessionRequest
+	(*RevokeSessionResponse)(nil),            // 47: telegramlite.auth.RevokeSessionResponse
+	(*RevokeOtherSessionsRequest)(nil),       // 48: telegramlite.auth.RevokeOtherSessionsRequest
+	(*RevokeOtherSessionsResponse)(nil),      // 49: telegramlite.auth.RevokeOtherSessionsResponse
+	(*RevokeTokensRequest)(nil),              // 50: telegramlite.auth.RevokeTokensRequest
+	(*RevokeTokensResponse)(nil),             // 51: telegramlite.auth.RevokeTokensResponse
+	(*SigningKey)(nil),                       // 52: telegramlite.auth.SigningKey
+	(*GetSigningKeysRequest)(nil),            // 53: telegramlite.auth.GetSigningKeysRequest
+	(*GetSigningKeysResponse)(nil),           // 54: telegramlite.auth.GetSigningKeysResponse
+	(*RevocationEvent)(nil),                  // 55: telegramlite.auth.RevocationEvent
+	(*GetRevocationsRequest)(nil),            // 56: telegramlite.auth.GetRevocationsRequest
+	(*GetRevocationsResponse)(nil),           // 57: telegramlite.auth.GetRevocationsResponse
+	(*DeactivateAccountRequest)(nil),         // 58: telegramlite.auth.DeactivateAccountRequest
+	(*DeactivateAccountResponse)(nil),        // 59: telegramlite.auth.DeactivateAccountResponse
+	(*DeleteAccountRequest)(nil),             // 60: telegramlite.auth.DeleteAccountRequest
+	(*DeleteAccountResponse)(nil),            // 61: telegramlite.auth.DeleteAccountResponse
+	(*AccountPurge)(nil),                     // 62: telegramlite.auth.AccountPurge
+	(*GetAccountPurgesRequest)(nil),          // 63: telegramlite.auth.GetAccountPurgesRequest
+	(*GetAccountPurgesResponse)(nil),         // 64: telegramlite.auth.GetAccountPurgesResponse
+	(*ExportUserDataRequest)(nil),            // 65: telegramlite.auth.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil),           // 66: telegramlite.auth.ExportUserDataResponse
+	(*AuthEvent)(nil),                        // 67: telegramlite.auth.AuthEvent
+	(*ListSecurityEventsRequest)(nil),        // 68: telegramlite.auth.ListSecurityEventsRequest
+	(*ListSecurityEventsResponse)(nil),       // 69: telegramlite.auth.ListSecurityEventsResponse
+	(*QueryAuthEventsRequest)(nil),           // 70: telegramlite.auth.QueryAuthEventsRequest
+	(*QueryAuthEventsResponse)(nil),          // 71: telegramlite.auth.QueryAuthEventsResponse
+	(*DeviceApprovalChallenge)(nil),          // 72: telegramlite.auth.DeviceApprovalChallenge
+	(*DeviceApproval)(nil),                   // 73: telegramlite.auth.DeviceApproval
+	(*GetDeviceApprovalRequest)(nil),         // 74: telegramlite.auth.GetDeviceApprovalRequest
+	(*GetDeviceApprovalResponse)(nil),        // 75: telegramlite.auth.GetDeviceApprovalResponse
+	(*SendDeviceApprovalCodeRequest)(nil),    // 76: telegramlite.auth.SendDeviceApprovalCodeRequest
+	(*SendDeviceApprovalCodeResponse)(nil),   // 77: telegramlite.auth.SendDeviceApprovalCodeResponse
+	(*VerifyDeviceApprovalCodeRequest)(nil),  // 78: telegramlite.auth.VerifyDeviceApprovalCodeRequest
+	(*VerifyDeviceApprovalCodeResponse)(nil), // 79: telegramlite.auth.VerifyDeviceApprovalCodeResponse
+	(*ListDeviceApprovalsRequest)(nil),       // 80: telegramlite.auth.ListDeviceApprovalsRequest
+	(*ListDeviceApprovalsResponse)(nil),      // 81: telegramlite.auth.ListDeviceApprovalsResponse
+	(*WatchDeviceApprovalsRequest)(nil),      // 82: telegramlite.auth.WatchDeviceApprovalsRequest
+	(*DecideDeviceApprovalRequest)(nil),      // 83: telegramlite.auth.DecideDeviceApprovalRequest
+	(*DecideDeviceApprovalResponse)(nil),     // 84: telegramlite.auth.DecideDeviceApprovalResponse
+	(*ExportLoginTokenRequest)(nil),          // 85: telegramlite.auth.ExportLoginTokenRequest
+	(*ExportLoginTokenResponse)(nil),         // 86: telegramlite.auth.ExportLoginTokenResponse
+	(*AcceptLoginTokenRequest)(nil),          // 87: telegramlite.auth.AcceptLoginTokenRequest
+	(*AcceptLoginTokenResponse)(nil),         // 88: telegramlite.auth.AcceptLoginTokenResponse
+	(*ImportLoginTokenRequest)(nil),          // 89: telegramlite.auth.ImportLoginTokenRequest
+	(*ImportLoginTokenResponse)(nil),         // 90: telegramlite.auth.ImportLoginTokenResponse
+	(*RegisterPushTokenRequest)(nil),         // 91: telegramlite.auth.RegisterPushTokenRequest
+	(*RegisterPushTokenResponse)(nil),        // 92: telegramlite.auth.RegisterPushTokenResponse
+	(*UnregisterPushTokenRequest)(nil),       // 93: telegramlite.auth.UnregisterPushTokenRequest
+	(*UnregisterPushTokenResponse)(nil),      // 94: telegramlite.auth.UnregisterPushTokenResponse
+	(*PushTarget)(nil),                       // 95: telegramlite.auth.PushTarget
+	(*GetPushTargetsRequest)(nil),            // 96: telegramlite.auth.GetPushTargetsRequest
+	(*GetPushTargetsResponse)(nil),           // 97: telegramlite.auth.GetPushTargetsResponse
+	(*InvalidatePushTokensRequest)(nil),      // 98: telegramlite.auth.InvalidatePushTokensRequest
+	(*InvalidatePushTokensResponse)(nil),     // 99: telegramlite.auth.InvalidatePushTokensResponse
+	(*CheckUsernameRequest)(nil),             // 100: telegramlite.auth.CheckUsernameRequest
+	(*CheckUsernameResponse)(nil),            // 101: telegramlite.auth.CheckUsernameResponse
+	(*ChangeUsernameRequest)(nil),            // 102: telegramlite.auth.ChangeUsernameRequest
+	(*ChangeUsernameResponse)(nil),           // 103: telegramlite.auth.ChangeUsernameResponse
+	(*PublicUserInfo)(nil),                   // 104: telegramlite.auth.PublicUserInfo
+	(*ResolveUsernameRequest)(nil),           // 105: telegramlite.auth.ResolveUsernameRequest
+	(*ResolveUsernameResponse)(nil),          // 106: telegramlite.auth.ResolveUsernameResponse
+	(*HeartbeatRequest)(nil),                 // 107: telegramlite.auth.HeartbeatRequest
+	(*HeartbeatResponse)(nil),                // 108: telegramlite.auth.HeartbeatResponse
+	(*HealthRequest)(nil),                    // 109: telegramlite.auth.HealthRequest
+	(*HealthResponse)(nil),                   // 110: telegramlite.auth.HealthResponse
+	(*HealthData)(nil),                       // 111: telegramlite.auth.HealthData
+	(*timestamppb.Timestamp)(nil),            // 112: google.protobuf.Timestamp
 }
 var file_auth_proto_depIdxs = []int32{
-	23, // 0: telegramlite.auth.Response.timestamp:type_name -> google.protobuf.Timestamp
-	23, // 1: telegramlite.auth.UserInfo.last_login_at:type_name -> google.protobuf.Timestamp
-	23, // 2: telegramlite.auth.UserInfo.created_at:type_name -> google.protobuf.Timestamp
-	23, // 3: telegramlite.auth.UserInfo.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 4: telegramlite.auth.DeviceInfo.device_type:type_name -> telegramlite.auth.DeviceType
-	23, // 5: telegramlite.auth.DeviceInfo.last_seen_at:type_name -> google.protobuf.Timestamp
-	23, // 6: telegramlite.auth.DeviceInfo.created_at:type_name -> google.protobuf.Timestamp
-	0,  // 7: telegramlite.auth.RegisterRequest.device_type:type_name -> telegramlite.auth.DeviceType
-	1,  // 8: telegramlite.auth.RegisterResponse.response:type_name -> telegramlite.auth.Response
-	7,  // 9: telegramlite.auth.RegisterResponse.data:type_name -> telegramlite.auth.RegisterData
-	2,  // 10: telegramlite.auth.RegisterData.user:type_name -> telegramlite.auth.UserInfo
-	3,  // 11: telegramlite.auth.RegisterData.device:type_name -> telegramlite.auth.DeviceInfo
-	4,  // 12: telegramlite.auth.RegisterData.token:type_name -> telegramlite.auth.TokenInfo
-	0,  // 13: telegramlite.auth.LoginRequest.device_type:type_name -> telegramlite.auth.DeviceType
-	1,  // 14: telegramlite.auth.LoginResponse.response:type_name -> telegramlite.auth.Response
-	10, // 15: telegramlite.auth.LoginResponse.data:type_name -> telegramlite.auth.LoginData
-	2,  // 16: telegramlite.auth.LoginData.user:type_name -> telegramlite.auth.UserInfo
-	3,  // 17: telegramlite.auth.LoginData.device:type_name -> telegramlite.auth.DeviceInfo
-	4,  // 18: telegramlite.auth.LoginData.token:type_name -> telegramlite.auth.TokenInfo
-	1,  // 19: telegramlite.auth.RefreshTokenResponse.response:type_name -> telegramlite.auth.Response
-	4,  // 20: telegramlite.auth.RefreshTokenResponse.token:type_name -> telegramlite.auth.TokenInfo
-	1,  // 21: telegramlite.auth.LogoutResponse.response:type_name -> telegramlite.auth.Response
-	1,  // 22: telegramlite.auth.VerifyTokenResponse.response:type_name -> telegramlite.auth.Response
-	17, // 23: telegramlite.auth.VerifyTokenResponse.data:type_name -> telegramlite.auth.VerifyTokenData
-	23, // 24: telegramlite.auth.VerifyTokenData.expires_at:type_name -> google.protobuf.Timestamp
-	1,  // 25: telegramlite.auth.GetUserInfoResponse.response:type_name -> telegramlite.auth.Response
-	2,  // 26: telegramlite.auth.GetUserInfoResponse.user:type_name -> telegramlite.auth.UserInfo
-	1,  // 27: telegramlite.auth.HealthResponse.response:type_name -> telegramlite.auth.Response
-	22, // 28: telegramlite.auth.HealthResponse.data:type_name -> telegramlite.auth.HealthData
-	23, // 29: telegramlite.auth.HealthData.timestamp:type_name -> google.protobuf.Timestamp
-	5,  // 30: telegramlite.auth.AuthService.Register:input_type -> telegramlite.auth.RegisterRequest
-	8,  // 31: telegramlite.auth.AuthService.Login:input_type -> telegramlite.auth.LoginRequest
-	11, // 32: telegramlite.auth.AuthService.RefreshToken:input_type -> telegramlite.auth.RefreshTokenRequest
-	13, // 33: telegramlite.auth.AuthService.Logout:input_type -> telegramlite.auth.LogoutRequest
-	15, // 34: telegramlite.auth.AuthService.VerifyToken:input_type -> telegramlite.auth.VerifyTokenRequest
-	18, // 35: telegramlite.auth.AuthService.GetUserInfo:input_type -> telegramlite.auth.GetUserInfoRequest
-	20, // 36: telegramlite.auth.AuthService.Health:input_type -> telegramlite.auth.HealthRequest
-	6,  // 37: telegramlite.auth.AuthService.Register:output_type -> telegramlite.auth.RegisterResponse
-	9,  // 38: telegramlite.auth.AuthService.Login:output_type -> telegramlite.auth.LoginResponse
-	12, // 39: telegramlite.auth.AuthService.RefreshToken:output_type -> telegramlite.auth.RefreshTokenResponse
-	14, // 40: telegramlite.auth.AuthService.Logout:output_type -> telegramlite.auth.LogoutResponse
-	16, // 41: telegramlite.auth.AuthService.VerifyToken:output_type -> telegramlite.auth.VerifyTokenResponse
-	19, // 42: telegramlite.auth.AuthService.GetUserInfo:output_type -> telegramlite.auth.GetUserInfoResponse
-	21, // 43: telegramlite.auth.AuthService.Health:output_type -> telegramlite.auth.HealthResponse
-	37, // [37:44] is the sub-list for method output_type
-	30, // [30:37] is the sub-list for method input_type
-	30, // [30:30] is the sub-list for extension type_name
-	30, // [30:30] is the sub-list for extension extendee
-	0,  // [0:30] is the sub-list for field type_name
+	112, // 0: telegramlite.auth.Response.timestamp:type_name -> google.protobuf.Timestamp
+	112, // 1: telegramlite.auth.UserInfo.last_login_at:type_name -> google.protobuf.Timestamp
+	112, // 2: telegramlite.auth.UserInfo.created_at:type_name -> google.protobuf.Timestamp
+	112, // 3: telegramlite.auth.UserInfo.updated_at:type_name -> google.protobuf.Timestamp
+	0,   // 4: telegramlite.auth.DeviceInfo.device_type:type_name -> telegramlite.auth.DeviceType
+	112, // 5: telegramlite.auth.DeviceInfo.last_seen_at:type_name -> google.protobuf.Timestamp
+	112, // 6: telegramlite.auth.DeviceInfo.created_at:type_name -> google.protobuf.Timestamp
+	0,   // 7: telegramlite.auth.RegisterRequest.device_type:type_name -> telegramlite.auth.DeviceType
+	1,   // 8: telegramlite.auth.RegisterResponse.response:type_name -> telegramlite.auth.Response
+	8,   // 9: telegramlite.auth.RegisterResponse.data:type_name -> telegramlite.auth.RegisterData
+	7,   // 10: telegramlite.auth.RegisterResponse.violations:type_name -> telegramlite.auth.PasswordViolation
+	2,   // 11: telegramlite.auth.RegisterData.user:type_name -> telegramlite.auth.UserInfo
+	3,   // 12: telegramlite.auth.RegisterData.device:type_name -> telegramlite.auth.DeviceInfo
+	4,   // 13: telegramlite.auth.RegisterData.token:type_name -> telegramlite.auth.TokenInfo
+	0,   // 14: telegramlite.auth.LoginRequest.device_type:type_name -> telegramlite.auth.DeviceType
+	1,   // 15: telegramlite.auth.LoginResponse.response:type_name -> telegramlite.auth.Response
+	12,  // 16: telegramlite.auth.LoginResponse.data:type_name -> telegramlite.auth.LoginData
+	11,  // 17: telegramlite.auth.LoginResponse.two_factor:type_name -> telegramlite.auth.TwoFactorChallenge
+	72,  // 18: telegramlite.auth.LoginResponse.device_approval:type_name -> telegramlite.auth.DeviceApprovalChallenge
+	2,   // 19: telegramlite.auth.LoginData.user:type_name -> telegramlite.auth.UserInfo
+	3,   // 20: telegramlite.auth.LoginData.device:type_name -> telegramlite.auth.DeviceInfo
+	4,   // 21: telegramlite.auth.LoginData.token:type_name -> telegramlite.auth.TokenInfo
+	1,   // 22: telegramlite.auth.SendCodeResponse.response:type_name -> telegramlite.auth.Response
+	15,  // 23: telegramlite.auth.SendCodeResponse.data:type_name -> telegramlite.auth.SendCodeData
+	0,   // 24: telegramlite.auth.VerifyCodeRequest.device_type:type_name -> telegramlite.auth.DeviceType
+	1,   // 25: telegramlite.auth.VerifyCodeResponse.response:type_name -> telegramlite.auth.Response
+	12,  // 26: telegramlite.auth.VerifyCodeResponse.data:type_name -> telegramlite.auth.LoginData
+	11,  // 27: telegramlite.auth.VerifyCodeResponse.two_factor:type_name -> telegramlite.auth.TwoFactorChallenge
+	1,   // 28: telegramlite.auth.RequestPasswordResetResponse.response:type_name -> telegramlite.auth.Response
+	1,   // 29: telegramlite.auth.ResetPasswordResponse.response:type_name -> telegramlite.auth.Response
+	7,   // 30: telegramlite.auth.ResetPasswordResponse.violations:type_name -> telegramlite.auth.PasswordViolation
+	1,   // 31: telegramlite.auth.ChangePasswordResponse.response:type_name -> telegramlite.auth.Response
+	7,   // 32: telegramlite.auth.ChangePasswordResponse.violations:type_name -> telegramlite.auth.PasswordViolation
+	1,   // 33: telegramlite.auth.VerifySecondFactorResponse.response:type_name -> telegramlite.auth.Response
+	12,  // 34: telegramlite.auth.VerifySecondFactorResponse.data:type_name -> telegramlite.auth.LoginData
+	1,   // 35: telegramlite.auth.GetTwoFactorStatusResponse.response:type_name -> telegramlite.auth.Response
+	112, // 36: telegramlite.auth.GetTwoFactorStatusResponse.enabled_at:type_name -> google.protobuf.Timestamp
+	1,   // 37: telegramlite.auth.EnrollTwoFactorResponse.response:type_name -> telegramlite.auth.Response
+	1,   // 38: telegramlite.auth.ConfirmTwoFactorResponse.response:type_name -> telegramlite.auth.Response
+	1,   // 39: telegramlite.auth.DisableTwoFactorResponse.response:type_name -> telegramlite.auth.Response
+	1,   // 40: telegramlite.auth.RefreshTokenResponse.response:type_name -> telegramlite.auth.Response
+	4,   // 41: telegramlite.auth.RefreshTokenResponse.token:type_name -> telegramlite.auth.TokenInfo
+	1,   // 42: telegramlite.auth.LogoutResponse.response:type_name -> telegramlite.auth.Response
+	1,   // 43: telegramlite.auth.VerifyTokenResponse.response:type_name -> telegramlite.auth.Response
+	40,  // 44: telegramlite.auth.VerifyTokenResponse.data:type_name -> telegramlite.auth.VerifyTokenData
+	112, // 45: telegramlite.auth.VerifyTokenData.expires_at:type_name -> google.protobuf.Timestamp
+	1,   // 46: telegramlite.auth.GetUserInfoResponse.response:type_name -> telegramlite.auth.Response
+	2,   // 47: telegramlite.auth.GetUserInfoResponse.user:type_name -> telegramlite.auth.UserInfo
+	0,   // 48: telegramlite.auth.SessionInfo.device_type:type_name -> telegramlite.auth.DeviceType
+	112, // 49: telegramlite.auth.SessionInfo.last_seen_at:type_name -> google.protobuf.Timestamp
+	112, // 50: telegramlite.auth.SessionInfo.created_at:type_name -> google.protobuf.Timestamp
+	1,   // 51: telegramlite.auth.ListSessionsResponse.response:type_name -> telegramlite.auth.Response
+	43,  // 52: telegramlite.auth.ListSessionsResponse.sessions:type_name -> telegramlite.auth.SessionInfo
+	1,   // 53: telegramlite.auth.RevokeSessionResponse.response:type_name -> telegramlite.auth.Response
+	1,   // 54: telegramlite.auth.RevokeOtherSessionsResponse.response:type_name -> telegramlite.auth.Response
+	1,   // 55: telegramlite.auth.RevokeTokensResponse.response:type_name -> telegramlite.auth.Response
+	112, // 56: telegramlite.auth.SigningKey.created_at:type_name -> google.protobuf.Timestamp
+	112, // 57: telegramlite.auth.SigningKey.expires_at:type_name -> google.protobuf.Timestamp
+	1,   // 58: telegramlite.auth.GetSigningKeysResponse.response:type_name -> telegramlite.auth.Response
+	52,  // 59: telegramlite.auth.GetSigningKeysResponse.keys:type_name -> telegramlite.auth.SigningKey
+	112, // 60: telegramlite.auth.RevocationEvent.revoked_at:type_name -> google.protobuf.Timestamp
+	112, // 61: telegramlite.auth.RevocationEvent.expires_at:type_name -> google.protobuf.Timestamp
+	1,   // 62: telegramlite.auth.GetRevocationsResponse.response:type_name -> telegramlite.auth.Response
+	55,  // 63: telegramlite.auth.GetRevocationsResponse.events:type_name -> telegramlite.auth.RevocationEvent
+	1,   // 64: telegramlite.auth.DeactivateAccountResponse.response:type_name -> telegramlite.auth.Response
+	1,   // 65: telegramlite.auth.DeleteAccountResponse.response:type_name -> telegramlite.auth.Response
+	112, // 66: telegramlite.auth.DeleteAccountResponse.purge_at:type_name -> google.protobuf.Timestamp
+	112, // 67: telegramlite.auth.AccountPurge.purged_at:type_name -> google.protobuf.Timestamp
+	1,   // 68: telegramlite.auth.GetAccountPurgesResponse.response:type_name -> telegramlite.auth.Response
+	62,  // 69: telegramlite.auth.GetAccountPurgesResponse.purges:type_name -> telegramlite.auth.AccountPurge
+	1,   // 70: telegramlite.auth.ExportUserDataResponse.response:type_name -> telegramlite.auth.Response
+	112, // 71: telegramlite.auth.AuthEvent.created_at:type_name -> google.protobuf.Timestamp
+	1,   // 72: telegramlite.auth.ListSecurityEventsResponse.response:type_name -> telegramlite.auth.Response
+	67,  // 73: telegramlite.auth.ListSecurityEventsResponse.events:type_name -> telegramlite.auth.AuthEvent
+	112, // 74: telegramlite.auth.QueryAuthEventsRequest.since:type_name -> google.protobuf.Timestamp
+	112, // 75: telegramlite.auth.QueryAuthEventsRequest.until:type_name -> google.protobuf.Timestamp
+	1,   // 76: telegramlite.auth.QueryAuthEventsResponse.response:type_name -> telegramlite.auth.Response
+	67,  // 77: telegramlite.auth.QueryAuthEventsResponse.events:type_name -> telegramlite.auth.AuthEvent
+	112, // 78: telegramlite.auth.DeviceApproval.created_at:type_name -> google.protobuf.Timestamp
+	112, // 79: telegramlite.auth.DeviceApproval.expires_at:type_name -> google.protobuf.Timestamp
+	1,   // 80: telegramlite.auth.GetDeviceApprovalResponse.response:type_name -> telegramlite.auth.Response
+	12,  // 81: telegramlite.auth.GetDeviceApprovalResponse.data:type_name -> telegramlite.auth.LoginData
+	11,  // 82: telegramlite.auth.GetDeviceApprovalResponse.two_factor:type_name -> telegramlite.auth.TwoFactorChallenge
+	72,  // 83: telegramlite.auth.GetDeviceApprovalResponse.device_approval:type_name -> telegramlite.auth.DeviceApprovalChallenge
+	1,   // 84: telegramlite.auth.SendDeviceApprovalCodeResponse.response:type_name -> telegramlite.auth.Response
+	15,  // 85: telegramlite.auth.SendDeviceApprovalCodeResponse.data:type_name -> telegramlite.auth.SendCodeData
+	1,   // 86: telegramlite.auth.VerifyDeviceApprovalCodeResponse.response:type_name -> telegramlite.auth.Response
+	12,  // 87: telegramlite.auth.VerifyDeviceApprovalCodeResponse.data:type_name -> telegramlite.auth.LoginData
+	11,  // 88: telegramlite.auth.VerifyDeviceApprovalCodeResponse.two_factor:type_name -> telegramlite.auth.TwoFactorChallenge
+	1,   // 89: telegramlite.auth.ListDeviceApprovalsResponse.response:type_name -> telegramlite.auth.Response
+	73,  // 90: telegramlite.auth.ListDeviceApprovalsResponse.approvals:type_name -> telegramlite.auth.DeviceApproval
+	1,   // 91: telegramlite.auth.DecideDeviceApprovalResponse.response:type_name -> telegramlite.auth.Response
+	0,   // 92: telegramlite.auth.ExportLoginTokenRequest.device_type:type_name -> telegramlite.auth.DeviceType
+	1,   // 93: telegramlite.auth.ExportLoginTokenResponse.response:type_name -> telegramlite.auth.Response
+	1,   // 94: telegramlite.auth.AcceptLoginTokenResponse.response:type_name -> telegramlite.auth.Response
+	1,   // 95: telegramlite.auth.ImportLoginTokenResponse.response:type_name -> telegramlite.auth.Response
+	12,  // 96: telegramlite.auth.ImportLoginTokenResponse.data:type_name -> telegramlite.auth.LoginData
+	11,  // 97: telegramlite.auth.ImportLoginTokenResponse.two_factor:type_name -> telegramlite.auth.TwoFactorChallenge
+	1,   // 98: telegramlite.auth.RegisterPushTokenResponse.response:type_name -> telegramlite.auth.Response
+	1,   // 99: telegramlite.auth.UnregisterPushTokenResponse.response:type_name -> telegramlite.auth.Response
+	0,   // 100: telegramlite.auth.PushTarget.device_type:type_name -> telegramlite.auth.DeviceType
+	1,   // 101: telegramlite.auth.GetPushTargetsResponse.response:type_name -> telegramlite.auth.Response
+	95,  // 102: telegramlite.auth.GetPushTargetsResponse.targets:type_name -> telegramlite.auth.PushTarget
+	1,   // 103: telegramlite.auth.InvalidatePushTokensResponse.response:type_name -> telegramlite.auth.Response
+	1,   // 104: telegramlite.auth.CheckUsernameResponse.response:type_name -> telegramlite.auth.Response
+	1,   // 105: telegramlite.auth.ChangeUsernameResponse.response:type_name -> telegramlite.auth.Response
+	2,   // 106: telegramlite.auth.ChangeUsernameResponse.user:type_name -> telegramlite.auth.UserInfo
+	1,   // 107: telegramlite.auth.ResolveUsernameResponse.response:type_name -> telegramlite.auth.Response
+	104, // 108: telegramlite.auth.ResolveUsernameResponse.user:type_name -> telegramlite.auth.PublicUserInfo
+	1,   // 109: telegramlite.auth.HeartbeatResponse.response:type_name -> telegramlite.auth.Response
+	1,   // 110: telegramlite.auth.HealthResponse.response:type_name -> telegramlite.auth.Response
+	111, // 111: telegramlite.auth.HealthResponse.data:type_name -> telegramlite.auth.HealthData
+	112, // 112: telegramlite.auth.HealthData.timestamp:type_name -> google.protobuf.Timestamp
+	5,   // 113: telegramlite.auth.AuthService.Register:input_type -> telegramlite.auth.RegisterRequest
+	9,   // 114: telegramlite.auth.AuthService.Login:input_type -> telegramlite.auth.LoginRequest
+	13,  // 115: telegramlite.auth.AuthService.SendCode:input_type -> telegramlite.auth.SendCodeRequest
+	16,  // 116: telegramlite.auth.AuthService.VerifyCode:input_type -> telegramlite.auth.VerifyCodeRequest
+	18,  // 117: telegramlite.auth.AuthService.RequestPasswordReset:input_type -> telegramlite.auth.RequestPasswordResetRequest
+	20,  // 118: telegramlite.auth.AuthService.ResetPassword:input_type -> telegramlite.auth.ResetPasswordRequest
+	22,  // 119: telegramlite.auth.AuthService.ChangePassword:input_type -> telegramlite.auth.ChangePasswordRequest
+	24,  // 120: telegramlite.auth.AuthService.VerifySecondFactor:input_type -> telegramlite.auth.VerifySecondFactorRequest
+	26,  // 121: telegramlite.auth.AuthService.GetTwoFactorStatus:input_type -> telegramlite.auth.GetTwoFactorStatusRequest
+	28,  // 122: telegramlite.auth.AuthService.EnrollTwoFactor:input_type -> telegramlite.auth.EnrollTwoFactorRequest
+	30,  // 123: telegramlite.auth.AuthService.ConfirmTwoFactor:input_type -> telegramlite.auth.ConfirmTwoFactorRequest
+	32,  // 124: telegramlite.auth.AuthService.DisableTwoFactor:input_type -> telegramlite.auth.DisableTwoFactorRequest
+	34,  // 125: telegramlite.auth.AuthService.RefreshToken:input_type -> telegramlite.auth.RefreshTokenRequest
+	36,  // 126: telegramlite.auth.AuthService.Logout:input_type -> telegramlite.auth.LogoutRequest
+	38,  // 127: telegramlite.auth.AuthService.VerifyToken:input_type -> telegramlite.auth.VerifyTokenRequest
+	41,  // 128: telegramlite.auth.AuthService.GetUserInfo:input_type -> telegramlite.auth.GetUserInfoRequest
+	44,  // 129: telegramlite.auth.AuthService.ListSessions:input_type -> telegramlite.auth.ListSessionsRequest
+	46,  // 130: telegramlite.auth.AuthService.RevokeSession:input_type -> telegramlite.auth.RevokeSessionRequest
+	48,  // 131: telegramlite.auth.AuthService.RevokeOtherSessions:input_type -> telegramlite.auth.RevokeOtherSessionsRequest
+	50,  // 132: telegramlite.auth.AuthService.RevokeTokens:input_type -> telegramlite.auth.RevokeTokensRequest
+	53,  // 133: telegramlite.auth.AuthService.GetSigningKeys:input_type -> telegramlite.auth.GetSigningKeysRequest
+	56,  // 134: telegramlite.auth.AuthService.GetRevocations:input_type -> telegramlite.auth.GetRevocationsRequest
+	58,  // 135: telegramlite.auth.AuthService.DeactivateAccount:input_type -> telegramlite.auth.DeactivateAccountRequest
+	60,  // 136: telegramlite.auth.AuthService.DeleteAccount:input_type -> telegramlite.auth.DeleteAccountRequest
+	63,  // 137: telegramlite.auth.AuthService.GetAccountPurges:input_type -> telegramlite.auth.GetAccountPurgesRequest
+	65,  // 138: telegramlite.auth.AuthService.ExportUserData:input_type -> telegramlite.auth.ExportUserDataRequest
+	68,  // 139: telegramlite.auth.AuthService.ListSecurityEvents:input_type -> telegramlite.auth.ListSecurityEventsRequest
+	70,  // 140: telegramlite.auth.AuthService.QueryAuthEvents:input_type -> telegramlite.auth.QueryAuthEventsRequest
+	74,  // 141: telegramlite.auth.AuthService.GetDeviceApproval:input_type -> telegramlite.auth.GetDeviceApprovalRequest
+	76,  // 142: telegramlite.auth.AuthService.SendDeviceApprovalCode:input_type -> telegramlite.auth.SendDeviceApprovalCodeRequest
+	78,  // 143: telegramlite.auth.AuthService.VerifyDeviceApprovalCode:input_type -> telegramlite.auth.VerifyDeviceApprovalCodeRequest
+	80,  // 144: telegramlite.auth.AuthService.ListDeviceApprovals:input_type -> telegramlite.auth.ListDeviceApprovalsRequest
+	82,  // 145: telegramlite.auth.AuthService.WatchDeviceApprovals:input_type -> telegramlite.auth.WatchDeviceApprovalsRequest
+	83,  // 146: telegramlite.auth.AuthService.DecideDeviceApproval:input_type -> telegramlite.auth.DecideDeviceApprovalRequest
+	85,  // 147: telegramlite.auth.AuthService.ExportLoginToken:input_type -> telegramlite.auth.ExportLoginTokenRequest
+	87,  // 148: telegramlite.auth.AuthService.AcceptLoginToken:input_type -> telegramlite.auth.AcceptLoginTokenRequest
+	89,  // 149: telegramlite.auth.AuthService.ImportLoginToken:input_type -> telegramlite.auth.ImportLoginTokenRequest
+	91,  // 150: telegramlite.auth.AuthService.RegisterPushToken:input_type -> telegramlite.auth.RegisterPushTokenRequest
+	93,  // 151: telegramlite.auth.AuthService.UnregisterPushToken:input_type -> telegramlite.auth.UnregisterPushTokenRequest
+	96,  // 152: telegramlite.auth.AuthService.GetPushTargets:input_type -> telegramlite.auth.GetPushTargetsRequest
+	98,  // 153: telegramlite.auth.AuthService.InvalidatePushTokens:input_type -> telegramlite.auth.InvalidatePushTokensRequest
+	100, // 154: telegramlite.auth.AuthService.CheckUsername:input_type -> telegramlite.auth.CheckUsernameRequest
+	102, // 155: telegramlite.auth.AuthService.ChangeUsername:input_type -> telegramlite.auth.ChangeUsernameRequest
+	105, // 156: telegramlite.auth.AuthService.ResolveUsername:input_type -> telegramlite.auth.ResolveUsernameRequest
+	107, // 157: telegramlite.auth.AuthService.Heartbeat:input_type -> telegramlite.auth.HeartbeatRequest
+	109, // 158: telegramlite.auth.AuthService.Health:input_type -> telegramlite.auth.HealthRequest
+	6,   // 159: telegramlite.auth.AuthService.Register:output_type -> telegramlite.auth.RegisterResponse
+	10,  // 160: telegramlite.auth.AuthService.Login:output_type -> telegramlite.auth.LoginResponse
+	14,  // 161: telegramlite.auth.AuthService.SendCode:output_type -> telegramlite.auth.SendCodeResponse
+	17,  // 162: telegramlite.auth.AuthService.VerifyCode:output_type -> telegramlite.auth.VerifyCodeResponse
+	19,  // 163: telegramlite.auth.AuthService.RequestPasswordReset:output_type -> telegramlite.auth.RequestPasswordResetResponse
+	21,  // 164: telegramlite.auth.AuthService.ResetPassword:output_type -> telegramlite.auth.ResetPasswordResponse
+	23,  // 165: telegramlite.auth.AuthService.ChangePassword:output_type -> telegramlite.auth.ChangePasswordResponse
+	25,  // 166: telegramlite.auth.AuthService.VerifySecondFactor:output_type -> telegramlite.auth.VerifySecondFactorResponse
+	27,  // 167: telegramlite.auth.AuthService.GetTwoFactorStatus:output_type -> telegramlite.auth.GetTwoFactorStatusResponse
+	29,  // 168: telegramlite.auth.AuthService.EnrollTwoFactor:output_type -> telegramlite.auth.EnrollTwoFactorResponse
+	31,  // 169: telegramlite.auth.AuthService.ConfirmTwoFactor:output_type -> telegramlite.auth.ConfirmTwoFactorResponse
+	33,  // 170: telegramlite.auth.AuthService.DisableTwoFactor:output_type -> telegramlite.auth.DisableTwoFactorResponse
+	35,  // 171: telegramlite.auth.AuthService.RefreshToken:output_type -> telegramlite.auth.RefreshTokenResponse
+	37,  // 172: telegramlite.auth.AuthService.Logout:output_type -> telegramlite.auth.LogoutResponse
+	39,  // 173: telegramlite.auth.AuthService.VerifyToken:output_type -> telegramlite.auth.VerifyTokenResponse
+	42,  // 174: telegramlite.auth.AuthService.GetUserInfo:output_type -> telegramlite.auth.GetUserInfoResponse
+	45,  // 175: telegramlite.auth.AuthService.ListSessions:output_type -> telegramlite.auth.ListSessionsResponse
+	47,  // 176: telegramlite.auth.AuthService.RevokeSession:output_type -> telegramlite.auth.RevokeSessionResponse
+	49,  // 177: telegramlite.auth.AuthService.RevokeOtherSessions:output_type -> telegramlite.auth.RevokeOtherSessionsResponse
+	51,  // 178: telegramlite.auth.AuthService.RevokeTokens:output_type -> telegramlite.auth.RevokeTokensResponse
+	54,  // 179: telegramlite.auth.AuthService.GetSigningKeys:output_type -> telegramlite.auth.GetSigningKeysResponse
+	57,  // 180: telegramlite.auth.AuthService.GetRevocations:output_type -> telegramlite.auth.GetRevocationsResponse
+	59,  // 181: telegramlite.auth.AuthService.DeactivateAccount:output_type -> telegramlite.auth.DeactivateAccountResponse
+	61,  // 182: telegramlite.auth.AuthService.DeleteAccount:output_type -> telegramlite.auth.DeleteAccountResponse
+	64,  // 183: telegramlite.auth.AuthService.GetAccountPurges:output_type -> telegramlite.auth.GetAccountPurgesResponse
+	66,  // 184: telegramlite.auth.AuthService.ExportUserData:output_type -> telegramlite.auth.ExportUserDataResponse
+	69,  // 185: telegramlite.auth.AuthService.ListSecurityEvents:output_type -> telegramlite.auth.ListSecurityEventsResponse
+	71,  // 186: telegramlite.auth.AuthService.QueryAuthEvents:output_type -> telegramlite.auth.QueryAuthEventsResponse
+	75,  // 187: telegramlite.auth.AuthService.GetDeviceApproval:output_type -> telegramlite.auth.GetDeviceApprovalResponse
+	77,  // 188: telegramlite.auth.AuthService.SendDeviceApprovalCode:output_type -> telegramlite.auth.SendDeviceApprovalCodeResponse
+	79,  // 189: telegramlite.auth.AuthService.VerifyDeviceApprovalCode:output_type -> telegramlite.auth.VerifyDeviceApprovalCodeResponse
+	81,  // 190: telegramlite.auth.AuthService.ListDeviceApprovals:output_type -> telegramlite.auth.ListDeviceApprovalsResponse
+	73,  // 191: telegramlite.auth.AuthService.WatchDeviceApprovals:output_type -> telegramlite.auth.DeviceApproval
+	84,  // 192: telegramlite.auth.AuthService.DecideDeviceApproval:output_type -> telegramlite.auth.DecideDeviceApprovalResponse
+	86,  // 193: telegramlite.auth.AuthService.ExportLoginToken:output_type -> telegramlite.auth.ExportLoginTokenResponse
+	88,  // 194: telegramlite.auth.AuthService.AcceptLoginToken:output_type -> telegramlite.auth.AcceptLoginTokenResponse
+	90,  // 195: telegramlite.auth.AuthService.ImportLoginToken:output_type -> telegramlite.auth.ImportLoginTokenResponse
+	92,  // 196: telegramlite.auth.AuthService.RegisterPushToken:output_type -> telegramlite.auth.RegisterPushTokenResponse
+	94,  // 197: telegramlite.auth.AuthService.UnregisterPushToken:output_type -> telegramlite.auth.UnregisterPushTokenResponse
+	97,  // 198: telegramlite.auth.AuthService.GetPushTargets:output_type -> telegramlite.auth.GetPushTargetsResponse
+	99,  // 199: telegramlite.auth.AuthService.InvalidatePushTokens:output_type -> telegramlite.auth.InvalidatePushTokensResponse
+	101, // 200: telegramlite.auth.AuthService.CheckUsername:output_type -> telegramlite.auth.CheckUsernameResponse
+	103, // 201: telegramlite.auth.AuthService.ChangeUsername:output_type -> telegramlite.auth.ChangeUsernameResponse
+	106, // 202: telegramlite.auth.AuthService.ResolveUsername:output_type -> telegramlite.auth.ResolveUsernameResponse
+	108, // 203: telegramlite.auth.AuthService.Heartbeat:output_type -> telegramlite.auth.HeartbeatResponse
+	110, // 204: telegramlite.auth.AuthService.Health:output_type -> telegramlite.auth.HealthResponse
+	159, // [159:205] is the sub-list for method output_type
+	113, // [113:159] is the sub-list for method input_type
+	113, // [113:113] is the sub-list for extension type_name
+	113, // [113:113] is the sub-list for extension extendee
+	0,   // [0:113] is the sub-list for field type_name
 }
 
 func init() { file_auth_proto_init() }
@@ -1716,18 +7712,40 @@ func file_auth_proto_init() {
 	if File_auth_proto != nil {
 		return
 	}
-	file_auth_proto_msgTypes[7].OneofWrappers = []any{
+	file_auth_proto_msgTypes[8].OneofWrappers = []any{
 		(*LoginRequest_Phone)(nil),
 		(*LoginRequest_Email)(nil),
 		(*LoginRequest_Username)(nil),
 	}
+	file_auth_proto_msgTypes[12].OneofWrappers = []any{
+		(*SendCodeRequest_Phone)(nil),
+		(*SendCodeRequest_Email)(nil),
+	}
+	file_auth_proto_msgTypes[15].OneofWrappers = []any{
+		(*VerifyCodeRequest_Phone)(nil),
+		(*VerifyCodeRequest_Email)(nil),
+	}
+	file_auth_proto_msgTypes[17].OneofWrappers = []any{
+		(*RequestPasswordResetRequest_Phone)(nil),
+		(*RequestPasswordResetRequest_Email)(nil),
+	}
+	file_auth_proto_msgTypes[49].OneofWrappers = []any{
+		(*RevokeTokensRequest_TokenId)(nil),
+		(*RevokeTokensRequest_DeviceId)(nil),
+		(*RevokeTokensRequest_UserId)(nil),
+	}
+	file_auth_proto_msgTypes[54].OneofWrappers = []any{
+		(*RevocationEvent_TokenId)(nil),
+		(*RevocationEvent_DeviceId)(nil),
+		(*RevocationEvent_UserId)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_proto_rawDesc), len(file_auth_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   22,
+			NumMessages:   111,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -14,13 +15,16 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/jacl-coder/TelegramLite/common/go/logger"
 	pb "github.com/jacl-coder/telegramlite/auth_service/api/proto"
 	"github.com/jacl-coder/telegramlite/auth_service/internal/config"
 	"github.com/jacl-coder/telegramlite/auth_service/internal/handler"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/middleware"
 	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/sender"
 	"github.com/jacl-coder/telegramlite/auth_service/internal/service"
 	"github.com/jacl-coder/telegramlite/auth_service/pkg"
 )
@@ -74,19 +78,174 @@ func main() {
 		os.Exit(1)
 	}
 
+	// 创建等待组和上下文
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(context.Background())
+
 	// 初始化JWT管理器
-	jwtManager := pkg.NewJWTManager(
-		cfg.JWT.Secret,
-		cfg.JWT.ExpireDuration(),
-		cfg.JWT.RefreshExpireDuration(),
-	)
+	var jwtManager *pkg.JWTManager
+	if cfg.JWT.UseKeySet() {
+		keySet := pkg.NewKeySet()
+		keyService := service.NewKeyService(keySet, cfg.JWT.Algorithm, cfg.JWT.KeyRotationInterval(), cfg.JWT.KeyOverlapDuration())
+		if err := keyService.Load(); err != nil {
+			appLogger.Error("Failed to load signing keys", logger.Fields{"error": err.Error()})
+			os.Exit(1)
+		}
+
+		// 定期同步密钥并执行计划轮换
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			keyService.Run(ctx, time.Minute)
+		}()
+
+		jwtManager = pkg.NewJWTManagerWithKeys(
+			keySet,
+			cfg.JWT.Secret,
+			cfg.JWT.ExpireDuration(),
+			cfg.JWT.RefreshExpireDuration(),
+		)
+
+		// 旧HS256 token只在配置的停用时间之前接受，每次使用都记录日志以便确认迁移完成
+		legacyRetireAt, err := cfg.JWT.LegacySecretRetireTime()
+		if err != nil {
+			appLogger.Error("Invalid legacy secret retire time", logger.Fields{"error": err.Error()})
+			os.Exit(1)
+		}
+		if !legacyRetireAt.IsZero() {
+			jwtManager.SetLegacySecretRetireAt(legacyRetireAt, func(subject string) {
+				appLogger.Warn("Accepted token signed with legacy HS256 secret", logger.Fields{
+					"subject":   subject,
+					"retire_at": legacyRetireAt.Format(time.RFC3339),
+				})
+			})
+		}
+	} else {
+		jwtManager = pkg.NewJWTManager(
+			cfg.JWT.Secret,
+			cfg.JWT.ExpireDuration(),
+			cfg.JWT.RefreshExpireDuration(),
+		)
+	}
 
 	// 初始化服务层
-	authService := service.NewAuthService(jwtManager)
+	var authOptions []service.Option
+	if cfg.Account.DefaultRegion != "" {
+		if !pkg.IsSupportedRegion(cfg.Account.DefaultRegion) {
+			appLogger.Error("Unsupported default phone region", logger.Fields{"region": cfg.Account.DefaultRegion})
+			os.Exit(1)
+		}
+		authOptions = append(authOptions, service.WithDefaultRegion(cfg.Account.DefaultRegion))
+	}
+	passwordPolicy, err := newPasswordPolicy(&cfg.PasswordPolicy)
+	if err != nil {
+		appLogger.Error("Failed to load password policy", logger.Fields{"error": err.Error()})
+		os.Exit(1)
+	}
+	passwordManager, err := pkg.NewPasswordManagerWithConfig(pkg.PasswordHashConfig{
+		Algorithm:  cfg.PasswordHash.Algorithm,
+		Memory:     cfg.PasswordHash.MemoryKiB,
+		Time:       cfg.PasswordHash.Time,
+		Threads:    cfg.PasswordHash.Threads,
+		BcryptCost: cfg.PasswordHash.BcryptCost,
+	})
+	if err != nil {
+		appLogger.Error("Invalid password hash config", logger.Fields{"error": err.Error()})
+		os.Exit(1)
+	}
+	smsSender, err := newSMSSender(cfg)
+	if err != nil {
+		appLogger.Error("Invalid sms sender config", logger.Fields{"error": err.Error()})
+		os.Exit(1)
+	}
+	emailSender, err := newEmailSender(cfg)
+	if err != nil {
+		appLogger.Error("Invalid email sender config", logger.Fields{"error": err.Error()})
+		os.Exit(1)
+	}
+	authOptions = append(authOptions,
+		service.WithPasswordManager(passwordManager),
+		service.WithPasswordPolicy(passwordPolicy),
+		service.WithSenders(smsSender, emailSender),
+		service.WithCodePolicy(service.CodePolicy{
+			Length:         cfg.Code.Length,
+			TTL:            time.Duration(cfg.Code.TTLSeconds) * time.Second,
+			MaxAttempts:    cfg.Code.MaxAttempts,
+			ResendCooldown: time.Duration(cfg.Code.ResendCooldownSeconds) * time.Second,
+		}),
+		service.WithTOTPIssuer(cfg.Account.TOTPIssuer),
+		service.WithPasswordReset(time.Duration(cfg.Account.PasswordResetTTLMinutes)*time.Minute, cfg.Account.PasswordResetURL),
+		service.WithDeletionGracePeriod(time.Duration(cfg.Account.DeletionGraceDays)*24*time.Hour),
+		service.WithAuditRetention(time.Duration(cfg.Audit.RetentionDays)*24*time.Hour),
+		service.WithOAuth(service.OAuthPolicy{
+			Issuer:           cfg.OAuth.Issuer,
+			AuthorizationURL: cfg.OAuth.AuthorizationURL,
+			CodeTTL:          time.Duration(cfg.OAuth.CodeTTLSeconds) * time.Second,
+			RefreshTTL:       time.Duration(cfg.OAuth.RefreshTTLDays) * 24 * time.Hour,
+		}),
+		service.WithBots(service.BotPolicy{
+			MaxPerOwner: cfg.Bots.MaxPerOwner,
+			RateLimit:   cfg.Bots.RateLimit,
+			RateWindow:  time.Duration(cfg.Bots.RateWindowSeconds) * time.Second,
+		}),
+		service.WithPersonalAccessTokens(service.PersonalAccessTokenPolicy{
+			MaxTTL:     time.Duration(cfg.PersonalAccessTokens.MaxTTLDays) * 24 * time.Hour,
+			MaxPerUser: cfg.PersonalAccessTokens.MaxPerUser,
+		}),
+		service.WithUsernamePolicy(service.UsernamePolicy{
+			ChangeCooldown: time.Duration(cfg.Usernames.ChangeCooldownDays) * 24 * time.Hour,
+			HoldPeriod:     time.Duration(cfg.Usernames.HoldDays) * 24 * time.Hour,
+			Reserved:       cfg.Usernames.Reserved,
+		}),
+		service.WithDevicePolicy(service.DevicePolicy{
+			IdleTimeout: time.Duration(cfg.Devices.IdleTimeoutSeconds) * time.Second,
+			StaleAfter:  cfg.Devices.StaleAfter(),
+		}),
+		service.WithDeviceApproval(service.DeviceApprovalPolicy{
+			Enabled: cfg.DeviceApproval.Enabled,
+			Timeout: time.Duration(cfg.DeviceApproval.TimeoutSeconds) * time.Second,
+		}),
+		service.WithLoginProtection(service.LoginProtectionPolicy{
+			Window:           time.Duration(cfg.LoginProtection.WindowMinutes) * time.Minute,
+			AccountThreshold: cfg.LoginProtection.AccountThreshold,
+			IPThreshold:      cfg.LoginProtection.IPThreshold,
+			DeviceThreshold:  cfg.LoginProtection.DeviceThreshold,
+			BaseLockout:      time.Duration(cfg.LoginProtection.BaseLockoutSeconds) * time.Second,
+			MaxLockout:       time.Duration(cfg.LoginProtection.MaxLockoutMinutes) * time.Minute,
+		}),
+	)
+	authService := service.NewAuthService(jwtManager, authOptions...)
 
-	// 创建等待组和上下文
-	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
+	// 将存量手机号和邮箱迁移为规范化格式
+	migrated, err := authService.MigrateIdentifiers()
+	if err != nil {
+		appLogger.Error("Failed to migrate user identifiers", logger.Fields{"error": err.Error()})
+		os.Exit(1)
+	}
+	if migrated > 0 {
+		appLogger.Info("User identifiers normalized", logger.Fields{"count": migrated})
+	}
+
+	// 定期清除删除宽限期已结束的账号
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		authService.RunAccountPurger(ctx, cfg.Account.PurgeInterval())
+	}()
+
+	// 定期清理超过保留期的安全事件
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		authService.RunAuditPruner(ctx, cfg.Audit.PruneInterval())
+	}()
+
+	// 定期写入设备心跳，将没有心跳的设备标记为离线并删除长期未使用的设备
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		authService.RunDeviceReaper(ctx, cfg.Devices.ReapInterval())
+	}()
 
 	// 启动 HTTP 服务器
 	wg.Add(1)
@@ -116,6 +275,9 @@ func main() {
 	// 等待所有服务器关闭
 	wg.Wait()
 
+	// 等待后台发送中的消息
+	authService.WaitBackground()
+
 	// 关闭数据库连接
 	if err := repository.CloseDB(); err != nil {
 		appLogger.Error("Error closing database", logger.Fields{"error": err.Error()})
@@ -129,13 +291,78 @@ func main() {
 	appLogger.Info("Auth Service shutdown complete")
 }
 
+// newSMSSender 根据配置创建短信发送器，未配置时拒绝发送
+func newSMSSender(cfg *config.Config) (sender.Sender, error) {
+	switch cfg.Sender.SMS.Provider {
+	case "http":
+		return sender.NewSMSSender(cfg.Sender.SMS.Endpoint, cfg.Sender.SMS.APIKey, cfg.Sender.SMS.SignName), nil
+	case "log":
+		return newLogSender(cfg)
+	case "", "none":
+		return sender.NewDisabledSender(), nil
+	default:
+		return nil, fmt.Errorf("unknown sms provider %q", cfg.Sender.SMS.Provider)
+	}
+}
+
+// newEmailSender 根据配置创建邮件发送器，未配置时拒绝发送
+func newEmailSender(cfg *config.Config) (sender.Sender, error) {
+	switch cfg.Sender.Email.Provider {
+	case "smtp":
+		return sender.NewEmailSender(cfg.Sender.Email.Host, cfg.Sender.Email.Port, cfg.Sender.Email.Username, cfg.Sender.Email.Password, cfg.Sender.Email.From), nil
+	case "log":
+		return newLogSender(cfg)
+	case "", "none":
+		return sender.NewDisabledSender(), nil
+	default:
+		return nil, fmt.Errorf("unknown email provider %q", cfg.Sender.Email.Provider)
+	}
+}
+
+// newLogSender log发送器会将验证码明文写入日志，只允许在debug模式下显式启用
+func newLogSender(cfg *config.Config) (sender.Sender, error) {
+	if cfg.Server.Mode != "debug" {
+		return nil, errors.New("log sender is only allowed in debug mode")
+	}
+	return sender.NewLogSender(cfg.Sender.LogFile), nil
+}
+
+// newPasswordPolicy 根据配置创建密码策略，未配置的长度限制使用默认值
+func newPasswordPolicy(cfg *config.PasswordPolicyConfig) (*pkg.PasswordPolicy, error) {
+	policy := pkg.DefaultPasswordPolicy()
+	if cfg.MinLength > 0 {
+		policy.MinLength = cfg.MinLength
+	}
+	if cfg.MaxLength > 0 {
+		policy.MaxLength = cfg.MaxLength
+	}
+	policy.RequireLowercase = cfg.RequireLowercase
+	policy.RequireUppercase = cfg.RequireUppercase
+	policy.RequireDigit = cfg.RequireDigit
+	policy.RequireSymbol = cfg.RequireSymbol
+	policy.HistorySize = cfg.HistorySize
+
+	if cfg.BlocklistFile != "" {
+		if err := policy.LoadBlocklist(cfg.BlocklistFile); err != nil {
+			return nil, err
+		}
+	}
+	return policy, nil
+}
+
 // startHTTPServer 启动HTTP服务器
 func startHTTPServer(ctx context.Context, authService *service.AuthService, cfg *config.Config, appLogger logger.Logger) {
 	// 初始化处理器
 	authHandler := handler.NewAuthHandler(authService)
+	authMiddleware := middleware.NewAuthMiddleware(authService)
 
 	// 设置路由
-	router := setupRouter(authHandler, cfg.Server.Mode, appLogger)
+	router := setupRouter(authHandler, authMiddleware, cfg.Server.Mode, appLogger)
+	// 只采信可信代理设置的X-Forwarded-For, 未配置时使用连接对端地址; 客户端IP用于登录失败限制
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		appLogger.Error("Invalid trusted proxies", logger.Fields{"error": err.Error()})
+		os.Exit(1)
+	}
 
 	// 创建HTTP服务器
 	serverAddr := fmt.Sprintf(":%d", cfg.Server.Port)
@@ -182,10 +409,14 @@ func startGRPCServer(ctx context.Context, authService *service.AuthService, cfg
 	}
 
 	// 创建gRPC服务器
-	server := grpc.NewServer()
+	server := grpc.NewServer(grpcServerOptions(ctx, &cfg.GRPCTLS, appLogger)...)
 
 	// 注册服务
 	grpcAuthHandler := handler.NewGRPCAuthHandler(authService)
+	if err := grpcAuthHandler.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		appLogger.Error("Invalid trusted proxies", logger.Fields{"error": err.Error()})
+		os.Exit(1)
+	}
 	pb.RegisterAuthServiceServer(server, grpcAuthHandler)
 
 	// 注册反射服务（开发环境使用）
@@ -223,7 +454,39 @@ func startGRPCServer(ctx context.Context, authService *service.AuthService, cfg
 	}
 }
 
-func setupRouter(authHandler *handler.AuthHandler, mode string, appLogger logger.Logger) *gin.Engine {
+// grpcServerOptions 启用mTLS时要求调用方出示服务证书，并按服务身份限制内部RPC
+// 未启用mTLS时内部RPC一律拒绝，除非显式开启allow_insecure_internal_rpc
+func grpcServerOptions(ctx context.Context, cfg *config.GRPCTLSConfig, appLogger logger.Logger) []grpc.ServerOption {
+	acl := handler.InternalRPCCallers.Merge(cfg.ACL)
+	if !cfg.Enabled {
+		if cfg.AllowInsecureInternalRPC {
+			appLogger.Warn("gRPC mTLS disabled and allow_insecure_internal_rpc set, internal RPCs accept any caller")
+			return nil
+		}
+		appLogger.Warn("gRPC mTLS disabled, internal RPCs are rejected")
+		return []grpc.ServerOption{
+			grpc.ChainUnaryInterceptor(acl.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(acl.StreamServerInterceptor()),
+		}
+	}
+
+	certs, err := pkg.NewCertReloader(cfg.CertFile, cfg.KeyFile, cfg.CAFile)
+	if err != nil {
+		appLogger.Error("Failed to load gRPC TLS certificates", logger.Fields{"error": err.Error()})
+		os.Exit(1)
+	}
+	go certs.Run(ctx, cfg.ReloadInterval(), func(err error) {
+		appLogger.Error("Failed to reload gRPC TLS certificates", logger.Fields{"error": err.Error()})
+	})
+
+	return []grpc.ServerOption{
+		grpc.Creds(credentials.NewTLS(certs.ServerTLSConfig())),
+		grpc.ChainUnaryInterceptor(acl.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(acl.StreamServerInterceptor()),
+	}
+}
+
+func setupRouter(authHandler *handler.AuthHandler, authMiddleware *middleware.AuthMiddleware, mode string, appLogger logger.Logger) *gin.Engine {
 	// 设置Gin模式
 	gin.SetMode(mode)
 
@@ -242,6 +505,16 @@ func setupRouter(authHandler *handler.AuthHandler, mode string, appLogger logger
 	// 添加恢复中间件
 	router.Use(gin.Recovery())
 
+	// 签名公钥 (JWKS)
+	router.GET("/.well-known/jwks.json", authHandler.JWKS)
+
+	// OAuth2/OpenID Connect: 第三方应用使用的标准端点
+	router.GET("/.well-known/openid-configuration", authHandler.OpenIDConfiguration)
+	router.POST("/oauth/token", authHandler.OAuthToken)
+	router.GET("/oauth/userinfo", authHandler.OAuthUserInfo)
+	router.POST("/oauth/userinfo", authHandler.OAuthUserInfo)
+	router.POST("/oauth/revoke", authHandler.OAuthRevoke)
+
 	// API路由组
 	api := router.Group("/api/v1")
 	{
@@ -251,8 +524,125 @@ func setupRouter(authHandler *handler.AuthHandler, mode string, appLogger logger
 			auth.POST("/register", authHandler.Register)
 			auth.POST("/login", authHandler.Login)
 			auth.POST("/refresh", authHandler.RefreshToken)
-			auth.POST("/logout", authHandler.Logout)
+			auth.POST("/code/send", authHandler.SendCode)     // 发送登录验证码
+			auth.POST("/code/verify", authHandler.VerifyCode) // 验证码登录/注册
+			auth.POST("/password/forgot", authHandler.RequestPasswordReset)
+			auth.POST("/password/reset", authHandler.ResetPassword)
+			auth.POST("/password/change", authMiddleware.RequireAuth(), authHandler.ChangePassword)
+			auth.POST("/logout", authMiddleware.RequireAuth(), authHandler.Logout)
 			auth.GET("/user", authHandler.GetUserInfo) // 获取当前用户信息
+
+			// 设备心跳: 超过一段时间没有心跳的设备标记为离线
+			auth.POST("/heartbeat", authMiddleware.RequireAuth(), authHandler.Heartbeat)
+
+			// 会话管理
+			sessions := auth.Group("/sessions")
+			sessions.Use(authMiddleware.RequireAuth())
+			{
+				sessions.GET("", authHandler.ListSessions)
+				sessions.DELETE("/:device_id", authHandler.RevokeSession)
+				sessions.POST("/revoke-others", authHandler.RevokeOtherSessions)
+			}
+
+			// 两步验证
+			auth.POST("/2fa/verify", authHandler.VerifySecondFactor) // 使用登录返回的挑战token完成登录
+			twoFactor := auth.Group("/2fa")
+			twoFactor.Use(authMiddleware.RequireAuth())
+			{
+				twoFactor.GET("", authHandler.GetTwoFactorStatus)
+				twoFactor.POST("/enroll", authHandler.EnrollTwoFactor)
+				twoFactor.POST("/confirm", authHandler.ConfirmTwoFactor)
+				twoFactor.POST("/disable", authHandler.DisableTwoFactor)
+			}
+
+			// 新设备登录审批: 新设备凭登录返回的审批token查询结果，超时后改用验证码
+			auth.POST("/device-approval/status", authHandler.CheckDeviceApproval)
+			auth.POST("/device-approval/code/send", authHandler.SendDeviceApprovalCode)
+			auth.POST("/device-approval/code/verify", authHandler.VerifyDeviceApprovalCode)
+			deviceApprovals := auth.Group("/device-approvals")
+			deviceApprovals.Use(authMiddleware.RequireAuth())
+			{
+				deviceApprovals.GET("", authHandler.ListDeviceApprovals)
+				deviceApprovals.POST("/:approval_id/approve", authHandler.ApproveDevice)
+				deviceApprovals.POST("/:approval_id/deny", authHandler.DenyDevice)
+			}
+
+			// 扫码登录: 网页/桌面端申请token并长轮询结果，已登录的手机扫码确认
+			auth.POST("/qr/export", authHandler.ExportLoginToken)
+			auth.POST("/qr/import", authHandler.ImportLoginToken)
+			auth.POST("/qr/accept", authMiddleware.RequireAuth(), authHandler.AcceptLoginToken)
+
+			// 推送token: 客户端每次启动时注册
+			auth.PUT("/push-token", authMiddleware.RequireAuth(), authHandler.RegisterPushToken)
+			auth.DELETE("/push-token", authMiddleware.RequireAuth(), authHandler.UnregisterPushToken)
+
+			// 停用/删除账号
+			account := auth.Group("/account")
+			account.Use(authMiddleware.RequireAuth())
+			{
+				account.POST("/deactivate", authHandler.DeactivateAccount)
+				account.POST("/delete", authHandler.DeleteAccount)
+			}
+
+			// 安全事件
+			auth.GET("/security/events", authMiddleware.RequireAuth(), authHandler.ListSecurityEvents)
+
+			// 个人访问token: 供自动化脚本使用，只能访问授权范围内的接口
+			tokens := auth.Group("/tokens")
+			tokens.Use(authMiddleware.RequireAuth())
+			{
+				tokens.POST("", authHandler.CreatePersonalAccessToken)
+				tokens.GET("", authHandler.ListPersonalAccessTokens)
+				tokens.DELETE("/:token_id", authHandler.RevokePersonalAccessToken)
+			}
+
+			// 更换手机号/邮箱: 先验证新号码，更换手机号还需其他已登录设备确认
+			phone := auth.Group("/phone")
+			phone.Use(authMiddleware.RequireAuth())
+			{
+				phone.POST("/code", authHandler.SendPhoneChangeCode)
+				phone.POST("", authHandler.RequestPhoneChange)
+				phone.GET("/changes", authHandler.ListPhoneChanges)
+				phone.GET("/changes/:change_id", authHandler.GetPhoneChange)
+				phone.POST("/changes/:change_id/approve", authHandler.ApprovePhoneChange)
+				phone.POST("/changes/:change_id/deny", authHandler.DenyPhoneChange)
+			}
+			auth.POST("/email/code", authMiddleware.RequireAuth(), authHandler.SendEmailChangeCode)
+			auth.POST("/email", authMiddleware.RequireAuth(), authHandler.ChangeEmail)
+
+			// 账号安全通知: 各设备领取发给自己的通知 (如手机号/邮箱已更换)
+			auth.GET("/notifications", authMiddleware.RequireAuth(), authHandler.ListNotifications)
+
+			// 用户名: 检查可用性、修改 (旧用户名保留一段时间) 和@用户名解析
+			auth.GET("/username/check", authHandler.CheckUsername)
+			auth.PUT("/username", authMiddleware.RequireAuth(), authHandler.ChangeUsername)
+			auth.GET("/username/history", authMiddleware.RequireAuth(), authHandler.ListUsernameHistory)
+			auth.GET("/resolve/:username", authHandler.ResolveUsername)
+		}
+
+		// OAuth: 授权确认页面、第三方应用管理和已授权应用管理
+		oauth := api.Group("/oauth")
+		oauth.Use(authMiddleware.RequireAuth())
+		{
+			oauth.GET("/authorize", authHandler.OAuthAuthorize)
+			oauth.POST("/authorize", authHandler.OAuthDecide)
+			oauth.POST("/clients", authHandler.RegisterOAuthClient)
+			oauth.GET("/clients", authHandler.ListOAuthClients)
+			oauth.DELETE("/clients/:client_id", authHandler.DeleteOAuthClient)
+			oauth.GET("/consents", authHandler.ListOAuthConsents)
+			oauth.DELETE("/consents/:client_id", authHandler.RevokeOAuthConsent)
+		}
+
+		// 机器人管理: 只有普通用户的访问token可以调用，机器人token不是JWT
+		bots := api.Group("/bots")
+		bots.Use(authMiddleware.RequireAuth())
+		{
+			bots.POST("", authHandler.CreateBot)
+			bots.GET("", authHandler.ListBots)
+			bots.DELETE("/:bot_id", authHandler.DeleteBot)
+			bots.POST("/:bot_id/tokens", authHandler.CreateBotToken)
+			bots.GET("/:bot_id/tokens", authHandler.ListBotTokens)
+			bots.DELETE("/:bot_id/tokens/:token_id", authHandler.RevokeBotToken)
 		}
 
 		// 健康检查
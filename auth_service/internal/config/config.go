@@ -24,13 +24,31 @@ type Config struct {
 	Database DatabaseConfig `mapstructure:"database"`
 	Redis    RedisConfig    `mapstructure:"redis"`
 	JWT      JWTConfig      `mapstructure:"jwt"`
-	Log      LogConfig      `mapstructure:"log"`
+	Account  AccountConfig  `mapstructure:"account"`
+	Code     CodeConfig     `mapstructure:"verification_code"`
+	Sender   SenderConfig   `mapstructure:"sender"`
+
+	LoginProtection LoginProtectionConfig `mapstructure:"login_protection"`
+	PasswordPolicy  PasswordPolicyConfig  `mapstructure:"password_policy"`
+	PasswordHash    PasswordHashConfig    `mapstructure:"password_hash"`
+	Audit           AuditConfig           `mapstructure:"audit"`
+	DeviceApproval  DeviceApprovalConfig  `mapstructure:"device_approval"`
+	OAuth           OAuthConfig           `mapstructure:"oauth"`
+	Bots            BotsConfig            `mapstructure:"bots"`
+	GRPCTLS         GRPCTLSConfig         `mapstructure:"grpc_tls"`
+	Log             LogConfig             `mapstructure:"log"`
+
+	PersonalAccessTokens PersonalAccessTokensConfig `mapstructure:"personal_access_tokens"`
+	Usernames            UsernamesConfig            `mapstructure:"usernames"`
+	Devices              DevicesConfig              `mapstructure:"devices"`
 }
 
 type ServerConfig struct {
 	Port     int    `mapstructure:"port"`
 	GRPCPort int    `mapstructure:"grpc_port"`
 	Mode     string `mapstructure:"mode"`
+
+	TrustedProxies []string `mapstructure:"trusted_proxies"` // 可信代理(IP或CIDR), 为空时不采信X-Forwarded-For
 }
 
 type DatabaseConfig struct {
@@ -52,6 +70,11 @@ type JWTConfig struct {
 	Secret             string `mapstructure:"secret"`
 	ExpireHours        int    `mapstructure:"expire_hours"`
 	RefreshExpireHours int    `mapstructure:"refresh_expire_hours"`
+	Algorithm          string `mapstructure:"algorithm"`          // 签名算法: HS256, RS256, EdDSA
+	KeyRotationHours   int    `mapstructure:"key_rotation_hours"` // 签名密钥轮换周期
+	KeyOverlapHours    int    `mapstructure:"key_overlap_hours"`  // 轮换后旧密钥继续验证的时长, 默认等于刷新token有效期
+	// LegacySecretRetireAt 使用非对称算法时secret停止验证旧HS256 token的时间(RFC3339), 为空时不接受旧token
+	LegacySecretRetireAt string `mapstructure:"legacy_secret_retire_at"`
 }
 
 func (j JWTConfig) ExpireDuration() time.Duration {
@@ -62,6 +85,208 @@ func (j JWTConfig) RefreshExpireDuration() time.Duration {
 	return time.Duration(j.RefreshExpireHours) * time.Hour
 }
 
+// UseKeySet 是否使用非对称密钥签名
+func (j JWTConfig) UseKeySet() bool {
+	return j.Algorithm != "" && j.Algorithm != "HS256"
+}
+
+// LegacySecretRetireTime 解析旧HS256密钥的停用时间, 未配置时返回零值
+func (j JWTConfig) LegacySecretRetireTime() (time.Time, error) {
+	if j.LegacySecretRetireAt == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, j.LegacySecretRetireAt)
+}
+
+func (j JWTConfig) KeyRotationInterval() time.Duration {
+	if j.KeyRotationHours <= 0 {
+		return 30 * 24 * time.Hour
+	}
+	return time.Duration(j.KeyRotationHours) * time.Hour
+}
+
+func (j JWTConfig) KeyOverlapDuration() time.Duration {
+	if j.KeyOverlapHours <= 0 {
+		return j.RefreshExpireDuration()
+	}
+	return time.Duration(j.KeyOverlapHours) * time.Hour
+}
+
+// AccountConfig 账号配置
+type AccountConfig struct {
+	DefaultRegion string `mapstructure:"default_region"` // 国内格式手机号的默认地区, 如 CN
+	TOTPIssuer    string `mapstructure:"totp_issuer"`    // 两步验证器应用中显示的服务名
+
+	PasswordResetTTLMinutes int    `mapstructure:"password_reset_ttl_minutes"` // 密码重置token有效期
+	PasswordResetURL        string `mapstructure:"password_reset_url"`         // 重置链接模板, {token}替换为重置token; 为空时直接发送token
+
+	DeletionGraceDays    int `mapstructure:"deletion_grace_days"`    // 申请删除到清除账号数据的宽限期
+	PurgeIntervalMinutes int `mapstructure:"purge_interval_minutes"` // 检查到期账号的周期
+}
+
+// PurgeInterval 检查到期账号的周期，默认1小时
+func (a AccountConfig) PurgeInterval() time.Duration {
+	if a.PurgeIntervalMinutes <= 0 {
+		return time.Hour
+	}
+	return time.Duration(a.PurgeIntervalMinutes) * time.Minute
+}
+
+// AuditConfig 安全审计事件配置
+type AuditConfig struct {
+	RetentionDays        int `mapstructure:"retention_days"`         // 安全事件保留天数
+	PruneIntervalMinutes int `mapstructure:"prune_interval_minutes"` // 清理过期事件的周期
+}
+
+// PruneInterval 清理过期事件的周期，默认1小时
+func (a AuditConfig) PruneInterval() time.Duration {
+	if a.PruneIntervalMinutes <= 0 {
+		return time.Hour
+	}
+	return time.Duration(a.PruneIntervalMinutes) * time.Minute
+}
+
+// GRPCTLSConfig gRPC服务间mTLS配置
+type GRPCTLSConfig struct {
+	Enabled       bool   `mapstructure:"enabled"`
+	CertFile      string `mapstructure:"cert_file"`      // 本服务证书
+	KeyFile       string `mapstructure:"key_file"`       // 本服务私钥
+	CAFile        string `mapstructure:"ca_file"`        // 签发服务证书的CA
+	ReloadSeconds int    `mapstructure:"reload_seconds"` // 检查证书文件变化的周期
+
+	ACL map[string][]string `mapstructure:"acl"` // RPC方法 -> 允许调用的服务名, 覆盖默认规则; "*" 对应未列出的方法
+
+	// 未启用mTLS时仍允许任何调用方访问内部RPC, 仅用于本地开发; 默认拒绝
+	AllowInsecureInternalRPC bool `mapstructure:"allow_insecure_internal_rpc"`
+}
+
+// ReloadInterval 检查证书文件变化的周期，默认1分钟
+func (g GRPCTLSConfig) ReloadInterval() time.Duration {
+	if g.ReloadSeconds <= 0 {
+		return time.Minute
+	}
+	return time.Duration(g.ReloadSeconds) * time.Second
+}
+
+// DeviceApprovalConfig 新设备登录审批配置
+type DeviceApprovalConfig struct {
+	Enabled        bool `mapstructure:"enabled"`         // 新设备密码登录需已登录的设备批准
+	TimeoutSeconds int  `mapstructure:"timeout_seconds"` // 等待审批的时长, 超时后可改用验证码
+}
+
+// OAuthConfig OAuth2/OpenID Connect配置
+type OAuthConfig struct {
+	Issuer           string `mapstructure:"issuer"`            // 对外的服务地址, 作为ID Token的iss和各端点的前缀
+	AuthorizationURL string `mapstructure:"authorization_url"` // 网页客户端的授权确认页面, 默认 {issuer}/oauth/authorize
+	CodeTTLSeconds   int    `mapstructure:"code_ttl_seconds"`  // 授权码有效期
+	RefreshTTLDays   int    `mapstructure:"refresh_ttl_days"`  // 第三方应用刷新token有效期
+}
+
+// BotsConfig 机器人配置
+type BotsConfig struct {
+	MaxPerOwner       int `mapstructure:"max_per_owner"`       // 每个用户最多创建的机器人数
+	RateLimit         int `mapstructure:"rate_limit"`          // 每个机器人在窗口内允许的请求数
+	RateWindowSeconds int `mapstructure:"rate_window_seconds"` // 频率限制窗口
+}
+
+// PersonalAccessTokensConfig 个人访问token配置
+type PersonalAccessTokensConfig struct {
+	MaxTTLDays int `mapstructure:"max_ttl_days"` // 最长有效期
+	MaxPerUser int `mapstructure:"max_per_user"` // 每个用户同时有效的token数
+}
+
+// UsernamesConfig 用户名配置
+type UsernamesConfig struct {
+	ChangeCooldownDays int      `mapstructure:"change_cooldown_days"` // 两次修改用户名的最短间隔
+	HoldDays           int      `mapstructure:"hold_days"`            // 旧用户名为原用户保留的天数
+	Reserved           []string `mapstructure:"reserved"`             // 额外的保留用户名
+}
+
+// DevicesConfig 设备在线状态与闲置设备清理配置
+type DevicesConfig struct {
+	IdleTimeoutSeconds  int `mapstructure:"idle_timeout_seconds"`  // 超过该时长没有心跳的设备标记为离线
+	ReapIntervalSeconds int `mapstructure:"reap_interval_seconds"` // 写入心跳、清理离线和闲置设备的周期
+	StaleMonths         int `mapstructure:"stale_months"`          // 超过该月数未使用的设备被删除, 负数表示不删除
+}
+
+// ReapInterval 写入心跳和清理设备的周期，默认30秒
+func (d DevicesConfig) ReapInterval() time.Duration {
+	if d.ReapIntervalSeconds <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(d.ReapIntervalSeconds) * time.Second
+}
+
+// StaleAfter 闲置设备的删除期限，每月按30天计算，负数表示不删除
+func (d DevicesConfig) StaleAfter() time.Duration {
+	if d.StaleMonths < 0 {
+		return -1
+	}
+	return time.Duration(d.StaleMonths) * 30 * 24 * time.Hour
+}
+
+// CodeConfig 验证码配置
+type CodeConfig struct {
+	Length                int `mapstructure:"length"`
+	TTLSeconds            int `mapstructure:"ttl_seconds"`
+	MaxAttempts           int `mapstructure:"max_attempts"`
+	ResendCooldownSeconds int `mapstructure:"resend_cooldown_seconds"`
+}
+
+// LoginProtectionConfig 登录防暴力破解配置
+type LoginProtectionConfig struct {
+	WindowMinutes      int `mapstructure:"window_minutes"`       // 失败计数有效期
+	AccountThreshold   int `mapstructure:"account_threshold"`    // 每个账号允许的连续失败次数
+	IPThreshold        int `mapstructure:"ip_threshold"`         // 每个IP允许的连续失败次数
+	DeviceThreshold    int `mapstructure:"device_threshold"`     // 每个设备允许的连续失败次数
+	BaseLockoutSeconds int `mapstructure:"base_lockout_seconds"` // 首次锁定时长, 之后每次失败翻倍
+	MaxLockoutMinutes  int `mapstructure:"max_lockout_minutes"`  // 最长锁定时长
+}
+
+// PasswordPolicyConfig 密码策略配置
+type PasswordPolicyConfig struct {
+	MinLength        int    `mapstructure:"min_length"`
+	MaxLength        int    `mapstructure:"max_length"`
+	RequireLowercase bool   `mapstructure:"require_lowercase"`
+	RequireUppercase bool   `mapstructure:"require_uppercase"`
+	RequireDigit     bool   `mapstructure:"require_digit"`
+	RequireSymbol    bool   `mapstructure:"require_symbol"`
+	HistorySize      int    `mapstructure:"history_size"`   // 不允许与最近N个密码重复, 0表示不限制
+	BlocklistFile    string `mapstructure:"blocklist_file"` // 常见密码黑名单文件, 每行一个
+}
+
+// PasswordHashConfig 密码哈希配置，修改后旧哈希在用户下次登录时自动升级
+type PasswordHashConfig struct {
+	Algorithm  string `mapstructure:"algorithm"`   // argon2id, bcrypt
+	MemoryKiB  uint32 `mapstructure:"memory_kib"`  // argon2id 内存开销
+	Time       uint32 `mapstructure:"time"`        // argon2id 迭代次数
+	Threads    uint8  `mapstructure:"threads"`     // argon2id 并行度
+	BcryptCost int    `mapstructure:"bcrypt_cost"` // algorithm 为 bcrypt 时使用
+}
+
+// SenderConfig 短信/邮件发送配置
+type SenderConfig struct {
+	LogFile string      `mapstructure:"log_file"` // log发送器写入的文件, 为空时只写日志
+	SMS     SMSConfig   `mapstructure:"sms"`
+	Email   EmailConfig `mapstructure:"email"`
+}
+
+type SMSConfig struct {
+	Provider string `mapstructure:"provider"` // none(默认, 拒绝发送), http, log(仅debug模式)
+	Endpoint string `mapstructure:"endpoint"`
+	APIKey   string `mapstructure:"api_key"`
+	SignName string `mapstructure:"sign_name"`
+}
+
+type EmailConfig struct {
+	Provider string `mapstructure:"provider"` // none(默认, 拒绝发送), smtp, log(仅debug模式)
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from"`
+}
+
 // LoadConfig 加载配置文件
 func LoadConfig(path string) (*Config, error) {
 	viper.SetConfigName("config")
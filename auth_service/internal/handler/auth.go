@@ -1,11 +1,16 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/jacl-coder/telegramlite/auth_service/internal/middleware"
 	"github.com/jacl-coder/telegramlite/auth_service/internal/service"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
 )
 
 // AuthHandler 认证处理器
@@ -38,8 +43,433 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
 	result, err := h.authService.Register(&req)
 	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+			Data:    passwordViolations(err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "注册成功",
+		Data:    result,
+	})
+}
+
+// Login 用户登录
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req service.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	result, err := h.authService.Login(&req)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, Response{
+			Code:    401,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: loginMessage(result),
+		Data:    result,
+	})
+}
+
+// SendCode 发送登录验证码
+func (h *AuthHandler) SendCode(c *gin.Context) {
+	var req service.SendCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	result, err := h.authService.SendCode(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "验证码已发送",
+		Data:    result,
+	})
+}
+
+// VerifyCode 验证码登录，账号不存在时自动注册
+func (h *AuthHandler) VerifyCode(c *gin.Context) {
+	var req service.VerifyCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	result, err := h.authService.VerifyCode(&req)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, Response{
+			Code:    401,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: loginMessage(result),
+		Data:    result,
+	})
+}
+
+// RequestPasswordReset 申请重置密码
+func (h *AuthHandler) RequestPasswordReset(c *gin.Context) {
+	var req service.RequestPasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	if err := h.authService.RequestPasswordReset(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "如果账号存在，重置链接已发送",
+	})
+}
+
+// ResetPassword 使用重置token设置新密码
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req service.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	if err := h.authService.ResetPassword(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+			Data:    passwordViolations(err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "密码已重置，请重新登录",
+	})
+}
+
+// ChangePassword 修改密码
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var req service.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+	userID, _ := middleware.GetUserID(c)
+	deviceID, _ := middleware.GetDeviceID(c)
+
+	result, err := h.authService.ChangePassword(userID, deviceID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+			Data:    passwordViolations(err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "密码修改成功",
+		Data:    result,
+	})
+}
+
+// DeactivateAccount 停用账号
+func (h *AuthHandler) DeactivateAccount(c *gin.Context) {
+	var req service.AccountActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+	userID, _ := middleware.GetUserID(c)
+
+	if err := h.authService.DeactivateAccount(userID, &req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "账号已停用，重新登录即可恢复",
+	})
+}
+
+// DeleteAccount 删除账号，宽限期结束后清除数据
+func (h *AuthHandler) DeleteAccount(c *gin.Context) {
+	var req service.AccountActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+	userID, _ := middleware.GetUserID(c)
+
+	result, err := h.authService.DeleteAccount(userID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "账号将在宽限期结束后删除，期间重新登录可取消",
+		Data:    result,
+	})
+}
+
+// passwordViolations 密码策略错误时返回结构化的违规项，供客户端逐条展示
+func passwordViolations(err error) interface{} {
+	var policyErr *pkg.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return nil
+	}
+	return gin.H{"violations": policyErr.Violations}
+}
+
+// VerifySecondFactor 完成两步验证登录
+func (h *AuthHandler) VerifySecondFactor(c *gin.Context) {
+	var req service.VerifySecondFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	result, err := h.authService.VerifySecondFactor(&req)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, Response{
+			Code:    401,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "登录成功",
+		Data:    result,
+	})
+}
+
+// CheckDeviceApproval 新设备查询登录审批结果，已批准时返回token
+func (h *AuthHandler) CheckDeviceApproval(c *gin.Context) {
+	var req service.DeviceApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	result, err := h.authService.CheckDeviceApproval(&req)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, Response{
+			Code:    401,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: loginMessage(result),
+		Data:    result,
+	})
+}
+
+// SendDeviceApprovalCode 审批超时后发送验证码
+func (h *AuthHandler) SendDeviceApprovalCode(c *gin.Context) {
+	var req service.DeviceApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	result, err := h.authService.SendDeviceApprovalCode(req.ApprovalToken)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "验证码已发送",
+		Data:    result,
+	})
+}
+
+// VerifyDeviceApprovalCode 审批超时后使用验证码完成新设备登录
+func (h *AuthHandler) VerifyDeviceApprovalCode(c *gin.Context) {
+	var req service.DeviceApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	result, err := h.authService.VerifyDeviceApprovalCode(&req)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, Response{
+			Code:    401,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: loginMessage(result),
+		Data:    result,
+	})
+}
+
+// ListDeviceApprovals 获取等待审批的新设备登录请求
+func (h *AuthHandler) ListDeviceApprovals(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	approvals, err := h.authService.ListDeviceApprovals(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Code:    500,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取登录请求成功",
+		Data:    approvals,
+	})
+}
+
+// ApproveDevice 批准新设备登录
+func (h *AuthHandler) ApproveDevice(c *gin.Context) {
+	h.decideDeviceApproval(c, true)
+}
+
+// DenyDevice 拒绝新设备登录
+func (h *AuthHandler) DenyDevice(c *gin.Context) {
+	h.decideDeviceApproval(c, false)
+}
+
+func (h *AuthHandler) decideDeviceApproval(c *gin.Context, approve bool) {
+	userID, _ := middleware.GetUserID(c)
+	deviceID, _ := middleware.GetDeviceID(c)
+
+	approvalID, err := strconv.ParseUint(c.Param("approval_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的请求ID",
+		})
+		return
+	}
+
+	if err := h.authService.DecideDeviceApproval(userID, deviceID, uint(approvalID), approve); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			Code:    400,
 			Message: err.Error(),
@@ -47,16 +477,53 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
+	message := "已拒绝登录"
+	if approve {
+		message = "已批准登录"
+	}
 	c.JSON(http.StatusOK, Response{
 		Code:    0,
-		Message: "注册成功",
+		Message: message,
+	})
+}
+
+// ExportLoginToken 网页/桌面端申请二维码登录token
+func (h *AuthHandler) ExportLoginToken(c *gin.Context) {
+	var req service.ExportLoginTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	req.ClientIP = c.ClientIP()
+
+	result, err := h.authService.ExportLoginToken(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "请使用手机扫码登录",
 		Data:    result,
 	})
 }
 
-// Login 用户登录
-func (h *AuthHandler) Login(c *gin.Context) {
-	var req service.LoginRequest
+// AcceptLoginToken 已登录的手机扫码确认登录
+func (h *AuthHandler) AcceptLoginToken(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	deviceID, _ := middleware.GetDeviceID(c)
+
+	var req struct {
+		Token string `json:"token" binding:"required"`
+	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			Code:    400,
@@ -65,7 +532,37 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	result, err := h.authService.Login(&req)
+	if err := h.authService.AcceptLoginToken(userID, deviceID, req.Token); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "已确认登录",
+	})
+}
+
+// ImportLoginToken 网页/桌面端长轮询扫码结果，wait为最长等待秒数
+func (h *AuthHandler) ImportLoginToken(c *gin.Context) {
+	var req service.ImportLoginTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	wait, _ := strconv.Atoi(c.Query("wait"))
+	req.Wait = time.Duration(wait) * time.Second
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	result, err := h.authService.ImportLoginToken(c.Request.Context(), &req)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, Response{
 			Code:    401,
@@ -76,11 +573,169 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	c.JSON(http.StatusOK, Response{
 		Code:    0,
-		Message: "登录成功",
+		Message: loginMessage(result),
 		Data:    result,
 	})
 }
 
+// RegisterPushToken 为当前设备注册推送token
+func (h *AuthHandler) RegisterPushToken(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	deviceID, _ := middleware.GetDeviceID(c)
+
+	var req service.RegisterPushTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.authService.RegisterPushToken(userID, deviceID, &req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "推送token已注册",
+	})
+}
+
+// UnregisterPushToken 清除当前设备的推送token
+func (h *AuthHandler) UnregisterPushToken(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	deviceID, _ := middleware.GetDeviceID(c)
+
+	if err := h.authService.UnregisterPushToken(userID, deviceID); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "推送token已清除",
+	})
+}
+
+// GetTwoFactorStatus 获取两步验证状态
+func (h *AuthHandler) GetTwoFactorStatus(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	status, err := h.authService.GetTwoFactorStatus(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Code:    500,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取成功",
+		Data:    status,
+	})
+}
+
+// EnrollTwoFactor 生成两步验证密钥和恢复码
+func (h *AuthHandler) EnrollTwoFactor(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	enrollment, err := h.authService.EnrollTwoFactor(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "请使用验证器应用扫码并确认",
+		Data:    enrollment,
+	})
+}
+
+// ConfirmTwoFactor 确认并启用两步验证
+func (h *AuthHandler) ConfirmTwoFactor(c *gin.Context) {
+	var req struct {
+		Code string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	if err := h.authService.ConfirmTwoFactor(userID, req.Code, c.ClientIP(), c.Request.UserAgent()); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "两步验证已启用",
+	})
+}
+
+// DisableTwoFactor 关闭两步验证
+func (h *AuthHandler) DisableTwoFactor(c *gin.Context) {
+	var req service.DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	userID, _ := middleware.GetUserID(c)
+	if err := h.authService.DisableTwoFactor(userID, &req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "两步验证已关闭",
+	})
+}
+
+// loginMessage 登录响应消息，需要两步验证时提示客户端继续
+func loginMessage(result *service.AuthResponse) string {
+	if result.TwoFactor != nil {
+		return "需要两步验证"
+	}
+	if result.DeviceApproval != nil {
+		return "需要在已登录的设备上确认"
+	}
+	if result.LoginToken != nil {
+		return "等待扫码确认"
+	}
+	return "登录成功"
+}
+
 // RefreshToken 刷新token
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var req struct {
@@ -95,7 +750,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	result, err := h.authService.RefreshToken(req.RefreshToken)
+	result, err := h.authService.RefreshToken(req.RefreshToken, c.ClientIP(), c.Request.UserAgent())
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, Response{
 			Code:    401,
@@ -113,9 +768,9 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 // Logout 登出
 func (h *AuthHandler) Logout(c *gin.Context) {
-	// 从JWT中获取设备ID (这里简化处理，实际应该从middleware中获取)
-	deviceID := c.GetUint("device_id")
-	if deviceID == 0 {
+	// 从middleware中获取当前token声明
+	claims, ok := middleware.GetClaims(c)
+	if !ok || claims.DeviceID == 0 {
 		c.JSON(http.StatusBadRequest, Response{
 			Code:    400,
 			Message: "无效的设备信息",
@@ -123,7 +778,7 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		return
 	}
 
-	err := h.authService.Logout(deviceID)
+	err := h.authService.Logout(claims, c.ClientIP(), c.Request.UserAgent())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, Response{
 			Code:    500,
@@ -138,6 +793,122 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	})
 }
 
+// Heartbeat 设备心跳，超过一段时间没有心跳的设备标记为离线
+func (h *AuthHandler) Heartbeat(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	deviceID, _ := middleware.GetDeviceID(c)
+
+	result, err := h.authService.Heartbeat(userID, deviceID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "ok",
+		Data:    result,
+	})
+}
+
+// ListSessions 获取活跃会话列表
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	deviceID, _ := middleware.GetDeviceID(c)
+
+	sessions, err := h.authService.ListSessions(userID, deviceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Code:    500,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取会话列表成功",
+		Data:    sessions,
+	})
+}
+
+// ListSecurityEvents 查看当前用户最近的安全事件
+func (h *AuthHandler) ListSecurityEvents(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	events, cursor, err := h.authService.ListSecurityEvents(userID, c.Query("cursor"), limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取安全事件成功",
+		Data: gin.H{
+			"events": events,
+			"cursor": cursor,
+		},
+	})
+}
+
+// RevokeSession 终止指定会话
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	deviceID, err := strconv.ParseUint(c.Param("device_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的设备ID",
+		})
+		return
+	}
+
+	if err := h.authService.RevokeSession(userID, uint(deviceID)); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "会话已终止",
+	})
+}
+
+// RevokeOtherSessions 终止除当前设备外的所有会话
+func (h *AuthHandler) RevokeOtherSessions(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	deviceID, _ := middleware.GetDeviceID(c)
+
+	count, err := h.authService.RevokeOtherSessions(userID, deviceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Code:    500,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "其他会话已终止",
+		Data: gin.H{
+			"revoked_count": count,
+		},
+	})
+}
+
 // GetUserInfo 获取用户信息
 func (h *AuthHandler) GetUserInfo(c *gin.Context) {
 	// 从Header获取Access Token
@@ -172,6 +943,19 @@ func (h *AuthHandler) GetUserInfo(c *gin.Context) {
 	})
 }
 
+// JWKS 签名公钥集合 (/.well-known/jwks.json)
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	keys := h.authService.GetSigningKeys()
+
+	jwks := pkg.JWKS{Keys: make([]pkg.JWK, 0, len(keys))}
+	for _, key := range keys {
+		jwks.Keys = append(jwks.Keys, key.JWK())
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, jwks)
+}
+
 // Health 健康检查
 func (h *AuthHandler) Health(c *gin.Context) {
 	c.JSON(http.StatusOK, Response{
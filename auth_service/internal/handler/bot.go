@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/middleware"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/service"
+)
+
+// CreateBot 创建机器人，返回机器人信息和第一个token
+func (h *AuthHandler) CreateBot(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	var req service.CreateBotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	result, err := h.authService.CreateBot(userID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "机器人已创建，请妥善保存token",
+		Data:    result,
+	})
+}
+
+// ListBots 获取当前用户创建的机器人
+func (h *AuthHandler) ListBots(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	bots, err := h.authService.ListBots(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Code:    500,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取机器人列表成功",
+		Data:    bots,
+	})
+}
+
+// DeleteBot 删除机器人
+func (h *AuthHandler) DeleteBot(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	botID, ok := botIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.DeleteBot(userID, botID, c.ClientIP(), c.Request.UserAgent()); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "机器人已删除",
+	})
+}
+
+// CreateBotToken 为机器人签发新token
+func (h *AuthHandler) CreateBotToken(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	botID, ok := botIDParam(c)
+	if !ok {
+		return
+	}
+
+	token, err := h.authService.CreateBotToken(userID, botID, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "token已创建，请妥善保存",
+		Data:    token,
+	})
+}
+
+// ListBotTokens 获取机器人的token列表
+func (h *AuthHandler) ListBotTokens(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	botID, ok := botIDParam(c)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.authService.ListBotTokens(userID, botID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取token列表成功",
+		Data:    tokens,
+	})
+}
+
+// RevokeBotToken 吊销机器人token
+func (h *AuthHandler) RevokeBotToken(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	botID, ok := botIDParam(c)
+	if !ok {
+		return
+	}
+	tokenID, err := strconv.ParseUint(c.Param("token_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的token ID",
+		})
+		return
+	}
+
+	if err := h.authService.RevokeBotToken(userID, botID, uint(tokenID), c.ClientIP(), c.Request.UserAgent()); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "token已吊销",
+	})
+}
+
+// botIDParam 解析路径中的机器人ID，无效时直接返回400
+func botIDParam(c *gin.Context) (uint, bool) {
+	botID, err := strconv.ParseUint(c.Param("bot_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的机器人ID",
+		})
+		return 0, false
+	}
+	return uint(botID), true
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// parseTrustedProxies 解析可信代理列表 (IP或CIDR)
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// grpcClientIP 获取gRPC调用的客户端IP
+// 网关转发的请求通过x-forwarded-for/x-real-ip元数据透传原始IP，否则使用连接对端地址；
+// 只采信来自trustedProxies的元数据，未配置时不采信任何元数据，防止客户端伪造IP绕过限制
+func grpcClientIP(ctx context.Context, trustedProxies []*net.IPNet) string {
+	peerIP := grpcPeerIP(ctx)
+	if !containsIP(trustedProxies, peerIP) {
+		return peerIP
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-forwarded-for"); len(values) > 0 {
+			if ip := strings.TrimSpace(strings.Split(values[0], ",")[0]); ip != "" {
+				return ip
+			}
+		}
+		if values := md.Get("x-real-ip"); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+
+	return peerIP
+}
+
+// grpcPeerIP 连接对端地址
+func grpcPeerIP(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			return p.Addr.String()
+		}
+		return host
+	}
+	return ""
+}
+
+// containsIP IP是否属于任一网段
+func containsIP(networks []*net.IPNet, rawIP string) bool {
+	ip := net.ParseIP(rawIP)
+	if ip == nil {
+		return false
+	}
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// grpcUserAgent 客户端User-Agent
+func grpcUserAgent(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("user-agent"); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestGRPCClientIP(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 50000},
+	})
+	forwarded := metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", "203.0.113.7, 10.0.0.5"))
+
+	// 未配置可信代理时不采信透传的IP
+	assert.Equal(t, "10.0.0.5", grpcClientIP(ctx, nil))
+	assert.Equal(t, "10.0.0.5", grpcClientIP(forwarded, nil))
+	empty, err := parseTrustedProxies([]string{})
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.5", grpcClientIP(forwarded, empty))
+
+	trusted, err := parseTrustedProxies([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.7", grpcClientIP(forwarded, trusted))
+
+	// 来自非可信地址的元数据被忽略
+	untrusted, err := parseTrustedProxies([]string{"192.168.1.1"})
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.5", grpcClientIP(forwarded, untrusted))
+
+	_, err = parseTrustedProxies([]string{"not-an-ip"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/middleware"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/service"
+)
+
+// SendPhoneChangeCode 向新手机号发送验证码
+func (h *AuthHandler) SendPhoneChangeCode(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	var req service.SendCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	result, err := h.authService.SendPhoneChangeCode(userID, req.Phone)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "验证码已发送",
+		Data:    result,
+	})
+}
+
+// RequestPhoneChange 验证新手机号并发起更换，需在其他已登录设备上确认
+func (h *AuthHandler) RequestPhoneChange(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	deviceID, _ := middleware.GetDeviceID(c)
+
+	var req service.ChangePhoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	change, err := h.authService.RequestPhoneChange(userID, deviceID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	message := "请在其他已登录的设备上确认更换"
+	if change.Status != model.PhoneChangePending {
+		message = "手机号已更换"
+	}
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: message,
+		Data:    change,
+	})
+}
+
+// ListPhoneChanges 获取等待确认的手机号更换请求
+func (h *AuthHandler) ListPhoneChanges(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	changes, err := h.authService.ListPhoneChanges(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Code:    500,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取更换请求成功",
+		Data:    changes,
+	})
+}
+
+// GetPhoneChange 查询手机号更换请求的状态
+func (h *AuthHandler) GetPhoneChange(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	changeID, ok := phoneChangeIDParam(c)
+	if !ok {
+		return
+	}
+
+	change, err := h.authService.GetPhoneChange(userID, changeID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, Response{
+			Code:    404,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取更换请求成功",
+		Data:    change,
+	})
+}
+
+// ApprovePhoneChange 确认更换手机号
+func (h *AuthHandler) ApprovePhoneChange(c *gin.Context) {
+	h.decidePhoneChange(c, true)
+}
+
+// DenyPhoneChange 拒绝更换手机号
+func (h *AuthHandler) DenyPhoneChange(c *gin.Context) {
+	h.decidePhoneChange(c, false)
+}
+
+func (h *AuthHandler) decidePhoneChange(c *gin.Context, approve bool) {
+	userID, _ := middleware.GetUserID(c)
+	deviceID, _ := middleware.GetDeviceID(c)
+
+	changeID, ok := phoneChangeIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.DecidePhoneChange(userID, deviceID, changeID, approve, c.ClientIP(), c.Request.UserAgent()); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	message := "已拒绝更换手机号"
+	if approve {
+		message = "手机号已更换"
+	}
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: message,
+	})
+}
+
+// SendEmailChangeCode 向新邮箱发送验证码
+func (h *AuthHandler) SendEmailChangeCode(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	var req service.SendCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	result, err := h.authService.SendEmailChangeCode(userID, req.Email)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "验证码已发送",
+		Data:    result,
+	})
+}
+
+// ChangeEmail 验证新邮箱并更换
+func (h *AuthHandler) ChangeEmail(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	deviceID, _ := middleware.GetDeviceID(c)
+
+	var req service.ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	user, err := h.authService.ChangeEmail(userID, deviceID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "邮箱已更换",
+		Data:    user,
+	})
+}
+
+// ListNotifications 获取当前设备未读的账号安全通知
+func (h *AuthHandler) ListNotifications(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	deviceID, _ := middleware.GetDeviceID(c)
+
+	notifications, err := h.authService.ListNotifications(userID, deviceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Code:    500,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取通知成功",
+		Data:    notifications,
+	})
+}
+
+// phoneChangeIDParam 解析路径中的更换请求ID，无效时直接返回400
+func phoneChangeIDParam(c *gin.Context) (uint, bool) {
+	changeID, err := strconv.ParseUint(c.Param("change_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的请求ID",
+		})
+		return 0, false
+	}
+	return uint(changeID), true
+}
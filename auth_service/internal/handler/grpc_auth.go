@@ -2,17 +2,38 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/jacl-coder/telegramlite/auth_service/api/proto"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
 	"github.com/jacl-coder/telegramlite/auth_service/internal/service"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
 )
 
+// InternalRPCCallers 内部RPC默认允许的调用方服务，未启用mTLS时这些RPC一律拒绝
+// 未列出的RPC不限制调用方
+var InternalRPCCallers = pkg.ServiceACL{
+	"GetRevocations":       {"user-service"},
+	"GetAccountPurges":     {"user-service"},
+	"ExportUserData":       {"user-service"},
+	"RevokeTokens":         {"admin-service"},
+	"QueryAuthEvents":      {"admin-service"},
+	"GetPushTargets":       {"push-service"},
+	"InvalidatePushTokens": {"push-service"},
+}
+
 // GRPCAuthHandler gRPC认证处理器
 type GRPCAuthHandler struct {
 	pb.UnimplementedAuthServiceServer
-	authService *service.AuthService
+	authService    *service.AuthService
+	trustedProxies []*net.IPNet // 为空时不采信调用方透传的客户端IP
 }
 
 // NewGRPCAuthHandler 创建新的gRPC认证处理器
@@ -22,6 +43,16 @@ func NewGRPCAuthHandler(authService *service.AuthService) *GRPCAuthHandler {
 	}
 }
 
+// SetTrustedProxies 设置可信代理 (IP或CIDR)，只有来自这些地址的调用才采信元数据中的客户端IP
+func (h *GRPCAuthHandler) SetTrustedProxies(proxies []string) error {
+	networks, err := parseTrustedProxies(proxies)
+	if err != nil {
+		return err
+	}
+	h.trustedProxies = networks
+	return nil
+}
+
 // Register 用户注册
 func (h *GRPCAuthHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
 	// 转换请求到service层
@@ -33,6 +64,8 @@ func (h *GRPCAuthHandler) Register(ctx context.Context, req *pb.RegisterRequest)
 		DeviceToken: req.DeviceToken,
 		DeviceType:  convertDeviceTypeToDomain(req.DeviceType),
 		DeviceName:  req.DeviceName,
+		ClientIP:    grpcClientIP(ctx, h.trustedProxies),
+		UserAgent:   grpcUserAgent(ctx),
 	}
 
 	// 调用业务逻辑
@@ -44,6 +77,7 @@ func (h *GRPCAuthHandler) Register(ctx context.Context, req *pb.RegisterRequest)
 				Message:   err.Error(),
 				Timestamp: timestamppb.Now(),
 			},
+			Violations: convertPasswordViolationsToProto(err),
 		}, nil
 	}
 
@@ -68,6 +102,8 @@ func (h *GRPCAuthHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.
 		Password:    req.Password,
 		DeviceType:  convertDeviceTypeToDomain(req.DeviceType),
 		DeviceName:  req.DeviceName,
+		ClientIP:    grpcClientIP(ctx, h.trustedProxies),
+		UserAgent:   grpcUserAgent(ctx),
 	}
 
 	// 处理登录凭证
@@ -76,6 +112,8 @@ func (h *GRPCAuthHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.
 		serviceReq.Phone = cred.Phone
 	case *pb.LoginRequest_Email:
 		serviceReq.Email = cred.Email
+	case *pb.LoginRequest_Username:
+		serviceReq.Username = cred.Username
 	default:
 		return &pb.LoginResponse{
 			Response: &pb.Response{
@@ -98,6 +136,30 @@ func (h *GRPCAuthHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.
 		}, nil
 	}
 
+	if resp.TwoFactor != nil {
+		return &pb.LoginResponse{
+			Response: &pb.Response{
+				Code:      0,
+				Message:   "需要两步验证",
+				Timestamp: timestamppb.Now(),
+			},
+			Data:      &pb.LoginData{User: convertUserToProto(resp.User)},
+			TwoFactor: convertTwoFactorChallengeToProto(resp.TwoFactor),
+		}, nil
+	}
+
+	if resp.DeviceApproval != nil {
+		return &pb.LoginResponse{
+			Response: &pb.Response{
+				Code:      0,
+				Message:   "需要在已登录的设备上确认",
+				Timestamp: timestamppb.Now(),
+			},
+			Data:           &pb.LoginData{User: convertUserToProto(resp.User)},
+			DeviceApproval: convertDeviceApprovalChallengeToProto(resp.DeviceApproval),
+		}, nil
+	}
+
 	return &pb.LoginResponse{
 		Response: &pb.Response{
 			Code:      0,
@@ -112,11 +174,27 @@ func (h *GRPCAuthHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.
 	}, nil
 }
 
-// RefreshToken 刷新Token
-func (h *GRPCAuthHandler) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
-	token, err := h.authService.RefreshToken(req.RefreshToken)
+// SendCode 发送登录验证码
+func (h *GRPCAuthHandler) SendCode(ctx context.Context, req *pb.SendCodeRequest) (*pb.SendCodeResponse, error) {
+	serviceReq := &service.SendCodeRequest{}
+	switch target := req.Target.(type) {
+	case *pb.SendCodeRequest_Phone:
+		serviceReq.Phone = target.Phone
+	case *pb.SendCodeRequest_Email:
+		serviceReq.Email = target.Email
+	default:
+		return &pb.SendCodeResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   "手机号或邮箱必须提供一个",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	resp, err := h.authService.SendCode(serviceReq)
 	if err != nil {
-		return &pb.RefreshTokenResponse{
+		return &pb.SendCodeResponse{
 			Response: &pb.Response{
 				Code:      400,
 				Message:   err.Error(),
@@ -125,23 +203,49 @@ func (h *GRPCAuthHandler) RefreshToken(ctx context.Context, req *pb.RefreshToken
 		}, nil
 	}
 
-	return &pb.RefreshTokenResponse{
+	return &pb.SendCodeResponse{
 		Response: &pb.Response{
 			Code:      0,
-			Message:   "Token刷新成功",
+			Message:   "验证码已发送",
 			Timestamp: timestamppb.Now(),
 		},
-		Token: convertTokenToProto(token),
+		Data: &pb.SendCodeData{
+			ExpiresIn:   resp.ExpiresIn,
+			ResendAfter: resp.ResendAfter,
+		},
 	}, nil
 }
 
-// Logout 用户注销
-func (h *GRPCAuthHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
-	// 首先需要根据device_token获取device_id
-	// 这里我们需要添加一个helper方法到service
-	err := h.authService.LogoutByDeviceToken(req.DeviceToken)
+// VerifyCode 验证码登录，账号不存在时自动注册
+func (h *GRPCAuthHandler) VerifyCode(ctx context.Context, req *pb.VerifyCodeRequest) (*pb.VerifyCodeResponse, error) {
+	serviceReq := &service.VerifyCodeRequest{
+		Code:        req.Code,
+		Username:    req.Username,
+		DeviceToken: req.DeviceToken,
+		DeviceType:  convertDeviceTypeToDomain(req.DeviceType),
+		DeviceName:  req.DeviceName,
+		ClientIP:    grpcClientIP(ctx, h.trustedProxies),
+		UserAgent:   grpcUserAgent(ctx),
+	}
+
+	switch target := req.Target.(type) {
+	case *pb.VerifyCodeRequest_Phone:
+		serviceReq.Phone = target.Phone
+	case *pb.VerifyCodeRequest_Email:
+		serviceReq.Email = target.Email
+	default:
+		return &pb.VerifyCodeResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   "手机号或邮箱必须提供一个",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	resp, err := h.authService.VerifyCode(serviceReq)
 	if err != nil {
-		return &pb.LogoutResponse{
+		return &pb.VerifyCodeResponse{
 			Response: &pb.Response{
 				Code:      400,
 				Message:   err.Error(),
@@ -150,20 +254,100 @@ func (h *GRPCAuthHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*p
 		}, nil
 	}
 
-	return &pb.LogoutResponse{
+	message := "登录成功"
+	if resp.TwoFactor != nil {
+		message = "需要两步验证"
+	}
+
+	return &pb.VerifyCodeResponse{
 		Response: &pb.Response{
 			Code:      0,
-			Message:   "注销成功",
+			Message:   message,
 			Timestamp: timestamppb.Now(),
 		},
+		Data: &pb.LoginData{
+			User:   convertUserToProto(resp.User),
+			Device: convertDeviceToProto(resp.Device),
+			Token:  convertTokenToProto(resp.Token),
+		},
+		IsNewUser: resp.IsNewUser,
+		TwoFactor: convertTwoFactorChallengeToProto(resp.TwoFactor),
 	}, nil
 }
 
-// VerifyToken 验证Token (给其他服务调用)
-func (h *GRPCAuthHandler) VerifyToken(ctx context.Context, req *pb.VerifyTokenRequest) (*pb.VerifyTokenResponse, error) {
+// RequestPasswordReset 申请重置密码
+func (h *GRPCAuthHandler) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	serviceReq := &service.RequestPasswordResetRequest{
+		ClientIP:  grpcClientIP(ctx, h.trustedProxies),
+		UserAgent: grpcUserAgent(ctx),
+	}
+	switch target := req.Target.(type) {
+	case *pb.RequestPasswordResetRequest_Phone:
+		serviceReq.Phone = target.Phone
+	case *pb.RequestPasswordResetRequest_Email:
+		serviceReq.Email = target.Email
+	default:
+		return &pb.RequestPasswordResetResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   "手机号或邮箱必须提供一个",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	if err := h.authService.RequestPasswordReset(serviceReq); err != nil {
+		return &pb.RequestPasswordResetResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.RequestPasswordResetResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "如果账号存在，重置链接已发送",
+			Timestamp: timestamppb.Now(),
+		},
+	}, nil
+}
+
+// ResetPassword 使用重置token设置新密码
+func (h *GRPCAuthHandler) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+	err := h.authService.ResetPassword(&service.ResetPasswordRequest{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+		ClientIP:    grpcClientIP(ctx, h.trustedProxies),
+		UserAgent:   grpcUserAgent(ctx),
+	})
+	if err != nil {
+		return &pb.ResetPasswordResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+			Violations: convertPasswordViolationsToProto(err),
+		}, nil
+	}
+
+	return &pb.ResetPasswordResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "密码已重置，请重新登录",
+			Timestamp: timestamppb.Now(),
+		},
+	}, nil
+}
+
+// ChangePassword 修改密码
+func (h *GRPCAuthHandler) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
 	claims, err := h.authService.ParseToken(req.AccessToken)
 	if err != nil {
-		return &pb.VerifyTokenResponse{
+		return &pb.ChangePasswordResponse{
 			Response: &pb.Response{
 				Code:      401,
 				Message:   "Token无效",
@@ -172,27 +356,124 @@ func (h *GRPCAuthHandler) VerifyToken(ctx context.Context, req *pb.VerifyTokenRe
 		}, nil
 	}
 
-	return &pb.VerifyTokenResponse{
+	resp, err := h.authService.ChangePassword(claims.UserID, claims.DeviceID, &service.ChangePasswordRequest{
+		OldPassword:         req.OldPassword,
+		Code:                req.Code,
+		NewPassword:         req.NewPassword,
+		RevokeOtherSessions: req.RevokeOtherSessions,
+		ClientIP:            grpcClientIP(ctx, h.trustedProxies),
+		UserAgent:           grpcUserAgent(ctx),
+	})
+	if err != nil {
+		return &pb.ChangePasswordResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+			Violations: convertPasswordViolationsToProto(err),
+		}, nil
+	}
+
+	return &pb.ChangePasswordResponse{
 		Response: &pb.Response{
 			Code:      0,
-			Message:   "Token验证成功",
+			Message:   "密码修改成功",
 			Timestamp: timestamppb.Now(),
 		},
-		Data: &pb.VerifyTokenData{
-			Valid:       true,
-			UserId:      uint64(claims.UserID),
-			DeviceId:    uint64(claims.DeviceID),
-			DeviceToken: claims.DeviceToken,
-			ExpiresAt:   timestamppb.New(claims.ExpiresAt.Time),
+		RevokedSessions: int32(resp.RevokedSessions),
+	}, nil
+}
+
+// VerifySecondFactor 完成两步验证登录
+func (h *GRPCAuthHandler) VerifySecondFactor(ctx context.Context, req *pb.VerifySecondFactorRequest) (*pb.VerifySecondFactorResponse, error) {
+	resp, err := h.authService.VerifySecondFactor(&service.VerifySecondFactorRequest{
+		ChallengeToken: req.ChallengeToken,
+		Code:           req.Code,
+		ClientIP:       grpcClientIP(ctx, h.trustedProxies),
+		UserAgent:      grpcUserAgent(ctx),
+	})
+	if err != nil {
+		return &pb.VerifySecondFactorResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.VerifySecondFactorResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "登录成功",
+			Timestamp: timestamppb.Now(),
+		},
+		Data: &pb.LoginData{
+			User:   convertUserToProto(resp.User),
+			Device: convertDeviceToProto(resp.Device),
+			Token:  convertTokenToProto(resp.Token),
 		},
 	}, nil
 }
 
-// GetUserInfo 获取用户信息
-func (h *GRPCAuthHandler) GetUserInfo(ctx context.Context, req *pb.GetUserInfoRequest) (*pb.GetUserInfoResponse, error) {
-	user, err := h.authService.GetUserByToken(req.AccessToken)
+// GetTwoFactorStatus 获取两步验证状态
+func (h *GRPCAuthHandler) GetTwoFactorStatus(ctx context.Context, req *pb.GetTwoFactorStatusRequest) (*pb.GetTwoFactorStatusResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
 	if err != nil {
-		return &pb.GetUserInfoResponse{
+		return &pb.GetTwoFactorStatusResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	status, err := h.authService.GetTwoFactorStatus(claims.UserID)
+	if err != nil {
+		return &pb.GetTwoFactorStatusResponse{
+			Response: &pb.Response{
+				Code:      500,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	var enabledAt *timestamppb.Timestamp
+	if status.EnabledAt != nil {
+		enabledAt = timestamppb.New(*status.EnabledAt)
+	}
+
+	return &pb.GetTwoFactorStatusResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "获取成功",
+			Timestamp: timestamppb.Now(),
+		},
+		Enabled:                status.Enabled,
+		EnabledAt:              enabledAt,
+		RecoveryCodesRemaining: status.RecoveryCodesRemaining,
+	}, nil
+}
+
+// EnrollTwoFactor 生成两步验证密钥和恢复码
+func (h *GRPCAuthHandler) EnrollTwoFactor(ctx context.Context, req *pb.EnrollTwoFactorRequest) (*pb.EnrollTwoFactorResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.EnrollTwoFactorResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	enrollment, err := h.authService.EnrollTwoFactor(claims.UserID)
+	if err != nil {
+		return &pb.EnrollTwoFactorResponse{
 			Response: &pb.Response{
 				Code:      400,
 				Message:   err.Error(),
@@ -201,13 +482,1218 @@ func (h *GRPCAuthHandler) GetUserInfo(ctx context.Context, req *pb.GetUserInfoRe
 		}, nil
 	}
 
-	return &pb.GetUserInfoResponse{
+	return &pb.EnrollTwoFactorResponse{
 		Response: &pb.Response{
 			Code:      0,
-			Message:   "获取用户信息成功",
+			Message:   "请使用验证器应用扫码并确认",
 			Timestamp: timestamppb.Now(),
 		},
-		User: convertUserToProto(user),
+		Secret:          enrollment.Secret,
+		ProvisioningUri: enrollment.ProvisioningURI,
+		RecoveryCodes:   enrollment.RecoveryCodes,
+	}, nil
+}
+
+// ConfirmTwoFactor 确认并启用两步验证
+func (h *GRPCAuthHandler) ConfirmTwoFactor(ctx context.Context, req *pb.ConfirmTwoFactorRequest) (*pb.ConfirmTwoFactorResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.ConfirmTwoFactorResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	if err := h.authService.ConfirmTwoFactor(claims.UserID, req.Code, grpcClientIP(ctx, h.trustedProxies), grpcUserAgent(ctx)); err != nil {
+		return &pb.ConfirmTwoFactorResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.ConfirmTwoFactorResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "两步验证已启用",
+			Timestamp: timestamppb.Now(),
+		},
+	}, nil
+}
+
+// DisableTwoFactor 关闭两步验证
+func (h *GRPCAuthHandler) DisableTwoFactor(ctx context.Context, req *pb.DisableTwoFactorRequest) (*pb.DisableTwoFactorResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.DisableTwoFactorResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	if err := h.authService.DisableTwoFactor(claims.UserID, &service.DisableTwoFactorRequest{
+		Password:  req.Password,
+		Code:      req.Code,
+		ClientIP:  grpcClientIP(ctx, h.trustedProxies),
+		UserAgent: grpcUserAgent(ctx),
+	}); err != nil {
+		return &pb.DisableTwoFactorResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.DisableTwoFactorResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "两步验证已关闭",
+			Timestamp: timestamppb.Now(),
+		},
+	}, nil
+}
+
+// RefreshToken 刷新Token
+func (h *GRPCAuthHandler) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	token, err := h.authService.RefreshToken(req.RefreshToken, grpcClientIP(ctx, h.trustedProxies), grpcUserAgent(ctx))
+	if err != nil {
+		return &pb.RefreshTokenResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.RefreshTokenResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "Token刷新成功",
+			Timestamp: timestamppb.Now(),
+		},
+		Token: convertTokenToProto(token),
+	}, nil
+}
+
+// Logout 用户注销
+func (h *GRPCAuthHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	// 首先需要根据device_token获取device_id
+	// 这里我们需要添加一个helper方法到service
+	err := h.authService.LogoutByDeviceToken(req.DeviceToken, grpcClientIP(ctx, h.trustedProxies), grpcUserAgent(ctx))
+	if err != nil {
+		return &pb.LogoutResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.LogoutResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "注销成功",
+			Timestamp: timestamppb.Now(),
+		},
+	}, nil
+}
+
+// VerifyToken 验证Token (给其他服务调用)
+func (h *GRPCAuthHandler) VerifyToken(ctx context.Context, req *pb.VerifyTokenRequest) (*pb.VerifyTokenResponse, error) {
+	if pkg.IsBotToken(req.AccessToken) {
+		return h.verifyBotToken(req.AccessToken), nil
+	}
+	if pkg.IsPersonalAccessToken(req.AccessToken) {
+		return h.verifyPersonalAccessToken(req.AccessToken), nil
+	}
+
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.VerifyTokenResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.VerifyTokenResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "Token验证成功",
+			Timestamp: timestamppb.Now(),
+		},
+		Data: &pb.VerifyTokenData{
+			Valid:       true,
+			UserId:      uint64(claims.UserID),
+			DeviceId:    uint64(claims.DeviceID),
+			DeviceToken: claims.DeviceToken,
+			ExpiresAt:   timestamppb.New(claims.ExpiresAt.Time),
+			AccountType: pkg.AccountTypeUser,
+			TokenKind:   pkg.TokenKindSession,
+		},
+	}, nil
+}
+
+// verifyPersonalAccessToken 验证个人访问token，返回其授权范围
+func (h *GRPCAuthHandler) verifyPersonalAccessToken(token string) *pb.VerifyTokenResponse {
+	identity, err := h.authService.VerifyPersonalAccessToken(token)
+	if err != nil {
+		return &pb.VerifyTokenResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}
+	}
+
+	return &pb.VerifyTokenResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "Token验证成功",
+			Timestamp: timestamppb.Now(),
+		},
+		Data: &pb.VerifyTokenData{
+			Valid:       true,
+			UserId:      uint64(identity.UserID),
+			ExpiresAt:   timestamppb.New(identity.ExpiresAt),
+			AccountType: pkg.AccountTypeUser,
+			Scopes:      identity.Scopes,
+			TokenKind:   pkg.TokenKindPersonalAccessToken,
+		},
+	}
+}
+
+// verifyBotToken 验证机器人token，超过频率限制时返回429
+func (h *GRPCAuthHandler) verifyBotToken(token string) *pb.VerifyTokenResponse {
+	bot, err := h.authService.VerifyBotToken(token)
+	if err != nil {
+		code := int32(401)
+		message := "Token无效"
+		if errors.Is(err, service.ErrBotRateLimited) {
+			code = 429
+			message = err.Error()
+		}
+		return &pb.VerifyTokenResponse{
+			Response: &pb.Response{
+				Code:      code,
+				Message:   message,
+				Timestamp: timestamppb.Now(),
+			},
+		}
+	}
+
+	return &pb.VerifyTokenResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "Token验证成功",
+			Timestamp: timestamppb.Now(),
+		},
+		Data: &pb.VerifyTokenData{
+			Valid:       true,
+			UserId:      uint64(bot.BotID),
+			ExpiresAt:   timestamppb.New(bot.ExpiresAt),
+			AccountType: pkg.AccountTypeBot,
+			TokenKind:   pkg.TokenKindBot,
+		},
+	}
+}
+
+// GetUserInfo 获取用户信息
+func (h *GRPCAuthHandler) GetUserInfo(ctx context.Context, req *pb.GetUserInfoRequest) (*pb.GetUserInfoResponse, error) {
+	user, err := h.authService.GetUserByToken(req.AccessToken)
+	if err != nil {
+		return &pb.GetUserInfoResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.GetUserInfoResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "获取用户信息成功",
+			Timestamp: timestamppb.Now(),
+		},
+		User: convertUserToProto(user),
+	}, nil
+}
+
+// ListSessions 获取活跃会话
+func (h *GRPCAuthHandler) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.ListSessionsResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	sessions, err := h.authService.ListSessions(claims.UserID, claims.DeviceID)
+	if err != nil {
+		return &pb.ListSessionsResponse{
+			Response: &pb.Response{
+				Code:      500,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	pbSessions := make([]*pb.SessionInfo, 0, len(sessions))
+	for _, session := range sessions {
+		pbSessions = append(pbSessions, convertSessionToProto(session))
+	}
+
+	return &pb.ListSessionsResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "获取会话列表成功",
+			Timestamp: timestamppb.Now(),
+		},
+		Sessions: pbSessions,
+	}, nil
+}
+
+// RevokeSession 终止指定会话
+func (h *GRPCAuthHandler) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.RevokeSessionResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	if err := h.authService.RevokeSession(claims.UserID, uint(req.DeviceId)); err != nil {
+		return &pb.RevokeSessionResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.RevokeSessionResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "会话已终止",
+			Timestamp: timestamppb.Now(),
+		},
+	}, nil
+}
+
+// RevokeOtherSessions 终止其他会话
+func (h *GRPCAuthHandler) RevokeOtherSessions(ctx context.Context, req *pb.RevokeOtherSessionsRequest) (*pb.RevokeOtherSessionsResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.RevokeOtherSessionsResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	count, err := h.authService.RevokeOtherSessions(claims.UserID, claims.DeviceID)
+	if err != nil {
+		return &pb.RevokeOtherSessionsResponse{
+			Response: &pb.Response{
+				Code:      500,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.RevokeOtherSessionsResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "其他会话已终止",
+			Timestamp: timestamppb.Now(),
+		},
+		RevokedCount: int32(count),
+	}, nil
+}
+
+// RevokeTokens 吊销Token
+func (h *GRPCAuthHandler) RevokeTokens(ctx context.Context, req *pb.RevokeTokensRequest) (*pb.RevokeTokensResponse, error) {
+	var err error
+	switch target := req.Target.(type) {
+	case *pb.RevokeTokensRequest_TokenId:
+		err = h.authService.RevokeTokenByID(target.TokenId)
+	case *pb.RevokeTokensRequest_DeviceId:
+		err = h.authService.RevokeDeviceTokens(uint(target.DeviceId))
+	case *pb.RevokeTokensRequest_UserId:
+		err = h.authService.RevokeUserTokens(uint(target.UserId))
+	default:
+		return &pb.RevokeTokensResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   "请指定要吊销的token",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	if err != nil {
+		return &pb.RevokeTokensResponse{
+			Response: &pb.Response{
+				Code:      500,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.RevokeTokensResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "Token吊销成功",
+			Timestamp: timestamppb.Now(),
+		},
+	}, nil
+}
+
+// GetSigningKeys 获取签名公钥
+func (h *GRPCAuthHandler) GetSigningKeys(ctx context.Context, req *pb.GetSigningKeysRequest) (*pb.GetSigningKeysResponse, error) {
+	keys := h.authService.GetSigningKeys()
+
+	pbKeys := make([]*pb.SigningKey, 0, len(keys))
+	for _, key := range keys {
+		pbKey, err := convertSigningKeyToProto(key)
+		if err != nil {
+			return &pb.GetSigningKeysResponse{
+				Response: &pb.Response{
+					Code:      500,
+					Message:   err.Error(),
+					Timestamp: timestamppb.Now(),
+				},
+			}, nil
+		}
+		pbKeys = append(pbKeys, pbKey)
+	}
+
+	return &pb.GetSigningKeysResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "获取签名公钥成功",
+			Timestamp: timestamppb.Now(),
+		},
+		Keys: pbKeys,
+	}, nil
+}
+
+// GetRevocations 同步Token吊销事件
+func (h *GRPCAuthHandler) GetRevocations(ctx context.Context, req *pb.GetRevocationsRequest) (*pb.GetRevocationsResponse, error) {
+	events, err := h.authService.ListRevocations(req.Cursor, int(req.Limit))
+	if err != nil {
+		return &pb.GetRevocationsResponse{
+			Response: &pb.Response{
+				Code:      500,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	cursor := req.Cursor
+	pbEvents := make([]*pb.RevocationEvent, 0, len(events))
+	for _, event := range events {
+		pbEvents = append(pbEvents, convertRevocationEventToProto(event))
+		cursor = event.ID
+	}
+
+	return &pb.GetRevocationsResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "获取吊销事件成功",
+			Timestamp: timestamppb.Now(),
+		},
+		Events: pbEvents,
+		Cursor: cursor,
+	}, nil
+}
+
+// DeactivateAccount 停用账号
+func (h *GRPCAuthHandler) DeactivateAccount(ctx context.Context, req *pb.DeactivateAccountRequest) (*pb.DeactivateAccountResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.DeactivateAccountResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	err = h.authService.DeactivateAccount(claims.UserID, &service.AccountActionRequest{
+		Password:  req.Password,
+		Code:      req.Code,
+		ClientIP:  grpcClientIP(ctx, h.trustedProxies),
+		UserAgent: grpcUserAgent(ctx),
+	})
+	if err != nil {
+		return &pb.DeactivateAccountResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.DeactivateAccountResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "账号已停用，重新登录即可恢复",
+			Timestamp: timestamppb.Now(),
+		},
+	}, nil
+}
+
+// DeleteAccount 删除账号
+func (h *GRPCAuthHandler) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.DeleteAccountResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	resp, err := h.authService.DeleteAccount(claims.UserID, &service.AccountActionRequest{
+		Password:  req.Password,
+		Code:      req.Code,
+		ClientIP:  grpcClientIP(ctx, h.trustedProxies),
+		UserAgent: grpcUserAgent(ctx),
+	})
+	if err != nil {
+		return &pb.DeleteAccountResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.DeleteAccountResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "账号将在宽限期结束后删除，期间重新登录可取消",
+			Timestamp: timestamppb.Now(),
+		},
+		PurgeAt: timestamppb.New(resp.PurgeAt),
+	}, nil
+}
+
+// GetAccountPurges 同步已清除的账号
+func (h *GRPCAuthHandler) GetAccountPurges(ctx context.Context, req *pb.GetAccountPurgesRequest) (*pb.GetAccountPurgesResponse, error) {
+	purges, err := h.authService.ListAccountPurges(req.Cursor, int(req.Limit))
+	if err != nil {
+		return &pb.GetAccountPurgesResponse{
+			Response: &pb.Response{
+				Code:      500,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	cursor := req.Cursor
+	pbPurges := make([]*pb.AccountPurge, 0, len(purges))
+	for i := range purges {
+		pbPurge := convertAccountPurgeToProto(&purges[i])
+		pbPurges = append(pbPurges, pbPurge)
+		cursor = pbPurge.Id
+	}
+
+	return &pb.GetAccountPurgesResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "获取账号清除记录成功",
+			Timestamp: timestamppb.Now(),
+		},
+		Purges: pbPurges,
+		Cursor: cursor,
+	}, nil
+}
+
+// ExportUserData 导出用户在认证服务中的全部数据
+func (h *GRPCAuthHandler) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error) {
+	export, err := h.authService.ExportUserData(uint(req.UserId))
+	if err != nil {
+		return &pb.ExportUserDataResponse{
+			Response: &pb.Response{
+				Code:      404,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	data, err := json.Marshal(export)
+	if err != nil {
+		return &pb.ExportUserDataResponse{
+			Response: &pb.Response{
+				Code:      500,
+				Message:   "导出数据失败",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.ExportUserDataResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "导出成功",
+			Timestamp: timestamppb.Now(),
+		},
+		Data: data,
+	}, nil
+}
+
+// ListSecurityEvents 查看当前用户最近的安全事件
+func (h *GRPCAuthHandler) ListSecurityEvents(ctx context.Context, req *pb.ListSecurityEventsRequest) (*pb.ListSecurityEventsResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.ListSecurityEventsResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	events, cursor, err := h.authService.ListSecurityEvents(claims.UserID, req.Cursor, int(req.Limit))
+	if err != nil {
+		return &pb.ListSecurityEventsResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	pbEvents := make([]*pb.AuthEvent, 0, len(events))
+	for i := range events {
+		pbEvents = append(pbEvents, convertAuthEventToProto(&events[i]))
+	}
+
+	return &pb.ListSecurityEventsResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "获取安全事件成功",
+			Timestamp: timestamppb.Now(),
+		},
+		Events: pbEvents,
+		Cursor: cursor,
+	}, nil
+}
+
+// QueryAuthEvents 按用户、IP或时间范围查询安全事件
+func (h *GRPCAuthHandler) QueryAuthEvents(ctx context.Context, req *pb.QueryAuthEventsRequest) (*pb.QueryAuthEventsResponse, error) {
+	query := service.AuthEventQuery{
+		UserID: uint(req.UserId),
+		IP:     req.Ip,
+		Cursor: req.Cursor,
+		Limit:  int(req.Limit),
+	}
+	if req.Since != nil {
+		query.Since = req.Since.AsTime()
+	}
+	if req.Until != nil {
+		query.Until = req.Until.AsTime()
+	}
+
+	events, cursor, err := h.authService.QueryAuthEvents(query)
+	if err != nil {
+		return &pb.QueryAuthEventsResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	pbEvents := make([]*pb.AuthEvent, 0, len(events))
+	for i := range events {
+		pbEvents = append(pbEvents, convertAuthEventToProto(&events[i]))
+	}
+
+	return &pb.QueryAuthEventsResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "查询安全事件成功",
+			Timestamp: timestamppb.Now(),
+		},
+		Events: pbEvents,
+		Cursor: cursor,
+	}, nil
+}
+
+// GetDeviceApproval 新设备查询登录审批结果
+func (h *GRPCAuthHandler) GetDeviceApproval(ctx context.Context, req *pb.GetDeviceApprovalRequest) (*pb.GetDeviceApprovalResponse, error) {
+	resp, err := h.authService.CheckDeviceApproval(&service.DeviceApprovalRequest{
+		ApprovalToken: req.ApprovalToken,
+		ClientIP:      grpcClientIP(ctx, h.trustedProxies),
+		UserAgent:     grpcUserAgent(ctx),
+	})
+	if err != nil {
+		return &pb.GetDeviceApprovalResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.GetDeviceApprovalResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   loginMessage(resp),
+			Timestamp: timestamppb.Now(),
+		},
+		Data:           convertLoginDataToProto(resp),
+		TwoFactor:      convertTwoFactorChallengeToProto(resp.TwoFactor),
+		DeviceApproval: convertDeviceApprovalChallengeToProto(resp.DeviceApproval),
+	}, nil
+}
+
+// SendDeviceApprovalCode 审批超时后发送验证码
+func (h *GRPCAuthHandler) SendDeviceApprovalCode(ctx context.Context, req *pb.SendDeviceApprovalCodeRequest) (*pb.SendDeviceApprovalCodeResponse, error) {
+	resp, err := h.authService.SendDeviceApprovalCode(req.ApprovalToken)
+	if err != nil {
+		return &pb.SendDeviceApprovalCodeResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.SendDeviceApprovalCodeResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "验证码已发送",
+			Timestamp: timestamppb.Now(),
+		},
+		Data: &pb.SendCodeData{
+			ExpiresIn:   resp.ExpiresIn,
+			ResendAfter: resp.ResendAfter,
+		},
+	}, nil
+}
+
+// VerifyDeviceApprovalCode 审批超时后使用验证码完成新设备登录
+func (h *GRPCAuthHandler) VerifyDeviceApprovalCode(ctx context.Context, req *pb.VerifyDeviceApprovalCodeRequest) (*pb.VerifyDeviceApprovalCodeResponse, error) {
+	resp, err := h.authService.VerifyDeviceApprovalCode(&service.DeviceApprovalRequest{
+		ApprovalToken: req.ApprovalToken,
+		Code:          req.Code,
+		ClientIP:      grpcClientIP(ctx, h.trustedProxies),
+		UserAgent:     grpcUserAgent(ctx),
+	})
+	if err != nil {
+		return &pb.VerifyDeviceApprovalCodeResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.VerifyDeviceApprovalCodeResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   loginMessage(resp),
+			Timestamp: timestamppb.Now(),
+		},
+		Data:      convertLoginDataToProto(resp),
+		TwoFactor: convertTwoFactorChallengeToProto(resp.TwoFactor),
+	}, nil
+}
+
+// ListDeviceApprovals 已登录设备获取等待审批的新设备登录请求
+func (h *GRPCAuthHandler) ListDeviceApprovals(ctx context.Context, req *pb.ListDeviceApprovalsRequest) (*pb.ListDeviceApprovalsResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.ListDeviceApprovalsResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	approvals, err := h.authService.ListDeviceApprovals(claims.UserID)
+	if err != nil {
+		return &pb.ListDeviceApprovalsResponse{
+			Response: &pb.Response{
+				Code:      500,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	pbApprovals := make([]*pb.DeviceApproval, 0, len(approvals))
+	for i := range approvals {
+		pbApprovals = append(pbApprovals, convertDeviceApprovalToProto(&approvals[i]))
+	}
+
+	return &pb.ListDeviceApprovalsResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "获取登录请求成功",
+			Timestamp: timestamppb.Now(),
+		},
+		Approvals: pbApprovals,
+	}, nil
+}
+
+// WatchDeviceApprovals 已登录设备订阅新的登录审批请求，直到客户端断开
+func (h *GRPCAuthHandler) WatchDeviceApprovals(req *pb.WatchDeviceApprovalsRequest, stream pb.AuthService_WatchDeviceApprovalsServer) error {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "Token无效")
+	}
+
+	return h.authService.WatchDeviceApprovals(stream.Context(), claims.UserID, func(approval *model.DeviceApproval) error {
+		return stream.Send(convertDeviceApprovalToProto(approval))
+	})
+}
+
+// DecideDeviceApproval 已登录设备批准或拒绝新设备登录
+func (h *GRPCAuthHandler) DecideDeviceApproval(ctx context.Context, req *pb.DecideDeviceApprovalRequest) (*pb.DecideDeviceApprovalResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.DecideDeviceApprovalResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	if err := h.authService.DecideDeviceApproval(claims.UserID, claims.DeviceID, uint(req.ApprovalId), req.Approve); err != nil {
+		return &pb.DecideDeviceApprovalResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	message := "已拒绝登录"
+	if req.Approve {
+		message = "已批准登录"
+	}
+	return &pb.DecideDeviceApprovalResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   message,
+			Timestamp: timestamppb.Now(),
+		},
+	}, nil
+}
+
+// ExportLoginToken 网页/桌面端申请二维码登录token
+func (h *GRPCAuthHandler) ExportLoginToken(ctx context.Context, req *pb.ExportLoginTokenRequest) (*pb.ExportLoginTokenResponse, error) {
+	resp, err := h.authService.ExportLoginToken(&service.ExportLoginTokenRequest{
+		DeviceToken: req.DeviceToken,
+		DeviceType:  convertDeviceTypeToDomain(req.DeviceType),
+		DeviceName:  req.DeviceName,
+		ClientIP:    grpcClientIP(ctx, h.trustedProxies),
+	})
+	if err != nil {
+		return &pb.ExportLoginTokenResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.ExportLoginTokenResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "请使用手机扫码登录",
+			Timestamp: timestamppb.Now(),
+		},
+		Token:     resp.Token,
+		QrPayload: resp.QRPayload,
+		ExpiresIn: resp.ExpiresIn,
+	}, nil
+}
+
+// AcceptLoginToken 已登录的手机扫码确认登录
+func (h *GRPCAuthHandler) AcceptLoginToken(ctx context.Context, req *pb.AcceptLoginTokenRequest) (*pb.AcceptLoginTokenResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.AcceptLoginTokenResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	if err := h.authService.AcceptLoginToken(claims.UserID, claims.DeviceID, req.Token); err != nil {
+		return &pb.AcceptLoginTokenResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.AcceptLoginTokenResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "已确认登录",
+			Timestamp: timestamppb.Now(),
+		},
+	}, nil
+}
+
+// ImportLoginToken 网页/桌面端长轮询扫码结果
+func (h *GRPCAuthHandler) ImportLoginToken(ctx context.Context, req *pb.ImportLoginTokenRequest) (*pb.ImportLoginTokenResponse, error) {
+	resp, err := h.authService.ImportLoginToken(ctx, &service.ImportLoginTokenRequest{
+		Token:       req.Token,
+		DeviceToken: req.DeviceToken,
+		Wait:        time.Duration(req.WaitSeconds) * time.Second,
+		ClientIP:    grpcClientIP(ctx, h.trustedProxies),
+		UserAgent:   grpcUserAgent(ctx),
+	})
+	if err != nil {
+		return &pb.ImportLoginTokenResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	if resp.LoginToken != nil {
+		return &pb.ImportLoginTokenResponse{
+			Response: &pb.Response{
+				Code:      0,
+				Message:   loginMessage(resp),
+				Timestamp: timestamppb.Now(),
+			},
+			Pending:   true,
+			ExpiresIn: resp.LoginToken.ExpiresIn,
+		}, nil
+	}
+
+	return &pb.ImportLoginTokenResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   loginMessage(resp),
+			Timestamp: timestamppb.Now(),
+		},
+		Data:      convertLoginDataToProto(resp),
+		TwoFactor: convertTwoFactorChallengeToProto(resp.TwoFactor),
+	}, nil
+}
+
+// RegisterPushToken 为当前设备注册推送token
+func (h *GRPCAuthHandler) RegisterPushToken(ctx context.Context, req *pb.RegisterPushTokenRequest) (*pb.RegisterPushTokenResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.RegisterPushTokenResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	err = h.authService.RegisterPushToken(claims.UserID, claims.DeviceID, &service.RegisterPushTokenRequest{
+		Provider: req.Provider,
+		Token:    req.Token,
+	})
+	if err != nil {
+		return &pb.RegisterPushTokenResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.RegisterPushTokenResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "推送token已注册",
+			Timestamp: timestamppb.Now(),
+		},
+	}, nil
+}
+
+// UnregisterPushToken 清除当前设备的推送token
+func (h *GRPCAuthHandler) UnregisterPushToken(ctx context.Context, req *pb.UnregisterPushTokenRequest) (*pb.UnregisterPushTokenResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.UnregisterPushTokenResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	if err := h.authService.UnregisterPushToken(claims.UserID, claims.DeviceID); err != nil {
+		return &pb.UnregisterPushTokenResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.UnregisterPushTokenResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "推送token已清除",
+			Timestamp: timestamppb.Now(),
+		},
+	}, nil
+}
+
+// GetPushTargets 获取用户所有可推送的设备
+func (h *GRPCAuthHandler) GetPushTargets(ctx context.Context, req *pb.GetPushTargetsRequest) (*pb.GetPushTargetsResponse, error) {
+	targets, err := h.authService.GetPushTargets(uint(req.UserId))
+	if err != nil {
+		return &pb.GetPushTargetsResponse{
+			Response: &pb.Response{
+				Code:      500,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	pbTargets := make([]*pb.PushTarget, 0, len(targets))
+	for i := range targets {
+		pbTargets = append(pbTargets, convertPushTargetToProto(&targets[i]))
+	}
+
+	return &pb.GetPushTargetsResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "获取推送目标成功",
+			Timestamp: timestamppb.Now(),
+		},
+		Targets: pbTargets,
+	}, nil
+}
+
+// InvalidatePushTokens 清除推送通道报告失效的token
+func (h *GRPCAuthHandler) InvalidatePushTokens(ctx context.Context, req *pb.InvalidatePushTokensRequest) (*pb.InvalidatePushTokensResponse, error) {
+	invalidated, err := h.authService.InvalidatePushTokens(req.Provider, req.Tokens)
+	if err != nil {
+		return &pb.InvalidatePushTokensResponse{
+			Response: &pb.Response{
+				Code:      500,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.InvalidatePushTokensResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "已清除失效的推送token",
+			Timestamp: timestamppb.Now(),
+		},
+		Invalidated: invalidated,
+	}, nil
+}
+
+// CheckUsername 检查用户名是否可用
+func (h *GRPCAuthHandler) CheckUsername(ctx context.Context, req *pb.CheckUsernameRequest) (*pb.CheckUsernameResponse, error) {
+	var userID uint
+	if req.AccessToken != "" {
+		claims, err := h.authService.ParseToken(req.AccessToken)
+		if err != nil {
+			return &pb.CheckUsernameResponse{
+				Response: &pb.Response{
+					Code:      401,
+					Message:   "Token无效",
+					Timestamp: timestamppb.Now(),
+				},
+			}, nil
+		}
+		userID = claims.UserID
+	}
+
+	result, err := h.authService.CheckUsername(userID, req.Username)
+	if err != nil {
+		return &pb.CheckUsernameResponse{
+			Response: &pb.Response{
+				Code:      500,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	message := "用户名可用"
+	if !result.Available {
+		message = result.Message
+	}
+	return &pb.CheckUsernameResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   message,
+			Timestamp: timestamppb.Now(),
+		},
+		Available: result.Available,
+		Reason:    result.Reason,
+	}, nil
+}
+
+// ChangeUsername 修改用户名
+func (h *GRPCAuthHandler) ChangeUsername(ctx context.Context, req *pb.ChangeUsernameRequest) (*pb.ChangeUsernameResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.ChangeUsernameResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	user, err := h.authService.ChangeUsername(claims.UserID, &service.ChangeUsernameRequest{
+		Username:  req.Username,
+		ClientIP:  grpcClientIP(ctx, h.trustedProxies),
+		UserAgent: grpcUserAgent(ctx),
+	})
+	if err != nil {
+		return &pb.ChangeUsernameResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.ChangeUsernameResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "用户名已修改",
+			Timestamp: timestamppb.Now(),
+		},
+		User: convertUserToProto(user),
+	}, nil
+}
+
+// ResolveUsername 将@用户名解析为用户的公开信息
+func (h *GRPCAuthHandler) ResolveUsername(ctx context.Context, req *pb.ResolveUsernameRequest) (*pb.ResolveUsernameResponse, error) {
+	user, err := h.authService.ResolveUsername(req.Username)
+	if err != nil {
+		return &pb.ResolveUsernameResponse{
+			Response: &pb.Response{
+				Code:      404,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.ResolveUsernameResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "解析用户名成功",
+			Timestamp: timestamppb.Now(),
+		},
+		User: convertPublicUserToProto(user),
+	}, nil
+}
+
+// Heartbeat 设备心跳
+func (h *GRPCAuthHandler) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest) (*pb.HeartbeatResponse, error) {
+	claims, err := h.authService.ParseToken(req.AccessToken)
+	if err != nil {
+		return &pb.HeartbeatResponse{
+			Response: &pb.Response{
+				Code:      401,
+				Message:   "Token无效",
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	result, err := h.authService.Heartbeat(claims.UserID, claims.DeviceID)
+	if err != nil {
+		return &pb.HeartbeatResponse{
+			Response: &pb.Response{
+				Code:      400,
+				Message:   err.Error(),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.HeartbeatResponse{
+		Response: &pb.Response{
+			Code:      0,
+			Message:   "ok",
+			Timestamp: timestamppb.Now(),
+		},
+		Interval: int32(result.Interval),
 	}, nil
 }
 
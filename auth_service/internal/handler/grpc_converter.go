@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"errors"
+	"strconv"
+
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/jacl-coder/telegramlite/auth_service/api/proto"
 	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/service"
 	"github.com/jacl-coder/telegramlite/auth_service/pkg"
 )
 
@@ -76,15 +80,35 @@ func convertDeviceToProto(device *model.Device) *pb.DeviceInfo {
 	}
 
 	return &pb.DeviceInfo{
-		Id:          uint64(device.ID),
-		UserId:      uint64(device.UserID),
-		DeviceToken: device.DeviceToken,
-		DeviceType:  convertDeviceTypeToProto(device.DeviceType),
-		DeviceName:  device.DeviceName,
-		PushToken:   device.PushToken,
-		IsOnline:    device.IsOnline,
-		LastSeenAt:  lastSeenAt,
-		CreatedAt:   timestamppb.New(device.CreatedAt),
+		Id:           uint64(device.ID),
+		UserId:       uint64(device.UserID),
+		DeviceToken:  device.DeviceToken,
+		DeviceType:   convertDeviceTypeToProto(device.DeviceType),
+		DeviceName:   device.DeviceName,
+		PushToken:    device.PushToken,
+		IsOnline:     device.IsOnline,
+		LastSeenAt:   lastSeenAt,
+		CreatedAt:    timestamppb.New(device.CreatedAt),
+		PushProvider: device.PushProvider,
+	}
+}
+
+// convertSessionToProto 转换会话到protobuf
+func convertSessionToProto(session *service.Session) *pb.SessionInfo {
+	var lastSeenAt *timestamppb.Timestamp
+	if session.LastSeenAt != nil {
+		lastSeenAt = timestamppb.New(*session.LastSeenAt)
+	}
+
+	return &pb.SessionInfo{
+		DeviceId:   uint64(session.DeviceID),
+		DeviceType: convertDeviceTypeToProto(session.DeviceType),
+		DeviceName: session.DeviceName,
+		Ip:         session.IP,
+		IsOnline:   session.IsOnline,
+		IsCurrent:  session.IsCurrent,
+		LastSeenAt: lastSeenAt,
+		CreatedAt:  timestamppb.New(session.CreatedAt),
 	}
 }
 
@@ -101,3 +125,149 @@ func convertTokenToProto(token *pkg.TokenResponse) *pb.TokenInfo {
 		ExpiresIn:    token.ExpiresIn,
 	}
 }
+
+// convertTwoFactorChallengeToProto 转换两步验证挑战
+func convertTwoFactorChallengeToProto(challenge *service.TwoFactorChallenge) *pb.TwoFactorChallenge {
+	if challenge == nil {
+		return nil
+	}
+
+	return &pb.TwoFactorChallenge{
+		ChallengeToken: challenge.ChallengeToken,
+		ExpiresIn:      challenge.ExpiresIn,
+	}
+}
+
+// convertDeviceApprovalChallengeToProto 转换新设备登录审批挑战
+func convertDeviceApprovalChallengeToProto(challenge *service.DeviceApprovalChallenge) *pb.DeviceApprovalChallenge {
+	if challenge == nil {
+		return nil
+	}
+
+	return &pb.DeviceApprovalChallenge{
+		ApprovalToken: challenge.ApprovalToken,
+		Status:        challenge.Status,
+		FallbackIn:    challenge.FallbackIn,
+		ExpiresIn:     challenge.ExpiresIn,
+	}
+}
+
+// convertDeviceApprovalToProto 转换等待审批的新设备登录
+func convertDeviceApprovalToProto(approval *model.DeviceApproval) *pb.DeviceApproval {
+	return &pb.DeviceApproval{
+		Id:         uint64(approval.ID),
+		DeviceType: approval.DeviceType,
+		DeviceName: approval.DeviceName,
+		Ip:         approval.IP,
+		UserAgent:  approval.UserAgent,
+		CreatedAt:  timestamppb.New(approval.CreatedAt),
+		ExpiresAt:  timestamppb.New(approval.ExpiresAt),
+	}
+}
+
+// convertLoginDataToProto 转换登录结果，未完成登录时只包含用户
+func convertLoginDataToProto(resp *service.AuthResponse) *pb.LoginData {
+	return &pb.LoginData{
+		User:   convertUserToProto(resp.User),
+		Device: convertDeviceToProto(resp.Device),
+		Token:  convertTokenToProto(resp.Token),
+	}
+}
+
+// convertPushTargetToProto 转换推送目标
+func convertPushTargetToProto(target *service.PushTarget) *pb.PushTarget {
+	return &pb.PushTarget{
+		DeviceId:   uint64(target.DeviceID),
+		DeviceType: convertDeviceTypeToProto(target.DeviceType),
+		Provider:   target.Provider,
+		Token:      target.Token,
+	}
+}
+
+// convertPublicUserToProto 转换用户公开信息到protobuf
+func convertPublicUserToProto(user *service.PublicUser) *pb.PublicUserInfo {
+	return &pb.PublicUserInfo{
+		Id:          uint64(user.ID),
+		Username:    user.Username,
+		AvatarUrl:   user.AvatarURL,
+		AccountType: user.AccountType,
+	}
+}
+
+// convertPasswordViolationsToProto 提取密码策略错误中的违规项，其他错误返回nil
+func convertPasswordViolationsToProto(err error) []*pb.PasswordViolation {
+	var policyErr *pkg.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return nil
+	}
+
+	violations := make([]*pb.PasswordViolation, 0, len(policyErr.Violations))
+	for _, violation := range policyErr.Violations {
+		violations = append(violations, &pb.PasswordViolation{
+			Code:    violation.Code,
+			Message: violation.Message,
+		})
+	}
+	return violations
+}
+
+// convertSigningKeyToProto 转换签名公钥到protobuf
+func convertSigningKeyToProto(key *pkg.SigningKey) (*pb.SigningKey, error) {
+	publicKey, err := key.MarshalPublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SigningKey{
+		Kid:       key.ID,
+		Algorithm: key.Algorithm,
+		PublicKey: publicKey,
+		CreatedAt: timestamppb.New(key.CreatedAt),
+		ExpiresAt: timestamppb.New(key.ExpiresAt),
+	}, nil
+}
+
+// convertAccountPurgeToProto 转换账号清除记录到protobuf
+func convertAccountPurgeToProto(purge *model.AccountPurge) *pb.AccountPurge {
+	return &pb.AccountPurge{
+		Id:       strconv.FormatUint(uint64(purge.ID), 10),
+		UserId:   uint64(purge.UserID),
+		PurgedAt: timestamppb.New(purge.PurgedAt),
+	}
+}
+
+// convertAuthEventToProto 转换安全事件到protobuf
+func convertAuthEventToProto(event *model.AuthEvent) *pb.AuthEvent {
+	return &pb.AuthEvent{
+		Id:        uint64(event.ID),
+		UserId:    uint64(event.UserID),
+		DeviceId:  uint64(event.DeviceID),
+		Event:     event.Event,
+		Outcome:   event.Outcome,
+		Reason:    event.Reason,
+		Ip:        event.IP,
+		UserAgent: event.UserAgent,
+		Details:   event.Details,
+		CreatedAt: timestamppb.New(event.CreatedAt),
+	}
+}
+
+// convertRevocationEventToProto 转换吊销事件到protobuf
+func convertRevocationEventToProto(event *model.RevocationEvent) *pb.RevocationEvent {
+	pbEvent := &pb.RevocationEvent{
+		Id:        event.ID,
+		RevokedAt: timestamppb.New(event.RevokedAt),
+		ExpiresAt: timestamppb.New(event.ExpiresAt),
+	}
+
+	switch {
+	case event.TokenID != "":
+		pbEvent.Target = &pb.RevocationEvent_TokenId{TokenId: event.TokenID}
+	case event.DeviceID != 0:
+		pbEvent.Target = &pb.RevocationEvent_DeviceId{DeviceId: uint64(event.DeviceID)}
+	case event.UserID != 0:
+		pbEvent.Target = &pb.RevocationEvent_UserId{UserId: uint64(event.UserID)}
+	}
+
+	return pbEvent
+}
@@ -0,0 +1,306 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/middleware"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/service"
+)
+
+// oauthDecisionRequest 用户在授权确认页面的选择
+type oauthDecisionRequest struct {
+	service.OAuthAuthorizeRequest
+	Approve bool `json:"approve"`
+}
+
+// OpenIDConfiguration OpenID Connect发现文档 (/.well-known/openid-configuration)
+func (h *AuthHandler) OpenIDConfiguration(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.authService.OpenIDConfiguration())
+}
+
+// OAuthToken token端点 (RFC 6749 3.2)，请求和响应都使用标准格式
+func (h *AuthHandler) OAuthToken(c *gin.Context) {
+	var req service.OAuthTokenRequest
+	if err := c.ShouldBindWith(&req, binding.Form); err != nil {
+		writeOAuthError(c, &service.OAuthError{Code: service.OAuthErrInvalidRequest, Description: "malformed request"})
+		return
+	}
+	req.ClientID, req.ClientSecret = oauthClientCredentials(c, req.ClientID, req.ClientSecret)
+	req.ClientIP = c.ClientIP()
+
+	result, err := h.authService.ExchangeOAuthToken(&req)
+	if err != nil {
+		writeOAuthError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	c.JSON(http.StatusOK, result)
+}
+
+// OAuthUserInfo userinfo端点，使用第三方应用的访问token
+func (h *AuthHandler) OAuthUserInfo(c *gin.Context) {
+	token, ok := bearerToken(c)
+	if !ok {
+		writeOAuthError(c, &service.OAuthError{Code: service.OAuthErrInvalidToken, Description: "bearer token is required"})
+		return
+	}
+
+	info, err := h.authService.OAuthUserInfo(token)
+	if err != nil {
+		writeOAuthError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, info)
+}
+
+// OAuthRevoke 吊销刷新token (RFC 7009)
+func (h *AuthHandler) OAuthRevoke(c *gin.Context) {
+	clientID, clientSecret := oauthClientCredentials(c, c.PostForm("client_id"), c.PostForm("client_secret"))
+
+	if err := h.authService.RevokeOAuthToken(clientID, clientSecret, c.PostForm("token")); err != nil {
+		writeOAuthError(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// OAuthAuthorize 授权确认页面加载时调用：已授权时直接返回回调地址，否则返回需要用户确认的内容
+func (h *AuthHandler) OAuthAuthorize(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	var req service.OAuthAuthorizeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	result, err := h.authService.Authorize(userID, &req)
+	if err != nil {
+		writeAuthorizeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code: 0,
+		Data: result,
+	})
+}
+
+// OAuthDecide 用户同意或拒绝授权，返回跳转回第三方应用的地址
+func (h *AuthHandler) OAuthDecide(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	deviceID, _ := middleware.GetDeviceID(c)
+
+	var req oauthDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	result, err := h.authService.DecideAuthorization(userID, deviceID, &req.OAuthAuthorizeRequest, req.Approve)
+	if err != nil {
+		writeAuthorizeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code: 0,
+		Data: result,
+	})
+}
+
+// RegisterOAuthClient 注册第三方应用
+func (h *AuthHandler) RegisterOAuthClient(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	var req service.RegisterOAuthClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+
+	client, err := h.authService.RegisterOAuthClient(userID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "应用已注册，请妥善保存客户端密钥",
+		Data:    client,
+	})
+}
+
+// ListOAuthClients 获取当前用户注册的第三方应用
+func (h *AuthHandler) ListOAuthClients(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	clients, err := h.authService.ListOAuthClients(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Code:    500,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取应用列表成功",
+		Data:    clients,
+	})
+}
+
+// DeleteOAuthClient 删除第三方应用
+func (h *AuthHandler) DeleteOAuthClient(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	if err := h.authService.DeleteOAuthClient(userID, c.Param("client_id")); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "应用已删除",
+	})
+}
+
+// ListOAuthConsents 获取当前用户授权过的第三方应用
+func (h *AuthHandler) ListOAuthConsents(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	consents, err := h.authService.ListOAuthConsents(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Code:    500,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取授权列表成功",
+		Data:    consents,
+	})
+}
+
+// RevokeOAuthConsent 撤销对第三方应用的授权
+func (h *AuthHandler) RevokeOAuthConsent(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	deviceID, _ := middleware.GetDeviceID(c)
+
+	if err := h.authService.RevokeOAuthConsent(userID, deviceID, c.Param("client_id")); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "已撤销授权",
+	})
+}
+
+// oauthClientCredentials 优先使用HTTP Basic认证中的客户端凭证 (RFC 6749 2.3.1)
+func oauthClientCredentials(c *gin.Context, clientID, clientSecret string) (string, string) {
+	username, password, ok := c.Request.BasicAuth()
+	if !ok {
+		return clientID, clientSecret
+	}
+	// Basic认证中的凭证需先经过application/x-www-form-urlencoded编码
+	if decoded, err := url.QueryUnescape(username); err == nil {
+		username = decoded
+	}
+	if decoded, err := url.QueryUnescape(password); err == nil {
+		password = decoded
+	}
+	return username, password
+}
+
+// bearerToken 从Authorization header获取Bearer token
+func bearerToken(c *gin.Context) (string, bool) {
+	parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") || parts[1] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// writeOAuthError 以标准格式返回OAuth错误，其他错误视为server_error
+func writeOAuthError(c *gin.Context, err error) {
+	var oauthErr *service.OAuthError
+	if !errors.As(err, &oauthErr) {
+		c.JSON(http.StatusInternalServerError, service.OAuthError{Code: "server_error"})
+		return
+	}
+
+	status := http.StatusBadRequest
+	switch oauthErr.Code {
+	case service.OAuthErrInvalidClient:
+		status = http.StatusUnauthorized
+		if _, _, ok := c.Request.BasicAuth(); ok {
+			c.Header("WWW-Authenticate", `Basic realm="telegramlite"`)
+		}
+	case service.OAuthErrInvalidToken:
+		status = http.StatusUnauthorized
+		c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+	case service.OAuthErrInsufficientScope:
+		status = http.StatusForbidden
+		c.Header("WWW-Authenticate", `Bearer error="insufficient_scope"`)
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(status, oauthErr)
+}
+
+// writeAuthorizeError 授权请求的client_id或redirect_uri无效，不能跳转回应用，直接提示用户
+func writeAuthorizeError(c *gin.Context, err error) {
+	var oauthErr *service.OAuthError
+	if errors.As(err, &oauthErr) {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "授权请求无效: " + oauthErr.Description,
+			Data:    oauthErr,
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, Response{
+		Code:    500,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,442 @@
+package handler
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/middleware"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/service"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+const testRedirectURI = "https://app.example.com/callback"
+
+// oauthTestServer 启动与线上相同路由的授权服务，返回服务地址和一个已登录用户的访问token
+func oauthTestServer(t *testing.T) (string, string) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	originalDB := repository.DB
+	repository.DB = db
+	t.Cleanup(func() { repository.DB = originalDB })
+	require.NoError(t, repository.AutoMigrate())
+
+	key, err := pkg.GenerateSigningKey(pkg.AlgorithmRS256)
+	require.NoError(t, err)
+	keySet := pkg.NewKeySet()
+	keySet.SetKeys([]*pkg.SigningKey{key}, key.ID)
+	jwtManager := pkg.NewJWTManagerWithKeys(keySet, "", time.Hour, 7*24*time.Hour)
+
+	var router http.Handler
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		router.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	authService := service.NewAuthService(jwtManager, service.WithOAuth(service.OAuthPolicy{Issuer: server.URL}))
+	authHandler := NewAuthHandler(authService)
+	authMiddleware := middleware.NewAuthMiddleware(authService)
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/.well-known/jwks.json", authHandler.JWKS)
+	engine.GET("/.well-known/openid-configuration", authHandler.OpenIDConfiguration)
+	engine.POST("/oauth/token", authHandler.OAuthToken)
+	engine.GET("/oauth/userinfo", authHandler.OAuthUserInfo)
+	engine.POST("/oauth/revoke", authHandler.OAuthRevoke)
+	oauth := engine.Group("/api/v1/oauth", authMiddleware.RequireAuth())
+	oauth.GET("/authorize", authHandler.OAuthAuthorize)
+	oauth.POST("/authorize", authHandler.OAuthDecide)
+	oauth.POST("/clients", authHandler.RegisterOAuthClient)
+	oauth.DELETE("/consents/:client_id", authHandler.RevokeOAuthConsent)
+	router = engine
+
+	registered, err := authService.Register(&service.RegisterRequest{
+		Phone:       "+8613800000000",
+		Email:       "alice@example.com",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "web-1",
+		DeviceType:  "web",
+	})
+	require.NoError(t, err)
+	return server.URL, registered.Token.AccessToken
+}
+
+// oauthTestClient 模拟第三方应用和浏览器中的授权确认页面
+type oauthTestClient struct {
+	t         *testing.T
+	baseURL   string
+	userToken string // 用户在网页客户端的登录token
+	discovery service.OpenIDConfiguration
+
+	clientID     string
+	clientSecret string
+}
+
+func (c *oauthTestClient) do(method, path string, body interface{}, token string) (int, map[string]interface{}) {
+	var reader *strings.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(c.t, err)
+		reader = strings.NewReader(string(data))
+	} else {
+		reader = strings.NewReader("")
+	}
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	require.NoError(c.t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return c.send(req)
+}
+
+func (c *oauthTestClient) send(req *http.Request) (int, map[string]interface{}) {
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(c.t, err)
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	return resp.StatusCode, result
+}
+
+// token 以表单方式请求token端点，机密客户端使用HTTP Basic认证，公开客户端只提交client_id
+func (c *oauthTestClient) token(form url.Values) (int, map[string]interface{}) {
+	if c.clientSecret == "" {
+		form.Set("client_id", c.clientID)
+	}
+	req, err := http.NewRequest(http.MethodPost, c.discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	require.NoError(c.t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if c.clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.clientID), url.QueryEscape(c.clientSecret))
+	}
+	return c.send(req)
+}
+
+func (c *oauthTestClient) userInfo(accessToken string) (int, map[string]interface{}) {
+	req, err := http.NewRequest(http.MethodGet, c.discovery.UserinfoEndpoint, nil)
+	require.NoError(c.t, err)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	return c.send(req)
+}
+
+// authorize 发起授权请求，approve为nil时只加载授权页面
+func (c *oauthTestClient) authorize(params url.Values, approve *bool) (int, map[string]interface{}) {
+	if approve == nil {
+		return c.do(http.MethodGet, "/api/v1/oauth/authorize?"+params.Encode(), nil, c.userToken)
+	}
+	body := map[string]interface{}{"approve": *approve}
+	for key := range params {
+		body[key] = params.Get(key)
+	}
+	return c.do(http.MethodPost, "/api/v1/oauth/authorize", body, c.userToken)
+}
+
+func newPKCE(t *testing.T) (verifier, challenge string) {
+	first, err := pkg.NewTokenID()
+	require.NoError(t, err)
+	second, err := pkg.NewTokenID()
+	require.NoError(t, err)
+	verifier = first + second
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func authorizeParams(clientID, scope, challenge string) url.Values {
+	return url.Values{
+		"response_type":         {"code"},
+		"client_id":             {clientID},
+		"redirect_uri":          {testRedirectURI},
+		"scope":                 {scope},
+		"state":                 {"state-123"},
+		"nonce":                 {"nonce-456"},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
+	}
+}
+
+// redirectParams 解析跳转回应用的地址
+func redirectParams(t *testing.T, result map[string]interface{}) url.Values {
+	data, ok := result["data"].(map[string]interface{})
+	require.True(t, ok, "unexpected response: %v", result)
+	redirectTo, ok := data["redirect_to"].(string)
+	require.True(t, ok, "expected redirect, got: %v", data)
+	require.True(t, strings.HasPrefix(redirectTo, testRedirectURI+"?"), redirectTo)
+	u, err := url.Parse(redirectTo)
+	require.NoError(t, err)
+	return u.Query()
+}
+
+// verifyIDToken 按发现文档中的jwks_uri校验ID Token签名和声明
+func verifyIDToken(t *testing.T, client *oauthTestClient, idToken string) jwt.MapClaims {
+	resp, err := http.Get(client.discovery.JWKSURI)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var jwks pkg.JWKS
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&jwks))
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		for _, key := range jwks.Keys {
+			if key.Kid == kid && key.Kty == "RSA" {
+				n, _ := base64.RawURLEncoding.DecodeString(key.N)
+				e, _ := base64.RawURLEncoding.DecodeString(key.E)
+				return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+			}
+		}
+		return nil, pkg.ErrUnknownKeyID
+	},
+		jwt.WithValidMethods(client.discovery.IDTokenSigningAlgValuesSupported),
+		jwt.WithIssuer(client.discovery.Issuer),
+		jwt.WithAudience(client.clientID),
+		jwt.WithExpirationRequired(),
+	)
+	require.NoError(t, err)
+	return claims
+}
+
+func TestOAuthConformance(t *testing.T) {
+	baseURL, userToken := oauthTestServer(t)
+	client := &oauthTestClient{t: t, baseURL: baseURL, userToken: userToken}
+
+	// 发现文档
+	resp, err := http.Get(baseURL + "/.well-known/openid-configuration")
+	require.NoError(t, err)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&client.discovery))
+	resp.Body.Close()
+	assert.Equal(t, baseURL, client.discovery.Issuer)
+	assert.Equal(t, baseURL+"/oauth/token", client.discovery.TokenEndpoint)
+	assert.Contains(t, client.discovery.ResponseTypesSupported, "code")
+	assert.Equal(t, []string{"S256"}, client.discovery.CodeChallengeMethodsSupported)
+	assert.Equal(t, []string{pkg.AlgorithmRS256}, client.discovery.IDTokenSigningAlgValuesSupported)
+
+	// 注册机密客户端
+	status, result := client.do(http.MethodPost, "/api/v1/oauth/clients", map[string]interface{}{
+		"name":          "Example App",
+		"redirect_uris": []string{testRedirectURI},
+		"scopes":        []string{"openid", "profile", "offline_access"},
+	}, userToken)
+	require.Equal(t, http.StatusOK, status, result)
+	data := result["data"].(map[string]interface{})
+	client.clientID = data["client_id"].(string)
+	client.clientSecret = data["client_secret"].(string)
+	require.NotEmpty(t, client.clientSecret)
+
+	verifier, challenge := newPKCE(t)
+	params := authorizeParams(client.clientID, "openid profile offline_access", challenge)
+
+	// 首次授权需要用户确认
+	status, result = client.authorize(params, nil)
+	require.Equal(t, http.StatusOK, status, result)
+	consent := result["data"].(map[string]interface{})["consent"].(map[string]interface{})
+	assert.Equal(t, "Example App", consent["client"].(map[string]interface{})["name"])
+	assert.Len(t, consent["scopes"], 3)
+
+	approve := true
+	status, result = client.authorize(params, &approve)
+	require.Equal(t, http.StatusOK, status, result)
+	callback := redirectParams(t, result)
+	assert.Equal(t, "state-123", callback.Get("state"))
+	assert.Equal(t, baseURL, callback.Get("iss"))
+	code := callback.Get("code")
+	require.NotEmpty(t, code)
+
+	// 使用授权码和code_verifier换取token
+	codeForm := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {testRedirectURI},
+		"code_verifier": {verifier},
+	}
+	status, tokens := client.token(codeForm)
+	require.Equal(t, http.StatusOK, status, tokens)
+	assert.Equal(t, "Bearer", tokens["token_type"])
+	assert.Equal(t, "openid profile offline_access", tokens["scope"])
+	accessToken := tokens["access_token"].(string)
+	refreshToken := tokens["refresh_token"].(string)
+
+	idClaims := verifyIDToken(t, client, tokens["id_token"].(string))
+	assert.Equal(t, "nonce-456", idClaims["nonce"])
+	assert.Equal(t, "alice", idClaims["preferred_username"])
+	assert.NotContains(t, idClaims, "email")
+	assert.NotZero(t, idClaims["auth_time"])
+
+	// userinfo只返回授权范围内的字段
+	status, info := client.userInfo(accessToken)
+	require.Equal(t, http.StatusOK, status, info)
+	assert.Equal(t, idClaims["sub"], info["sub"])
+	assert.Equal(t, "alice", info["preferred_username"])
+	assert.NotContains(t, info, "email")
+	assert.NotContains(t, info, "phone_number")
+
+	// 第三方应用的访问token不能当作用户登录token使用
+	status, _ = client.do(http.MethodPost, "/api/v1/oauth/clients", map[string]interface{}{}, accessToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	// 刷新token轮换，旧token不能再用
+	refreshForm := url.Values{"grant_type": {"refresh_token"}, "refresh_token": {refreshToken}, "scope": {"openid"}}
+	status, refreshed := client.token(refreshForm)
+	require.Equal(t, http.StatusOK, status, refreshed)
+	assert.Equal(t, "openid", refreshed["scope"])
+	assert.NotEqual(t, refreshToken, refreshed["refresh_token"])
+	verifyIDToken(t, client, refreshed["id_token"].(string))
+
+	// 授权码只能使用一次，重复使用时该授权签发的刷新token全部吊销
+	status, body := client.token(codeForm)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "invalid_grant", body["error"])
+	status, body = client.token(url.Values{"grant_type": {"refresh_token"}, "refresh_token": {refreshed["refresh_token"].(string)}})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "invalid_grant", body["error"])
+
+	// 已授权的范围不再询问
+	verifier, challenge = newPKCE(t)
+	params = authorizeParams(client.clientID, "openid profile", challenge)
+	status, result = client.authorize(params, nil)
+	require.Equal(t, http.StatusOK, status, result)
+	code = redirectParams(t, result).Get("code")
+
+	// code_verifier不匹配
+	status, body = client.token(url.Values{
+		"grant_type": {"authorization_code"}, "code": {code}, "redirect_uri": {testRedirectURI},
+		"code_verifier": {verifier + "x"},
+	})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "invalid_grant", body["error"])
+
+	// 客户端密钥错误
+	secret := client.clientSecret
+	client.clientSecret = "wrong-secret"
+	status, body = client.token(url.Values{
+		"grant_type": {"authorization_code"}, "code": {code}, "redirect_uri": {testRedirectURI},
+		"code_verifier": {verifier},
+	})
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "invalid_client", body["error"])
+	client.clientSecret = secret
+
+	// 未注册的回调地址不能跳转回应用
+	bad := authorizeParams(client.clientID, "openid", challenge)
+	bad.Set("redirect_uri", "https://evil.example.com/callback")
+	status, _ = client.authorize(bad, nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	// 其他错误通过回调地址告知应用
+	bad = authorizeParams(client.clientID, "openid email", challenge)
+	_, result = client.authorize(bad, nil)
+	assert.Equal(t, "invalid_scope", redirectParams(t, result).Get("error"))
+
+	bad = authorizeParams(client.clientID, "openid", "")
+	_, result = client.authorize(bad, nil)
+	assert.Equal(t, "invalid_request", redirectParams(t, result).Get("error"))
+
+	bad = authorizeParams(client.clientID, "openid offline_access", challenge)
+	bad.Set("prompt", "consent")
+	deny := false
+	_, result = client.authorize(bad, &deny)
+	assert.Equal(t, "access_denied", redirectParams(t, result).Get("error"))
+
+	// 用户撤销授权后访问token不能再读取用户信息
+	status, _ = client.do(http.MethodDelete, "/api/v1/oauth/consents/"+client.clientID, nil, userToken)
+	require.Equal(t, http.StatusOK, status)
+	status, body = client.userInfo(accessToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "invalid_token", body["error"])
+
+	bad = authorizeParams(client.clientID, "openid", challenge)
+	bad.Set("prompt", "none")
+	_, result = client.authorize(bad, nil)
+	assert.Equal(t, "consent_required", redirectParams(t, result).Get("error"))
+}
+
+func TestOAuthPublicClient(t *testing.T) {
+	baseURL, userToken := oauthTestServer(t)
+	client := &oauthTestClient{t: t, baseURL: baseURL, userToken: userToken}
+	client.discovery.TokenEndpoint = baseURL + "/oauth/token"
+	client.discovery.UserinfoEndpoint = baseURL + "/oauth/userinfo"
+
+	status, result := client.do(http.MethodPost, "/api/v1/oauth/clients", map[string]interface{}{
+		"name":          "Example SPA",
+		"redirect_uris": []string{testRedirectURI},
+		"public":        true,
+	}, userToken)
+	require.Equal(t, http.StatusOK, status, result)
+	data := result["data"].(map[string]interface{})
+	client.clientID = data["client_id"].(string)
+	assert.NotContains(t, data, "client_secret")
+
+	verifier, challenge := newPKCE(t)
+	approve := true
+	_, result = client.authorize(authorizeParams(client.clientID, "openid email phone", challenge), &approve)
+	code := redirectParams(t, result).Get("code")
+
+	status, tokens := client.token(url.Values{
+		"grant_type": {"authorization_code"}, "code": {code}, "redirect_uri": {testRedirectURI},
+		"code_verifier": {verifier},
+	})
+	require.Equal(t, http.StatusOK, status, tokens)
+	assert.NotContains(t, tokens, "refresh_token")
+
+	status, info := client.userInfo(tokens["access_token"].(string))
+	require.Equal(t, http.StatusOK, status, info)
+	assert.Equal(t, "alice@example.com", info["email"])
+	assert.Equal(t, "+8613800000000", info["phone_number"])
+	_, err := strconv.Atoi(info["sub"].(string))
+	assert.NoError(t, err)
+
+	// 只允许本机地址使用http回调
+	status, _ = client.do(http.MethodPost, "/api/v1/oauth/clients", map[string]interface{}{
+		"name":          "Insecure App",
+		"redirect_uris": []string{"http://app.example.com/callback"},
+	}, userToken)
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = client.do(http.MethodPost, "/api/v1/oauth/clients", map[string]interface{}{
+		"name":          "Native App",
+		"redirect_uris": []string{"http://127.0.0.1:51000/callback", "com.example.app:/oauth"},
+		"public":        true,
+	}, userToken)
+	assert.Equal(t, http.StatusOK, status)
+
+	// 可执行脚本或读取本地文件的scheme一律拒绝，私有scheme只允许公开客户端使用
+	for _, redirectURI := range []string{
+		"javascript:alert(1)",
+		"JavaScript://app.example.com/%0aalert(1)",
+		"data:text/html,<script>alert(1)</script>",
+		"vbscript:msgbox(1)",
+		"file:///etc/passwd",
+		"myapp:/oauth",
+	} {
+		status, _ = client.do(http.MethodPost, "/api/v1/oauth/clients", map[string]interface{}{
+			"name":          "Malicious App",
+			"redirect_uris": []string{redirectURI},
+			"public":        true,
+		}, userToken)
+		assert.Equal(t, http.StatusBadRequest, status, redirectURI)
+	}
+	status, _ = client.do(http.MethodPost, "/api/v1/oauth/clients", map[string]interface{}{
+		"name":          "Confidential Native App",
+		"redirect_uris": []string{"com.example.app:/oauth"},
+	}, userToken)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
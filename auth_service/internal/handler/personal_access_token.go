@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/middleware"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/service"
+)
+
+// CreatePersonalAccessToken 创建个人访问token
+func (h *AuthHandler) CreatePersonalAccessToken(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	var req service.CreatePersonalAccessTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	token, err := h.authService.CreatePersonalAccessToken(userID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "token已创建，请妥善保存",
+		Data:    token,
+	})
+}
+
+// ListPersonalAccessTokens 获取当前用户的个人访问token
+func (h *AuthHandler) ListPersonalAccessTokens(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	tokens, err := h.authService.ListPersonalAccessTokens(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Code:    500,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取token列表成功",
+		Data:    tokens,
+	})
+}
+
+// RevokePersonalAccessToken 吊销个人访问token
+func (h *AuthHandler) RevokePersonalAccessToken(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	tokenID, err := strconv.ParseUint(c.Param("token_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的token ID",
+		})
+		return
+	}
+
+	if err := h.authService.RevokePersonalAccessToken(userID, uint(tokenID), c.ClientIP(), c.Request.UserAgent()); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "token已吊销",
+	})
+}
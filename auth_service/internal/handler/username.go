@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/middleware"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/service"
+)
+
+// CheckUsername 检查用户名是否可用 (注册前调用，无需登录)
+func (h *AuthHandler) CheckUsername(c *gin.Context) {
+	username := c.Query("username")
+	if username == "" {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "用户名不能为空",
+		})
+		return
+	}
+
+	result, err := h.authService.CheckUsername(0, username)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Code:    500,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "检查用户名成功",
+		Data:    result,
+	})
+}
+
+// ChangeUsername 修改当前用户的用户名
+func (h *AuthHandler) ChangeUsername(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	var req service.ChangeUsernameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误: " + err.Error(),
+		})
+		return
+	}
+	req.ClientIP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	user, err := h.authService.ChangeUsername(userID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "用户名已修改",
+		Data:    user,
+	})
+}
+
+// ListUsernameHistory 获取当前用户最近的用户名变更
+func (h *AuthHandler) ListUsernameHistory(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	history, err := h.authService.ListUsernameHistory(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Code:    500,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取用户名变更记录成功",
+		Data:    history,
+	})
+}
+
+// ResolveUsername 将@用户名解析为用户的公开信息
+func (h *AuthHandler) ResolveUsername(c *gin.Context) {
+	user, err := h.authService.ResolveUsername(c.Param("username"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, Response{
+			Code:    404,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "解析用户名成功",
+		Data:    user,
+	})
+}
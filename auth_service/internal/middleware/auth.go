@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/service"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+// AuthMiddleware JWT身份验证中间件
+type AuthMiddleware struct {
+	authService *service.AuthService
+}
+
+// NewAuthMiddleware 创建身份验证中间件
+func NewAuthMiddleware(authService *service.AuthService) *AuthMiddleware {
+	return &AuthMiddleware{
+		authService: authService,
+	}
+}
+
+// RequireAuth 要求身份验证的中间件
+func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 从Authorization header获取token
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"code":    401,
+				"message": "Authorization header is required",
+			})
+			c.Abort()
+			return
+		}
+
+		// 验证Bearer前缀
+		parts := strings.SplitN(authHeader, " ", 2)
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"code":    401,
+				"message": "Invalid authorization header format",
+			})
+			c.Abort()
+			return
+		}
+
+		token := parts[1]
+
+		// 验证token (包括吊销检查)
+		claims, err := m.authService.ParseToken(token)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"code":    401,
+				"message": "Invalid or expired token",
+			})
+			c.Abort()
+			return
+		}
+
+		// 将用户信息存储到context中
+		c.Set("user_id", claims.UserID)
+		c.Set("device_id", claims.DeviceID)
+		c.Set("device_token", claims.DeviceToken)
+		c.Set("token", token)
+		c.Set("claims", claims)
+
+		c.Next()
+	}
+}
+
+// GetUserID 从context获取用户ID
+func GetUserID(c *gin.Context) (uint, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return 0, false
+	}
+	return userID.(uint), true
+}
+
+// GetDeviceID 从context获取设备ID
+func GetDeviceID(c *gin.Context) (uint, bool) {
+	deviceID, exists := c.Get("device_id")
+	if !exists {
+		return 0, false
+	}
+	return deviceID.(uint), true
+}
+
+// GetClaims 从context获取token声明
+func GetClaims(c *gin.Context) (*pkg.Claims, bool) {
+	claims, exists := c.Get("claims")
+	if !exists {
+		return nil, false
+	}
+	return claims.(*pkg.Claims), true
+}
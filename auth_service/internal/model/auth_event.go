@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// 安全事件结果
+const (
+	AuthEventSuccess = "success"
+	AuthEventFailure = "failure"
+)
+
+// AuthEvent 安全审计事件 (只追加，超过保留期后清理)
+type AuthEvent struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	UserID    uint      `json:"user_id" gorm:"index:idx_auth_events_user;comment:用户ID, 账号未知时为0"`
+	DeviceID  uint      `json:"device_id" gorm:"comment:设备ID"`
+	Event     string    `json:"event" gorm:"size:50;not null;index;comment:事件类型"`
+	Outcome   string    `json:"outcome" gorm:"size:10;not null;comment:结果: success/failure"`
+	Reason    string    `json:"reason" gorm:"size:200;comment:失败原因或补充说明"`
+	IP        string    `json:"ip" gorm:"size:45;index;comment:客户端IP"`
+	UserAgent string    `json:"user_agent" gorm:"size:255;comment:客户端User-Agent"`
+	Details   string    `json:"details,omitempty" gorm:"type:text;comment:事件详情(JSON)"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
+
+// TableName 指定表名
+func (AuthEvent) TableName() string {
+	return "auth_events"
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// BotToken 机器人API token，只保存哈希
+// 一个机器人可以持有多个token，便于轮换
+type BotToken struct {
+	ID         uint       `json:"id" gorm:"primarykey"`
+	BotID      uint       `json:"bot_id" gorm:"not null;index;comment:机器人用户ID"`
+	TokenHash  string     `json:"-" gorm:"uniqueIndex;size:64;not null;comment:token哈希"`
+	Hint       string     `json:"hint" gorm:"size:8;comment:token末尾几位, 用于在列表中辨认"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" gorm:"comment:最近使用时间"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" gorm:"comment:吊销时间"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// TableName 指定表名
+func (BotToken) TableName() string {
+	return "bot_tokens"
+}
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// 新设备登录审批状态
+const (
+	DeviceApprovalPending   = "pending"   // 等待已登录设备审批
+	DeviceApprovalApproved  = "approved"  // 已批准, 等待新设备领取token
+	DeviceApprovalDenied    = "denied"    // 已拒绝
+	DeviceApprovalCompleted = "completed" // 新设备已完成登录
+)
+
+// DeviceApproval 新设备登录审批请求
+// 凭证验证通过但设备从未登录过时创建，由已登录的设备批准，超时后可改用验证码完成
+type DeviceApproval struct {
+	ID          uint       `json:"id" gorm:"primarykey"`
+	UserID      uint       `json:"user_id" gorm:"not null;index;comment:用户ID"`
+	TokenHash   string     `json:"-" gorm:"uniqueIndex;size:64;not null;comment:审批token哈希, 新设备凭此查询结果"`
+	DeviceToken string     `json:"-" gorm:"size:255;not null;comment:新设备Token"`
+	DeviceType  string     `json:"device_type" gorm:"size:20;comment:设备类型"`
+	DeviceName  string     `json:"device_name" gorm:"size:100;comment:设备名称"`
+	IP          string     `json:"ip" gorm:"size:45;comment:登录IP"`
+	UserAgent   string     `json:"user_agent" gorm:"size:255;comment:客户端User-Agent"`
+	Status      string     `json:"status" gorm:"size:20;not null;index;comment:审批状态"`
+	FallbackAt  time.Time  `json:"fallback_at" gorm:"comment:此后可改用验证码完成登录"`
+	ExpiresAt   time.Time  `json:"expires_at" gorm:"comment:过期时间"`
+	DecidedBy   uint       `json:"decided_by,omitempty" gorm:"comment:审批设备ID"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty" gorm:"comment:审批时间"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// TableName 指定表名
+func (DeviceApproval) TableName() string {
+	return "device_approvals"
+}
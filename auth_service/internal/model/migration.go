@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+)
+
+// DataMigration 已完成的一次性数据迁移，存在记录时启动不再执行
+type DataMigration struct {
+	Name        string    `json:"name" gorm:"primarykey;size:64;comment:迁移名称"`
+	Details     string    `json:"details" gorm:"type:text;comment:迁移结果(JSON)"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// TableName 指定表名
+func (DataMigration) TableName() string {
+	return "data_migrations"
+}
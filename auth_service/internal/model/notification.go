@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// 设备通知类型
+const (
+	NotificationPhoneChangeRequested = "phone_change_requested" // 其他设备申请更换手机号, 需确认
+	NotificationPhoneChanged         = "phone_changed"          // 手机号已更换
+	NotificationEmailChanged         = "email_changed"          // 邮箱已更换
+)
+
+// DeviceNotification 发给用户某台设备的账号安全通知，设备领取后标记为已读
+type DeviceNotification struct {
+	ID        uint       `json:"id" gorm:"primarykey"`
+	UserID    uint       `json:"-" gorm:"not null;index;comment:用户ID"`
+	DeviceID  uint       `json:"-" gorm:"not null;index;comment:设备ID"`
+	Type      string     `json:"type" gorm:"size:50;not null;comment:通知类型"`
+	Message   string     `json:"message" gorm:"size:255;comment:通知内容"`
+	ReadAt    *time.Time `json:"read_at,omitempty" gorm:"comment:领取时间"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName 指定表名
+func (DeviceNotification) TableName() string {
+	return "device_notifications"
+}
@@ -0,0 +1,88 @@
+package model
+
+import "time"
+
+// OAuth 授权范围
+const (
+	OAuthScopeOpenID        = "openid"         // 签发ID Token
+	OAuthScopeProfile       = "profile"        // 用户名和头像
+	OAuthScopeEmail         = "email"          // 邮箱
+	OAuthScopePhone         = "phone"          // 手机号
+	OAuthScopeOfflineAccess = "offline_access" // 签发刷新token
+)
+
+// OAuthClient 第三方应用
+// 公开客户端(单页应用、原生应用)没有密钥，机密客户端只保存密钥哈希；两者都必须使用PKCE
+type OAuthClient struct {
+	ID           uint      `json:"-" gorm:"primarykey"`
+	ClientID     string    `json:"client_id" gorm:"uniqueIndex;size:64;not null;comment:客户端ID"`
+	SecretHash   string    `json:"-" gorm:"size:64;comment:客户端密钥哈希, 公开客户端为空"`
+	Name         string    `json:"name" gorm:"size:100;not null;comment:应用名称"`
+	OwnerID      uint      `json:"owner_id" gorm:"not null;index;comment:注册应用的用户ID"`
+	RedirectURIs string    `json:"-" gorm:"size:2000;not null;comment:回调地址, 空格分隔"`
+	Scopes       string    `json:"-" gorm:"size:255;not null;comment:允许申请的授权范围, 空格分隔"`
+	Public       bool      `json:"public" gorm:"comment:是否为公开客户端"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TableName 指定表名
+func (OAuthClient) TableName() string {
+	return "oauth_clients"
+}
+
+// OAuthConsent 用户对第三方应用的授权记录，再次授权相同范围时不再询问
+type OAuthConsent struct {
+	ID        uint      `json:"-" gorm:"primarykey"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_oauth_consent_user_client;comment:用户ID"`
+	ClientID  string    `json:"client_id" gorm:"size:64;not null;uniqueIndex:idx_oauth_consent_user_client;comment:客户端ID"`
+	Scopes    string    `json:"-" gorm:"size:255;not null;comment:已授权范围, 空格分隔"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (OAuthConsent) TableName() string {
+	return "oauth_consents"
+}
+
+// OAuthAuthorizationCode 授权码，只保存哈希，使用一次后失效
+type OAuthAuthorizationCode struct {
+	ID            uint       `gorm:"primarykey"`
+	CodeHash      string     `gorm:"uniqueIndex;size:64;not null;comment:授权码哈希"`
+	ClientID      string     `gorm:"size:64;not null;comment:客户端ID"`
+	UserID        uint       `gorm:"not null;index;comment:用户ID"`
+	RedirectURI   string     `gorm:"size:500;not null;comment:授权请求中的回调地址"`
+	Scopes        string     `gorm:"size:255;not null;comment:授权范围, 空格分隔"`
+	Nonce         string     `gorm:"size:255;comment:OpenID Connect nonce"`
+	CodeChallenge string     `gorm:"size:128;not null;comment:PKCE code_challenge (S256)"`
+	AuthTime      time.Time  `gorm:"comment:用户授权时间"`
+	ExpiresAt     time.Time  `gorm:"comment:过期时间"`
+	UsedAt        *time.Time `gorm:"comment:兑换时间"`
+	CreatedAt     time.Time
+}
+
+// TableName 指定表名
+func (OAuthAuthorizationCode) TableName() string {
+	return "oauth_authorization_codes"
+}
+
+// OAuthRefreshToken 第三方应用的刷新token，只保存哈希
+// 同一授权码兑换的刷新token及其轮换构成一个令牌族，已使用的token再次出现时吊销整个令牌族
+type OAuthRefreshToken struct {
+	ID        uint       `gorm:"primarykey"`
+	TokenHash string     `gorm:"uniqueIndex;size:64;not null;comment:刷新token哈希"`
+	FamilyID  string     `gorm:"index;size:64;not null;comment:令牌族ID"`
+	ClientID  string     `gorm:"size:64;not null;index;comment:客户端ID"`
+	UserID    uint       `gorm:"not null;index;comment:用户ID"`
+	Scopes    string     `gorm:"size:255;not null;comment:授权范围, 空格分隔"`
+	AuthTime  time.Time  `gorm:"comment:用户授权时间"`
+	ExpiresAt time.Time  `gorm:"comment:过期时间"`
+	UsedAt    *time.Time `gorm:"comment:轮换使用时间"`
+	RevokedAt *time.Time `gorm:"comment:吊销时间"`
+	CreatedAt time.Time
+}
+
+// TableName 指定表名
+func (OAuthRefreshToken) TableName() string {
+	return "oauth_refresh_tokens"
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// PersonalAccessToken 个人访问token，供自动化脚本使用，只保存哈希
+// 只能访问授权范围内的接口，到期或吊销后失效
+type PersonalAccessToken struct {
+	ID         uint       `json:"id" gorm:"primarykey"`
+	UserID     uint       `json:"-" gorm:"not null;index;comment:用户ID"`
+	Name       string     `json:"name" gorm:"size:100;not null;comment:token名称"`
+	TokenHash  string     `json:"-" gorm:"uniqueIndex;size:64;not null;comment:token哈希"`
+	Hint       string     `json:"hint" gorm:"size:8;comment:token末尾几位, 用于在列表中辨认"`
+	Scopes     string     `json:"-" gorm:"size:500;not null;comment:授权范围, 空格分隔"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null;comment:过期时间"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" gorm:"comment:最近使用时间"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" gorm:"comment:吊销时间"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// TableName 指定表名
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 手机号变更状态
+const (
+	PhoneChangePending   = "pending"   // 新手机号已验证, 等待其他已登录设备确认
+	PhoneChangeCompleted = "completed" // 已确认并更换
+	PhoneChangeDenied    = "denied"    // 已拒绝
+)
+
+// PhoneChange 手机号变更请求
+// 新手机号通过验证码验证后创建，需由发起时已登录的其他设备确认后才更换
+type PhoneChange struct {
+	ID          uint       `json:"id" gorm:"primarykey"`
+	UserID      uint       `json:"user_id" gorm:"not null;index;comment:用户ID"`
+	NewPhone    string     `json:"new_phone" gorm:"size:20;not null;comment:新手机号"`
+	RequestedBy uint       `json:"requested_by" gorm:"not null;comment:发起设备ID"`
+	Approvers   string     `json:"-" gorm:"size:1000;comment:可以确认的设备ID, 空格分隔"`
+	IP          string     `json:"ip" gorm:"size:45;comment:发起IP"`
+	Status      string     `json:"status" gorm:"size:20;not null;index;comment:状态"`
+	ExpiresAt   time.Time  `json:"expires_at" gorm:"comment:过期时间"`
+	DecidedBy   uint       `json:"decided_by,omitempty" gorm:"comment:确认设备ID"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty" gorm:"comment:确认时间"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// TableName 指定表名
+func (PhoneChange) TableName() string {
+	return "phone_changes"
+}
+
+// CanApprove 设备是否在发起更换时已登录，只有这些设备可以确认
+func (c *PhoneChange) CanApprove(deviceID uint) bool {
+	id := strconv.FormatUint(uint64(deviceID), 10)
+	for _, approver := range strings.Fields(c.Approvers) {
+		if approver == id {
+			return true
+		}
+	}
+	return false
+}
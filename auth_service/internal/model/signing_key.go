@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+)
+
+// SigningKey JWT签名密钥
+// 最新创建的密钥用于签发token，旧密钥在过期前继续用于验证
+type SigningKey struct {
+	ID         uint      `json:"id" gorm:"primarykey"`
+	KeyID      string    `json:"key_id" gorm:"uniqueIndex;size:32;comment:密钥标识(kid)"`
+	Algorithm  string    `json:"algorithm" gorm:"size:16;comment:签名算法:RS256/EdDSA"`
+	PrivateKey string    `json:"-" gorm:"type:text;comment:PKCS#8 PEM私钥"`
+	ExpiresAt  time.Time `json:"expires_at" gorm:"index;comment:过期时间"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName 指定表名
+func (SigningKey) TableName() string {
+	return "signing_keys"
+}
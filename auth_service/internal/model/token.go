@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+)
+
+// RefreshToken 刷新token记录
+// 同一设备一次登录签发的刷新token及其后续轮换构成一个令牌族(FamilyID)，
+// 每个刷新token只能使用一次，重复使用将吊销整个令牌族。
+type RefreshToken struct {
+	ID        uint       `json:"id" gorm:"primarykey"`
+	TokenID   string     `json:"token_id" gorm:"uniqueIndex;size:64;comment:刷新token唯一标识(jti)"`
+	FamilyID  string     `json:"family_id" gorm:"index;size:64;comment:令牌族ID"`
+	UserID    uint       `json:"user_id" gorm:"not null;index;comment:用户ID"`
+	DeviceID  uint       `json:"device_id" gorm:"not null;index;comment:设备ID"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"comment:过期时间"`
+	UsedAt    *time.Time `json:"used_at" gorm:"comment:轮换使用时间"`
+	RevokedAt *time.Time `json:"revoked_at" gorm:"comment:吊销时间"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName 指定表名
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// RevocationEvent token吊销事件 (存储在Redis Stream中的结构)
+// TokenID、DeviceID、UserID三者只有一个非空
+type RevocationEvent struct {
+	ID        string    `json:"id"` // Stream条目ID, 作为订阅游标
+	TokenID   string    `json:"token_id,omitempty"`
+	DeviceID  uint      `json:"device_id,omitempty"`
+	UserID    uint      `json:"user_id,omitempty"`
+	RevokedAt time.Time `json:"revoked_at"`
+	ExpiresAt time.Time `json:"expires_at"` // 该吊销条目失效时间, 之后相关token已自然过期
+}
+
+// PasswordResetToken 密码重置token，只保存哈希，使用一次后失效
+type PasswordResetToken struct {
+	ID        uint       `json:"id" gorm:"primarykey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index;comment:用户ID"`
+	TokenHash string     `json:"-" gorm:"uniqueIndex;size:64;not null;comment:重置token哈希"`
+	RequestIP string     `json:"request_ip" gorm:"size:45;comment:发起重置的IP"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"comment:过期时间"`
+	UsedAt    *time.Time `json:"used_at" gorm:"comment:使用时间"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName 指定表名
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
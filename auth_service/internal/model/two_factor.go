@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+)
+
+// TwoFactor 用户的TOTP两步验证配置
+// EnabledAt为空表示已生成密钥但尚未用验证码确认，此时登录不要求二次验证
+type TwoFactor struct {
+	ID           uint       `json:"id" gorm:"primarykey"`
+	UserID       uint       `json:"user_id" gorm:"not null;uniqueIndex;comment:用户ID"`
+	Secret       string     `json:"-" gorm:"size:64;not null;comment:TOTP密钥(Base32)"`
+	LastUsedStep int64      `json:"-" gorm:"default:0;comment:最近一次使用的TOTP计数器, 防止验证码重放"`
+	EnabledAt    *time.Time `json:"enabled_at" gorm:"comment:启用时间"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (TwoFactor) TableName() string {
+	return "user_two_factors"
+}
+
+// Enabled 是否已启用
+func (t *TwoFactor) Enabled() bool {
+	return t != nil && t.EnabledAt != nil
+}
+
+// RecoveryCode 两步验证恢复码，每个只能使用一次
+type RecoveryCode struct {
+	ID        uint       `json:"id" gorm:"primarykey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index;comment:用户ID"`
+	CodeHash  string     `json:"-" gorm:"size:64;not null;comment:恢复码哈希"`
+	UsedAt    *time.Time `json:"used_at" gorm:"comment:使用时间"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName 指定表名
+func (RecoveryCode) TableName() string {
+	return "recovery_codes"
+}
@@ -9,17 +9,22 @@ import (
 // User 用户模型
 type User struct {
 	ID           uint           `json:"id" gorm:"primarykey"`
-	Phone        string         `json:"phone" gorm:"uniqueIndex;size:20;comment:手机号"`
-	Email        string         `json:"email" gorm:"uniqueIndex;size:255;comment:邮箱"`
+	Phone        string         `json:"phone" gorm:"uniqueIndex:idx_users_phone_set,where:phone <> '';size:20;comment:手机号"`
+	Email        string         `json:"email" gorm:"uniqueIndex:idx_users_email_set,where:email <> '';size:255;comment:邮箱"`
 	Username     string         `json:"username" gorm:"uniqueIndex;size:50;comment:用户名"`
 	PasswordHash string         `json:"-" gorm:"size:255;comment:密码哈希"`
 	AvatarURL    string         `json:"avatar_url" gorm:"size:500;comment:头像URL"`
 	IsActive     bool           `json:"is_active" gorm:"default:true;comment:是否激活"`
+	AccountType  string         `json:"account_type" gorm:"size:10;not null;default:user;index;comment:账号类型 user/bot"`
+	OwnerID      *uint          `json:"owner_id,omitempty" gorm:"index;comment:机器人的创建者用户ID"`
 	LastLoginAt  *time.Time     `json:"last_login_at" gorm:"comment:最后登录时间"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
 
+	DeactivatedAt       *time.Time `json:"deactivated_at,omitempty" gorm:"comment:停用时间"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty" gorm:"index;comment:计划清除时间, 到期前重新登录可取消"`
+
 	// 关联关系
 	Devices []Device `json:"devices,omitempty" gorm:"foreignKey:UserID"`
 }
@@ -29,19 +34,78 @@ func (User) TableName() string {
 	return "users"
 }
 
+// AccountPurge 已清除的账号，其他服务据此清理该用户的数据
+type AccountPurge struct {
+	ID       uint      `json:"id" gorm:"primarykey"`
+	UserID   uint      `json:"user_id" gorm:"not null;index;comment:用户ID"`
+	PurgedAt time.Time `json:"purged_at" gorm:"not null;comment:清除时间"`
+}
+
+// TableName 指定表名
+func (AccountPurge) TableName() string {
+	return "account_purges"
+}
+
+// LoginHistory 登录记录
+type LoginHistory struct {
+	ID         uint      `json:"id" gorm:"primarykey"`
+	UserID     uint      `json:"user_id" gorm:"not null;index;comment:用户ID"`
+	DeviceID   uint      `json:"device_id" gorm:"comment:设备ID"`
+	DeviceType string    `json:"device_type" gorm:"size:20;comment:设备类型"`
+	DeviceName string    `json:"device_name" gorm:"size:100;comment:设备名称"`
+	IP         string    `json:"ip" gorm:"size:45;comment:登录IP"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName 指定表名
+func (LoginHistory) TableName() string {
+	return "login_histories"
+}
+
+// PasswordHistory 历史密码哈希，用于禁止重复使用最近的密码
+type PasswordHistory struct {
+	ID           uint      `json:"id" gorm:"primarykey"`
+	UserID       uint      `json:"user_id" gorm:"not null;index;comment:用户ID"`
+	PasswordHash string    `json:"-" gorm:"size:255;not null;comment:密码哈希"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TableName 指定表名
+func (PasswordHistory) TableName() string {
+	return "password_histories"
+}
+
+// UsernameHistory 用户名变更记录
+// 旧用户名在HeldUntil之前为原用户保留，其他人不能使用，避免被冒用
+type UsernameHistory struct {
+	ID        uint      `json:"-" gorm:"primarykey"`
+	UserID    uint      `json:"-" gorm:"not null;index;comment:用户ID"`
+	Username  string    `json:"username" gorm:"size:50;not null;index;comment:旧用户名"`
+	ChangedAt time.Time `json:"changed_at" gorm:"not null;comment:变更时间"`
+	HeldUntil time.Time `json:"held_until" gorm:"not null;comment:保留截止时间"`
+}
+
+// TableName 指定表名
+func (UsernameHistory) TableName() string {
+	return "username_histories"
+}
+
 // Device 设备模型
 type Device struct {
-	ID          uint           `json:"id" gorm:"primarykey"`
-	UserID      uint           `json:"user_id" gorm:"not null;index;comment:用户ID"`
-	DeviceToken string         `json:"device_token" gorm:"uniqueIndex;size:255;comment:设备唯一标识"`
-	DeviceType  string         `json:"device_type" gorm:"size:20;comment:设备类型:ios/android/web/desktop"`
-	DeviceName  string         `json:"device_name" gorm:"size:100;comment:设备名称"`
-	PushToken   string         `json:"push_token" gorm:"size:255;comment:推送token"`
-	IsOnline    bool           `json:"is_online" gorm:"default:false;comment:是否在线"`
-	LastSeenAt  *time.Time     `json:"last_seen_at" gorm:"comment:最后活跃时间"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID            uint           `json:"id" gorm:"primarykey"`
+	UserID        uint           `json:"user_id" gorm:"not null;index;comment:用户ID"`
+	DeviceToken   string         `json:"device_token" gorm:"uniqueIndex;size:255;comment:设备唯一标识"`
+	DeviceType    string         `json:"device_type" gorm:"size:20;comment:设备类型:ios/android/web/desktop"`
+	DeviceName    string         `json:"device_name" gorm:"size:100;comment:设备名称"`
+	PushToken     string         `json:"push_token" gorm:"size:512;index;comment:推送token"`
+	PushProvider  string         `json:"push_provider" gorm:"size:20;comment:推送通道:apns/fcm/webpush"`
+	PushUpdatedAt *time.Time     `json:"-" gorm:"comment:推送token最近注册时间"`
+	IsOnline      bool           `json:"is_online" gorm:"default:false;comment:是否在线"`
+	LastIP        string         `json:"last_ip" gorm:"size:45;comment:最近活跃IP"`
+	LastSeenAt    *time.Time     `json:"last_seen_at" gorm:"comment:最后活跃时间"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// 关联关系
 	User User `json:"user,omitempty" gorm:"foreignKey:UserID"`
@@ -69,6 +133,13 @@ const (
 	DeviceTypeDesktop = "desktop"
 )
 
+// PushProvider 推送通道常量
+const (
+	PushProviderAPNs    = "apns"
+	PushProviderFCM     = "fcm"
+	PushProviderWebPush = "webpush"
+)
+
 // ValidDeviceTypes 有效的设备类型列表
 var ValidDeviceTypes = []string{
 	DeviceTypeIOS,
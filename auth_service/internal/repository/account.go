@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+// AccountRepository 账号停用、删除和清除
+type AccountRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountRepository 创建账号repository
+func NewAccountRepository() *AccountRepository {
+	return &AccountRepository{
+		db: GetDB(),
+	}
+}
+
+// Deactivate 停用账号，已停用的账号保留最初的停用时间
+func (r *AccountRepository) Deactivate(userID uint, at time.Time) error {
+	return r.db.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"is_active":      false,
+		"deactivated_at": gorm.Expr("COALESCE(deactivated_at, ?)", at),
+	}).Error
+}
+
+// ScheduleDeletion 停用账号并计划在purgeAt清除
+func (r *AccountRepository) ScheduleDeletion(userID uint, at, purgeAt time.Time) error {
+	return r.db.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"is_active":             false,
+		"deactivated_at":        gorm.Expr("COALESCE(deactivated_at, ?)", at),
+		"deletion_scheduled_at": purgeAt,
+	}).Error
+}
+
+// Restore 恢复账号并取消计划中的删除，账号已被清除时返回false
+func (r *AccountRepository) Restore(userID uint) (bool, error) {
+	result := r.db.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"is_active":             true,
+		"deactivated_at":        nil,
+		"deletion_scheduled_at": nil,
+	})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListDueDeletions 获取删除宽限期已结束的用户ID
+func (r *AccountRepository) ListDueDeletions(now time.Time, limit int) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.Model(&model.User{}).
+		Where("deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= ?", now).
+		Order("id").
+		Limit(limit).
+		Pluck("id", &userIDs).Error
+	return userIDs, err
+}
+
+// Purge 物理删除账号及其设备、token和安全设置，并记录清除事件
+// 账号已恢复(宽限期内重新登录)或已被清除时返回false
+func (r *AccountRepository) Purge(userID uint, now time.Time) (bool, error) {
+	purged := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().
+			Where("id = ? AND deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= ?", userID, now).
+			Delete(&model.User{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		for _, table := range []interface{}{
+			&model.Device{},
+			&model.RefreshToken{},
+			&model.TwoFactor{},
+			&model.RecoveryCode{},
+			&model.PasswordResetToken{},
+			&model.PasswordHistory{},
+			&model.UsernameHistory{},
+			&model.LoginHistory{},
+			&model.AuthEvent{},
+			&model.DeviceApproval{},
+			&model.PhoneChange{},
+			&model.DeviceNotification{},
+			&model.OAuthConsent{},
+			&model.OAuthAuthorizationCode{},
+			&model.OAuthRefreshToken{},
+			&model.PersonalAccessToken{},
+		} {
+			if err := tx.Unscoped().Where("user_id = ?", userID).Delete(table).Error; err != nil {
+				return err
+			}
+		}
+		// 该用户注册的第三方应用一并删除，其他用户的授权随之失效
+		if err := tx.Where("owner_id = ?", userID).Delete(&model.OAuthClient{}).Error; err != nil {
+			return err
+		}
+		// 该用户创建的机器人一并清除
+		if err := purgeBots(tx, userID, now); err != nil {
+			return err
+		}
+
+		purged = true
+		return tx.Create(&model.AccountPurge{UserID: userID, PurgedAt: now}).Error
+	})
+	return purged, err
+}
+
+// purgeBots 删除用户创建的机器人及其token，并为每个机器人记录清除事件
+func purgeBots(tx *gorm.DB, ownerID uint, now time.Time) error {
+	var botIDs []uint
+	if err := tx.Model(&model.User{}).Unscoped().
+		Where("owner_id = ? AND account_type = ?", ownerID, pkg.AccountTypeBot).
+		Pluck("id", &botIDs).Error; err != nil {
+		return err
+	}
+	if len(botIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Unscoped().Where("id IN ?", botIDs).Delete(&model.User{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("bot_id IN ?", botIDs).Delete(&model.BotToken{}).Error; err != nil {
+		return err
+	}
+	for _, botID := range botIDs {
+		if err := tx.Create(&model.AccountPurge{UserID: botID, PurgedAt: now}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListPurges 获取afterID之后的清除记录
+func (r *AccountRepository) ListPurges(afterID uint, limit int) ([]model.AccountPurge, error) {
+	var purges []model.AccountPurge
+	err := r.db.Where("id > ?", afterID).Order("id").Limit(limit).Find(&purges).Error
+	return purges, err
+}
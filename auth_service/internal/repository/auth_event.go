@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+// AuthEventFilter 安全事件查询条件，零值字段不过滤
+type AuthEventFilter struct {
+	UserID   uint
+	IP       string
+	Since    time.Time
+	Until    time.Time
+	BeforeID uint // 分页游标, 只返回ID小于该值的事件
+}
+
+// AuthEventRepository 安全审计事件数据访问层，只追加不修改
+type AuthEventRepository struct {
+	db *gorm.DB
+}
+
+// NewAuthEventRepository 创建安全事件repository
+func NewAuthEventRepository() *AuthEventRepository {
+	return &AuthEventRepository{
+		db: GetDB(),
+	}
+}
+
+// Create 追加一条安全事件
+func (r *AuthEventRepository) Create(event *model.AuthEvent) error {
+	return r.db.Create(event).Error
+}
+
+// List 按条件查询安全事件，按时间倒序
+func (r *AuthEventRepository) List(filter AuthEventFilter, limit int) ([]model.AuthEvent, error) {
+	query := r.db.Model(&model.AuthEvent{})
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.IP != "" {
+		query = query.Where("ip = ?", filter.IP)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("created_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("created_at < ?", filter.Until)
+	}
+	if filter.BeforeID != 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+
+	var events []model.AuthEvent
+	err := query.Order("id DESC").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+// DeleteBefore 删除before之前的事件，每次最多删除limit条，返回删除条数
+func (r *AuthEventRepository) DeleteBefore(before time.Time, limit int) (int64, error) {
+	subQuery := r.db.Model(&model.AuthEvent{}).Select("id").Where("created_at < ?", before).Order("id").Limit(limit)
+	result := r.db.Where("id IN (?)", subQuery).Delete(&model.AuthEvent{})
+	return result.RowsAffected, result.Error
+}
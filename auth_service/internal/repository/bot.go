@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+// tokenTouchInterval 机器人token和个人访问token最近使用时间的更新间隔，避免每次请求都写库
+const tokenTouchInterval = time.Minute
+
+// BotRepository 机器人账号和机器人token数据访问层
+type BotRepository struct {
+	db *gorm.DB
+}
+
+// NewBotRepository 创建机器人repository
+func NewBotRepository() *BotRepository {
+	return &BotRepository{
+		db: GetDB(),
+	}
+}
+
+// CountBots 统计用户创建的机器人数量
+func (r *BotRepository) CountBots(ownerID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.User{}).
+		Where("owner_id = ? AND account_type = ?", ownerID, pkg.AccountTypeBot).
+		Count(&count).Error
+	return count, err
+}
+
+// ListBots 获取用户创建的机器人
+func (r *BotRepository) ListBots(ownerID uint) ([]model.User, error) {
+	var bots []model.User
+	err := r.db.Where("owner_id = ? AND account_type = ?", ownerID, pkg.AccountTypeBot).
+		Order("id").
+		Find(&bots).Error
+	return bots, err
+}
+
+// GetBot 获取用户创建的机器人，不存在或不属于该用户时返回nil
+func (r *BotRepository) GetBot(ownerID, botID uint) (*model.User, error) {
+	var bot model.User
+	err := r.db.Where("id = ? AND owner_id = ? AND account_type = ?", botID, ownerID, pkg.AccountTypeBot).
+		First(&bot).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &bot, nil
+}
+
+// CreateBot 创建机器人账号
+func (r *BotRepository) CreateBot(bot *model.User) error {
+	return usernameConflict(r.db.Create(bot).Error)
+}
+
+// DeleteBot 删除机器人并吊销其所有token，记录清除事件以便其他服务清理机器人数据
+// 机器人不存在或不属于该用户时返回false
+func (r *BotRepository) DeleteBot(ownerID, botID uint, now time.Time) (bool, error) {
+	deleted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().
+			Where("id = ? AND owner_id = ? AND account_type = ?", botID, ownerID, pkg.AccountTypeBot).
+			Delete(&model.User{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Where("bot_id = ?", botID).Delete(&model.BotToken{}).Error; err != nil {
+			return err
+		}
+		deleted = true
+		return tx.Create(&model.AccountPurge{UserID: botID, PurgedAt: now}).Error
+	})
+	return deleted, err
+}
+
+// CreateToken 保存机器人token
+func (r *BotRepository) CreateToken(token *model.BotToken) error {
+	return r.db.Create(token).Error
+}
+
+// ListTokens 获取机器人的token (含已吊销的)
+func (r *BotRepository) ListTokens(botID uint) ([]model.BotToken, error) {
+	var tokens []model.BotToken
+	err := r.db.Where("bot_id = ?", botID).Order("id").Find(&tokens).Error
+	return tokens, err
+}
+
+// RevokeToken 吊销机器人token，token不存在或已吊销时返回false
+func (r *BotRepository) RevokeToken(botID, tokenID uint) (bool, error) {
+	result := r.db.Model(&model.BotToken{}).
+		Where("id = ? AND bot_id = ? AND revoked_at IS NULL", tokenID, botID).
+		Update("revoked_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetActiveToken 根据哈希获取未吊销的token，不存在时返回nil
+func (r *BotRepository) GetActiveToken(tokenHash string) (*model.BotToken, error) {
+	var token model.BotToken
+	err := r.db.Where("token_hash = ? AND revoked_at IS NULL", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// TouchToken 更新最近使用时间，距上次更新不足tokenTouchInterval时跳过
+func (r *BotRepository) TouchToken(token *model.BotToken, now time.Time) error {
+	if token.LastUsedAt != nil && now.Sub(*token.LastUsedAt) < tokenTouchInterval {
+		return nil
+	}
+	return r.db.Model(&model.BotToken{}).Where("id = ?", token.ID).Update("last_used_at", now).Error
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// BotRateKey 机器人请求计数 auth:bot_rate:<机器人ID>:<窗口序号> -> 窗口内请求数
+const BotRateKey = "auth:bot_rate:%d:%d"
+
+// BotRateLimitRepository 机器人请求频率限制 (固定窗口计数)
+// 与用户的登录尝试限制分开计数，机器人不会影响其创建者的限额
+type BotRateLimitRepository struct {
+	redis *redis.Client
+}
+
+// NewBotRateLimitRepository 创建机器人频率限制仓储实例
+func NewBotRateLimitRepository(redis *redis.Client) *BotRateLimitRepository {
+	return &BotRateLimitRepository{
+		redis: redis,
+	}
+}
+
+// Hit 记录一次请求，返回当前窗口内的请求数
+func (r *BotRateLimitRepository) Hit(ctx context.Context, botID uint, window time.Duration, now time.Time) (int64, error) {
+	key := fmt.Sprintf(BotRateKey, botID, now.UnixNano()/int64(window))
+	var incr *redis.IntCmd
+	_, err := r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.PExpire(ctx, key, window)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"fmt"
+	"strings"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -67,11 +68,47 @@ func AutoMigrate() error {
 	err := DB.AutoMigrate(
 		&model.User{},
 		&model.Device{},
+		&model.RefreshToken{},
+		&model.SigningKey{},
+		&model.TwoFactor{},
+		&model.RecoveryCode{},
+		&model.PasswordResetToken{},
+		&model.PasswordHistory{},
+		&model.UsernameHistory{},
+		&model.AccountPurge{},
+		&model.LoginHistory{},
+		&model.AuthEvent{},
+		&model.DeviceApproval{},
+		&model.PhoneChange{},
+		&model.DeviceNotification{},
+		&model.OAuthClient{},
+		&model.OAuthConsent{},
+		&model.OAuthAuthorizationCode{},
+		&model.OAuthRefreshToken{},
+		&model.BotToken{},
+		&model.PersonalAccessToken{},
+		&model.DataMigration{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	// 手机号和邮箱的唯一索引改为只约束非空值 (机器人和只绑定其中一项的账号留空)
+	for _, index := range []string{"idx_users_phone", "idx_users_email"} {
+		if DB.Migrator().HasIndex(&model.User{}, index) {
+			if err := DB.Migrator().DropIndex(&model.User{}, index); err != nil {
+				return fmt.Errorf("failed to drop legacy index %s: %w", index, err)
+			}
+		}
+	}
+
+	// 用户名比较不区分大小写，唯一约束同样不区分大小写，避免并发注册或改名绕过检查
+	if !DB.Migrator().HasIndex(&model.User{}, usernameLowerIndex) {
+		if err := createUsernameLowerIndex(); err != nil {
+			return err
+		}
+	}
+
 	// 使用统一日志系统
 	log := applogger.GetDefault()
 	if log != nil {
@@ -81,6 +118,47 @@ func AutoMigrate() error {
 	return nil
 }
 
+// usernameLowerIndex 不区分大小写的用户名唯一索引
+const usernameLowerIndex = "idx_users_username_lower"
+
+// createUsernameLowerIndex 创建不区分大小写的用户名唯一索引
+// 早期版本允许只有大小写不同的用户名，存在这样的账号时不创建索引并列出冲突的用户ID，需先人工改名
+func createUsernameLowerIndex() error {
+	var rows []struct {
+		ID       uint
+		Username string
+	}
+	err := DB.Table("users").
+		Select("id, LOWER(username) AS username").
+		Where("LOWER(username) IN (?)", DB.Table("users").
+			Select("LOWER(username)").
+			Group("LOWER(username)").
+			Having("COUNT(*) > 1")).
+		Order("LOWER(username), id").
+		Scan(&rows).Error
+	if err != nil {
+		return fmt.Errorf("failed to check case-insensitive username conflicts: %w", err)
+	}
+
+	if len(rows) > 0 {
+		var conflicts []string
+		for i, row := range rows {
+			if i == 0 || rows[i-1].Username != row.Username {
+				conflicts = append(conflicts, fmt.Sprintf("%s: %d", row.Username, row.ID))
+				continue
+			}
+			conflicts[len(conflicts)-1] += fmt.Sprintf(", %d", row.ID)
+		}
+		return fmt.Errorf("cannot create index %s, usernames differ only in case (username: user ids): %s; rename all but one account in each group and restart",
+			usernameLowerIndex, strings.Join(conflicts, "; "))
+	}
+
+	if err := DB.Exec("CREATE UNIQUE INDEX IF NOT EXISTS " + usernameLowerIndex + " ON users (LOWER(username))").Error; err != nil {
+		return fmt.Errorf("failed to create index %s: %w", usernameLowerIndex, err)
+	}
+	return nil
+}
+
 // GetDB 获取数据库实例
 func GetDB() *gorm.DB {
 	return DB
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+// DeviceApprovalRepository 新设备登录审批数据访问层
+type DeviceApprovalRepository struct {
+	db *gorm.DB
+}
+
+// NewDeviceApprovalRepository 创建新设备登录审批repository
+func NewDeviceApprovalRepository() *DeviceApprovalRepository {
+	return &DeviceApprovalRepository{
+		db: GetDB(),
+	}
+}
+
+// Create 保存审批请求，同一设备此前未完成的请求全部作废
+func (r *DeviceApprovalRepository) Create(approval *model.DeviceApproval) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.DeviceApproval{}).
+			Where("user_id = ? AND device_token = ? AND status IN ?", approval.UserID, approval.DeviceToken,
+				[]string{model.DeviceApprovalPending, model.DeviceApprovalApproved}).
+			Update("expires_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(approval).Error
+	})
+}
+
+// GetByTokenHash 获取未过期的审批请求，不存在或已过期时返回nil
+func (r *DeviceApprovalRepository) GetByTokenHash(tokenHash string, now time.Time) (*model.DeviceApproval, error) {
+	var approval model.DeviceApproval
+	err := r.db.Where("token_hash = ? AND expires_at > ?", tokenHash, now).First(&approval).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &approval, nil
+}
+
+// ListPending 获取用户等待审批的请求，按创建时间排序
+func (r *DeviceApprovalRepository) ListPending(userID uint, now time.Time) ([]model.DeviceApproval, error) {
+	var approvals []model.DeviceApproval
+	err := r.db.Where("user_id = ? AND status = ? AND expires_at > ?", userID, model.DeviceApprovalPending, now).
+		Order("id").Find(&approvals).Error
+	return approvals, err
+}
+
+// Decide 批准或拒绝等待中的请求，请求不存在、已过期或已处理时返回false
+func (r *DeviceApprovalRepository) Decide(userID, approvalID uint, status string, deviceID uint, now time.Time) (bool, error) {
+	result := r.db.Model(&model.DeviceApproval{}).
+		Where("id = ? AND user_id = ? AND status = ? AND expires_at > ?", approvalID, userID, model.DeviceApprovalPending, now).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"decided_by": deviceID,
+			"decided_at": now,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Complete 将请求标记为已完成，只能从fromStatus转换一次，并发领取时只有一个成功
+func (r *DeviceApprovalRepository) Complete(approvalID uint, fromStatus string, now time.Time) (bool, error) {
+	result := r.db.Model(&model.DeviceApproval{}).
+		Where("id = ? AND status = ? AND expires_at > ?", approvalID, fromStatus, now).
+		Update("status", model.DeviceApprovalCompleted)
+	return result.RowsAffected > 0, result.Error
+}
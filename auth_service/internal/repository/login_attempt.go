@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 登录失败计数相关Redis键
+const (
+	LoginFailureKey = "auth:login:fail:%s:%s" // auth:login:fail:<维度>:<标识> -> 连续失败次数
+	LoginLockKey    = "auth:login:lock:%s:%s" // auth:login:lock:<维度>:<标识>, TTL即剩余锁定时间
+)
+
+// LoginAttemptRepository 登录失败计数和临时锁定
+type LoginAttemptRepository struct {
+	redis *redis.Client
+}
+
+// NewLoginAttemptRepository 创建登录失败计数仓储实例
+func NewLoginAttemptRepository(redis *redis.Client) *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		redis: redis,
+	}
+}
+
+// LockRemaining 获取各维度中最长的剩余锁定时间，未锁定时返回0
+// subjects为{维度, 标识}对
+func (r *LoginAttemptRepository) LockRemaining(ctx context.Context, subjects [][2]string) (time.Duration, error) {
+	cmds := make([]*redis.DurationCmd, 0, len(subjects))
+	_, err := r.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, subject := range subjects {
+			cmds = append(cmds, pipe.PTTL(ctx, fmt.Sprintf(LoginLockKey, subject[0], subject[1])))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var remaining time.Duration
+	for _, cmd := range cmds {
+		// 键不存在时PTTL返回负值
+		if ttl := cmd.Val(); ttl > remaining {
+			remaining = ttl
+		}
+	}
+	return remaining, nil
+}
+
+// RecordFailure 累加失败次数并延长计数有效期，返回当前次数
+func (r *LoginAttemptRepository) RecordFailure(ctx context.Context, scope, id string, ttl time.Duration) (int64, error) {
+	key := fmt.Sprintf(LoginFailureKey, scope, id)
+	var incr *redis.IntCmd
+	_, err := r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.PExpire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+// Lock 锁定指定维度
+func (r *LoginAttemptRepository) Lock(ctx context.Context, scope, id string, duration time.Duration) error {
+	return r.redis.Set(ctx, fmt.Sprintf(LoginLockKey, scope, id), 1, duration).Err()
+}
+
+// Reset 清除指定维度的失败计数
+func (r *LoginAttemptRepository) Reset(ctx context.Context, scope, id string) error {
+	return r.redis.Del(ctx, fmt.Sprintf(LoginFailureKey, scope, id)).Err()
+}
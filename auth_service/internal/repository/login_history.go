@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+// LoginHistoryRepository 登录记录数据访问层
+type LoginHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewLoginHistoryRepository 创建登录记录repository
+func NewLoginHistoryRepository() *LoginHistoryRepository {
+	return &LoginHistoryRepository{
+		db: GetDB(),
+	}
+}
+
+// Create 记录一次登录
+func (r *LoginHistoryRepository) Create(record *model.LoginHistory) error {
+	return r.db.Create(record).Error
+}
+
+// ListByUser 获取用户的全部登录记录，按时间倒序
+func (r *LoginHistoryRepository) ListByUser(userID uint) ([]model.LoginHistory, error) {
+	var records []model.LoginHistory
+	err := r.db.Where("user_id = ?", userID).Order("id DESC").Find(&records).Error
+	return records, err
+}
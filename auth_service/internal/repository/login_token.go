@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LoginTokenKey 二维码登录token，TTL即剩余有效期
+const LoginTokenKey = "auth:login_token:%s" // auth:login_token:<token哈希> -> LoginToken
+
+// 二维码登录token状态
+const (
+	LoginTokenPending   = "pending"   // 等待手机扫码确认
+	LoginTokenAccepted  = "accepted"  // 已确认, 等待网页/桌面端领取token
+	LoginTokenRedeeming = "redeeming" // 网页/桌面端正在领取, 登录失败时恢复为已确认
+)
+
+// acceptLoginTokenScript 只有等待确认的token可以被确认，返回1表示确认成功
+var acceptLoginTokenScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], "status") ~= ARGV[1] then
+	return 0
+end
+redis.call("HSET", KEYS[1], "status", ARGV[2], "user_id", ARGV[3], "accepted_by", ARGV[4])
+return 1
+`)
+
+// transitionLoginTokenScript 状态为ARGV[1]时改为ARGV[2]，返回1表示修改成功
+var transitionLoginTokenScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], "status") ~= ARGV[1] then
+	return 0
+end
+redis.call("HSET", KEYS[1], "status", ARGV[2])
+return 1
+`)
+
+// LoginToken 二维码登录token状态，由导出token的网页/桌面端设备创建
+type LoginToken struct {
+	Status      string
+	DeviceToken string
+	DeviceType  string
+	DeviceName  string
+	IP          string
+	UserID      uint // 确认的账号
+	AcceptedBy  uint // 确认的设备ID
+	ExpiresAt   time.Time
+}
+
+// LoginTokenRepository 二维码登录token存储
+type LoginTokenRepository struct {
+	redis *redis.Client
+}
+
+// NewLoginTokenRepository 创建二维码登录token仓储实例
+func NewLoginTokenRepository(redis *redis.Client) *LoginTokenRepository {
+	return &LoginTokenRepository{
+		redis: redis,
+	}
+}
+
+// Save 保存等待确认的token
+func (r *LoginTokenRepository) Save(ctx context.Context, tokenHash string, token *LoginToken) error {
+	key := fmt.Sprintf(LoginTokenKey, tokenHash)
+	_, err := r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key,
+			"status", token.Status,
+			"device_token", token.DeviceToken,
+			"device_type", token.DeviceType,
+			"device_name", token.DeviceName,
+			"ip", token.IP,
+			"expires_at", token.ExpiresAt.Unix(),
+		)
+		pipe.ExpireAt(ctx, key, token.ExpiresAt)
+		return nil
+	})
+	return err
+}
+
+// Get 获取token状态，不存在或已过期时返回nil
+func (r *LoginTokenRepository) Get(ctx context.Context, tokenHash string) (*LoginToken, error) {
+	fields, err := r.redis.HGetAll(ctx, fmt.Sprintf(LoginTokenKey, tokenHash)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	userID, _ := strconv.ParseUint(fields["user_id"], 10, 64)
+	acceptedBy, _ := strconv.ParseUint(fields["accepted_by"], 10, 64)
+	expiresAt, _ := strconv.ParseInt(fields["expires_at"], 10, 64)
+	return &LoginToken{
+		Status:      fields["status"],
+		DeviceToken: fields["device_token"],
+		DeviceType:  fields["device_type"],
+		DeviceName:  fields["device_name"],
+		IP:          fields["ip"],
+		UserID:      uint(userID),
+		AcceptedBy:  uint(acceptedBy),
+		ExpiresAt:   time.Unix(expiresAt, 0),
+	}, nil
+}
+
+// Accept 确认等待中的token，token不存在或已被确认时返回false
+func (r *LoginTokenRepository) Accept(ctx context.Context, tokenHash string, userID, deviceID uint) (bool, error) {
+	accepted, err := acceptLoginTokenScript.Run(ctx, r.redis, []string{fmt.Sprintf(LoginTokenKey, tokenHash)},
+		LoginTokenPending, LoginTokenAccepted, userID, deviceID).Int()
+	if err != nil {
+		return false, err
+	}
+	return accepted == 1, nil
+}
+
+// Claim 开始领取已确认的token，token不存在或已被其他请求领取时返回false
+func (r *LoginTokenRepository) Claim(ctx context.Context, tokenHash string) (bool, error) {
+	return r.transition(ctx, tokenHash, LoginTokenAccepted, LoginTokenRedeeming)
+}
+
+// Release 领取失败时恢复为已确认，允许网页/桌面端在有效期内重试
+func (r *LoginTokenRepository) Release(ctx context.Context, tokenHash string) (bool, error) {
+	return r.transition(ctx, tokenHash, LoginTokenRedeeming, LoginTokenAccepted)
+}
+
+func (r *LoginTokenRepository) transition(ctx context.Context, tokenHash, from, to string) (bool, error) {
+	changed, err := transitionLoginTokenScript.Run(ctx, r.redis, []string{fmt.Sprintf(LoginTokenKey, tokenHash)}, from, to).Int()
+	if err != nil {
+		return false, err
+	}
+	return changed == 1, nil
+}
+
+// Delete 删除token，返回是否由本次调用删除
+func (r *LoginTokenRepository) Delete(ctx context.Context, tokenHash string) (bool, error) {
+	deleted, err := r.redis.Del(ctx, fmt.Sprintf(LoginTokenKey, tokenHash)).Result()
+	return deleted > 0, err
+}
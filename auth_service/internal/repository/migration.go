@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+// MigrationRepository 一次性数据迁移标记数据访问层
+type MigrationRepository struct {
+	db *gorm.DB
+}
+
+// NewMigrationRepository 创建数据迁移标记 repository
+func NewMigrationRepository() *MigrationRepository {
+	return &MigrationRepository{
+		db: GetDB(),
+	}
+}
+
+// IsCompleted 迁移是否已完成
+func (r *MigrationRepository) IsCompleted(name string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.DataMigration{}).Where("name = ?", name).Count(&count).Error
+	return count > 0, err
+}
+
+// Complete 记录迁移已完成，多个实例同时完成时保留最先写入的记录
+func (r *MigrationRepository) Complete(name, details string) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.DataMigration{
+		Name:        name,
+		Details:     details,
+		CompletedAt: time.Now(),
+	}).Error
+}
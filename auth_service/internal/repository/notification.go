@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+// NotificationRepository 设备通知数据访问层
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository 创建设备通知repository
+func NewNotificationRepository() *NotificationRepository {
+	return &NotificationRepository{
+		db: GetDB(),
+	}
+}
+
+// CreateForDevices 向用户的多台设备发送同一条通知
+func (r *NotificationRepository) CreateForDevices(userID uint, deviceIDs []uint, notificationType, message string) error {
+	if len(deviceIDs) == 0 {
+		return nil
+	}
+	notifications := make([]model.DeviceNotification, 0, len(deviceIDs))
+	for _, deviceID := range deviceIDs {
+		notifications = append(notifications, model.DeviceNotification{
+			UserID:   userID,
+			DeviceID: deviceID,
+			Type:     notificationType,
+			Message:  message,
+		})
+	}
+	return r.db.Create(&notifications).Error
+}
+
+// Claim 获取设备未读的通知并标记为已读，按创建时间排序
+func (r *NotificationRepository) Claim(userID, deviceID uint, now time.Time) ([]model.DeviceNotification, error) {
+	var notifications []model.DeviceNotification
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ? AND device_id = ? AND read_at IS NULL", userID, deviceID).
+			Order("id").Find(&notifications).Error
+		if err != nil || len(notifications) == 0 {
+			return err
+		}
+
+		ids := make([]uint, 0, len(notifications))
+		for i := range notifications {
+			ids = append(ids, notifications[i].ID)
+			notifications[i].ReadAt = &now
+		}
+		return tx.Model(&model.DeviceNotification{}).Where("id IN ?", ids).Update("read_at", now).Error
+	})
+	return notifications, err
+}
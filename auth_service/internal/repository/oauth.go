@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+// OAuthRepository 第三方应用、授权记录、授权码和刷新token数据访问层
+type OAuthRepository struct {
+	db *gorm.DB
+}
+
+// NewOAuthRepository 创建OAuth repository
+func NewOAuthRepository() *OAuthRepository {
+	return &OAuthRepository{
+		db: GetDB(),
+	}
+}
+
+// CreateClient 注册第三方应用
+func (r *OAuthRepository) CreateClient(client *model.OAuthClient) error {
+	return r.db.Create(client).Error
+}
+
+// GetClient 根据client_id获取应用，不存在时返回nil
+func (r *OAuthRepository) GetClient(clientID string) (*model.OAuthClient, error) {
+	var client model.OAuthClient
+	err := r.db.Where("client_id = ?", clientID).First(&client).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &client, nil
+}
+
+// GetClients 批量获取应用
+func (r *OAuthRepository) GetClients(clientIDs []string) ([]model.OAuthClient, error) {
+	var clients []model.OAuthClient
+	if len(clientIDs) == 0 {
+		return clients, nil
+	}
+	err := r.db.Where("client_id IN ?", clientIDs).Find(&clients).Error
+	return clients, err
+}
+
+// ListClientsByOwner 获取用户注册的应用
+func (r *OAuthRepository) ListClientsByOwner(ownerID uint) ([]model.OAuthClient, error) {
+	var clients []model.OAuthClient
+	err := r.db.Where("owner_id = ?", ownerID).Order("id").Find(&clients).Error
+	return clients, err
+}
+
+// DeleteClient 删除应用及所有用户对其的授权、授权码和刷新token，应用不存在时返回false
+func (r *OAuthRepository) DeleteClient(ownerID uint, clientID string) (bool, error) {
+	deleted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("owner_id = ? AND client_id = ?", ownerID, clientID).Delete(&model.OAuthClient{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		for _, table := range []interface{}{
+			&model.OAuthConsent{},
+			&model.OAuthAuthorizationCode{},
+			&model.OAuthRefreshToken{},
+		} {
+			if err := tx.Where("client_id = ?", clientID).Delete(table).Error; err != nil {
+				return err
+			}
+		}
+		deleted = true
+		return nil
+	})
+	return deleted, err
+}
+
+// GetConsent 获取用户对应用的授权记录，不存在时返回nil
+func (r *OAuthRepository) GetConsent(userID uint, clientID string) (*model.OAuthConsent, error) {
+	var consent model.OAuthConsent
+	err := r.db.Where("user_id = ? AND client_id = ?", userID, clientID).First(&consent).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &consent, nil
+}
+
+// SaveConsent 保存授权记录，已存在时替换授权范围
+func (r *OAuthRepository) SaveConsent(consent *model.OAuthConsent) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "client_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"scopes", "updated_at"}),
+	}).Create(consent).Error
+}
+
+// ListConsents 获取用户授权过的应用
+func (r *OAuthRepository) ListConsents(userID uint) ([]model.OAuthConsent, error) {
+	var consents []model.OAuthConsent
+	err := r.db.Where("user_id = ?", userID).Order("updated_at DESC").Find(&consents).Error
+	return consents, err
+}
+
+// DeleteConsent 撤销授权并吊销该应用持有的刷新token，授权不存在时返回false
+func (r *OAuthRepository) DeleteConsent(userID uint, clientID string) (bool, error) {
+	deleted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND client_id = ?", userID, clientID).Delete(&model.OAuthConsent{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		deleted = true
+		return revokeOAuthTokens(tx, userID, clientID)
+	})
+	return deleted, err
+}
+
+// CreateCode 保存授权码
+func (r *OAuthRepository) CreateCode(code *model.OAuthAuthorizationCode) error {
+	return r.db.Create(code).Error
+}
+
+// GetCodeByHash 获取授权码 (含已使用的)，不存在时返回nil
+func (r *OAuthRepository) GetCodeByHash(codeHash string) (*model.OAuthAuthorizationCode, error) {
+	var code model.OAuthAuthorizationCode
+	err := r.db.Where("code_hash = ?", codeHash).First(&code).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &code, nil
+}
+
+// MarkCodeUsed 标记授权码已使用，并发兑换时只有一个成功
+func (r *OAuthRepository) MarkCodeUsed(codeID uint, now time.Time) (bool, error) {
+	result := r.db.Model(&model.OAuthAuthorizationCode{}).
+		Where("id = ? AND used_at IS NULL", codeID).
+		Update("used_at", now)
+	return result.RowsAffected > 0, result.Error
+}
+
+// CreateRefreshToken 保存刷新token
+func (r *OAuthRepository) CreateRefreshToken(token *model.OAuthRefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetRefreshToken 根据哈希获取刷新token，不存在时返回nil
+func (r *OAuthRepository) GetRefreshToken(tokenHash string) (*model.OAuthRefreshToken, error) {
+	var token model.OAuthRefreshToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken 标记旧token已使用并保存轮换后的新token
+// 旧token已被使用或吊销时返回false，调用方应视为重用
+func (r *OAuthRepository) RotateRefreshToken(oldID uint, next *model.OAuthRefreshToken) (bool, error) {
+	rotated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.OAuthRefreshToken{}).
+			Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", oldID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+		rotated = true
+		return nil
+	})
+	return rotated, err
+}
+
+// RevokeFamily 吊销整个令牌族
+func (r *OAuthRepository) RevokeFamily(familyID string) error {
+	return r.db.Model(&model.OAuthRefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeTokens 吊销应用持有的该用户的所有刷新token
+func (r *OAuthRepository) RevokeTokens(userID uint, clientID string) error {
+	return revokeOAuthTokens(r.db, userID, clientID)
+}
+
+func revokeOAuthTokens(tx *gorm.DB, userID uint, clientID string) error {
+	return tx.Model(&model.OAuthRefreshToken{}).
+		Where("user_id = ? AND client_id = ? AND revoked_at IS NULL", userID, clientID).
+		Update("revoked_at", time.Now()).Error
+}
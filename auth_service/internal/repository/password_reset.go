@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+// PasswordResetRepository 密码重置token数据访问层
+type PasswordResetRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordResetRepository 创建密码重置token repository
+func NewPasswordResetRepository() *PasswordResetRepository {
+	return &PasswordResetRepository{
+		db: GetDB(),
+	}
+}
+
+// CreateToken 保存新的重置token，同时作废该用户之前未使用的token
+func (r *PasswordResetRepository) CreateToken(token *model.PasswordResetToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", token.UserID).
+			Update("used_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+// GetValidToken 获取未过期且未使用的重置token，无效时返回nil
+func (r *PasswordResetRepository) GetValidToken(tokenHash string) (*model.PasswordResetToken, error) {
+	var token model.PasswordResetToken
+	err := r.db.Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, time.Now()).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ConsumeToken 消耗未过期且未使用的重置token，无效时返回nil
+func (r *PasswordResetRepository) ConsumeToken(tokenHash string) (*model.PasswordResetToken, error) {
+	var token model.PasswordResetToken
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&model.PasswordResetToken{}).
+			Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("token_hash = ?", tokenHash).First(&token).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
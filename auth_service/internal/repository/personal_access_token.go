@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+// PersonalAccessTokenRepository 个人访问token数据访问层
+type PersonalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewPersonalAccessTokenRepository 创建个人访问token repository
+func NewPersonalAccessTokenRepository() *PersonalAccessTokenRepository {
+	return &PersonalAccessTokenRepository{
+		db: GetDB(),
+	}
+}
+
+// Create 保存个人访问token
+func (r *PersonalAccessTokenRepository) Create(token *model.PersonalAccessToken) error {
+	return r.db.Create(token).Error
+}
+
+// CountActive 统计用户未吊销且未过期的token数量
+func (r *PersonalAccessTokenRepository) CountActive(userID uint, now time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.PersonalAccessToken{}).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Count(&count).Error
+	return count, err
+}
+
+// ListByUser 获取用户的token (含已吊销和已过期的)
+func (r *PersonalAccessTokenRepository) ListByUser(userID uint) ([]model.PersonalAccessToken, error) {
+	var tokens []model.PersonalAccessToken
+	err := r.db.Where("user_id = ?", userID).Order("id DESC").Find(&tokens).Error
+	return tokens, err
+}
+
+// Revoke 吊销token，token不存在或已吊销时返回false
+func (r *PersonalAccessTokenRepository) Revoke(userID, tokenID uint) (bool, error) {
+	result := r.db.Model(&model.PersonalAccessToken{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", tokenID, userID).
+		Update("revoked_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetActive 根据哈希获取未吊销且未过期的token，不存在时返回nil
+func (r *PersonalAccessTokenRepository) GetActive(tokenHash string, now time.Time) (*model.PersonalAccessToken, error) {
+	var token model.PersonalAccessToken
+	err := r.db.Where("token_hash = ? AND revoked_at IS NULL AND expires_at > ?", tokenHash, now).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Touch 更新最近使用时间，距上次更新不足tokenTouchInterval时跳过
+func (r *PersonalAccessTokenRepository) Touch(token *model.PersonalAccessToken, now time.Time) error {
+	if token.LastUsedAt != nil && now.Sub(*token.LastUsedAt) < tokenTouchInterval {
+		return nil
+	}
+	return r.db.Model(&model.PersonalAccessToken{}).Where("id = ?", token.ID).Update("last_used_at", now).Error
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+// errPhoneTaken 事务内发现新手机号已被占用，用于回滚
+var errPhoneTaken = errors.New("phone taken")
+
+// PhoneChangeRepository 手机号变更请求数据访问层
+type PhoneChangeRepository struct {
+	db *gorm.DB
+}
+
+// NewPhoneChangeRepository 创建手机号变更请求repository
+func NewPhoneChangeRepository() *PhoneChangeRepository {
+	return &PhoneChangeRepository{
+		db: GetDB(),
+	}
+}
+
+// Create 保存变更请求，该用户此前等待确认的请求全部作废
+func (r *PhoneChangeRepository) Create(change *model.PhoneChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.PhoneChange{}).
+			Where("user_id = ? AND status = ?", change.UserID, model.PhoneChangePending).
+			Update("expires_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(change).Error
+	})
+}
+
+// Get 获取用户的变更请求，不存在时返回nil
+func (r *PhoneChangeRepository) Get(userID, changeID uint) (*model.PhoneChange, error) {
+	var change model.PhoneChange
+	err := r.db.Where("id = ? AND user_id = ?", changeID, userID).First(&change).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &change, nil
+}
+
+// ListPending 获取用户等待确认的请求，按创建时间排序
+func (r *PhoneChangeRepository) ListPending(userID uint, now time.Time) ([]model.PhoneChange, error) {
+	var changes []model.PhoneChange
+	err := r.db.Where("user_id = ? AND status = ? AND expires_at > ?", userID, model.PhoneChangePending, now).
+		Order("id").Find(&changes).Error
+	return changes, err
+}
+
+// Deny 拒绝等待中的请求，请求不存在、已过期或已处理时返回false
+func (r *PhoneChangeRepository) Deny(userID, changeID, deviceID uint, now time.Time) (bool, error) {
+	result := r.db.Model(&model.PhoneChange{}).
+		Where("id = ? AND user_id = ? AND status = ? AND expires_at > ?", changeID, userID, model.PhoneChangePending, now).
+		Updates(map[string]interface{}{
+			"status":     model.PhoneChangeDenied,
+			"decided_by": deviceID,
+			"decided_at": now,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Complete 确认请求并更换用户的手机号，请求已处理或新手机号已被其他账号使用时返回false
+func (r *PhoneChangeRepository) Complete(change *model.PhoneChange, deviceID uint, now time.Time) (bool, error) {
+	completed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.PhoneChange{}).
+			Where("id = ? AND status = ? AND expires_at > ?", change.ID, model.PhoneChangePending, now).
+			Updates(map[string]interface{}{
+				"status":     model.PhoneChangeCompleted,
+				"decided_by": deviceID,
+				"decided_at": now,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		var taken int64
+		err := tx.Model(&model.User{}).
+			Where("phone = ? AND id <> ?", change.NewPhone, change.UserID).
+			Count(&taken).Error
+		if err != nil {
+			return err
+		}
+		if taken > 0 {
+			return errPhoneTaken
+		}
+
+		if err := tx.Model(&model.User{}).Where("id = ?", change.UserID).Update("phone", change.NewPhone).Error; err != nil {
+			return err
+		}
+		completed = true
+		return nil
+	})
+	if errors.Is(err, errPhoneTaken) {
+		return false, nil
+	}
+	return completed, err
+}
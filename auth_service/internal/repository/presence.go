@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DeviceLastSeenKey 设备心跳 有序集合 member=设备ID, score=最近心跳时间(毫秒)，等待写入数据库
+const DeviceLastSeenKey = "auth:device:last_seen"
+
+// DeviceReaperLockKey 设备清理任务锁，value为持有者标识，TTL即锁的最长持有时间
+const DeviceReaperLockKey = "auth:device:reaper_lock"
+
+// releaseLockScript 只释放自己持有的锁，避免锁过期后删除其他实例的锁
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// removeFlushedScript 只移除心跳时间未变化的设备，写库期间又有新心跳的设备留到下一轮
+var removeFlushedScript = redis.NewScript(`
+local removed = 0
+for i = 1, #ARGV, 2 do
+	if tonumber(redis.call("ZSCORE", KEYS[1], ARGV[i])) == tonumber(ARGV[i + 1]) then
+		removed = removed + redis.call("ZREM", KEYS[1], ARGV[i])
+	end
+end
+return removed
+`)
+
+// DevicePresenceRepository 设备心跳缓冲，心跳只写Redis，由后台任务批量写入数据库
+type DevicePresenceRepository struct {
+	redis *redis.Client
+}
+
+// NewDevicePresenceRepository 创建设备心跳仓储实例
+func NewDevicePresenceRepository(redis *redis.Client) *DevicePresenceRepository {
+	return &DevicePresenceRepository{
+		redis: redis,
+	}
+}
+
+// Touch 记录设备心跳
+func (r *DevicePresenceRepository) Touch(ctx context.Context, deviceID uint, now time.Time) error {
+	return r.redis.ZAdd(ctx, DeviceLastSeenKey, redis.Z{
+		Score:  float64(now.UnixMilli()),
+		Member: strconv.FormatUint(uint64(deviceID), 10),
+	}).Err()
+}
+
+// Oldest 获取before之前最早的limit条心跳，设备ID -> 心跳时间
+func (r *DevicePresenceRepository) Oldest(ctx context.Context, before time.Time, limit int) (map[uint]time.Time, error) {
+	entries, err := r.redis.ZRangeByScoreWithScores(ctx, DeviceLastSeenKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(before.UnixMilli(), 10),
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uint]time.Time, len(entries))
+	for _, entry := range entries {
+		member, _ := entry.Member.(string)
+		deviceID, err := strconv.ParseUint(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		seen[uint(deviceID)] = time.UnixMilli(int64(entry.Score))
+	}
+	return seen, nil
+}
+
+// Remove 移除已写入数据库的心跳
+func (r *DevicePresenceRepository) Remove(ctx context.Context, seen map[uint]time.Time) error {
+	if len(seen) == 0 {
+		return nil
+	}
+	args := make([]interface{}, 0, len(seen)*2)
+	for deviceID, at := range seen {
+		args = append(args, strconv.FormatUint(uint64(deviceID), 10), strconv.FormatInt(at.UnixMilli(), 10))
+	}
+	return removeFlushedScript.Run(ctx, r.redis, []string{DeviceLastSeenKey}, args...).Err()
+}
+
+// AcquireReaperLock 获取设备清理任务锁，其他实例持有锁时返回false
+func (r *DevicePresenceRepository) AcquireReaperLock(ctx context.Context, owner string, ttl time.Duration) (bool, error) {
+	return r.redis.SetNX(ctx, DeviceReaperLockKey, owner, ttl).Result()
+}
+
+// ReleaseReaperLock 释放owner持有的设备清理任务锁
+func (r *DevicePresenceRepository) ReleaseReaperLock(ctx context.Context, owner string) error {
+	return releaseLockScript.Run(ctx, r.redis, []string{DeviceReaperLockKey}, owner).Err()
+}
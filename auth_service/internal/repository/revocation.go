@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+// Token吊销相关Redis键
+const (
+	RevokedTokenKey  = "auth:revoked:jti:%s"    // auth:revoked:jti:<jti>
+	RevokedDeviceKey = "auth:revoked:device:%d" // auth:revoked:device:123 -> 吊销时间(unix毫秒)
+	RevokedUserKey   = "auth:revoked:user:%d"   // auth:revoked:user:123 -> 吊销时间(unix毫秒)
+	RevocationStream = "auth:revocations"       // 吊销事件流, 供其他服务同步
+)
+
+// 吊销事件类型
+const (
+	revocationTypeToken  = "jti"
+	revocationTypeDevice = "device"
+	revocationTypeUser   = "user"
+)
+
+// TokenRevocationRepository 访问token吊销列表
+// 按jti吊销单个token；按设备/用户记录吊销时间，早于该时间签发的token均视为已吊销。
+// 所有条目在对应token过期后自动过期。每次吊销同时写入事件流，事件流保留retention时长。
+type TokenRevocationRepository struct {
+	redis     *redis.Client
+	retention time.Duration
+}
+
+// NewTokenRevocationRepository 创建token吊销仓储实例
+func NewTokenRevocationRepository(redis *redis.Client, retention time.Duration) *TokenRevocationRepository {
+	return &TokenRevocationRepository{
+		redis:     redis,
+		retention: retention,
+	}
+}
+
+// RevokeToken 吊销单个token
+func (r *TokenRevocationRepository) RevokeToken(ctx context.Context, tokenID string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+	key := fmt.Sprintf(RevokedTokenKey, tokenID)
+	return r.revoke(ctx, key, 1, revocationTypeToken, tokenID, time.Now(), ttl)
+}
+
+// RevokeDevice 吊销设备在revokedAt之前签发的所有token
+func (r *TokenRevocationRepository) RevokeDevice(ctx context.Context, deviceID uint, revokedAt time.Time, ttl time.Duration) error {
+	key := fmt.Sprintf(RevokedDeviceKey, deviceID)
+	return r.revoke(ctx, key, revokedAt.UnixMilli(), revocationTypeDevice, strconv.FormatUint(uint64(deviceID), 10), revokedAt, ttl)
+}
+
+// RevokeUser 吊销用户在revokedAt之前签发的所有token
+func (r *TokenRevocationRepository) RevokeUser(ctx context.Context, userID uint, revokedAt time.Time, ttl time.Duration) error {
+	key := fmt.Sprintf(RevokedUserKey, userID)
+	return r.revoke(ctx, key, revokedAt.UnixMilli(), revocationTypeUser, strconv.FormatUint(uint64(userID), 10), revokedAt, ttl)
+}
+
+// revoke 写入吊销条目并追加吊销事件
+func (r *TokenRevocationRepository) revoke(ctx context.Context, key string, value interface{}, eventType, target string, revokedAt time.Time, ttl time.Duration) error {
+	now := time.Now()
+	minID := strconv.FormatInt(now.Add(-r.retention).UnixMilli(), 10)
+
+	_, err := r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, key, value, ttl)
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: RevocationStream,
+			MinID:  minID,
+			Approx: true,
+			Values: map[string]interface{}{
+				"type":       eventType,
+				"target":     target,
+				"revoked_at": revokedAt.UnixMilli(),
+				"expires_at": now.Add(ttl).Unix(),
+			},
+		})
+		return nil
+	})
+	return err
+}
+
+// IsRevoked 检查token是否已被吊销，按毫秒比较签发时间和吊销时间
+func (r *TokenRevocationRepository) IsRevoked(ctx context.Context, tokenID string, userID, deviceID uint, issuedAt time.Time) (bool, error) {
+	values, err := r.redis.MGet(ctx,
+		fmt.Sprintf(RevokedTokenKey, tokenID),
+		fmt.Sprintf(RevokedDeviceKey, deviceID),
+		fmt.Sprintf(RevokedUserKey, userID),
+	).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+
+	if tokenID != "" && values[0] != nil {
+		return true, nil
+	}
+
+	for _, value := range values[1:] {
+		str, ok := value.(string)
+		if !ok {
+			continue
+		}
+		revokedAt, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return false, fmt.Errorf("invalid revocation entry: %w", err)
+		}
+		if issuedAt.UnixMilli() < revokedAt {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ListRevocations 获取游标之后的吊销事件, 游标为空时从最早保留的事件开始
+func (r *TokenRevocationRepository) ListRevocations(ctx context.Context, cursor string, count int64) ([]*model.RevocationEvent, error) {
+	start := "-"
+	if cursor != "" {
+		start = "(" + cursor
+	}
+
+	messages, err := r.redis.XRangeN(ctx, RevocationStream, start, "+", count).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revocations: %w", err)
+	}
+
+	events := make([]*model.RevocationEvent, 0, len(messages))
+	for _, message := range messages {
+		event, err := parseRevocationEvent(message)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// parseRevocationEvent 解析事件流条目
+func parseRevocationEvent(message redis.XMessage) (*model.RevocationEvent, error) {
+	eventType, _ := message.Values["type"].(string)
+	target, _ := message.Values["target"].(string)
+	revokedAt, err := strconv.ParseInt(fmt.Sprint(message.Values["revoked_at"]), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid revocation event %s: %w", message.ID, err)
+	}
+	expiresAt, err := strconv.ParseInt(fmt.Sprint(message.Values["expires_at"]), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid revocation event %s: %w", message.ID, err)
+	}
+
+	event := &model.RevocationEvent{
+		ID:        message.ID,
+		RevokedAt: time.UnixMilli(revokedAt),
+		ExpiresAt: time.Unix(expiresAt, 0),
+	}
+
+	switch eventType {
+	case revocationTypeToken:
+		event.TokenID = target
+	case revocationTypeDevice, revocationTypeUser:
+		id, err := strconv.ParseUint(target, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid revocation event %s: %w", message.ID, err)
+		}
+		if eventType == revocationTypeDevice {
+			event.DeviceID = uint(id)
+		} else {
+			event.UserID = uint(id)
+		}
+	default:
+		return nil, fmt.Errorf("unknown revocation event type %q", eventType)
+	}
+	return event, nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+// SigningKeyRepository 签名密钥数据访问层
+type SigningKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewSigningKeyRepository 创建签名密钥 repository
+func NewSigningKeyRepository() *SigningKeyRepository {
+	return &SigningKeyRepository{
+		db: GetDB(),
+	}
+}
+
+// CreateSigningKey 保存签名密钥
+func (r *SigningKeyRepository) CreateSigningKey(key *model.SigningKey) error {
+	return r.db.Create(key).Error
+}
+
+// ListValidSigningKeys 获取未过期的签名密钥，按创建时间倒序
+func (r *SigningKeyRepository) ListValidSigningKeys(now time.Time) ([]*model.SigningKey, error) {
+	var keys []*model.SigningKey
+	err := r.db.Where("expires_at > ?", now).Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+// DeleteExpiredSigningKeys 删除已过期的签名密钥
+func (r *SigningKeyRepository) DeleteExpiredSigningKeys(now time.Time) error {
+	return r.db.Where("expires_at <= ?", now).Delete(&model.SigningKey{}).Error
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+// RefreshTokenRepository 刷新token数据访问层
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository 创建刷新token repository
+func NewRefreshTokenRepository() *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		db: GetDB(),
+	}
+}
+
+// CreateRefreshToken 保存刷新token记录
+func (r *RefreshTokenRepository) CreateRefreshToken(token *model.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetRefreshTokenByTokenID 根据jti获取刷新token记录
+func (r *RefreshTokenRepository) GetRefreshTokenByTokenID(tokenID string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	err := r.db.Where("token_id = ?", tokenID).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken 标记旧token已使用并保存轮换后的新token
+// 旧token已被使用或吊销时返回false，调用方应视为重用
+func (r *RefreshTokenRepository) RotateRefreshToken(oldID uint, next *model.RefreshToken) (bool, error) {
+	rotated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", oldID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+		rotated = true
+		return nil
+	})
+	return rotated, err
+}
+
+// RevokeFamily 吊销整个令牌族
+func (r *RefreshTokenRepository) RevokeFamily(familyID string) error {
+	return r.db.Model(&model.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeDeviceTokens 吊销设备的所有刷新token
+func (r *RefreshTokenRepository) RevokeDeviceTokens(deviceID uint) error {
+	return r.db.Model(&model.RefreshToken{}).
+		Where("device_id = ? AND revoked_at IS NULL", deviceID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeUserTokens 吊销用户的所有刷新token
+func (r *RefreshTokenRepository) RevokeUserTokens(userID uint) error {
+	return r.db.Model(&model.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+// TwoFactorRepository 两步验证数据访问层
+type TwoFactorRepository struct {
+	db *gorm.DB
+}
+
+// NewTwoFactorRepository 创建两步验证repository
+func NewTwoFactorRepository() *TwoFactorRepository {
+	return &TwoFactorRepository{
+		db: GetDB(),
+	}
+}
+
+// GetByUserID 获取用户的两步验证配置
+func (r *TwoFactorRepository) GetByUserID(userID uint) (*model.TwoFactor, error) {
+	var twoFactor model.TwoFactor
+	err := r.db.Where("user_id = ?", userID).First(&twoFactor).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &twoFactor, nil
+}
+
+// SavePending 保存待确认的密钥和恢复码，替换之前未确认的配置
+func (r *TwoFactorRepository) SavePending(userID uint, secret string, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&model.TwoFactor{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&model.TwoFactor{UserID: userID, Secret: secret}).Error; err != nil {
+			return err
+		}
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+}
+
+// Enable 确认启用两步验证并记录已使用的计数器
+func (r *TwoFactorRepository) Enable(userID uint, step int64) error {
+	return r.db.Model(&model.TwoFactor{}).
+		Where("user_id = ? AND enabled_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"enabled_at":     time.Now(),
+			"last_used_step": step,
+		}).Error
+}
+
+// UseStep 记录已使用的TOTP计数器，计数器不大于上次使用值时返回false (重放)
+func (r *TwoFactorRepository) UseStep(userID uint, step int64) (bool, error) {
+	result := r.db.Model(&model.TwoFactor{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	return result.RowsAffected > 0, result.Error
+}
+
+// UseRecoveryCode 消耗一个恢复码，不存在或已使用时返回false
+func (r *TwoFactorRepository) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	result := r.db.Model(&model.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+// CountUnusedRecoveryCodes 统计剩余可用的恢复码
+func (r *TwoFactorRepository) CountUnusedRecoveryCodes(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// Delete 删除用户的两步验证配置和恢复码
+func (r *TwoFactorRepository) Delete(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&model.TwoFactor{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error
+	})
+}
+
+// replaceRecoveryCodes 用新的恢复码替换用户现有的恢复码
+func replaceRecoveryCodes(tx *gorm.DB, userID uint, codeHashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	codes := make([]model.RecoveryCode, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		codes = append(codes, model.RecoveryCode{UserID: userID, CodeHash: hash})
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	return tx.Create(&codes).Error
+}
+
+// 两步验证挑战相关Redis键
+const (
+	ChallengeAttemptsKey = "auth:2fa:attempts:%s" // auth:2fa:attempts:<挑战token jti> -> 尝试次数
+	ChallengeUsedKey     = "auth:2fa:used:%s"     // auth:2fa:used:<挑战token jti>, 挑战已完成登录
+)
+
+// incrChallengeScript 挑战未完成时累加尝试次数并返回，已完成时返回-1
+var incrChallengeScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[2]) == 1 then
+	return -1
+end
+local attempts = redis.call("INCR", KEYS[1])
+redis.call("EXPIREAT", KEYS[1], ARGV[1])
+return attempts
+`)
+
+// ChallengeAttemptRepository 两步验证挑战的尝试次数计数和一次性使用标记
+type ChallengeAttemptRepository struct {
+	redis *redis.Client
+}
+
+// NewChallengeAttemptRepository 创建挑战计数仓储实例
+func NewChallengeAttemptRepository(redis *redis.Client) *ChallengeAttemptRepository {
+	return &ChallengeAttemptRepository{
+		redis: redis,
+	}
+}
+
+// Incr 累加挑战的尝试次数，计数随挑战token一同过期；挑战已被使用时返回-1
+func (r *ChallengeAttemptRepository) Incr(ctx context.Context, challengeID string, expiresAt time.Time) (int64, error) {
+	keys := []string{
+		fmt.Sprintf(ChallengeAttemptsKey, challengeID),
+		fmt.Sprintf(ChallengeUsedKey, challengeID),
+	}
+	return incrChallengeScript.Run(ctx, r.redis, keys, expiresAt.Unix()).Int64()
+}
+
+// Consume 将挑战标记为已使用，标记随挑战token一同过期；已被使用时返回false
+// 并发提交同一挑战时只有一个请求能完成登录
+func (r *ChallengeAttemptRepository) Consume(ctx context.Context, challengeID string, expiresAt time.Time) (bool, error) {
+	err := r.redis.SetArgs(ctx, fmt.Sprintf(ChallengeUsedKey, challengeID), 1, redis.SetArgs{
+		Mode:     "NX",
+		ExpireAt: expiresAt,
+	}).Err()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
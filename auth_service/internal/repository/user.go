@@ -2,6 +2,7 @@ package repository
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -9,6 +10,9 @@ import (
 	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
 )
 
+// ErrUsernameTaken 写入时违反用户名唯一约束 (检查与写入之间被并发注册或改名占用)
+var ErrUsernameTaken = errors.New("用户名已被占用")
+
 // UserRepository 用户数据访问层
 type UserRepository struct {
 	db *gorm.DB
@@ -23,13 +27,13 @@ func NewUserRepository() *UserRepository {
 
 // CreateUser 创建用户
 func (r *UserRepository) CreateUser(user *model.User) error {
-	return r.db.Create(user).Error
+	return usernameConflict(r.db.Create(user).Error)
 }
 
-// GetUserByPhone 根据手机号获取用户
+// GetUserByPhone 根据手机号获取用户 (包含已停用账号)
 func (r *UserRepository) GetUserByPhone(phone string) (*model.User, error) {
 	var user model.User
-	err := r.db.Where("phone = ? AND is_active = ?", phone, true).First(&user).Error
+	err := r.db.Where("phone = ?", phone).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -39,10 +43,23 @@ func (r *UserRepository) GetUserByPhone(phone string) (*model.User, error) {
 	return &user, nil
 }
 
-// GetUserByEmail 根据邮箱获取用户
+// GetUserByEmail 根据邮箱获取用户 (包含已停用账号)
 func (r *UserRepository) GetUserByEmail(email string) (*model.User, error) {
 	var user model.User
-	err := r.db.Where("email = ? AND is_active = ?", email, true).First(&user).Error
+	err := r.db.Where("email = ?", email).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUserByUsername 根据用户名获取用户 (不区分大小写，包含已停用账号)
+func (r *UserRepository) GetUserByUsername(username string) (*model.User, error) {
+	var user model.User
+	err := r.db.Where("LOWER(username) = LOWER(?)", username).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -52,6 +69,31 @@ func (r *UserRepository) GetUserByEmail(email string) (*model.User, error) {
 	return &user, nil
 }
 
+// ListUsersWithUnnormalizedIdentifiers 分批获取手机号或邮箱可能未规范化的用户
+// 规范化的手机号为'+'加数字，去掉开头的'+'和数字后仍有剩余字符即包含空格、'-'、'.'、括号等分隔符
+func (r *UserRepository) ListUsersWithUnnormalizedIdentifiers(afterID uint, limit int) ([]model.User, error) {
+	var users []model.User
+	err := r.db.Where("id > ?", afterID).
+		Where("(phone <> '' AND (phone NOT LIKE '+%' OR LTRIM(SUBSTR(phone, 2), '0123456789') <> '')) OR email <> LOWER(TRIM(email))").
+		Order("id").
+		Limit(limit).
+		Find(&users).Error
+	return users, err
+}
+
+// UpdateIdentifiers 更新用户的手机号和邮箱
+func (r *UserRepository) UpdateIdentifiers(userID uint, phone, email string) error {
+	return r.db.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"phone": phone,
+		"email": email,
+	}).Error
+}
+
+// UpdateEmail 更新用户的邮箱
+func (r *UserRepository) UpdateEmail(userID uint, email string) error {
+	return r.db.Model(&model.User{}).Where("id = ?", userID).Update("email", email).Error
+}
+
 // GetUserByID 根据ID获取用户
 func (r *UserRepository) GetUserByID(id uint) (*model.User, error) {
 	var user model.User
@@ -65,11 +107,124 @@ func (r *UserRepository) GetUserByID(id uint) (*model.User, error) {
 	return &user, nil
 }
 
+// GetUserByIDIncludingInactive 根据ID获取用户，包含已停用和等待清除的账号
+func (r *UserRepository) GetUserByIDIncludingInactive(id uint) (*model.User, error) {
+	var user model.User
+	err := r.db.Where("id = ?", id).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
 // UpdateUser 更新用户信息
 func (r *UserRepository) UpdateUser(user *model.User) error {
 	return r.db.Save(user).Error
 }
 
+// UpdatePassword 更新密码哈希，historySize大于0时记录到历史密码并只保留最近historySize条
+func (r *UserRepository) UpdatePassword(userID uint, passwordHash string, historySize int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.User{}).Where("id = ?", userID).Update("password_hash", passwordHash).Error
+		if err != nil {
+			return err
+		}
+		return addPasswordHistory(tx, userID, passwordHash, historySize)
+	})
+}
+
+// ReplacePasswordHash 密码未变更时替换为重新计算的哈希，哈希已被修改时返回false
+func (r *UserRepository) ReplacePasswordHash(userID uint, oldHash, newHash string) (bool, error) {
+	result := r.db.Model(&model.User{}).
+		Where("id = ? AND password_hash = ?", userID, oldHash).
+		Update("password_hash", newHash)
+	return result.RowsAffected > 0, result.Error
+}
+
+// AddPasswordHistory 记录历史密码并只保留最近historySize条
+func (r *UserRepository) AddPasswordHistory(userID uint, passwordHash string, historySize int) error {
+	return addPasswordHistory(r.db, userID, passwordHash, historySize)
+}
+
+// GetPasswordHistory 获取最近limit个历史密码哈希
+func (r *UserRepository) GetPasswordHistory(userID uint, limit int) ([]string, error) {
+	var hashes []string
+	err := r.db.Model(&model.PasswordHistory{}).
+		Where("user_id = ?", userID).
+		Order("id DESC").
+		Limit(limit).
+		Pluck("password_hash", &hashes).Error
+	return hashes, err
+}
+
+// addPasswordHistory 记录历史密码并清理超出保留条数的旧记录
+func addPasswordHistory(tx *gorm.DB, userID uint, passwordHash string, historySize int) error {
+	if historySize <= 0 {
+		return nil
+	}
+	if err := tx.Create(&model.PasswordHistory{UserID: userID, PasswordHash: passwordHash}).Error; err != nil {
+		return err
+	}
+
+	keep := tx.Model(&model.PasswordHistory{}).
+		Select("id").
+		Where("user_id = ?", userID).
+		Order("id DESC").
+		Limit(historySize)
+	return tx.Where("user_id = ? AND id NOT IN (?)", userID, keep).Delete(&model.PasswordHistory{}).Error
+}
+
+// ChangeUsername 修改用户名并记录旧用户名，旧用户名保留到heldUntil
+// 用户重新使用自己保留的旧用户名时释放该保留
+func (r *UserRepository) ChangeUsername(userID uint, oldUsername, newUsername string, now, heldUntil time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.User{}).Where("id = ?", userID).Update("username", newUsername).Error
+		if err != nil {
+			return usernameConflict(err)
+		}
+		err = tx.Model(&model.UsernameHistory{}).
+			Where("user_id = ? AND LOWER(username) = LOWER(?) AND held_until > ?", userID, newUsername, now).
+			Update("held_until", now).Error
+		if err != nil {
+			return err
+		}
+		if oldUsername == "" {
+			return nil
+		}
+		return tx.Create(&model.UsernameHistory{
+			UserID:    userID,
+			Username:  oldUsername,
+			ChangedAt: now,
+			HeldUntil: heldUntil,
+		}).Error
+	})
+}
+
+// GetUsernameHold 获取仍在保留期内的旧用户名记录 (不区分大小写)，没有时返回nil
+func (r *UserRepository) GetUsernameHold(username string, now time.Time) (*model.UsernameHistory, error) {
+	var hold model.UsernameHistory
+	err := r.db.Where("LOWER(username) = LOWER(?) AND held_until > ?", username, now).
+		Order("held_until DESC").
+		First(&hold).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// GetUsernameHistory 获取用户最近limit次用户名变更，最新的在前
+func (r *UserRepository) GetUsernameHistory(userID uint, limit int) ([]model.UsernameHistory, error) {
+	var history []model.UsernameHistory
+	err := r.db.Where("user_id = ?", userID).Order("id DESC").Limit(limit).Find(&history).Error
+	return history, err
+}
+
 // UpdateLastLoginAt 更新最后登录时间
 func (r *UserRepository) UpdateLastLoginAt(userID uint) error {
 	now := time.Now()
@@ -106,6 +261,19 @@ func (r *DeviceRepository) GetDeviceByToken(deviceToken string) (*model.Device,
 	return &device, nil
 }
 
+// GetDeviceByID 根据ID获取设备
+func (r *DeviceRepository) GetDeviceByID(deviceID uint) (*model.Device, error) {
+	var device model.Device
+	err := r.db.Where("id = ?", deviceID).First(&device).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &device, nil
+}
+
 // GetUserDevices 获取用户的所有设备
 func (r *DeviceRepository) GetUserDevices(userID uint) ([]model.Device, error) {
 	var devices []model.Device
@@ -124,7 +292,143 @@ func (r *DeviceRepository) UpdateDeviceOnlineStatus(deviceID uint, isOnline bool
 	return r.db.Model(&model.Device{}).Where("id = ?", deviceID).Updates(updates).Error
 }
 
+// SetUserDevicesOffline 将用户所有设备标记为离线
+func (r *DeviceRepository) SetUserDevicesOffline(userID uint) error {
+	return r.db.Model(&model.Device{}).Where("user_id = ? AND is_online = ?", userID, true).Updates(map[string]interface{}{
+		"is_online":    false,
+		"last_seen_at": time.Now(),
+	}).Error
+}
+
+// TouchDevice 记录设备活跃时间和IP
+func (r *DeviceRepository) TouchDevice(deviceID uint, clientIP string) error {
+	updates := map[string]interface{}{
+		"last_seen_at": time.Now(),
+	}
+	if clientIP != "" {
+		updates["last_ip"] = clientIP
+	}
+	return r.db.Model(&model.Device{}).Where("id = ?", deviceID).Updates(updates).Error
+}
+
+// UpdatePushToken 设置设备的推送token，同一token此前绑定的其他设备会被清除 (应用重装或设备转手)
+func (r *DeviceRepository) UpdatePushToken(deviceID uint, provider, pushToken string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.Device{}).
+			Where("push_provider = ? AND push_token = ? AND id <> ?", provider, pushToken, deviceID).
+			Updates(map[string]interface{}{"push_token": "", "push_provider": ""}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&model.Device{}).Where("id = ?", deviceID).Updates(map[string]interface{}{
+			"push_token":      pushToken,
+			"push_provider":   provider,
+			"push_updated_at": time.Now(),
+		}).Error
+	})
+}
+
+// ClearPushTokens 清除设备的推送token
+func (r *DeviceRepository) ClearPushTokens(deviceIDs []uint) error {
+	if len(deviceIDs) == 0 {
+		return nil
+	}
+	return r.db.Model(&model.Device{}).Where("id IN ?", deviceIDs).
+		Updates(map[string]interface{}{"push_token": "", "push_provider": ""}).Error
+}
+
+// ClearPushTokensByValue 按推送通道和token清除，返回清除的设备数
+func (r *DeviceRepository) ClearPushTokensByValue(provider string, pushTokens []string) (int64, error) {
+	if len(pushTokens) == 0 {
+		return 0, nil
+	}
+	result := r.db.Model(&model.Device{}).Where("push_provider = ? AND push_token IN ?", provider, pushTokens).
+		Updates(map[string]interface{}{"push_token": "", "push_provider": ""})
+	return result.RowsAffected, result.Error
+}
+
+// GetPushDevices 获取用户已注册推送token的设备
+func (r *DeviceRepository) GetPushDevices(userID uint) ([]model.Device, error) {
+	var devices []model.Device
+	err := r.db.Where("user_id = ? AND push_token <> ''", userID).Order("id").Find(&devices).Error
+	return devices, err
+}
+
+// GetActiveSessionDevices 获取用户持有有效刷新token的设备 (即仍处于登录状态的会话)
+func (r *DeviceRepository) GetActiveSessionDevices(userID uint) ([]model.Device, error) {
+	var devices []model.Device
+	activeDevices := r.db.Model(&model.RefreshToken{}).
+		Select("device_id").
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now())
+	err := r.db.Where("user_id = ? AND id IN (?)", userID, activeDevices).
+		Order("last_seen_at DESC").
+		Find(&devices).Error
+	return devices, err
+}
+
 // DeleteDevice 删除设备
 func (r *DeviceRepository) DeleteDevice(deviceID uint) error {
 	return r.db.Delete(&model.Device{}, deviceID).Error
 }
+
+// UpdateLastSeen 批量写入设备心跳时间，心跳时间晚于onlineAfter的设备标记为在线
+// 数据库中已有更新的活跃时间(如刚登录)时不覆盖
+func (r *DeviceRepository) UpdateLastSeen(seen map[uint]time.Time, onlineAfter time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for deviceID, at := range seen {
+			err := tx.Model(&model.Device{}).
+				Where("id = ? AND (last_seen_at IS NULL OR last_seen_at < ?)", deviceID, at).
+				Updates(map[string]interface{}{
+					"last_seen_at": at,
+					"is_online":    at.After(onlineAfter),
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MarkIdleOffline 将before之后没有活跃记录的在线设备标记为离线，返回标记的设备数
+func (r *DeviceRepository) MarkIdleOffline(before time.Time) (int64, error) {
+	result := r.db.Model(&model.Device{}).
+		Where("is_online = ? AND (last_seen_at IS NULL OR last_seen_at < ?)", true, before).
+		Update("is_online", false)
+	return result.RowsAffected, result.Error
+}
+
+// ListStaleDevices 获取before之后没有使用过的设备 (从未记录活跃时间的按创建时间)
+func (r *DeviceRepository) ListStaleDevices(before time.Time, limit int) ([]model.Device, error) {
+	var devices []model.Device
+	err := r.db.Where("last_seen_at < ? OR (last_seen_at IS NULL AND created_at < ?)", before, before).
+		Order("id").
+		Limit(limit).
+		Find(&devices).Error
+	return devices, err
+}
+
+// DeleteDevices 彻底删除设备及其未读通知，设备再次登录时按新设备处理
+func (r *DeviceRepository) DeleteDevices(deviceIDs []uint) error {
+	if len(deviceIDs) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("device_id IN ?", deviceIDs).Delete(&model.DeviceNotification{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id IN ?", deviceIDs).Delete(&model.Device{}).Error
+	})
+}
+
+// usernameConflict 将用户名唯一索引 (idx_users_username, idx_users_username_lower) 的冲突转换为ErrUsernameTaken
+func usernameConflict(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	if strings.Contains(msg, "idx_users_username") || strings.Contains(msg, "users.username") {
+		return ErrUsernameTaken
+	}
+	return err
+}
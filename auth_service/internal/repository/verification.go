@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 验证码相关Redis键
+const (
+	VerificationCodeKey     = "auth:code:%s:%s"          // auth:code:<用途>:<手机号或邮箱> -> {hash, attempts}
+	VerificationCooldownKey = "auth:code:cooldown:%s:%s" // auth:code:cooldown:<用途>:<手机号或邮箱>
+)
+
+// incrAttemptsScript 验证码存在时累加尝试次数并返回{次数, 哈希}，不存在时返回nil
+// 避免对已过期的键执行HINCRBY而创建没有TTL的新键
+var incrAttemptsScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return nil
+end
+local attempts = redis.call("HINCRBY", KEYS[1], "attempts", 1)
+local hash = redis.call("HGET", KEYS[1], "hash")
+return {attempts, hash}
+`)
+
+// VerificationCodeRepository 一次性验证码存储
+type VerificationCodeRepository struct {
+	redis *redis.Client
+}
+
+// NewVerificationCodeRepository 创建验证码仓储实例
+func NewVerificationCodeRepository(redis *redis.Client) *VerificationCodeRepository {
+	return &VerificationCodeRepository{
+		redis: redis,
+	}
+}
+
+// AcquireCooldown 占用重发冷却期，冷却期内返回false
+func (r *VerificationCodeRepository) AcquireCooldown(ctx context.Context, purpose, target string, cooldown time.Duration) (bool, error) {
+	key := fmt.Sprintf(VerificationCooldownKey, purpose, target)
+	return r.redis.SetNX(ctx, key, 1, cooldown).Result()
+}
+
+// ReleaseCooldown 释放重发冷却期 (发送失败时允许立即重试)
+func (r *VerificationCodeRepository) ReleaseCooldown(ctx context.Context, purpose, target string) error {
+	key := fmt.Sprintf(VerificationCooldownKey, purpose, target)
+	return r.redis.Del(ctx, key).Err()
+}
+
+// SaveCode 保存验证码哈希，覆盖之前的验证码并重置尝试次数
+func (r *VerificationCodeRepository) SaveCode(ctx context.Context, purpose, target, codeHash string, ttl time.Duration) error {
+	key := fmt.Sprintf(VerificationCodeKey, purpose, target)
+	_, err := r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key, "hash", codeHash, "attempts", 0)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	return err
+}
+
+// IncrAttempts 记录一次验证尝试，返回累计次数和验证码哈希；验证码不存在时found为false
+func (r *VerificationCodeRepository) IncrAttempts(ctx context.Context, purpose, target string) (attempts int64, codeHash string, found bool, err error) {
+	key := fmt.Sprintf(VerificationCodeKey, purpose, target)
+	result, err := incrAttemptsScript.Run(ctx, r.redis, []string{key}).Slice()
+	if err == redis.Nil {
+		return 0, "", false, nil
+	}
+	if err != nil {
+		return 0, "", false, err
+	}
+	if len(result) != 2 {
+		return 0, "", false, fmt.Errorf("unexpected verification code entry")
+	}
+
+	attempts, _ = result[0].(int64)
+	codeHash, _ = result[1].(string)
+	return attempts, codeHash, true, nil
+}
+
+// DeleteCode 删除验证码，返回是否由本次调用删除 (保证验证码只能使用一次)
+func (r *VerificationCodeRepository) DeleteCode(ctx context.Context, purpose, target string) (bool, error) {
+	key := fmt.Sprintf(VerificationCodeKey, purpose, target)
+	deleted, err := r.redis.Del(ctx, key).Result()
+	return deleted > 0, err
+}
@@ -0,0 +1,28 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotConfigured 未配置发送渠道
+var ErrNotConfigured = errors.New("sender not configured")
+
+// DisabledSender 未配置发送渠道时使用的发送器，拒绝发送任何消息，避免验证码或重置链接以明文写入日志
+type DisabledSender struct{}
+
+// NewDisabledSender 创建拒绝发送的发送器
+func NewDisabledSender() *DisabledSender {
+	return &DisabledSender{}
+}
+
+// SendCode 拒绝发送验证码
+func (s *DisabledSender) SendCode(ctx context.Context, target, code string, ttl time.Duration) error {
+	return ErrNotConfigured
+}
+
+// SendPasswordReset 拒绝发送密码重置消息
+func (s *DisabledSender) SendPasswordReset(ctx context.Context, target, link string, ttl time.Duration) error {
+	return ErrNotConfigured
+}
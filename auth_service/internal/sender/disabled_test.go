@@ -0,0 +1,15 @@
+package sender
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisabledSender(t *testing.T) {
+	disabled := NewDisabledSender()
+	assert.ErrorIs(t, disabled.SendCode(context.Background(), "+8613800138000", "123456", time.Minute), ErrNotConfigured)
+	assert.ErrorIs(t, disabled.SendPasswordReset(context.Background(), "user@example.com", "token", time.Minute), ErrNotConfigured)
+}
@@ -0,0 +1,63 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EmailSender 通过SMTP发送邮件
+type EmailSender struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+// NewEmailSender 创建邮件发送器
+func NewEmailSender(host string, port int, username, password, from string) *EmailSender {
+	return &EmailSender{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+// SendCode 发送邮件验证码
+func (s *EmailSender) SendCode(ctx context.Context, target, code string, ttl time.Duration) error {
+	return s.send(target, "TelegramLite 验证码", codeText(code, ttl))
+}
+
+// SendPasswordReset 发送密码重置邮件
+func (s *EmailSender) SendPasswordReset(ctx context.Context, target, link string, ttl time.Duration) error {
+	return s.send(target, "TelegramLite 密码重置", resetText(link, ttl))
+}
+
+// send 发送纯文本邮件
+func (s *EmailSender) send(to, subject, body string) error {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", s.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(body)
+
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	if err := smtp.SendMail(addr, auth, s.from, []string{to}, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+)
+
+// LogSender 开发和测试用的发送器，将消息写入日志和可选的文件
+type LogSender struct {
+	filePath string
+	mu       sync.Mutex
+}
+
+// NewLogSender 创建日志发送器，filePath为空时只写日志
+func NewLogSender(filePath string) *LogSender {
+	return &LogSender{
+		filePath: filePath,
+	}
+}
+
+// SendCode 记录验证码
+func (s *LogSender) SendCode(ctx context.Context, target, code string, ttl time.Duration) error {
+	return s.write(target, codeText(code, ttl))
+}
+
+// SendPasswordReset 记录密码重置消息
+func (s *LogSender) SendPasswordReset(ctx context.Context, target, link string, ttl time.Duration) error {
+	return s.write(target, resetText(link, ttl))
+}
+
+// write 写入日志和文件
+func (s *LogSender) write(target, text string) error {
+	if log := applogger.GetDefault(); log != nil {
+		log.Info("Message sent via log sender", applogger.Fields{
+			"target": target,
+			"text":   text,
+		})
+	}
+
+	if s.filePath == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.filePath), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(s.filePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "%s\t%s\t%s\n", time.Now().Format(time.RFC3339), target, text)
+	return err
+}
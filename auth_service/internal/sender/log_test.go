@@ -0,0 +1,32 @@
+package sender
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogSender_SendCode(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "logs", "messages.log")
+	logSender := NewLogSender(filePath)
+
+	require.NoError(t, logSender.SendCode(context.Background(), "+8613800138000", "123456", 5*time.Minute))
+	require.NoError(t, logSender.SendCode(context.Background(), "user@example.com", "654321", 5*time.Minute))
+
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "+8613800138000")
+	assert.Contains(t, string(data), "123456")
+	assert.Contains(t, string(data), "user@example.com")
+	assert.Contains(t, string(data), "654321")
+}
+
+func TestLogSender_NoFile(t *testing.T) {
+	logSender := NewLogSender("")
+	assert.NoError(t, logSender.SendCode(context.Background(), "+8613800138000", "123456", time.Minute))
+}
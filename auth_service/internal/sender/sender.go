@@ -0,0 +1,35 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CodeSender 验证码发送接口
+type CodeSender interface {
+	// SendCode 向目标(手机号或邮箱)发送验证码
+	SendCode(ctx context.Context, target, code string, ttl time.Duration) error
+}
+
+// ResetSender 密码重置消息发送接口
+type ResetSender interface {
+	// SendPasswordReset 向目标发送密码重置链接 (未配置链接模板时为重置token)
+	SendPasswordReset(ctx context.Context, target, link string, ttl time.Duration) error
+}
+
+// Sender 短信/邮件发送器需同时支持验证码和密码重置消息
+type Sender interface {
+	CodeSender
+	ResetSender
+}
+
+// codeText 验证码消息正文
+func codeText(code string, ttl time.Duration) string {
+	return fmt.Sprintf("您的 TelegramLite 验证码是 %s，%d 分钟内有效，请勿泄露给他人。", code, int(ttl.Minutes()))
+}
+
+// resetText 密码重置消息正文
+func resetText(link string, ttl time.Duration) string {
+	return fmt.Sprintf("您正在重置 TelegramLite 密码，请在 %d 分钟内使用以下链接或凭证完成重置：%s\n如非本人操作请忽略。", int(ttl.Minutes()), link)
+}
@@ -0,0 +1,68 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SMSSender 通过HTTP短信网关发送短信
+type SMSSender struct {
+	endpoint string
+	apiKey   string
+	signName string
+	client   *http.Client
+}
+
+// NewSMSSender 创建短信发送器
+func NewSMSSender(endpoint, apiKey, signName string) *SMSSender {
+	return &SMSSender{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		signName: signName,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SendCode 发送短信验证码
+func (s *SMSSender) SendCode(ctx context.Context, target, code string, ttl time.Duration) error {
+	return s.send(ctx, target, codeText(code, ttl))
+}
+
+// SendPasswordReset 发送密码重置短信
+func (s *SMSSender) SendPasswordReset(ctx context.Context, target, link string, ttl time.Duration) error {
+	return s.send(ctx, target, resetText(link, ttl))
+}
+
+// send 调用短信网关
+func (s *SMSSender) send(ctx context.Context, phone, text string) error {
+	body, err := json.Marshal(map[string]string{
+		"phone":     phone,
+		"sign_name": s.signName,
+		"content":   text,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send sms: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sms gateway returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+const (
+	defaultDeletionGracePeriod = 30 * 24 * time.Hour
+	accountPurgeBatch          = 100
+	maxAccountPurgePageSize    = 1000
+)
+
+// AccountActionRequest 停用或删除账号请求，需要重新验证身份
+type AccountActionRequest struct {
+	Password  string `json:"password"`
+	Code      string `json:"code"` // 未设置密码的账号使用发送到手机号/邮箱的登录验证码
+	ClientIP  string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// DeleteAccountResponse 删除账号响应
+type DeleteAccountResponse struct {
+	PurgeAt time.Time `json:"purge_at"` // 账号数据将被清除的时间，此前重新登录可取消删除
+}
+
+// DeactivateAccount 停用账号并登出所有设备，重新登录后恢复
+func (s *AuthService) DeactivateAccount(userID uint, req *AccountActionRequest) error {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("用户不存在")
+	}
+
+	if err := s.reauthenticate(user, req.Password, req.Code, req.ClientIP, req.UserAgent); err != nil {
+		return err
+	}
+
+	if err := s.accountRepo.Deactivate(userID, time.Now()); err != nil {
+		return err
+	}
+	if err := s.logoutAllDevices(userID); err != nil {
+		return err
+	}
+
+	s.audit(AuditAccountDeactivated, auditEntry{
+		UserID:    userID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+	})
+	return nil
+}
+
+// DeleteAccount 停用账号并计划在宽限期结束后清除，宽限期内重新登录可取消
+func (s *AuthService) DeleteAccount(userID uint, req *AccountActionRequest) (*DeleteAccountResponse, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("用户不存在")
+	}
+
+	if err := s.reauthenticate(user, req.Password, req.Code, req.ClientIP, req.UserAgent); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	purgeAt := now.Add(s.deletionGracePeriod)
+	if err := s.accountRepo.ScheduleDeletion(userID, now, purgeAt); err != nil {
+		return nil, err
+	}
+	if err := s.logoutAllDevices(userID); err != nil {
+		return nil, err
+	}
+
+	s.audit(AuditAccountDeletionScheduled, auditEntry{
+		UserID:    userID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+		Details:   applogger.Fields{"purge_at": purgeAt},
+	})
+	return &DeleteAccountResponse{PurgeAt: purgeAt}, nil
+}
+
+// reauthenticate 敏感操作前重新验证身份：有密码的账号验证密码，否则验证登录验证码
+// 验证失败与登录失败共用计数，不能通过敏感操作接口绕过登录锁定猜测密码
+func (s *AuthService) reauthenticate(user *model.User, password, code, clientIP, userAgent string) error {
+	loginReq := &LoginRequest{ClientIP: clientIP, UserAgent: userAgent}
+	subjects := s.loginSubjects(user, loginReq)
+	if err := s.checkLoginLock(user.ID, subjects, loginReq); err != nil {
+		return err
+	}
+	if err := s.verifyIdentity(user, password, code); err != nil {
+		s.recordLoginFailure(user.ID, subjects, loginReq, err.Error())
+		return err
+	}
+	s.resetLoginFailures(subjects)
+	return nil
+}
+
+// verifyIdentity 有密码的账号验证密码，否则验证登录验证码
+func (s *AuthService) verifyIdentity(user *model.User, password, code string) error {
+	if user.PasswordHash != "" {
+		if err := s.passwordManager.VerifyPassword(user.PasswordHash, password); err != nil {
+			return errors.New("密码错误")
+		}
+		return nil
+	}
+
+	target := user.Phone
+	if target == "" {
+		target = user.Email
+	}
+	return s.checkCode(CodePurposeLogin, target, code)
+}
+
+// restoreAccount 停用或等待清除的账号重新登录后恢复
+func (s *AuthService) restoreAccount(user *model.User, req *LoginRequest) error {
+	if user.IsActive && user.DeletionScheduledAt == nil {
+		return nil
+	}
+
+	restored, err := s.accountRepo.Restore(user.ID)
+	if err != nil {
+		return err
+	}
+	if !restored {
+		return errors.New("用户不存在")
+	}
+
+	s.audit(AuditAccountRestored, auditEntry{
+		UserID:    user.ID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+		Details:   applogger.Fields{"deletion_canceled": user.DeletionScheduledAt != nil},
+	})
+
+	user.IsActive = true
+	user.DeactivatedAt = nil
+	user.DeletionScheduledAt = nil
+	return nil
+}
+
+// PurgeDueAccounts 清除宽限期已结束的账号，返回清除的账号数
+func (s *AuthService) PurgeDueAccounts() (int, error) {
+	purged := 0
+	for {
+		now := time.Now()
+		userIDs, err := s.accountRepo.ListDueDeletions(now, accountPurgeBatch)
+		if err != nil {
+			return purged, err
+		}
+
+		for _, userID := range userIDs {
+			// 先吊销token, 清除后吊销记录仍会同步到其他服务
+			if err := s.RevokeUserTokens(userID); err != nil {
+				return purged, err
+			}
+
+			ok, err := s.accountRepo.Purge(userID, now)
+			if err != nil {
+				return purged, err
+			}
+			if ok {
+				purged++
+				s.audit(AuditAccountPurged, auditEntry{UserID: userID})
+			}
+		}
+
+		if len(userIDs) < accountPurgeBatch {
+			return purged, nil
+		}
+	}
+}
+
+// RunAccountPurger 定期清除宽限期已结束的账号
+func (s *AuthService) RunAccountPurger(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := s.PurgeDueAccounts()
+			log := applogger.GetDefault()
+			if log == nil {
+				continue
+			}
+			if err != nil {
+				log.Error("Failed to purge deleted accounts", applogger.Fields{"error": err.Error()})
+			} else if purged > 0 {
+				log.Info("Deleted accounts purged", applogger.Fields{"count": purged})
+			}
+		}
+	}
+}
+
+// ListAccountPurges 获取游标之后的账号清除记录，游标为上一页最后一条记录的ID
+func (s *AuthService) ListAccountPurges(cursor string, limit int) ([]model.AccountPurge, error) {
+	var afterID uint64
+	if cursor != "" {
+		var err error
+		afterID, err = strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return nil, errors.New("无效的游标")
+		}
+	}
+	if limit <= 0 || limit > maxAccountPurgePageSize {
+		limit = maxAccountPurgePageSize
+	}
+	return s.accountRepo.ListPurges(uint(afterID), limit)
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+func TestAuthService_DeactivateAccount(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour))
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	userID := registered.User.ID
+
+	err = authService.DeactivateAccount(userID, &AccountActionRequest{Password: "wrong-password"})
+	assert.EqualError(t, err, "密码错误")
+
+	require.NoError(t, authService.DeactivateAccount(userID, &AccountActionRequest{Password: "password123"}))
+
+	user, err := repository.NewUserRepository().GetUserByID(userID)
+	require.NoError(t, err)
+	assert.Nil(t, user)
+
+	// 刷新token已随停用失效
+	_, err = authService.RefreshToken(registered.Token.RefreshToken, "", "")
+	assert.Error(t, err)
+
+	// 停用的手机号不能被重新注册
+	_, err = authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "bob",
+		Password:    "password123",
+		DeviceToken: "phone-2",
+		DeviceType:  "ios",
+	})
+	assert.EqualError(t, err, "手机号已被注册")
+
+	// 重新登录后恢复
+	resp, err := authService.Login(&LoginRequest{Username: "alice", Password: "password123", DeviceToken: "phone-1", DeviceType: "ios"})
+	require.NoError(t, err)
+	assert.True(t, resp.User.IsActive)
+	assert.Nil(t, resp.User.DeactivatedAt)
+
+	user, err = repository.NewUserRepository().GetUserByID(userID)
+	require.NoError(t, err)
+	require.NotNil(t, user)
+}
+
+func TestAuthService_ReauthenticationLockout(t *testing.T) {
+	setupTestDB(t)
+	redisServer := setupTestRedis(t)
+	authService := NewAuthService(
+		pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithLoginProtection(LoginProtectionPolicy{
+			Window:           15 * time.Minute,
+			AccountThreshold: 3,
+			IPThreshold:      100,
+			DeviceThreshold:  100,
+			BaseLockout:      time.Minute,
+			MaxLockout:       time.Hour,
+		}),
+	)
+
+	registered, err := authService.Register(&RegisterRequest{
+		Email:       "alice@example.com",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	userID := registered.User.ID
+
+	// 停用、删除和更换邮箱时的密码错误共用登录失败计数
+	assert.EqualError(t, authService.DeactivateAccount(userID, &AccountActionRequest{Password: "wrong-password", ClientIP: "203.0.113.7"}), "密码错误")
+	_, err = authService.DeleteAccount(userID, &AccountActionRequest{Password: "wrong-password", ClientIP: "203.0.113.7"})
+	assert.EqualError(t, err, "密码错误")
+	_, err = authService.ChangeEmail(userID, registered.Device.ID, &ChangeEmailRequest{Email: "bob@example.com", Code: "123456", Password: "wrong-password"})
+	assert.EqualError(t, err, "密码错误")
+
+	// 锁定期间正确的密码也被拒绝，登录同样被锁定
+	assert.Equal(t, errLoginLocked, authService.DeactivateAccount(userID, &AccountActionRequest{Password: "password123"}))
+	_, err = authService.Login(&LoginRequest{Email: "alice@example.com", Password: "password123", DeviceToken: "phone-1", DeviceType: "ios"})
+	assert.Equal(t, errLoginLocked, err)
+
+	redisServer.FastForward(2 * time.Minute)
+	require.NoError(t, authService.DeactivateAccount(userID, &AccountActionRequest{Password: "password123"}))
+}
+
+func TestAuthService_DeleteAccount(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour), WithDeletionGracePeriod(7*24*time.Hour))
+
+	registered, err := authService.Register(&RegisterRequest{
+		Email:       "alice@example.com",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	userID := registered.User.ID
+
+	before := time.Now()
+	resp, err := authService.DeleteAccount(userID, &AccountActionRequest{Password: "password123"})
+	require.NoError(t, err)
+	assert.WithinDuration(t, before.Add(7*24*time.Hour), resp.PurgeAt, time.Minute)
+
+	// 宽限期内重新登录取消删除
+	login, err := authService.Login(&LoginRequest{Email: "alice@example.com", Password: "password123", DeviceToken: "phone-1", DeviceType: "ios"})
+	require.NoError(t, err)
+	assert.Nil(t, login.User.DeletionScheduledAt)
+
+	_, err = authService.DeleteAccount(userID, &AccountActionRequest{Password: "password123"})
+	require.NoError(t, err)
+
+	// 宽限期未结束时不清除
+	purged, err := authService.PurgeDueAccounts()
+	require.NoError(t, err)
+	assert.Equal(t, 0, purged)
+
+	require.NoError(t, repository.GetDB().Model(&model.User{}).Where("id = ?", userID).
+		Update("deletion_scheduled_at", time.Now().Add(-time.Minute)).Error)
+
+	purged, err = authService.PurgeDueAccounts()
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	var users, devices, refreshTokens int64
+	repository.GetDB().Unscoped().Model(&model.User{}).Where("id = ?", userID).Count(&users)
+	repository.GetDB().Unscoped().Model(&model.Device{}).Where("user_id = ?", userID).Count(&devices)
+	repository.GetDB().Model(&model.RefreshToken{}).Where("user_id = ?", userID).Count(&refreshTokens)
+	assert.Zero(t, users)
+	assert.Zero(t, devices)
+	assert.Zero(t, refreshTokens)
+
+	_, err = authService.Login(&LoginRequest{Email: "alice@example.com", Password: "password123", DeviceToken: "phone-1", DeviceType: "ios"})
+	assert.ErrorIs(t, err, errInvalidCredentials)
+
+	// 其他服务按游标同步清除记录
+	purges, err := authService.ListAccountPurges("", 10)
+	require.NoError(t, err)
+	require.Len(t, purges, 1)
+	assert.Equal(t, userID, purges[0].UserID)
+
+	purges, err = authService.ListAccountPurges("1", 10)
+	require.NoError(t, err)
+	assert.Empty(t, purges)
+
+	_, err = authService.ListAccountPurges("invalid", 10)
+	assert.Error(t, err)
+
+	// 清除后邮箱可以重新注册
+	_, err = authService.Register(&RegisterRequest{
+		Email:       "alice@example.com",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+)
+
+// 审计事件类型
+const (
+	AuditRegistered   = "registered"    // 注册账号
+	AuditLogin        = "login"         // 登录成功
+	AuditNewDevice    = "new_device"    // 首次在新设备上登录
+	AuditLoginFailed  = "login_failed"  // 登录凭证错误
+	AuditLoginLocked  = "login_locked"  // 连续失败触发临时锁定
+	AuditLoginBlocked = "login_blocked" // 锁定期间的登录尝试被拒绝
+	AuditLogout       = "logout"        // 登出设备
+
+	AuditDeviceApprovalRequested = "device_approval_requested" // 新设备登录等待已登录设备批准
+	AuditDeviceApproved          = "device_approved"           // 已登录设备批准了新设备登录
+	AuditDeviceDenied            = "device_denied"             // 已登录设备拒绝了新设备登录
+	AuditLoginTokenAccepted      = "login_token_accepted"      // 手机扫码确认了网页/桌面端登录
+
+	AuditTokenRefreshed     = "token_refreshed"      // 刷新token
+	AuditRefreshTokenReused = "refresh_token_reused" // 已使用的刷新token再次出现, 令牌族被吊销
+
+	AuditPasswordResetRequested = "password_reset_requested" // 申请重置密码
+	AuditPasswordReset          = "password_reset"           // 通过重置token修改了密码
+	AuditPasswordChanged        = "password_changed"         // 登录状态下修改了密码
+
+	AuditTwoFactorEnabled  = "two_factor_enabled"  // 开启两步验证
+	AuditTwoFactorDisabled = "two_factor_disabled" // 关闭两步验证
+	AuditRecoveryCodeUsed  = "recovery_code_used"  // 使用恢复码完成两步验证, 恢复码已作废
+
+	AuditAccountDeactivated       = "account_deactivated"        // 停用账号
+	AuditAccountDeletionScheduled = "account_deletion_scheduled" // 申请删除账号, 进入宽限期
+	AuditAccountRestored          = "account_restored"           // 停用或待删除的账号重新登录后恢复
+	AuditAccountPurged            = "account_purged"             // 宽限期结束, 账号数据已清除
+
+	AuditOAuthConsentGranted = "oauth_consent_granted" // 授权第三方应用访问账号
+	AuditOAuthConsentRevoked = "oauth_consent_revoked" // 撤销对第三方应用的授权
+	AuditOAuthTokenReused    = "oauth_token_reused"    // 第三方应用的授权码或刷新token被重复使用, 相关token被吊销
+
+	AuditBotCreated      = "bot_created"       // 创建机器人
+	AuditBotDeleted      = "bot_deleted"       // 删除机器人
+	AuditBotTokenCreated = "bot_token_created" // 为机器人签发token
+	AuditBotTokenRevoked = "bot_token_revoked" // 吊销机器人token
+
+	AuditPersonalAccessTokenCreated = "personal_access_token_created" // 创建个人访问token
+	AuditPersonalAccessTokenRevoked = "personal_access_token_revoked" // 吊销个人访问token
+
+	AuditUsernameChanged = "username_changed" // 修改用户名
+
+	AuditPhoneChangeRequested = "phone_change_requested" // 新手机号已验证, 等待其他已登录设备确认
+	AuditPhoneChanged         = "phone_changed"          // 更换了手机号
+	AuditPhoneChangeDenied    = "phone_change_denied"    // 其他已登录设备拒绝了手机号更换
+	AuditEmailChanged         = "email_changed"          // 更换了邮箱
+
+	AuditStaleDeviceRemoved = "stale_device_removed" // 长期未使用的设备被删除, 设备的token已吊销
+)
+
+// failedAuditEvents 结果为失败的事件类型
+var failedAuditEvents = map[string]bool{
+	AuditLoginFailed:        true,
+	AuditLoginLocked:        true,
+	AuditLoginBlocked:       true,
+	AuditRefreshTokenReused: true,
+	AuditDeviceDenied:       true,
+	AuditPhoneChangeDenied:  true,
+	AuditOAuthTokenReused:   true,
+}
+
+const (
+	defaultAuditRetention    = 90 * 24 * time.Hour
+	maxAuthEventPageSize     = 100
+	defaultAuthEventPageSize = 20
+	auditPruneBatch          = 1000
+)
+
+// auditEntry 审计事件内容，Details中的字段写入日志和事件详情
+type auditEntry struct {
+	UserID    uint
+	DeviceID  uint
+	ClientIP  string
+	UserAgent string
+	Reason    string
+	Details   applogger.Fields
+}
+
+// AuthEventQuery 管理员查询安全事件的条件，零值字段不过滤
+type AuthEventQuery struct {
+	UserID uint
+	IP     string
+	Since  time.Time
+	Until  time.Time
+	Cursor string
+	Limit  int
+}
+
+// audit 记录安全审计事件：写入日志并追加到auth_events
+// 写入失败只记录日志，不影响正在进行的认证操作
+func (s *AuthService) audit(event string, entry auditEntry) {
+	outcome := model.AuthEventSuccess
+	if failedAuditEvents[event] {
+		outcome = model.AuthEventFailure
+	}
+
+	record := &model.AuthEvent{
+		UserID:    entry.UserID,
+		DeviceID:  entry.DeviceID,
+		Event:     event,
+		Outcome:   outcome,
+		Reason:    truncate(entry.Reason, 200),
+		IP:        entry.ClientIP,
+		UserAgent: truncate(entry.UserAgent, 255),
+	}
+	if len(entry.Details) > 0 {
+		if details, err := json.Marshal(entry.Details); err == nil {
+			record.Details = string(details)
+		}
+	}
+	persistErr := s.authEventRepo.Create(record)
+
+	log := applogger.GetDefault()
+	if log == nil {
+		return
+	}
+
+	fields := applogger.Fields{"audit": true, "event": event, "outcome": outcome}
+	if entry.UserID != 0 {
+		fields["user_id"] = entry.UserID
+	}
+	if entry.DeviceID != 0 {
+		fields["device_id"] = entry.DeviceID
+	}
+	if entry.ClientIP != "" {
+		fields["client_ip"] = entry.ClientIP
+	}
+	if entry.Reason != "" {
+		fields["reason"] = entry.Reason
+	}
+	for k, v := range entry.Details {
+		fields[k] = v
+	}
+
+	switch event {
+	case AuditLoginLocked, AuditLoginBlocked, AuditRefreshTokenReused, AuditDeviceDenied:
+		log.Warn("Auth audit event", fields)
+	default:
+		log.Info("Auth audit event", fields)
+	}
+
+	if persistErr != nil {
+		log.Error("Failed to persist auth audit event", applogger.Fields{
+			"event": event,
+			"error": persistErr.Error(),
+		})
+	}
+}
+
+// ListSecurityEvents 用户查看自己最近的安全事件，cursor为上一页最后一条事件的ID
+func (s *AuthService) ListSecurityEvents(userID uint, cursor string, limit int) ([]model.AuthEvent, string, error) {
+	return s.QueryAuthEvents(AuthEventQuery{UserID: userID, Cursor: cursor, Limit: limit})
+}
+
+// QueryAuthEvents 按用户、IP或时间范围查询安全事件 (管理接口)，按时间倒序
+func (s *AuthService) QueryAuthEvents(query AuthEventQuery) ([]model.AuthEvent, string, error) {
+	filter := repository.AuthEventFilter{
+		UserID: query.UserID,
+		IP:     query.IP,
+		Since:  query.Since,
+		Until:  query.Until,
+	}
+	if query.Cursor != "" {
+		beforeID, err := strconv.ParseUint(query.Cursor, 10, 64)
+		if err != nil {
+			return nil, "", errors.New("无效的游标")
+		}
+		filter.BeforeID = uint(beforeID)
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultAuthEventPageSize
+	}
+	if limit > maxAuthEventPageSize {
+		limit = maxAuthEventPageSize
+	}
+
+	events, err := s.authEventRepo.List(filter, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(events) == limit {
+		nextCursor = strconv.FormatUint(uint64(events[len(events)-1].ID), 10)
+	}
+	return events, nextCursor, nil
+}
+
+// PruneAuthEvents 删除超过保留期的安全事件，返回删除条数
+func (s *AuthService) PruneAuthEvents(now time.Time) (int64, error) {
+	before := now.Add(-s.auditRetention)
+	var total int64
+	for {
+		deleted, err := s.authEventRepo.DeleteBefore(before, auditPruneBatch)
+		total += deleted
+		if err != nil {
+			return total, err
+		}
+		if deleted < auditPruneBatch {
+			return total, nil
+		}
+	}
+}
+
+// RunAuditPruner 定期清理过期的安全事件，直到ctx取消
+func (s *AuthService) RunAuditPruner(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pruned, err := s.PruneAuthEvents(time.Now())
+			log := applogger.GetDefault()
+			if log == nil {
+				continue
+			}
+			if err != nil {
+				log.Error("Failed to prune auth events", applogger.Fields{"error": err.Error()})
+			} else if pruned > 0 {
+				log.Info("Expired auth events pruned", applogger.Fields{"count": pruned})
+			}
+		}
+	}
+}
+
+// truncate 按字符截断字符串
+func truncate(value string, max int) string {
+	runes := []rune(value)
+	if len(runes) <= max {
+		return value
+	}
+	return string(runes[:max])
+}
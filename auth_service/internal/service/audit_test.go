@@ -0,0 +1,119 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+func TestAuthService_AuditEvents(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour), WithAuditRetention(24*time.Hour))
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+		ClientIP:    "10.0.0.1",
+		UserAgent:   "TelegramLite-iOS/1.0",
+	})
+	require.NoError(t, err)
+	userID := registered.User.ID
+
+	_, err = authService.Login(&LoginRequest{Username: "alice", Password: "wrong-password", DeviceToken: "laptop-1", DeviceType: "web", ClientIP: "10.0.0.2"})
+	require.ErrorIs(t, err, errInvalidCredentials)
+	_, err = authService.Login(&LoginRequest{Username: "nobody", Password: "password123", DeviceToken: "laptop-1", DeviceType: "web", ClientIP: "10.0.0.2"})
+	require.ErrorIs(t, err, errInvalidCredentials)
+
+	login, err := authService.Login(&LoginRequest{Username: "alice", Password: "password123", DeviceToken: "laptop-1", DeviceType: "web", ClientIP: "10.0.0.2", UserAgent: "Mozilla/5.0"})
+	require.NoError(t, err)
+
+	_, err = authService.RefreshToken(login.Token.RefreshToken, "10.0.0.2", "Mozilla/5.0")
+	require.NoError(t, err)
+
+	claims, err := authService.ParseToken(login.Token.AccessToken)
+	require.NoError(t, err)
+	require.NoError(t, authService.Logout(claims, "10.0.0.2", "Mozilla/5.0"))
+
+	// 用户只能看到自己的事件，按时间倒序
+	events, cursor, err := authService.ListSecurityEvents(userID, "", 0)
+	require.NoError(t, err)
+	assert.Empty(t, cursor)
+
+	var types []string
+	for _, event := range events {
+		assert.Equal(t, userID, event.UserID)
+		types = append(types, event.Event)
+	}
+	assert.Equal(t, []string{
+		AuditLogout,
+		AuditTokenRefreshed,
+		AuditLogin,
+		AuditNewDevice,
+		AuditLoginFailed,
+		AuditRegistered,
+	}, types)
+
+	failed := events[4]
+	assert.Equal(t, model.AuthEventFailure, failed.Outcome)
+	assert.Equal(t, "密码错误", failed.Reason)
+	assert.Equal(t, "10.0.0.2", failed.IP)
+
+	newDevice := events[3]
+	assert.Equal(t, model.AuthEventSuccess, newDevice.Outcome)
+	assert.Equal(t, login.Device.ID, newDevice.DeviceID)
+	assert.Equal(t, "Mozilla/5.0", newDevice.UserAgent)
+	assert.Contains(t, newDevice.Details, `"device_type":"web"`)
+
+	// 分页
+	page, cursor, err := authService.ListSecurityEvents(userID, "", 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.NotEmpty(t, cursor)
+	page, _, err = authService.ListSecurityEvents(userID, cursor, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, AuditLogin, page[0].Event)
+
+	_, _, err = authService.ListSecurityEvents(userID, "invalid", 2)
+	assert.Error(t, err)
+
+	// 管理员按IP查询，包含不存在账号的失败登录
+	byIP, _, err := authService.QueryAuthEvents(AuthEventQuery{IP: "10.0.0.2"})
+	require.NoError(t, err)
+	assert.Len(t, byIP, 6)
+
+	var unknown []model.AuthEvent
+	for _, event := range byIP {
+		if event.UserID == 0 {
+			unknown = append(unknown, event)
+		}
+	}
+	require.Len(t, unknown, 1)
+	assert.Equal(t, "账号不存在", unknown[0].Reason)
+
+	byTime, _, err := authService.QueryAuthEvents(AuthEventQuery{UserID: userID, Until: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	assert.Empty(t, byTime)
+
+	// 超过保留期的事件被清理
+	require.NoError(t, repository.GetDB().Model(&model.AuthEvent{}).
+		Where("event = ?", AuditRegistered).
+		Update("created_at", time.Now().Add(-48*time.Hour)).Error)
+
+	pruned, err := authService.PruneAuthEvents(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), pruned)
+
+	events, _, err = authService.ListSecurityEvents(userID, "", 0)
+	require.NoError(t, err)
+	assert.Len(t, events, 5)
+}
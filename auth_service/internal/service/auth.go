@@ -1,30 +1,129 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"strings"
+	"sync"
+	"time"
 
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
 	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
 	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/sender"
 	"github.com/jacl-coder/telegramlite/auth_service/pkg"
 )
 
 // AuthService 认证服务
 type AuthService struct {
-	userRepo        *repository.UserRepository
-	deviceRepo      *repository.DeviceRepository
-	jwtManager      *pkg.JWTManager
-	passwordManager *pkg.PasswordManager
+	userRepo           *repository.UserRepository
+	deviceRepo         *repository.DeviceRepository
+	refreshTokenRepo   *repository.RefreshTokenRepository
+	revocationRepo     *repository.TokenRevocationRepository  // 未配置Redis时为nil
+	codeRepo           *repository.VerificationCodeRepository // 未配置Redis时为nil
+	twoFactorRepo      *repository.TwoFactorRepository
+	passwordResetRepo  *repository.PasswordResetRepository
+	accountRepo        *repository.AccountRepository
+	loginHistoryRepo   *repository.LoginHistoryRepository
+	authEventRepo      *repository.AuthEventRepository
+	deviceApprovalRepo *repository.DeviceApprovalRepository
+	oauthRepo          *repository.OAuthRepository
+	botRepo            *repository.BotRepository
+	patRepo            *repository.PersonalAccessTokenRepository
+	phoneChangeRepo    *repository.PhoneChangeRepository
+	notificationRepo   *repository.NotificationRepository
+	migrationRepo      *repository.MigrationRepository
+	challengeRepo      *repository.ChallengeAttemptRepository // 未配置Redis时为nil
+	loginAttemptRepo   *repository.LoginAttemptRepository     // 未配置Redis时为nil, 此时不限制登录尝试
+	loginTokenRepo     *repository.LoginTokenRepository       // 未配置Redis时为nil, 此时不支持扫码登录
+	botRateRepo        *repository.BotRateLimitRepository     // 未配置Redis时为nil, 此时不限制机器人请求频率
+	presenceRepo       *repository.DevicePresenceRepository   // 未配置Redis时为nil, 此时心跳直接写入数据库
+	jwtManager         *pkg.JWTManager
+	passwordManager    *pkg.PasswordManager
+	passwordPolicy     *pkg.PasswordPolicy
+	defaultRegion      string // 解析国内格式手机号的默认地区
+	smsSender          sender.Sender
+	emailSender        sender.Sender
+	codePolicy         CodePolicy
+	totpIssuer         string // 验证器应用中显示的服务名
+	loginPolicy        LoginProtectionPolicy
+	passwordResetTTL   time.Duration
+	passwordResetURL   string // 重置链接模板, {token}替换为重置token
+
+	deletionGracePeriod time.Duration // 申请删除到清除账号数据的宽限期
+	auditRetention      time.Duration // 安全事件保留时长
+	deviceApproval      DeviceApprovalPolicy
+	oauthPolicy         OAuthPolicy
+	botPolicy           BotPolicy
+	patPolicy           PersonalAccessTokenPolicy
+	usernamePolicy      UsernamePolicy
+	devicePolicy        DevicePolicy
+
+	background sync.WaitGroup // 后台发送密码重置消息等异步任务
 }
 
+// maxRevocationPageSize 单次同步吊销事件的最大条数
+const maxRevocationPageSize = 1000
+
 // NewAuthService 创建认证服务
-func NewAuthService(jwtManager *pkg.JWTManager) *AuthService {
-	return &AuthService{
-		userRepo:        repository.NewUserRepository(),
-		deviceRepo:      repository.NewDeviceRepository(),
-		jwtManager:      jwtManager,
-		passwordManager: pkg.NewPasswordManager(),
-	}
+func NewAuthService(jwtManager *pkg.JWTManager, opts ...Option) *AuthService {
+	service := &AuthService{
+		userRepo:           repository.NewUserRepository(),
+		deviceRepo:         repository.NewDeviceRepository(),
+		refreshTokenRepo:   repository.NewRefreshTokenRepository(),
+		twoFactorRepo:      repository.NewTwoFactorRepository(),
+		passwordResetRepo:  repository.NewPasswordResetRepository(),
+		accountRepo:        repository.NewAccountRepository(),
+		loginHistoryRepo:   repository.NewLoginHistoryRepository(),
+		authEventRepo:      repository.NewAuthEventRepository(),
+		deviceApprovalRepo: repository.NewDeviceApprovalRepository(),
+		oauthRepo:          repository.NewOAuthRepository(),
+		botRepo:            repository.NewBotRepository(),
+		patRepo:            repository.NewPersonalAccessTokenRepository(),
+		phoneChangeRepo:    repository.NewPhoneChangeRepository(),
+		notificationRepo:   repository.NewNotificationRepository(),
+		migrationRepo:      repository.NewMigrationRepository(),
+		jwtManager:         jwtManager,
+		passwordManager:    pkg.NewPasswordManager(),
+		passwordPolicy:     pkg.DefaultPasswordPolicy(),
+		defaultRegion:      defaultPhoneRegion,
+		smsSender:          sender.NewDisabledSender(),
+		emailSender:        sender.NewDisabledSender(),
+		codePolicy:         defaultCodePolicy(),
+		totpIssuer:         defaultTOTPIssuer,
+		loginPolicy:        defaultLoginProtectionPolicy(),
+		passwordResetTTL:   defaultPasswordResetTTL,
+
+		deletionGracePeriod: defaultDeletionGracePeriod,
+		auditRetention:      defaultAuditRetention,
+		deviceApproval:      DeviceApprovalPolicy{Timeout: defaultDeviceApprovalTimeout},
+		oauthPolicy:         defaultOAuthPolicy(),
+		botPolicy:           defaultBotPolicy(),
+		patPolicy:           defaultPersonalAccessTokenPolicy(),
+		usernamePolicy:      defaultUsernamePolicy(),
+		devicePolicy:        defaultDevicePolicy(),
+	}
+
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	if redisClient := repository.GetRedis(); redisClient != nil {
+		service.revocationRepo = repository.NewTokenRevocationRepository(redisClient, jwtManager.TokenDuration())
+		service.codeRepo = repository.NewVerificationCodeRepository(redisClient)
+		service.challengeRepo = repository.NewChallengeAttemptRepository(redisClient)
+		service.loginAttemptRepo = repository.NewLoginAttemptRepository(redisClient)
+		service.loginTokenRepo = repository.NewLoginTokenRepository(redisClient)
+		service.botRateRepo = repository.NewBotRateLimitRepository(redisClient)
+		service.presenceRepo = repository.NewDevicePresenceRepository(redisClient)
+	}
+
+	return service
+}
+
+// WaitBackground 等待后台异步任务完成，服务关闭前调用
+func (s *AuthService) WaitBackground() {
+	s.background.Wait()
 }
 
 // RegisterRequest 注册请求
@@ -36,16 +135,21 @@ type RegisterRequest struct {
 	DeviceToken string `json:"device_token" binding:"required"`
 	DeviceType  string `json:"device_type" binding:"required"`
 	DeviceName  string `json:"device_name"`
+	ClientIP    string `json:"-"`
+	UserAgent   string `json:"-"`
 }
 
 // LoginRequest 登录请求
 type LoginRequest struct {
 	Phone       string `json:"phone"`
 	Email       string `json:"email"`
+	Username    string `json:"username"`
 	Password    string `json:"password" binding:"required"`
 	DeviceToken string `json:"device_token" binding:"required"`
 	DeviceType  string `json:"device_type" binding:"required"`
 	DeviceName  string `json:"device_name"`
+	ClientIP    string `json:"-"`
+	UserAgent   string `json:"-"`
 }
 
 // AuthResponse 认证响应
@@ -53,6 +157,12 @@ type AuthResponse struct {
 	User   *model.User        `json:"user"`
 	Device *model.Device      `json:"device"`
 	Token  *pkg.TokenResponse `json:"token"`
+
+	IsNewUser bool                `json:"is_new_user,omitempty"` // 验证码登录时自动注册了新账号
+	TwoFactor *TwoFactorChallenge `json:"two_factor,omitempty"`  // 需要两步验证时返回, 此时Device和Token为空
+
+	DeviceApproval *DeviceApprovalChallenge `json:"device_approval,omitempty"` // 新设备等待审批时返回, 此时Device和Token为空
+	LoginToken     *LoginTokenResponse      `json:"login_token,omitempty"`     // 扫码登录尚未确认时返回, 此时其他字段为空
 }
 
 // Register 用户注册
@@ -62,15 +172,28 @@ func (s *AuthService) Register(req *RegisterRequest) (*AuthResponse, error) {
 		return nil, errors.New("手机号或邮箱必须提供一个")
 	}
 
-	if !s.passwordManager.IsValidPassword(req.Password) {
-		return nil, errors.New("密码长度至少6位")
+	if err := s.checkPassword(nil, req.Password); err != nil {
+		return nil, err
 	}
 
 	if !isValidDeviceType(req.DeviceType) {
 		return nil, errors.New("无效的设备类型")
 	}
 
+	// 规范化手机号和邮箱
+	phone, err := s.normalizePhone(req.Phone)
+	if err != nil {
+		return nil, err
+	}
+	req.Phone = phone
+	req.Email = pkg.NormalizeEmail(req.Email)
+	req.Username = pkg.NormalizeUsername(req.Username)
+
 	// 检查用户是否已存在
+	if err := s.checkNewUsername(req.Username, false); err != nil {
+		return nil, err
+	}
+
 	if req.Phone != "" {
 		existingUser, err := s.userRepo.GetUserByPhone(req.Phone)
 		if err != nil {
@@ -104,11 +227,15 @@ func (s *AuthService) Register(req *RegisterRequest) (*AuthResponse, error) {
 		Username:     req.Username,
 		PasswordHash: hashedPassword,
 		IsActive:     true,
+		AccountType:  pkg.AccountTypeUser,
 	}
 
 	if err := s.userRepo.CreateUser(user); err != nil {
 		return nil, err
 	}
+	if err := s.userRepo.AddPasswordHistory(user.ID, hashedPassword, s.passwordPolicy.HistorySize); err != nil {
+		return nil, err
+	}
 
 	// 创建设备
 	device := &model.Device{
@@ -124,11 +251,19 @@ func (s *AuthService) Register(req *RegisterRequest) (*AuthResponse, error) {
 	}
 
 	// 生成token
-	tokenResponse, err := s.jwtManager.GenerateTokenPair(user.ID, device.ID, device.DeviceToken)
+	tokenResponse, err := s.issueTokens(user.ID, device, req.ClientIP)
 	if err != nil {
 		return nil, err
 	}
 
+	s.audit(AuditRegistered, auditEntry{
+		UserID:    user.ID,
+		DeviceID:  device.ID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+		Details:   applogger.Fields{"method": "password"},
+	})
+
 	// 隐藏密码
 	user.PasswordHash = ""
 
@@ -142,8 +277,8 @@ func (s *AuthService) Register(req *RegisterRequest) (*AuthResponse, error) {
 // Login 用户登录
 func (s *AuthService) Login(req *LoginRequest) (*AuthResponse, error) {
 	// 验证输入
-	if req.Phone == "" && req.Email == "" {
-		return nil, errors.New("手机号或邮箱必须提供一个")
+	if req.Phone == "" && req.Email == "" && req.Username == "" {
+		return nil, errors.New("手机号、邮箱或用户名必须提供一个")
 	}
 
 	if !isValidDeviceType(req.DeviceType) {
@@ -151,26 +286,51 @@ func (s *AuthService) Login(req *LoginRequest) (*AuthResponse, error) {
 	}
 
 	// 获取用户
-	var user *model.User
-	var err error
-
-	if req.Phone != "" {
-		user, err = s.userRepo.GetUserByPhone(req.Phone)
-	} else {
-		user, err = s.userRepo.GetUserByEmail(req.Email)
+	user, err := s.resolveUser(req.Phone, req.Email, req.Username)
+	if err != nil {
+		return nil, err
 	}
 
-	if err != nil {
+	// 检查账号、IP、设备是否处于锁定期
+	subjects := s.loginSubjects(user, req)
+	var userID uint
+	if user != nil {
+		userID = user.ID
+	}
+	if err := s.checkLoginLock(userID, subjects, req); err != nil {
 		return nil, err
 	}
 
+	// 验证密码，账号不存在和密码错误返回相同的错误
 	if user == nil {
-		return nil, errors.New("用户不存在")
+		s.verifyDummyPassword(req.Password)
+		s.recordLoginFailure(0, subjects, req, "账号不存在")
+		return nil, errInvalidCredentials
 	}
-
-	// 验证密码
 	if err := s.passwordManager.VerifyPassword(user.PasswordHash, req.Password); err != nil {
-		return nil, errors.New("密码错误")
+		s.recordLoginFailure(user.ID, subjects, req, "密码错误")
+		return nil, errInvalidCredentials
+	}
+
+	s.resetLoginFailures(subjects)
+	s.rehashPassword(user, req.Password)
+
+	// 没有有效会话的设备需已登录的设备批准
+	required, err := s.requiresDeviceApproval(user, req)
+	if err != nil {
+		return nil, err
+	}
+	if required {
+		return s.requestDeviceApproval(user, req)
+	}
+	return s.beginLogin(user, req)
+}
+
+// completeLogin 凭证验证通过后绑定设备并签发token
+func (s *AuthService) completeLogin(user *model.User, req *LoginRequest) (*AuthResponse, error) {
+	// 停用或等待清除的账号重新登录后恢复
+	if err := s.restoreAccount(user, req); err != nil {
+		return nil, err
 	}
 
 	// 检查或创建设备
@@ -191,6 +351,16 @@ func (s *AuthService) Login(req *LoginRequest) (*AuthResponse, error) {
 		if err := s.deviceRepo.CreateDevice(device); err != nil {
 			return nil, err
 		}
+		s.audit(AuditNewDevice, auditEntry{
+			UserID:    user.ID,
+			DeviceID:  device.ID,
+			ClientIP:  req.ClientIP,
+			UserAgent: req.UserAgent,
+			Details: applogger.Fields{
+				"device_type": device.DeviceType,
+				"device_name": device.DeviceName,
+			},
+		})
 	} else {
 		// 验证设备是否属于该用户
 		if device.UserID != user.ID {
@@ -207,12 +377,31 @@ func (s *AuthService) Login(req *LoginRequest) (*AuthResponse, error) {
 		return nil, err
 	}
 
+	// 记录登录历史
+	err = s.loginHistoryRepo.Create(&model.LoginHistory{
+		UserID:     user.ID,
+		DeviceID:   device.ID,
+		DeviceType: device.DeviceType,
+		DeviceName: device.DeviceName,
+		IP:         req.ClientIP,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	// 生成token
-	tokenResponse, err := s.jwtManager.GenerateTokenPair(user.ID, device.ID, device.DeviceToken)
+	tokenResponse, err := s.issueTokens(user.ID, device, req.ClientIP)
 	if err != nil {
 		return nil, err
 	}
 
+	s.audit(AuditLogin, auditEntry{
+		UserID:    user.ID,
+		DeviceID:  device.ID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+	})
+
 	// 隐藏密码
 	user.PasswordHash = ""
 
@@ -224,7 +413,9 @@ func (s *AuthService) Login(req *LoginRequest) (*AuthResponse, error) {
 }
 
 // RefreshToken 刷新token
-func (s *AuthService) RefreshToken(refreshToken string) (*pkg.TokenResponse, error) {
+// 每个刷新token只能使用一次，使用后轮换为同一令牌族的新token；
+// 已使用过的token再次出现说明可能被盗用，此时吊销整个令牌族并登出该设备
+func (s *AuthService) RefreshToken(refreshToken, clientIP, userAgent string) (*pkg.TokenResponse, error) {
 	// 输入验证
 	if refreshToken == "" {
 		return nil, errors.New("刷新token不能为空")
@@ -236,25 +427,187 @@ func (s *AuthService) RefreshToken(refreshToken string) (*pkg.TokenResponse, err
 		return nil, errors.New("无效的刷新token")
 	}
 
+	// 查找服务端记录
+	record, err := s.refreshTokenRepo.GetRefreshTokenByTokenID(claims.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if record == nil || record.FamilyID != claims.FamilyID || record.DeviceID != claims.DeviceID {
+		return nil, errors.New("无效的刷新token")
+	}
+
+	if record.RevokedAt != nil {
+		return nil, errors.New("刷新token已失效")
+	}
+
+	if record.UsedAt != nil {
+		return nil, s.handleRefreshTokenReuse(record, clientIP, userAgent)
+	}
+
 	// 获取设备信息
-	device, err := s.deviceRepo.GetDeviceByToken("")
+	device, err := s.deviceRepo.GetDeviceByID(claims.DeviceID)
 	if err != nil {
 		return nil, err
 	}
 
-	if device == nil || device.ID != claims.DeviceID {
+	if device == nil || device.UserID != claims.UserID {
 		return nil, errors.New("设备不存在")
 	}
 
-	// 生成新的token对
-	return s.jwtManager.GenerateTokenPair(claims.UserID, claims.DeviceID, device.DeviceToken)
+	// 生成新的token对并轮换
+	tokenResponse, err := s.jwtManager.GenerateTokenPair(claims.UserID, device.ID, device.DeviceToken, record.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	rotated, err := s.refreshTokenRepo.RotateRefreshToken(record.ID, newRefreshTokenRecord(tokenResponse.RefreshClaims))
+	if err != nil {
+		return nil, err
+	}
+
+	if !rotated {
+		// 并发请求抢先使用了同一个token
+		return nil, s.handleRefreshTokenReuse(record, clientIP, userAgent)
+	}
+
+	if err := s.deviceRepo.TouchDevice(device.ID, clientIP); err != nil {
+		return nil, err
+	}
+
+	s.audit(AuditTokenRefreshed, auditEntry{
+		UserID:    claims.UserID,
+		DeviceID:  device.ID,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+	})
+	return tokenResponse, nil
 }
 
-// Logout 登出
-func (s *AuthService) Logout(deviceID uint) error {
+// Logout 登出当前token所属设备
+func (s *AuthService) Logout(claims *pkg.Claims, clientIP, userAgent string) error {
+	if err := s.RevokeAccessToken(claims); err != nil {
+		return err
+	}
+	if err := s.logoutDevice(claims.DeviceID); err != nil {
+		return err
+	}
+
+	s.audit(AuditLogout, auditEntry{
+		UserID:    claims.UserID,
+		DeviceID:  claims.DeviceID,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+	})
+	return nil
+}
+
+// logoutDevice 吊销设备的所有token并标记离线
+func (s *AuthService) logoutDevice(deviceID uint) error {
+	if err := s.RevokeDeviceTokens(deviceID); err != nil {
+		return err
+	}
 	return s.deviceRepo.UpdateDeviceOnlineStatus(deviceID, false)
 }
 
+// RevokeAccessToken 吊销单个访问token
+func (s *AuthService) RevokeAccessToken(claims *pkg.Claims) error {
+	if s.revocationRepo == nil || claims.ID == "" {
+		return nil
+	}
+	return s.revocationRepo.RevokeToken(context.Background(), claims.ID, time.Until(claims.ExpiresAt.Time))
+}
+
+// RevokeTokenByID 按jti吊销访问token (无法得知过期时间, 按最长有效期保留)
+func (s *AuthService) RevokeTokenByID(tokenID string) error {
+	if tokenID == "" {
+		return errors.New("token ID不能为空")
+	}
+	if s.revocationRepo == nil {
+		return errors.New("token吊销服务不可用")
+	}
+	return s.revocationRepo.RevokeToken(context.Background(), tokenID, s.jwtManager.TokenDuration())
+}
+
+// RevokeDeviceTokens 吊销设备的所有访问token和刷新token
+func (s *AuthService) RevokeDeviceTokens(deviceID uint) error {
+	if s.revocationRepo != nil {
+		err := s.revocationRepo.RevokeDevice(context.Background(), deviceID, time.Now(), s.jwtManager.TokenDuration())
+		if err != nil {
+			return err
+		}
+	}
+	return s.refreshTokenRepo.RevokeDeviceTokens(deviceID)
+}
+
+// RevokeUserTokens 吊销用户所有设备的访问token和刷新token (修改密码、封禁等场景)
+func (s *AuthService) RevokeUserTokens(userID uint) error {
+	if s.revocationRepo != nil {
+		err := s.revocationRepo.RevokeUser(context.Background(), userID, time.Now(), s.jwtManager.TokenDuration())
+		if err != nil {
+			return err
+		}
+	}
+	return s.refreshTokenRepo.RevokeUserTokens(userID)
+}
+
+// issueTokens 为设备签发新的令牌族，该设备此前的刷新token全部失效
+func (s *AuthService) issueTokens(userID uint, device *model.Device, clientIP string) (*pkg.TokenResponse, error) {
+	if err := s.refreshTokenRepo.RevokeDeviceTokens(device.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.deviceRepo.TouchDevice(device.ID, clientIP); err != nil {
+		return nil, err
+	}
+
+	familyID, err := pkg.NewTokenID()
+	if err != nil {
+		return nil, err
+	}
+	tokenResponse, err := s.jwtManager.GenerateTokenPair(userID, device.ID, device.DeviceToken, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.refreshTokenRepo.CreateRefreshToken(newRefreshTokenRecord(tokenResponse.RefreshClaims)); err != nil {
+		return nil, err
+	}
+
+	return tokenResponse, nil
+}
+
+// handleRefreshTokenReuse 处理刷新token重用：吊销令牌族并登出设备
+func (s *AuthService) handleRefreshTokenReuse(record *model.RefreshToken, clientIP, userAgent string) error {
+	s.audit(AuditRefreshTokenReused, auditEntry{
+		UserID:    record.UserID,
+		DeviceID:  record.DeviceID,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+		Reason:    "刷新token重复使用，已吊销令牌族",
+		Details:   applogger.Fields{"family_id": record.FamilyID},
+	})
+
+	if err := s.refreshTokenRepo.RevokeFamily(record.FamilyID); err != nil {
+		return err
+	}
+	if err := s.logoutDevice(record.DeviceID); err != nil {
+		return err
+	}
+	return errors.New("刷新token已被使用，请重新登录")
+}
+
+// newRefreshTokenRecord 根据刷新token声明构建服务端记录
+func newRefreshTokenRecord(claims *pkg.RefreshClaims) *model.RefreshToken {
+	return &model.RefreshToken{
+		TokenID:   claims.ID,
+		FamilyID:  claims.FamilyID,
+		UserID:    claims.UserID,
+		DeviceID:  claims.DeviceID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}
+}
+
 // isValidDeviceType 验证设备类型
 func isValidDeviceType(deviceType string) bool {
 	deviceType = strings.ToLower(deviceType)
@@ -267,7 +620,7 @@ func isValidDeviceType(deviceType string) bool {
 }
 
 // LogoutByDeviceToken 根据设备Token注销
-func (s *AuthService) LogoutByDeviceToken(deviceToken string) error {
+func (s *AuthService) LogoutByDeviceToken(deviceToken, clientIP, userAgent string) error {
 	device, err := s.deviceRepo.GetDeviceByToken(deviceToken)
 	if err != nil {
 		return err
@@ -275,18 +628,60 @@ func (s *AuthService) LogoutByDeviceToken(deviceToken string) error {
 	if device == nil {
 		return errors.New("设备不存在")
 	}
-	return s.deviceRepo.UpdateDeviceOnlineStatus(device.ID, false)
+	if err := s.logoutDevice(device.ID); err != nil {
+		return err
+	}
+
+	s.audit(AuditLogout, auditEntry{
+		UserID:    device.UserID,
+		DeviceID:  device.ID,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+	})
+	return nil
 }
 
-// ParseToken 解析Token并验证
+// ParseToken 解析Token并验证，已吊销的token视为无效
 func (s *AuthService) ParseToken(tokenString string) (*pkg.Claims, error) {
-	return s.jwtManager.VerifyToken(tokenString)
+	claims, err := s.jwtManager.VerifyToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.revocationRepo != nil {
+		revoked, err := s.revocationRepo.IsRevoked(context.Background(), claims.ID, claims.UserID, claims.DeviceID, claims.IssuedAtTime())
+		if err != nil {
+			// 无法确认吊销状态时拒绝
+			return nil, err
+		}
+		if revoked {
+			return nil, errors.New("token已被吊销")
+		}
+	}
+
+	return claims, nil
+}
+
+// ListRevocations 获取游标之后的token吊销事件
+func (s *AuthService) ListRevocations(cursor string, limit int) ([]*model.RevocationEvent, error) {
+	if s.revocationRepo == nil {
+		return nil, errors.New("token吊销服务不可用")
+	}
+	if limit <= 0 || limit > maxRevocationPageSize {
+		limit = maxRevocationPageSize
+	}
+	return s.revocationRepo.ListRevocations(context.Background(), cursor, int64(limit))
+}
+
+// GetSigningKeys 获取当前有效的签名公钥
+func (s *AuthService) GetSigningKeys() []*pkg.SigningKey {
+	return s.jwtManager.SigningKeys()
 }
 
 // GetUserByToken 通过Token获取用户信息
 func (s *AuthService) GetUserByToken(tokenString string) (*model.User, error) {
 	// 解析token
-	claims, err := s.jwtManager.VerifyToken(tokenString)
+	claims, err := s.ParseToken(tokenString)
 	if err != nil {
 		return nil, errors.New("无效的token")
 	}
@@ -2,9 +2,11 @@ package service
 
 import (
 	"testing"
+	"time"
 
 	"github.com/jacl-coder/telegramlite/auth_service/pkg"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAuthService_Register(t *testing.T) {
@@ -87,7 +89,7 @@ func TestAuthService_Login(t *testing.T) {
 		errMsg  string
 	}{
 		{
-			name: "missing phone and email",
+			name: "missing phone, email and username",
 			req: &LoginRequest{
 				Phone:       "",
 				Email:       "",
@@ -96,7 +98,7 @@ func TestAuthService_Login(t *testing.T) {
 				DeviceType:  "ios",
 			},
 			wantErr: true,
-			errMsg:  "手机号或邮箱必须提供一个",
+			errMsg:  "手机号、邮箱或用户名必须提供一个",
 		},
 		{
 			name: "invalid device type",
@@ -150,7 +152,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := authService.RefreshToken(tt.refreshToken)
+			result, err := authService.RefreshToken(tt.refreshToken, "", "")
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -161,6 +163,59 @@ func TestAuthService_RefreshToken(t *testing.T) {
 	}
 }
 
+func TestAuthService_RefreshTokenRotation(t *testing.T) {
+	setupTestDB(t)
+	setupTestRedis(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour))
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+
+	// 每次刷新都签发新的刷新token，同一令牌族
+	first, err := authService.RefreshToken(registered.Token.RefreshToken, "10.0.0.1", "")
+	require.NoError(t, err)
+	assert.NotEqual(t, registered.Token.RefreshToken, first.RefreshToken)
+	assert.Equal(t, registered.Token.RefreshClaims.FamilyID, first.RefreshClaims.FamilyID)
+
+	second, err := authService.RefreshToken(first.RefreshToken, "10.0.0.1", "")
+	require.NoError(t, err)
+	_, err = authService.ParseToken(second.AccessToken)
+	require.NoError(t, err)
+
+	// 已轮换的token再次出现时吊销整个令牌族并登出设备
+	_, err = authService.RefreshToken(first.RefreshToken, "10.0.0.2", "")
+	assert.EqualError(t, err, "刷新token已被使用，请重新登录")
+
+	_, err = authService.RefreshToken(second.RefreshToken, "10.0.0.1", "")
+	assert.EqualError(t, err, "刷新token已失效")
+	_, err = authService.ParseToken(second.AccessToken)
+	assert.Error(t, err)
+
+	events, _, err := authService.ListSecurityEvents(registered.User.ID, "", 20)
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+	assert.Equal(t, AuditRefreshTokenReused, events[0].Event)
+	assert.Equal(t, "10.0.0.2", events[0].IP)
+
+	// 重新登录后开始新的令牌族
+	login, err := authService.Login(&LoginRequest{
+		Phone:       "+8613800000000",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, registered.Token.RefreshClaims.FamilyID, login.Token.RefreshClaims.FamilyID)
+	_, err = authService.RefreshToken(login.Token.RefreshToken, "", "")
+	require.NoError(t, err)
+}
+
 func TestAuthService_GetUserInfo(t *testing.T) {
 	jwtManager := pkg.NewJWTManager("test-secret", 3600, 7*24*3600)
 	authService := NewAuthService(jwtManager)
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+const (
+	botTokenHintLength = 4
+	// maxBotTokens 每个机器人同时有效的token数，轮换时先创建新token再吊销旧token
+	maxBotTokens = 5
+)
+
+// botUsernamePattern 机器人用户名: 字母开头，只含字母、数字和下划线，以bot结尾 (不区分大小写)
+var botUsernamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{1,28}[bB][oO][tT]$`)
+
+// ErrBotRateLimited 机器人请求超过频率限制
+var ErrBotRateLimited = errors.New("机器人请求过于频繁，请稍后再试")
+
+var errInvalidBotToken = errors.New("机器人token无效")
+
+// BotPolicy 机器人策略
+type BotPolicy struct {
+	MaxPerOwner int           // 每个用户最多创建的机器人数
+	RateLimit   int           // 每个机器人在RateWindow内允许的请求数
+	RateWindow  time.Duration // 频率限制窗口
+}
+
+// defaultBotPolicy 默认机器人策略
+func defaultBotPolicy() BotPolicy {
+	return BotPolicy{
+		MaxPerOwner: 20,
+		RateLimit:   30,
+		RateWindow:  time.Second,
+	}
+}
+
+// CreateBotRequest 创建机器人请求
+type CreateBotRequest struct {
+	Username  string `json:"username" binding:"required"`
+	AvatarURL string `json:"avatar_url"`
+	ClientIP  string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// BotTokenResponse 新创建的机器人token，明文只在创建时返回一次
+type BotTokenResponse struct {
+	model.BotToken
+	Token string `json:"token"`
+}
+
+// CreateBotResponse 创建机器人响应
+type CreateBotResponse struct {
+	Bot   *model.User       `json:"bot"`
+	Token *BotTokenResponse `json:"token"`
+}
+
+// BotIdentity 机器人token验证结果
+type BotIdentity struct {
+	BotID     uint
+	OwnerID   uint
+	ExpiresAt time.Time // 机器人token长期有效，返回本次验证结果的缓存上限
+}
+
+// CreateBot 创建机器人账号并签发第一个token
+// 机器人没有密码、手机号和邮箱，不能通过登录流程获取访问token
+func (s *AuthService) CreateBot(ownerID uint, req *CreateBotRequest) (*CreateBotResponse, error) {
+	owner, err := s.userRepo.GetUserByID(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if owner == nil || owner.AccountType == pkg.AccountTypeBot {
+		return nil, errors.New("只有普通用户可以创建机器人")
+	}
+
+	username := pkg.NormalizeUsername(req.Username)
+	if !botUsernamePattern.MatchString(username) {
+		return nil, errors.New("机器人用户名须为5-32位字母、数字或下划线，以字母开头并以bot结尾")
+	}
+	if err := s.checkNewUsername(username, true); err != nil {
+		return nil, err
+	}
+
+	count, err := s.botRepo.CountBots(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= int64(s.botPolicy.MaxPerOwner) {
+		return nil, errors.New("创建的机器人数量已达上限")
+	}
+
+	bot := &model.User{
+		Username:    username,
+		AvatarURL:   strings.TrimSpace(req.AvatarURL),
+		IsActive:    true,
+		AccountType: pkg.AccountTypeBot,
+		OwnerID:     &ownerID,
+	}
+	if err := s.botRepo.CreateBot(bot); err != nil {
+		return nil, err
+	}
+
+	token, err := s.issueBotToken(bot.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(AuditBotCreated, auditEntry{
+		UserID:    ownerID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+		Details:   applogger.Fields{"bot_id": bot.ID, "username": bot.Username},
+	})
+
+	return &CreateBotResponse{Bot: bot, Token: token}, nil
+}
+
+// ListBots 获取用户创建的机器人
+func (s *AuthService) ListBots(ownerID uint) ([]model.User, error) {
+	return s.botRepo.ListBots(ownerID)
+}
+
+// DeleteBot 删除机器人，其token立即失效
+func (s *AuthService) DeleteBot(ownerID, botID uint, clientIP, userAgent string) error {
+	deleted, err := s.botRepo.DeleteBot(ownerID, botID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.New("机器人不存在")
+	}
+
+	s.audit(AuditBotDeleted, auditEntry{
+		UserID:    ownerID,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+		Details:   applogger.Fields{"bot_id": botID},
+	})
+	return nil
+}
+
+// CreateBotToken 为机器人签发新token
+func (s *AuthService) CreateBotToken(ownerID, botID uint, clientIP, userAgent string) (*BotTokenResponse, error) {
+	if _, err := s.ownedBot(ownerID, botID); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.botRepo.ListTokens(botID)
+	if err != nil {
+		return nil, err
+	}
+	active := 0
+	for _, token := range tokens {
+		if token.RevokedAt == nil {
+			active++
+		}
+	}
+	if active >= maxBotTokens {
+		return nil, errors.New("有效token数量已达上限，请先吊销不用的token")
+	}
+
+	token, err := s.issueBotToken(botID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(AuditBotTokenCreated, auditEntry{
+		UserID:    ownerID,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+		Details:   applogger.Fields{"bot_id": botID, "token_id": token.ID},
+	})
+	return token, nil
+}
+
+// ListBotTokens 获取机器人的token (不含明文)
+func (s *AuthService) ListBotTokens(ownerID, botID uint) ([]model.BotToken, error) {
+	if _, err := s.ownedBot(ownerID, botID); err != nil {
+		return nil, err
+	}
+	return s.botRepo.ListTokens(botID)
+}
+
+// RevokeBotToken 吊销机器人token
+func (s *AuthService) RevokeBotToken(ownerID, botID, tokenID uint, clientIP, userAgent string) error {
+	if _, err := s.ownedBot(ownerID, botID); err != nil {
+		return err
+	}
+
+	revoked, err := s.botRepo.RevokeToken(botID, tokenID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.New("token不存在或已吊销")
+	}
+
+	s.audit(AuditBotTokenRevoked, auditEntry{
+		UserID:    ownerID,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+		Details:   applogger.Fields{"bot_id": botID, "token_id": tokenID},
+	})
+	return nil
+}
+
+// VerifyBotToken 验证机器人token并计入机器人的请求频率
+// 机器人或其创建者的账号被停用时token失效
+func (s *AuthService) VerifyBotToken(token string) (*BotIdentity, error) {
+	botID, ok := pkg.ParseBotToken(token)
+	if !ok {
+		return nil, errInvalidBotToken
+	}
+
+	record, err := s.botRepo.GetActiveToken(hashCode(token))
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record.BotID != botID {
+		return nil, errInvalidBotToken
+	}
+
+	bot, err := s.userRepo.GetUserByID(botID)
+	if err != nil {
+		return nil, err
+	}
+	if bot == nil || bot.AccountType != pkg.AccountTypeBot || bot.OwnerID == nil {
+		return nil, errInvalidBotToken
+	}
+	owner, err := s.userRepo.GetUserByID(*bot.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	if owner == nil {
+		return nil, errInvalidBotToken
+	}
+
+	now := time.Now()
+	if err := s.checkBotRateLimit(botID, now); err != nil {
+		return nil, err
+	}
+	if err := s.botRepo.TouchToken(record, now); err != nil {
+		s.logTokenBookkeepingError(err)
+	}
+
+	return &BotIdentity{
+		BotID:     botID,
+		OwnerID:   owner.ID,
+		ExpiresAt: now.Add(s.jwtManager.TokenDuration()),
+	}, nil
+}
+
+// checkBotRateLimit 超过频率限制时拒绝
+// 未配置Redis时不限制 (服务启动时必须连接Redis，只有测试和嵌入使用时会出现)；
+// Redis不可用时无法计数，按超过限制拒绝，机器人稍后重试
+func (s *AuthService) checkBotRateLimit(botID uint, now time.Time) error {
+	if s.botRateRepo == nil {
+		return nil
+	}
+	count, err := s.botRateRepo.Hit(context.Background(), botID, s.botPolicy.RateWindow, now)
+	if err != nil {
+		if log := applogger.GetDefault(); log != nil {
+			log.Error("Failed to check bot rate limit", applogger.Fields{"bot_id": botID, "error": err.Error()})
+		}
+		return ErrBotRateLimited
+	}
+	if count > int64(s.botPolicy.RateLimit) {
+		return ErrBotRateLimited
+	}
+	return nil
+}
+
+// ownedBot 获取用户创建的机器人
+func (s *AuthService) ownedBot(ownerID, botID uint) (*model.User, error) {
+	bot, err := s.botRepo.GetBot(ownerID, botID)
+	if err != nil {
+		return nil, err
+	}
+	if bot == nil {
+		return nil, errors.New("机器人不存在")
+	}
+	return bot, nil
+}
+
+// issueBotToken 生成并保存机器人token (只保存哈希)
+func (s *AuthService) issueBotToken(botID uint) (*BotTokenResponse, error) {
+	value, err := pkg.NewBotToken(botID)
+	if err != nil {
+		return nil, err
+	}
+	record := &model.BotToken{
+		BotID:     botID,
+		TokenHash: hashCode(value),
+		Hint:      value[len(value)-botTokenHintLength:],
+	}
+	if err := s.botRepo.CreateToken(record); err != nil {
+		return nil, err
+	}
+	return &BotTokenResponse{BotToken: *record, Token: value}, nil
+}
+
+// logTokenBookkeepingError 记录机器人token和个人访问token使用记录的错误，不影响请求结果
+func (s *AuthService) logTokenBookkeepingError(err error) {
+	if log := applogger.GetDefault(); log != nil {
+		log.Warn("Token bookkeeping failed", applogger.Fields{"error": err.Error()})
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+func TestAuthService_Bots(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour), WithBots(BotPolicy{MaxPerOwner: 2}))
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	ownerID := registered.User.ID
+	assert.Equal(t, pkg.AccountTypeUser, registered.User.AccountType)
+
+	// 用户名须以bot结尾且未被占用
+	_, err = authService.CreateBot(ownerID, &CreateBotRequest{Username: "weather"})
+	assert.Error(t, err)
+	_, err = authService.CreateBot(ownerID, &CreateBotRequest{Username: "alice"})
+	assert.Error(t, err)
+
+	created, err := authService.CreateBot(ownerID, &CreateBotRequest{Username: "@weather_bot"})
+	require.NoError(t, err)
+	bot := created.Bot
+	assert.Equal(t, "weather_bot", bot.Username)
+	assert.Equal(t, pkg.AccountTypeBot, bot.AccountType)
+	require.NotNil(t, bot.OwnerID)
+	assert.Equal(t, ownerID, *bot.OwnerID)
+
+	// 多个机器人都没有手机号和邮箱
+	_, err = authService.CreateBot(ownerID, &CreateBotRequest{Username: "NewsBot"})
+	require.NoError(t, err)
+	_, err = authService.CreateBot(ownerID, &CreateBotRequest{Username: "third_bot"})
+	assert.Error(t, err, "超过每个用户的机器人数量上限")
+
+	// token只保存哈希
+	firstToken := created.Token.Token
+	var stored model.BotToken
+	require.NoError(t, repository.GetDB().First(&stored, created.Token.ID).Error)
+	assert.NotEqual(t, firstToken, stored.TokenHash)
+	assert.Equal(t, firstToken[len(firstToken)-4:], stored.Hint)
+
+	identity, err := authService.VerifyBotToken(firstToken)
+	require.NoError(t, err)
+	assert.Equal(t, bot.ID, identity.BotID)
+	assert.Equal(t, ownerID, identity.OwnerID)
+
+	// 机器人token不是JWT，不能用于用户接口；机器人也不能通过密码登录
+	_, err = authService.ParseToken(firstToken)
+	assert.Error(t, err)
+	_, err = authService.Login(&LoginRequest{Username: "weather_bot", Password: "", DeviceToken: "bot-device", DeviceType: "web"})
+	assert.ErrorIs(t, err, errInvalidCredentials)
+
+	// 轮换token
+	second, err := authService.CreateBotToken(ownerID, bot.ID, "", "")
+	require.NoError(t, err)
+	_, err = authService.CreateBotToken(ownerID+1, bot.ID, "", "")
+	assert.Error(t, err)
+
+	require.NoError(t, authService.RevokeBotToken(ownerID, bot.ID, created.Token.ID, "", ""))
+	_, err = authService.VerifyBotToken(firstToken)
+	assert.Error(t, err)
+	_, err = authService.VerifyBotToken(second.Token)
+	assert.NoError(t, err)
+
+	tokens, err := authService.ListBotTokens(ownerID, bot.ID)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	assert.NotNil(t, tokens[0].RevokedAt)
+
+	// 其他机器人的ID不能搭配该token
+	_, secret, _ := strings.Cut(second.Token, ":")
+	forged := "999:" + secret
+	_, err = authService.VerifyBotToken(forged)
+	assert.Error(t, err)
+
+	// 创建者停用账号后机器人token失效
+	require.NoError(t, authService.DeactivateAccount(ownerID, &AccountActionRequest{Password: "password123"}))
+	_, err = authService.VerifyBotToken(second.Token)
+	assert.Error(t, err)
+
+	// 删除机器人并记录清除事件
+	require.NoError(t, authService.DeleteBot(ownerID, bot.ID, "", ""))
+	assert.Error(t, authService.DeleteBot(ownerID, bot.ID, "", ""))
+
+	bots, err := authService.ListBots(ownerID)
+	require.NoError(t, err)
+	require.Len(t, bots, 1)
+	assert.Equal(t, "NewsBot", bots[0].Username)
+
+	purges, err := authService.ListAccountPurges("", 10)
+	require.NoError(t, err)
+	require.Len(t, purges, 1)
+	assert.Equal(t, bot.ID, purges[0].UserID)
+}
+
+func TestAuthService_BotRateLimit(t *testing.T) {
+	setupTestDB(t)
+	redisServer := setupTestRedis(t)
+	authService := NewAuthService(
+		pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithBots(BotPolicy{RateLimit: 2, RateWindow: time.Hour}),
+	)
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	created, err := authService.CreateBot(registered.User.ID, &CreateBotRequest{Username: "weather_bot"})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = authService.VerifyBotToken(created.Token.Token)
+		require.NoError(t, err)
+	}
+	_, err = authService.VerifyBotToken(created.Token.Token)
+	assert.ErrorIs(t, err, ErrBotRateLimited)
+
+	// Redis不可用时无法计数，拒绝而不是放行
+	redisServer.FlushAll()
+	redisServer.SetError("connection refused")
+	_, err = authService.VerifyBotToken(created.Token.Token)
+	assert.ErrorIs(t, err, ErrBotRateLimited)
+
+	redisServer.SetError("")
+	_, err = authService.VerifyBotToken(created.Token.Token)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,423 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+const (
+	codePurposeChangePhone = "change_phone"
+	codePurposeChangeEmail = "change_email"
+	phoneChangeTTL         = 15 * time.Minute // 等待其他设备确认的时长
+)
+
+var errInvalidPhoneChange = errors.New("更换请求不存在或已处理")
+
+// ChangePhoneRequest 更换手机号请求
+// 账号尚未绑定手机号时没有设备确认，需提供当前密码 (未设置密码的账号提供登录验证码current_code)
+type ChangePhoneRequest struct {
+	Phone       string `json:"phone" binding:"required"`
+	Code        string `json:"code" binding:"required"` // 新手机号收到的验证码
+	Password    string `json:"password"`
+	CurrentCode string `json:"current_code"`
+	ClientIP    string `json:"-"`
+	UserAgent   string `json:"-"`
+}
+
+// ChangeEmailRequest 更换邮箱请求，需提供当前密码 (未设置密码的账号提供登录验证码current_code)
+type ChangeEmailRequest struct {
+	Email       string `json:"email" binding:"required"`
+	Code        string `json:"code" binding:"required"` // 新邮箱收到的验证码
+	Password    string `json:"password"`
+	CurrentCode string `json:"current_code"`
+	ClientIP    string `json:"-"`
+	UserAgent   string `json:"-"`
+}
+
+// SendPhoneChangeCode 向新手机号发送验证码，新手机号不能已被其他账号使用
+func (s *AuthService) SendPhoneChangeCode(userID uint, phone string) (*SendCodeResponse, error) {
+	user, err := s.contactChangeUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.newPhoneTarget(user, phone)
+	if err != nil {
+		return nil, err
+	}
+	return s.sendContactChangeCode(changeCodePurpose(codePurposeChangePhone, userID), target)
+}
+
+// SendEmailChangeCode 向新邮箱发送验证码，新邮箱不能已被其他账号使用
+func (s *AuthService) SendEmailChangeCode(userID uint, email string) (*SendCodeResponse, error) {
+	user, err := s.contactChangeUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.newEmailTarget(user, email)
+	if err != nil {
+		return nil, err
+	}
+	return s.sendContactChangeCode(changeCodePurpose(codePurposeChangeEmail, userID), target)
+}
+
+// RequestPhoneChange 验证新手机号后发起更换，需由此时已登录的其他设备确认
+// 账号尚未绑定手机号时重新验证身份后直接绑定，无需确认
+func (s *AuthService) RequestPhoneChange(userID, deviceID uint, req *ChangePhoneRequest) (*model.PhoneChange, error) {
+	user, err := s.contactChangeUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.newPhoneTarget(user, req.Phone)
+	if err != nil {
+		return nil, err
+	}
+
+	var confirmers []uint
+	if user.Phone != "" {
+		confirmers, err = s.otherSessionDevices(userID, deviceID)
+		if err != nil {
+			return nil, err
+		}
+		if len(confirmers) == 0 {
+			return nil, errors.New("更换手机号需在另一台已登录的设备上确认，请先在其他设备登录")
+		}
+	} else if err := s.reauthenticate(user, req.Password, req.CurrentCode, req.ClientIP, req.UserAgent); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCode(changeCodePurpose(codePurposeChangePhone, userID), target.phone, req.Code); err != nil {
+		return nil, err
+	}
+
+	return s.createPhoneChange(user, deviceID, target.phone, confirmers, req.ClientIP, req.UserAgent)
+}
+
+// ListPhoneChanges 已登录设备获取等待确认的手机号更换请求
+func (s *AuthService) ListPhoneChanges(userID uint) ([]model.PhoneChange, error) {
+	return s.phoneChangeRepo.ListPending(userID, time.Now())
+}
+
+// GetPhoneChange 发起设备查询更换请求的状态
+func (s *AuthService) GetPhoneChange(userID, changeID uint) (*model.PhoneChange, error) {
+	change, err := s.phoneChangeRepo.Get(userID, changeID)
+	if err != nil {
+		return nil, err
+	}
+	if change == nil {
+		return nil, errInvalidPhoneChange
+	}
+	return change, nil
+}
+
+// DecidePhoneChange 其他已登录设备确认或拒绝更换手机号，确认后立即生效
+// 只有发起更换时已登录的设备可以确认，之后登录的设备只能拒绝
+func (s *AuthService) DecidePhoneChange(userID, deviceID, changeID uint, approve bool, clientIP, userAgent string) error {
+	now := time.Now()
+	change, err := s.phoneChangeRepo.Get(userID, changeID)
+	if err != nil {
+		return err
+	}
+	if change == nil || change.Status != model.PhoneChangePending || !now.Before(change.ExpiresAt) {
+		return errInvalidPhoneChange
+	}
+	if change.RequestedBy == deviceID {
+		return errors.New("请在发起更换以外的已登录设备上确认")
+	}
+	active, err := s.otherSessionDevices(userID, change.RequestedBy)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(active, deviceID) {
+		return errors.New("当前设备未登录")
+	}
+	if approve && !change.CanApprove(deviceID) {
+		return errors.New("只有发起更换时已登录的设备才能确认")
+	}
+
+	if !approve {
+		denied, err := s.phoneChangeRepo.Deny(userID, changeID, deviceID, now)
+		if err != nil {
+			return err
+		}
+		if !denied {
+			return errInvalidPhoneChange
+		}
+		s.audit(AuditPhoneChangeDenied, auditEntry{
+			UserID:    userID,
+			DeviceID:  deviceID,
+			ClientIP:  clientIP,
+			UserAgent: userAgent,
+			Details:   applogger.Fields{"change_id": changeID},
+		})
+		return nil
+	}
+
+	user, err := s.contactChangeUser(userID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkPhoneAvailable(userID, change.NewPhone); err != nil {
+		return err
+	}
+	return s.completePhoneChange(user, change, deviceID, clientIP, userAgent)
+}
+
+// ChangeEmail 重新验证身份并验证新邮箱后更换邮箱
+func (s *AuthService) ChangeEmail(userID, deviceID uint, req *ChangeEmailRequest) (*model.User, error) {
+	user, err := s.contactChangeUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.reauthenticate(user, req.Password, req.CurrentCode, req.ClientIP, req.UserAgent); err != nil {
+		return nil, err
+	}
+	target, err := s.newEmailTarget(user, req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCode(changeCodePurpose(codePurposeChangeEmail, userID), target.email, req.Code); err != nil {
+		return nil, err
+	}
+	return s.applyEmailChange(user, deviceID, target.email, req.ClientIP, req.UserAgent)
+}
+
+// ListNotifications 获取当前设备未读的账号安全通知，获取后标记为已读
+func (s *AuthService) ListNotifications(userID, deviceID uint) ([]model.DeviceNotification, error) {
+	return s.notificationRepo.Claim(userID, deviceID, time.Now())
+}
+
+// createPhoneChange 保存已验证的更换请求并通知其他设备确认，没有需要确认的设备时直接完成
+func (s *AuthService) createPhoneChange(user *model.User, deviceID uint, phone string, confirmers []uint, clientIP, userAgent string) (*model.PhoneChange, error) {
+	now := time.Now()
+	change := &model.PhoneChange{
+		UserID:      user.ID,
+		NewPhone:    phone,
+		RequestedBy: deviceID,
+		Approvers:   joinDeviceIDs(confirmers),
+		IP:          clientIP,
+		Status:      model.PhoneChangePending,
+		ExpiresAt:   now.Add(phoneChangeTTL),
+	}
+	if err := s.phoneChangeRepo.Create(change); err != nil {
+		return nil, err
+	}
+
+	if len(confirmers) == 0 {
+		if err := s.completePhoneChange(user, change, deviceID, clientIP, userAgent); err != nil {
+			return nil, err
+		}
+		return s.phoneChangeRepo.Get(user.ID, change.ID)
+	}
+
+	s.audit(AuditPhoneChangeRequested, auditEntry{
+		UserID:    user.ID,
+		DeviceID:  deviceID,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+		Details:   applogger.Fields{"change_id": change.ID, "new_phone": maskIdentifier(phone)},
+	})
+	s.notifyDevices(user.ID, confirmers, model.NotificationPhoneChangeRequested,
+		fmt.Sprintf("有设备申请将手机号更换为 %s，请确认是否为本人操作", maskIdentifier(phone)))
+	return change, nil
+}
+
+// completePhoneChange 更换手机号，记录审计事件并通知其他设备
+func (s *AuthService) completePhoneChange(user *model.User, change *model.PhoneChange, deviceID uint, clientIP, userAgent string) error {
+	completed, err := s.phoneChangeRepo.Complete(change, deviceID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !completed {
+		return errInvalidPhoneChange
+	}
+
+	s.audit(AuditPhoneChanged, auditEntry{
+		UserID:    user.ID,
+		DeviceID:  deviceID,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+		Details: applogger.Fields{
+			"change_id": change.ID,
+			"old_phone": maskIdentifier(user.Phone),
+			"new_phone": maskIdentifier(change.NewPhone),
+		},
+	})
+	s.notifyOtherDevices(user.ID, deviceID, model.NotificationPhoneChanged,
+		fmt.Sprintf("账号手机号已更换为 %s", maskIdentifier(change.NewPhone)))
+	return nil
+}
+
+// applyEmailChange 更换邮箱，记录审计事件并通知其他设备
+func (s *AuthService) applyEmailChange(user *model.User, deviceID uint, email, clientIP, userAgent string) (*model.User, error) {
+	if err := s.userRepo.UpdateEmail(user.ID, email); err != nil {
+		return nil, err
+	}
+
+	s.audit(AuditEmailChanged, auditEntry{
+		UserID:    user.ID,
+		DeviceID:  deviceID,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+		Details: applogger.Fields{
+			"old_email": maskIdentifier(user.Email),
+			"new_email": maskIdentifier(email),
+		},
+	})
+	s.notifyOtherDevices(user.ID, deviceID, model.NotificationEmailChanged,
+		fmt.Sprintf("账号邮箱已更换为 %s", maskIdentifier(email)))
+
+	user.Email = email
+	user.PasswordHash = ""
+	return user, nil
+}
+
+// contactChangeUser 获取要更换手机号或邮箱的用户，机器人没有手机号和邮箱
+func (s *AuthService) contactChangeUser(userID uint) (*model.User, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.AccountType == pkg.AccountTypeBot {
+		return nil, errors.New("用户不存在")
+	}
+	return user, nil
+}
+
+// newPhoneTarget 规范化新手机号，不能与当前手机号相同或已被其他账号使用
+func (s *AuthService) newPhoneTarget(user *model.User, phone string) (codeTarget, error) {
+	normalized, err := s.normalizePhone(phone)
+	if err != nil {
+		return codeTarget{}, err
+	}
+	if normalized == "" {
+		return codeTarget{}, errors.New("手机号不能为空")
+	}
+	if normalized == user.Phone {
+		return codeTarget{}, errors.New("新手机号与当前手机号相同")
+	}
+	if err := s.checkPhoneAvailable(user.ID, normalized); err != nil {
+		return codeTarget{}, err
+	}
+	return codeTarget{phone: normalized}, nil
+}
+
+// newEmailTarget 规范化新邮箱，不能与当前邮箱相同或已被其他账号使用
+func (s *AuthService) newEmailTarget(user *model.User, email string) (codeTarget, error) {
+	normalized := pkg.NormalizeEmail(email)
+	if !strings.Contains(normalized, "@") {
+		return codeTarget{}, errors.New("邮箱格式无效")
+	}
+	if normalized == user.Email {
+		return codeTarget{}, errors.New("新邮箱与当前邮箱相同")
+	}
+	existing, err := s.userRepo.GetUserByEmail(normalized)
+	if err != nil {
+		return codeTarget{}, err
+	}
+	if existing != nil && existing.ID != user.ID {
+		return codeTarget{}, errors.New("邮箱已被注册")
+	}
+	return codeTarget{email: normalized}, nil
+}
+
+// checkPhoneAvailable 手机号是否未被其他账号使用
+func (s *AuthService) checkPhoneAvailable(userID uint, phone string) error {
+	existing, err := s.userRepo.GetUserByPhone(phone)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != userID {
+		return errors.New("手机号已被注册")
+	}
+	return nil
+}
+
+// sendContactChangeCode 发送更换手机号或邮箱的验证码
+func (s *AuthService) sendContactChangeCode(purpose string, target codeTarget) (*SendCodeResponse, error) {
+	if err := s.issueCode(purpose, target); err != nil {
+		return nil, err
+	}
+	return &SendCodeResponse{
+		ExpiresIn:   int64(s.codePolicy.TTL.Seconds()),
+		ResendAfter: int64(s.codePolicy.ResendCooldown.Seconds()),
+	}, nil
+}
+
+// otherSessionDevices 用户除当前设备外仍处于登录状态的设备
+func (s *AuthService) otherSessionDevices(userID, deviceID uint) ([]uint, error) {
+	devices, err := s.deviceRepo.GetActiveSessionDevices(userID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(devices))
+	for _, device := range devices {
+		if device.ID != deviceID {
+			ids = append(ids, device.ID)
+		}
+	}
+	return ids, nil
+}
+
+// notifyOtherDevices 通知用户除当前设备外所有已登录的设备
+func (s *AuthService) notifyOtherDevices(userID, deviceID uint, notificationType, message string) {
+	devices, err := s.otherSessionDevices(userID, deviceID)
+	if err != nil {
+		s.logNotificationError(err)
+		return
+	}
+	s.notifyDevices(userID, devices, notificationType, message)
+}
+
+// notifyDevices 发送设备通知，失败只记录日志，不影响已完成的操作
+func (s *AuthService) notifyDevices(userID uint, deviceIDs []uint, notificationType, message string) {
+	if err := s.notificationRepo.CreateForDevices(userID, deviceIDs, notificationType, message); err != nil {
+		s.logNotificationError(err)
+	}
+}
+
+// logNotificationError 记录设备通知失败
+func (s *AuthService) logNotificationError(err error) {
+	if log := applogger.GetDefault(); log != nil {
+		log.Warn("Failed to notify devices", applogger.Fields{"error": err.Error()})
+	}
+}
+
+// joinDeviceIDs 设备ID列表转为空格分隔的字符串
+func joinDeviceIDs(deviceIDs []uint) string {
+	ids := make([]string, 0, len(deviceIDs))
+	for _, deviceID := range deviceIDs {
+		ids = append(ids, strconv.FormatUint(uint64(deviceID), 10))
+	}
+	return strings.Join(ids, " ")
+}
+
+// changeCodePurpose 更换手机号/邮箱的验证码按用户区分，避免不同账号申请同一号码时互相覆盖
+func changeCodePurpose(purpose string, userID uint) string {
+	return fmt.Sprintf("%s:%d", purpose, userID)
+}
+
+// maskIdentifier 隐藏手机号或邮箱的中间部分，用于审计事件和通知
+func maskIdentifier(value string) string {
+	if value == "" {
+		return ""
+	}
+	if local, domain, ok := strings.Cut(value, "@"); ok {
+		if len(local) > 1 {
+			local = local[:1] + "***"
+		}
+		return local + "@" + domain
+	}
+	if len(value) <= 7 {
+		return value
+	}
+	return value[:3] + strings.Repeat("*", len(value)-7) + value[len(value)-4:]
+}
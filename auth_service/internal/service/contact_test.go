@@ -0,0 +1,277 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+func TestAuthService_PhoneChange(t *testing.T) {
+	setupTestDB(t)
+	setupTestRedis(t)
+	smsSender := &stubSender{}
+	authService := NewAuthService(
+		pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithSenders(smsSender, &stubSender{}),
+	)
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	userID, phoneID := registered.User.ID, registered.Device.ID
+
+	_, err = authService.Register(&RegisterRequest{
+		Phone:       "+8613900000000",
+		Username:    "bob",
+		Password:    "password123",
+		DeviceToken: "phone-2",
+		DeviceType:  "android",
+	})
+	require.NoError(t, err)
+
+	// 只有一台设备登录时无法确认；新手机号不能已被其他账号使用
+	_, err = authService.RequestPhoneChange(userID, phoneID, &ChangePhoneRequest{Phone: "+8613700000000", Code: "123456"})
+	assert.EqualError(t, err, "更换手机号需在另一台已登录的设备上确认，请先在其他设备登录")
+	_, err = authService.SendPhoneChangeCode(userID, "+8613900000000")
+	assert.EqualError(t, err, "手机号已被注册")
+	_, err = authService.SendPhoneChangeCode(userID, "+8613800000000")
+	assert.Error(t, err)
+
+	desktop, err := authService.Login(&LoginRequest{
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "desktop-1",
+		DeviceType:  "desktop",
+	})
+	require.NoError(t, err)
+	desktopID := desktop.Device.ID
+
+	// 验证码发送到新手机号，验证通过后创建等待确认的请求
+	_, err = authService.SendPhoneChangeCode(userID, "+8613700000000")
+	require.NoError(t, err)
+	assert.Equal(t, "+8613700000000", smsSender.targets[len(smsSender.targets)-1])
+	_, err = authService.RequestPhoneChange(userID, phoneID, &ChangePhoneRequest{Phone: "+8613700000000", Code: "000000"})
+	assert.EqualError(t, err, "验证码错误")
+	change, err := authService.RequestPhoneChange(userID, phoneID, &ChangePhoneRequest{Phone: "+8613700000000", Code: smsSender.lastCode()})
+	require.NoError(t, err)
+	assert.Equal(t, model.PhoneChangePending, change.Status)
+
+	notifications, err := authService.ListNotifications(userID, desktopID)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, model.NotificationPhoneChangeRequested, notifications[0].Type)
+	assert.NotContains(t, notifications[0].Message, "+8613700000000")
+
+	// 发起之后才登录的设备不能确认
+	tablet, err := authService.Login(&LoginRequest{
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "tablet-1",
+		DeviceType:  "android",
+	})
+	require.NoError(t, err)
+	tabletID := tablet.Device.ID
+	assert.EqualError(t, authService.DecidePhoneChange(userID, tabletID, change.ID, true, "", ""), "只有发起更换时已登录的设备才能确认")
+
+	// 发起设备不能自己确认
+	assert.Error(t, authService.DecidePhoneChange(userID, phoneID, change.ID, true, "", ""))
+	require.NoError(t, authService.DecidePhoneChange(userID, desktopID, change.ID, true, "", ""))
+	assert.Error(t, authService.DecidePhoneChange(userID, desktopID, change.ID, true, "", ""))
+
+	status, err := authService.GetPhoneChange(userID, change.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.PhoneChangeCompleted, status.Status)
+
+	// 可以用新手机号登录，旧手机号已释放
+	_, err = authService.Login(&LoginRequest{Phone: "+8613700000000", Password: "password123", DeviceToken: "phone-1", DeviceType: "ios"})
+	require.NoError(t, err)
+	_, err = authService.Login(&LoginRequest{Phone: "+8613800000000", Password: "password123", DeviceToken: "phone-1", DeviceType: "ios"})
+	assert.Error(t, err)
+
+	// 发起设备收到更换完成的通知，确认设备不重复通知；通知只领取一次
+	notifications, err = authService.ListNotifications(userID, phoneID)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, model.NotificationPhoneChanged, notifications[0].Type)
+	notifications, err = authService.ListNotifications(userID, phoneID)
+	require.NoError(t, err)
+	assert.Empty(t, notifications)
+	notifications, err = authService.ListNotifications(userID, desktopID)
+	require.NoError(t, err)
+	assert.Empty(t, notifications)
+
+	// 之后登录的设备可以拒绝，拒绝后不更换
+	_, err = authService.SendPhoneChangeCode(userID, "+8613600000000")
+	require.NoError(t, err)
+	denied, err := authService.RequestPhoneChange(userID, phoneID, &ChangePhoneRequest{Phone: "+8613600000000", Code: smsSender.lastCode()})
+	require.NoError(t, err)
+	newDevice, err := authService.Login(&LoginRequest{Username: "alice", Password: "password123", DeviceToken: "web-1", DeviceType: "web"})
+	require.NoError(t, err)
+	require.NoError(t, authService.DecidePhoneChange(userID, newDevice.Device.ID, denied.ID, false, "", ""))
+	user, err := authService.contactChangeUser(userID)
+	require.NoError(t, err)
+	assert.Equal(t, "+8613700000000", user.Phone)
+
+	// 已退出登录的设备不能确认
+	_, err = authService.SendPhoneChangeCode(userID, "+8613500000000")
+	require.NoError(t, err)
+	pending, err := authService.RequestPhoneChange(userID, phoneID, &ChangePhoneRequest{Phone: "+8613500000000", Code: smsSender.lastCode()})
+	require.NoError(t, err)
+	require.NoError(t, authService.RevokeSession(userID, desktopID))
+	assert.EqualError(t, authService.DecidePhoneChange(userID, desktopID, pending.ID, true, "", ""), "当前设备未登录")
+
+	events, _, err := authService.ListSecurityEvents(userID, "", 50)
+	require.NoError(t, err)
+	var eventTypes []string
+	for _, event := range events {
+		eventTypes = append(eventTypes, event.Event)
+	}
+	assert.Contains(t, eventTypes, AuditPhoneChangeRequested)
+	assert.Contains(t, eventTypes, AuditPhoneChanged)
+	assert.Contains(t, eventTypes, AuditPhoneChangeDenied)
+}
+
+func TestAuthService_BindPhoneRequiresReauthentication(t *testing.T) {
+	setupTestDB(t)
+	setupTestRedis(t)
+	smsSender := &stubSender{}
+	authService := NewAuthService(
+		pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithSenders(smsSender, &stubSender{}),
+	)
+
+	registered, err := authService.Register(&RegisterRequest{
+		Email:       "alice@example.com",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	userID, phoneID := registered.User.ID, registered.Device.ID
+
+	// 尚未绑定手机号时没有设备确认，需验证当前密码
+	_, err = authService.SendPhoneChangeCode(userID, "+8613700000000")
+	require.NoError(t, err)
+	code := smsSender.lastCode()
+	_, err = authService.RequestPhoneChange(userID, phoneID, &ChangePhoneRequest{Phone: "+8613700000000", Code: code})
+	assert.EqualError(t, err, "密码错误")
+
+	change, err := authService.RequestPhoneChange(userID, phoneID, &ChangePhoneRequest{
+		Phone: "+8613700000000", Code: code, Password: "password123",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, model.PhoneChangeCompleted, change.Status)
+}
+
+func TestAuthService_EmailChange(t *testing.T) {
+	setupTestDB(t)
+	redisServer := setupTestRedis(t)
+	emailSender := &stubSender{}
+	authService := NewAuthService(
+		pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithSenders(&stubSender{}, emailSender),
+	)
+
+	registered, err := authService.Register(&RegisterRequest{
+		Email:       "alice@example.com",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	userID, phoneID := registered.User.ID, registered.Device.ID
+
+	_, err = authService.Register(&RegisterRequest{
+		Email:       "bob@example.com",
+		Username:    "bob",
+		Password:    "password123",
+		DeviceToken: "phone-2",
+		DeviceType:  "android",
+	})
+	require.NoError(t, err)
+
+	desktop, err := authService.Login(&LoginRequest{
+		Email:       "alice@example.com",
+		Password:    "password123",
+		DeviceToken: "desktop-1",
+		DeviceType:  "desktop",
+	})
+	require.NoError(t, err)
+
+	_, err = authService.SendEmailChangeCode(userID, "Bob@Example.com")
+	assert.EqualError(t, err, "邮箱已被注册")
+	_, err = authService.SendEmailChangeCode(userID, "alice2@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "alice2@example.com", emailSender.targets[len(emailSender.targets)-1])
+	code := emailSender.lastCode()
+
+	// 只有会话没有密码时不能更换 (防止会话被盗后接管账号)
+	_, err = authService.ChangeEmail(userID, phoneID, &ChangeEmailRequest{Email: "alice2@example.com", Code: code})
+	assert.EqualError(t, err, "密码错误")
+	_, err = authService.ChangeEmail(userID, phoneID, &ChangeEmailRequest{Email: "alice2@example.com", Code: "000000", Password: "password123"})
+	assert.EqualError(t, err, "验证码错误")
+
+	user, err := authService.ChangeEmail(userID, phoneID, &ChangeEmailRequest{Email: "alice2@example.com", Code: code, Password: "password123"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice2@example.com", user.Email)
+
+	// 验证码只能使用一次
+	_, err = authService.ChangeEmail(userID, phoneID, &ChangeEmailRequest{Email: "alice2@example.com", Code: code, Password: "password123"})
+	assert.Error(t, err)
+
+	_, err = authService.Login(&LoginRequest{Email: "alice2@example.com", Password: "password123", DeviceToken: "phone-1", DeviceType: "ios"})
+	require.NoError(t, err)
+
+	// 其他设备收到通知，当前设备不通知
+	notifications, err := authService.ListNotifications(userID, desktop.Device.ID)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, model.NotificationEmailChanged, notifications[0].Type)
+	notifications, err = authService.ListNotifications(userID, phoneID)
+	require.NoError(t, err)
+	assert.Empty(t, notifications)
+
+	// 未设置密码的账号用发送到当前邮箱的登录验证码重新验证身份
+	_, err = authService.SendCode(&SendCodeRequest{Email: "carol@example.com"})
+	require.NoError(t, err)
+	carol, err := authService.VerifyCode(&VerifyCodeRequest{
+		Email: "carol@example.com", Code: emailSender.lastCode(), Username: "carol",
+		DeviceToken: "carol-phone", DeviceType: "ios",
+	})
+	require.NoError(t, err)
+	require.True(t, carol.IsNewUser)
+
+	redisServer.FastForward(time.Minute)
+	_, err = authService.SendEmailChangeCode(carol.User.ID, "carol2@example.com")
+	require.NoError(t, err)
+	newCode := emailSender.lastCode()
+	_, err = authService.SendCode(&SendCodeRequest{Email: "carol@example.com"})
+	require.NoError(t, err)
+	currentCode := emailSender.lastCode()
+
+	_, err = authService.ChangeEmail(carol.User.ID, carol.Device.ID, &ChangeEmailRequest{Email: "carol2@example.com", Code: newCode})
+	assert.EqualError(t, err, "验证码不能为空")
+	user, err = authService.ChangeEmail(carol.User.ID, carol.Device.ID, &ChangeEmailRequest{
+		Email: "carol2@example.com", Code: newCode, CurrentCode: currentCode,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "carol2@example.com", user.Email)
+}
+
+func TestMaskIdentifier(t *testing.T) {
+	assert.Equal(t, "+86*******0000", maskIdentifier("+8613700000000"))
+	assert.Equal(t, "a***@example.com", maskIdentifier("alice@example.com"))
+	assert.Equal(t, "", maskIdentifier(""))
+}
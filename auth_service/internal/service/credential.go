@@ -0,0 +1,153 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+// defaultPhoneRegion 未配置时解析国内格式手机号使用的地区
+const defaultPhoneRegion = "CN"
+
+const (
+	identifierMigrationName  = "normalize_identifiers" // 存量标识迁移的完成标记
+	identifierMigrationBatch = 500                     // 存量标识迁移的批大小
+)
+
+// identifierMigrationResult 存量标识迁移结果，跳过的用户需人工处理
+type identifierMigrationResult struct {
+	Updated            int    `json:"updated"`
+	UnparseablePhones  []uint `json:"unparseable_phone_user_ids,omitempty"`
+	ConflictingUserIDs []uint `json:"conflicting_user_ids,omitempty"`
+}
+
+// normalizePhone 将手机号规范化为E.164格式，空字符串原样返回
+func (s *AuthService) normalizePhone(phone string) (string, error) {
+	if phone == "" {
+		return "", nil
+	}
+	normalized, err := pkg.NormalizePhone(phone, s.defaultRegion)
+	if err != nil {
+		return "", errors.New("手机号格式无效")
+	}
+	return normalized, nil
+}
+
+// resolveUser 按手机号、邮箱或用户名查找用户，按该顺序取第一个非空凭证
+// 机器人只能使用机器人token，在密码、验证码登录和密码重置中视为不存在
+func (s *AuthService) resolveUser(phone, email, username string) (*model.User, error) {
+	var user *model.User
+	var err error
+	switch {
+	case phone != "":
+		normalized, normErr := s.normalizePhone(phone)
+		if normErr != nil {
+			return nil, normErr
+		}
+		user, err = s.userRepo.GetUserByPhone(normalized)
+	case email != "":
+		user, err = s.userRepo.GetUserByEmail(pkg.NormalizeEmail(email))
+	case username != "":
+		user, err = s.userRepo.GetUserByUsername(pkg.NormalizeUsername(username))
+	default:
+		return nil, errors.New("手机号、邮箱或用户名必须提供一个")
+	}
+	if err != nil || user == nil || user.AccountType == pkg.AccountTypeBot {
+		return nil, err
+	}
+	return user, nil
+}
+
+// MigrateIdentifiers 将存量用户的手机号和邮箱迁移为规范化格式，返回更新的用户数
+// 迁移完成后写入标记，之后启动不再扫描；无法解析或与其他账号冲突的记录保持原样，
+// 其用户ID随标记保存并汇总记录一次日志
+func (s *AuthService) MigrateIdentifiers() (int, error) {
+	completed, err := s.migrationRepo.IsCompleted(identifierMigrationName)
+	if err != nil || completed {
+		return 0, err
+	}
+
+	result := identifierMigrationResult{}
+	var afterID uint
+	for {
+		users, err := s.userRepo.ListUsersWithUnnormalizedIdentifiers(afterID, identifierMigrationBatch)
+		if err != nil {
+			return result.Updated, err
+		}
+
+		for _, user := range users {
+			afterID = user.ID
+
+			phone, err := s.normalizePhone(user.Phone)
+			if err != nil {
+				result.UnparseablePhones = append(result.UnparseablePhones, user.ID)
+				phone = user.Phone
+			}
+			email := pkg.NormalizeEmail(user.Email)
+
+			if phone == user.Phone && email == user.Email {
+				continue
+			}
+
+			if conflict, err := s.identifierTaken(user.ID, phone, email); err != nil {
+				return result.Updated, err
+			} else if conflict {
+				result.ConflictingUserIDs = append(result.ConflictingUserIDs, user.ID)
+				continue
+			}
+
+			if err := s.userRepo.UpdateIdentifiers(user.ID, phone, email); err != nil {
+				return result.Updated, err
+			}
+			result.Updated++
+		}
+
+		if len(users) < identifierMigrationBatch {
+			break
+		}
+	}
+
+	details, err := json.Marshal(result)
+	if err != nil {
+		return result.Updated, err
+	}
+	if err := s.migrationRepo.Complete(identifierMigrationName, string(details)); err != nil {
+		return result.Updated, err
+	}
+
+	if len(result.UnparseablePhones) > 0 || len(result.ConflictingUserIDs) > 0 {
+		if log := applogger.GetDefault(); log != nil {
+			log.Warn("Identifier migration skipped users, fix them manually", applogger.Fields{
+				"unparseable_phone_user_ids": result.UnparseablePhones,
+				"conflicting_user_ids":       result.ConflictingUserIDs,
+			})
+		}
+	}
+	return result.Updated, nil
+}
+
+// identifierTaken 规范化后的手机号或邮箱是否已被其他账号使用
+func (s *AuthService) identifierTaken(userID uint, phone, email string) (bool, error) {
+	if phone != "" {
+		other, err := s.userRepo.GetUserByPhone(phone)
+		if err != nil {
+			return false, err
+		}
+		if other != nil && other.ID != userID {
+			return true, nil
+		}
+	}
+	if email != "" {
+		other, err := s.userRepo.GetUserByEmail(email)
+		if err != nil {
+			return false, err
+		}
+		if other != nil && other.ID != userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+func TestAuthService_LoginCredentialVariants(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour))
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "138 0013 8000",
+		Email:       "Alice@Example.com",
+		Username:    "Alice",
+		Password:    "password123",
+		DeviceToken: "device-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "+8613800138000", registered.User.Phone)
+	assert.Equal(t, "alice@example.com", registered.User.Email)
+
+	// 不同写法的同一号码/邮箱不能重复注册
+	_, err = authService.Register(&RegisterRequest{
+		Phone:       "+86 138-0013-8000",
+		Username:    "other",
+		Password:    "password123",
+		DeviceToken: "device-2",
+		DeviceType:  "ios",
+	})
+	assert.EqualError(t, err, "手机号已被注册")
+
+	logins := []*LoginRequest{
+		{Phone: "+86 13800138000"},
+		{Phone: "008613800138000"},
+		{Email: "ALICE@example.com "},
+		{Username: "@alice"},
+	}
+	for _, req := range logins {
+		req.Password = "password123"
+		req.DeviceToken = "device-1"
+		req.DeviceType = "ios"
+
+		result, err := authService.Login(req)
+		require.NoError(t, err)
+		assert.Equal(t, registered.User.ID, result.User.ID)
+	}
+}
+
+func TestAuthService_MigrateIdentifiers(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour))
+
+	users := []*model.User{
+		{Phone: "13800138000", Email: "Bob@Example.com", Username: "bob", IsActive: true},
+		{Phone: "+8613900139000", Email: "carol@example.com", Username: "carol", IsActive: true},
+		// 与carol规范化后冲突, 保持原样
+		{Phone: "139 0013 9000", Email: "dave@example.com", Username: "dave", IsActive: true},
+		// 带国家码但包含'.'或括号的号码同样需要规范化
+		{Phone: "+86.137.0013.7000", Username: "erin", IsActive: true},
+		{Phone: "+86(136)00136000", Username: "frank", IsActive: true},
+		// 无法解析, 保持原样
+		{Phone: "not-a-phone", Username: "grace", IsActive: true},
+	}
+	for _, user := range users {
+		require.NoError(t, repository.DB.Create(user).Error)
+	}
+
+	migrated, err := authService.MigrateIdentifiers()
+	require.NoError(t, err)
+	assert.Equal(t, 3, migrated)
+
+	bob, err := authService.userRepo.GetUserByID(users[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "+8613800138000", bob.Phone)
+	assert.Equal(t, "bob@example.com", bob.Email)
+
+	dave, err := authService.userRepo.GetUserByID(users[2].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "139 0013 9000", dave.Phone)
+
+	for i, want := range map[int]string{3: "+8613700137000", 4: "+8613600136000", 5: "not-a-phone"} {
+		user, err := authService.userRepo.GetUserByID(users[i].ID)
+		require.NoError(t, err)
+		assert.Equal(t, want, user.Phone)
+	}
+
+	// 跳过的用户ID随完成标记保存
+	var marker model.DataMigration
+	require.NoError(t, repository.DB.Where("name = ?", identifierMigrationName).First(&marker).Error)
+	assert.JSONEq(t, fmt.Sprintf(`{"updated":3,"unparseable_phone_user_ids":[%d],"conflicting_user_ids":[%d]}`, users[5].ID, users[2].ID), marker.Details)
+
+	// 迁移只执行一次，之后启动不再扫描
+	late := &model.User{Phone: "135 0013 5000", Username: "heidi", IsActive: true}
+	require.NoError(t, repository.DB.Create(late).Error)
+	migrated, err = authService.MigrateIdentifiers()
+	require.NoError(t, err)
+	assert.Zero(t, migrated)
+	user, err := authService.userRepo.GetUserByID(late.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "135 0013 5000", user.Phone)
+}
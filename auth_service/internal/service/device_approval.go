@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+const (
+	codePurposeDeviceApproval     = "device_approval"
+	defaultDeviceApprovalTimeout  = 2 * time.Minute
+	deviceApprovalCodeWindow      = 15 * time.Minute // 审批超时后允许使用验证码完成登录的时长
+	deviceApprovalWatchInterval   = time.Second
+	deviceApprovalStatusCodeReady = "code_required"
+)
+
+var errInvalidDeviceApproval = errors.New("登录请求无效或已过期，请重新登录")
+
+// DeviceApprovalPolicy 新设备登录审批策略
+// 启用后，没有有效会话的设备通过密码登录时不立即签发token，需已登录的设备批准；
+// 超时未处理(或没有可审批的设备)时改为向账号绑定的手机号/邮箱发送验证码
+type DeviceApprovalPolicy struct {
+	Enabled bool
+	Timeout time.Duration // 等待审批的时长
+}
+
+// DeviceApprovalChallenge 新设备等待审批时返回
+type DeviceApprovalChallenge struct {
+	ApprovalToken string `json:"approval_token,omitempty"` // 只在创建时返回
+	Status        string `json:"status"`                   // pending: 等待审批; code_required: 已超时, 需使用验证码
+	FallbackIn    int64  `json:"fallback_in"`              // 距可使用验证码的秒数
+	ExpiresIn     int64  `json:"expires_in"`
+}
+
+// DeviceApprovalRequest 新设备查询审批结果或提交验证码
+type DeviceApprovalRequest struct {
+	ApprovalToken string `json:"approval_token" binding:"required"`
+	Code          string `json:"code"`
+	ClientIP      string `json:"-"`
+	UserAgent     string `json:"-"`
+}
+
+// requiresDeviceApproval 密码登录的设备是否需要审批
+// device_token由客户端提供，不能证明设备身份；只有该设备上仍有服务端签发的有效刷新token族时免审批，
+// 登出、会话过期或被吊销后的设备与从未登录过的设备一样需要审批
+func (s *AuthService) requiresDeviceApproval(user *model.User, req *LoginRequest) (bool, error) {
+	if !s.deviceApproval.Enabled {
+		return false, nil
+	}
+	devices, err := s.deviceRepo.GetActiveSessionDevices(user.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, device := range devices {
+		if device.DeviceToken == req.DeviceToken {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// requestDeviceApproval 创建审批请求，没有可审批的设备时可立即使用验证码
+func (s *AuthService) requestDeviceApproval(user *model.User, req *LoginRequest) (*AuthResponse, error) {
+	trusted, err := s.deviceRepo.GetActiveSessionDevices(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	fallbackAt := now.Add(s.deviceApproval.Timeout)
+	if len(trusted) == 0 {
+		fallbackAt = now
+	}
+
+	approval := &model.DeviceApproval{
+		UserID:      user.ID,
+		TokenHash:   hashCode(token),
+		DeviceToken: req.DeviceToken,
+		DeviceType:  req.DeviceType,
+		DeviceName:  req.DeviceName,
+		IP:          req.ClientIP,
+		UserAgent:   truncate(req.UserAgent, 255),
+		Status:      model.DeviceApprovalPending,
+		FallbackAt:  fallbackAt,
+		ExpiresAt:   fallbackAt.Add(deviceApprovalCodeWindow),
+	}
+	if err := s.deviceApprovalRepo.Create(approval); err != nil {
+		return nil, err
+	}
+
+	s.audit(AuditDeviceApprovalRequested, auditEntry{
+		UserID:    user.ID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+		Details: applogger.Fields{
+			"approval_id":     approval.ID,
+			"device_type":     req.DeviceType,
+			"trusted_devices": len(trusted),
+		},
+	})
+
+	challenge := deviceApprovalChallenge(approval, now)
+	challenge.ApprovalToken = token
+
+	user.PasswordHash = ""
+	return &AuthResponse{User: user, DeviceApproval: challenge}, nil
+}
+
+// ListDeviceApprovals 已登录设备获取等待审批的新设备登录请求
+func (s *AuthService) ListDeviceApprovals(userID uint) ([]model.DeviceApproval, error) {
+	return s.deviceApprovalRepo.ListPending(userID, time.Now())
+}
+
+// WatchDeviceApprovals 持续推送新的审批请求，直到ctx取消或send返回错误
+func (s *AuthService) WatchDeviceApprovals(ctx context.Context, userID uint, send func(*model.DeviceApproval) error) error {
+	ticker := time.NewTicker(deviceApprovalWatchInterval)
+	defer ticker.Stop()
+
+	var lastID uint
+	for {
+		approvals, err := s.deviceApprovalRepo.ListPending(userID, time.Now())
+		if err != nil {
+			return err
+		}
+		for i := range approvals {
+			if approvals[i].ID <= lastID {
+				continue
+			}
+			if err := send(&approvals[i]); err != nil {
+				return err
+			}
+			lastID = approvals[i].ID
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DecideDeviceApproval 已登录设备批准或拒绝新设备登录
+func (s *AuthService) DecideDeviceApproval(userID, deviceID, approvalID uint, approve bool) error {
+	status, event := model.DeviceApprovalDenied, AuditDeviceDenied
+	if approve {
+		status, event = model.DeviceApprovalApproved, AuditDeviceApproved
+	}
+
+	decided, err := s.deviceApprovalRepo.Decide(userID, approvalID, status, deviceID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !decided {
+		return errors.New("登录请求不存在或已处理")
+	}
+
+	s.audit(event, auditEntry{
+		UserID:   userID,
+		DeviceID: deviceID,
+		Details:  applogger.Fields{"approval_id": approvalID},
+	})
+	return nil
+}
+
+// CheckDeviceApproval 新设备查询审批结果，已批准时继续登录
+func (s *AuthService) CheckDeviceApproval(req *DeviceApprovalRequest) (*AuthResponse, error) {
+	approval, user, err := s.getDeviceApproval(req.ApprovalToken)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	switch approval.Status {
+	case model.DeviceApprovalApproved:
+		return s.completeDeviceApproval(approval, user, model.DeviceApprovalApproved, req)
+	case model.DeviceApprovalDenied:
+		return nil, errors.New("登录请求已被拒绝")
+	case model.DeviceApprovalPending:
+		user.PasswordHash = ""
+		return &AuthResponse{User: user, DeviceApproval: deviceApprovalChallenge(approval, now)}, nil
+	default:
+		return nil, errInvalidDeviceApproval
+	}
+}
+
+// SendDeviceApprovalCode 审批超时后向账号绑定的手机号或邮箱发送验证码
+func (s *AuthService) SendDeviceApprovalCode(approvalToken string) (*SendCodeResponse, error) {
+	approval, user, err := s.getDeviceApproval(approvalToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDeviceApprovalFallback(approval); err != nil {
+		return nil, err
+	}
+
+	if err := s.issueCode(codePurposeDeviceApproval, deviceApprovalCodeTarget(user)); err != nil {
+		return nil, err
+	}
+
+	return &SendCodeResponse{
+		ExpiresIn:   int64(s.codePolicy.TTL.Seconds()),
+		ResendAfter: int64(s.codePolicy.ResendCooldown.Seconds()),
+	}, nil
+}
+
+// VerifyDeviceApprovalCode 审批超时后使用验证码完成新设备登录
+func (s *AuthService) VerifyDeviceApprovalCode(req *DeviceApprovalRequest) (*AuthResponse, error) {
+	approval, user, err := s.getDeviceApproval(req.ApprovalToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDeviceApprovalFallback(approval); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCode(codePurposeDeviceApproval, deviceApprovalCodeTarget(user).value(), req.Code); err != nil {
+		return nil, err
+	}
+	return s.completeDeviceApproval(approval, user, model.DeviceApprovalPending, req)
+}
+
+// getDeviceApproval 按审批token获取请求和对应的账号
+func (s *AuthService) getDeviceApproval(approvalToken string) (*model.DeviceApproval, *model.User, error) {
+	if approvalToken == "" {
+		return nil, nil, errInvalidDeviceApproval
+	}
+
+	approval, err := s.deviceApprovalRepo.GetByTokenHash(hashCode(approvalToken), time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	if approval == nil {
+		return nil, nil, errInvalidDeviceApproval
+	}
+
+	user, err := s.userRepo.GetUserByIDIncludingInactive(approval.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil {
+		return nil, nil, errInvalidDeviceApproval
+	}
+	return approval, user, nil
+}
+
+// completeDeviceApproval 领取审批结果并继续登录，审批只能领取一次
+// 启用了两步验证的账号此时仍需完成两步验证
+func (s *AuthService) completeDeviceApproval(approval *model.DeviceApproval, user *model.User, fromStatus string, req *DeviceApprovalRequest) (*AuthResponse, error) {
+	completed, err := s.deviceApprovalRepo.Complete(approval.ID, fromStatus, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !completed {
+		return nil, errInvalidDeviceApproval
+	}
+
+	return s.beginLogin(user, &LoginRequest{
+		DeviceToken: approval.DeviceToken,
+		DeviceType:  approval.DeviceType,
+		DeviceName:  approval.DeviceName,
+		ClientIP:    req.ClientIP,
+		UserAgent:   req.UserAgent,
+	})
+}
+
+// checkDeviceApprovalFallback 只有等待中且已超时的请求可以使用验证码
+func checkDeviceApprovalFallback(approval *model.DeviceApproval) error {
+	if approval.Status != model.DeviceApprovalPending {
+		return errInvalidDeviceApproval
+	}
+	if time.Now().Before(approval.FallbackAt) {
+		return errors.New("请在已登录的设备上确认，或稍后使用验证码")
+	}
+	return nil
+}
+
+// deviceApprovalCodeTarget 验证码发送到账号绑定的手机号，未绑定时发送到邮箱
+func deviceApprovalCodeTarget(user *model.User) codeTarget {
+	if user.Phone != "" {
+		return codeTarget{phone: user.Phone}
+	}
+	return codeTarget{email: user.Email}
+}
+
+// deviceApprovalChallenge 审批请求的当前状态
+func deviceApprovalChallenge(approval *model.DeviceApproval, now time.Time) *DeviceApprovalChallenge {
+	challenge := &DeviceApprovalChallenge{
+		Status:    model.DeviceApprovalPending,
+		ExpiresIn: int64(approval.ExpiresAt.Sub(now).Seconds()),
+	}
+	if fallbackIn := approval.FallbackAt.Sub(now); fallbackIn > 0 {
+		challenge.FallbackIn = int64(fallbackIn.Seconds())
+	} else {
+		challenge.Status = deviceApprovalStatusCodeReady
+	}
+	return challenge
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+func TestAuthService_DeviceApproval(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithDeviceApproval(DeviceApprovalPolicy{Enabled: true, Timeout: time.Minute}))
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	userID := registered.User.ID
+	trustedDeviceID := registered.Device.ID
+
+	// 仍有有效会话的设备不需要审批
+	again, err := authService.Login(&LoginRequest{Username: "alice", Password: "password123", DeviceToken: "phone-1", DeviceType: "ios"})
+	require.NoError(t, err)
+	require.NotNil(t, again.Token)
+	assert.Nil(t, again.DeviceApproval)
+
+	newDeviceLogin := func() *DeviceApprovalChallenge {
+		resp, err := authService.Login(&LoginRequest{
+			Username:    "alice",
+			Password:    "password123",
+			DeviceToken: "laptop-1",
+			DeviceType:  "web",
+			DeviceName:  "Chrome",
+			ClientIP:    "10.0.0.2",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp.DeviceApproval)
+		assert.Nil(t, resp.Token)
+		assert.Nil(t, resp.Device)
+		return resp.DeviceApproval
+	}
+
+	// 新设备登录等待批准
+	challenge := newDeviceLogin()
+	assert.NotEmpty(t, challenge.ApprovalToken)
+	assert.Equal(t, model.DeviceApprovalPending, challenge.Status)
+	assert.Positive(t, challenge.FallbackIn)
+
+	pending, err := authService.ListDeviceApprovals(userID)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "Chrome", pending[0].DeviceName)
+	assert.Equal(t, "10.0.0.2", pending[0].IP)
+
+	status, err := authService.CheckDeviceApproval(&DeviceApprovalRequest{ApprovalToken: challenge.ApprovalToken})
+	require.NoError(t, err)
+	require.NotNil(t, status.DeviceApproval)
+	assert.Nil(t, status.Token)
+
+	// 超时前不能使用验证码
+	_, err = authService.SendDeviceApprovalCode(challenge.ApprovalToken)
+	assert.Error(t, err)
+
+	// 已登录设备批准后新设备领取token，只能领取一次
+	require.NoError(t, authService.DecideDeviceApproval(userID, trustedDeviceID, pending[0].ID, true))
+	assert.Error(t, authService.DecideDeviceApproval(userID, trustedDeviceID, pending[0].ID, false))
+
+	approved, err := authService.CheckDeviceApproval(&DeviceApprovalRequest{ApprovalToken: challenge.ApprovalToken, ClientIP: "10.0.0.2"})
+	require.NoError(t, err)
+	require.NotNil(t, approved.Token)
+	assert.Equal(t, "laptop-1", approved.Device.DeviceToken)
+
+	_, err = authService.CheckDeviceApproval(&DeviceApprovalRequest{ApprovalToken: challenge.ApprovalToken})
+	assert.Error(t, err)
+
+	// 批准后该设备再次登录不需要审批
+	relogin, err := authService.Login(&LoginRequest{Username: "alice", Password: "password123", DeviceToken: "laptop-1", DeviceType: "web"})
+	require.NoError(t, err)
+	assert.NotNil(t, relogin.Token)
+
+	// 登出后的设备token不能证明设备身份，使用已知的device_token登录仍需审批
+	claims, err := authService.ParseToken(relogin.Token.AccessToken)
+	require.NoError(t, err)
+	require.NoError(t, authService.Logout(claims, "", ""))
+	loggedOut, err := authService.Login(&LoginRequest{Username: "alice", Password: "password123", DeviceToken: "laptop-1", DeviceType: "web"})
+	require.NoError(t, err)
+	require.NotNil(t, loggedOut.DeviceApproval)
+	assert.Nil(t, loggedOut.Token)
+	pending, err = authService.ListDeviceApprovals(userID)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.NoError(t, authService.DecideDeviceApproval(userID, trustedDeviceID, pending[0].ID, false))
+
+	// 拒绝
+	tabletLogin := func() *DeviceApprovalChallenge {
+		resp, err := authService.Login(&LoginRequest{Username: "alice", Password: "password123", DeviceToken: "tablet-1", DeviceType: "android"})
+		require.NoError(t, err)
+		require.NotNil(t, resp.DeviceApproval)
+		return resp.DeviceApproval
+	}
+	denied := tabletLogin()
+	pending, err = authService.ListDeviceApprovals(userID)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.NoError(t, authService.DecideDeviceApproval(userID, trustedDeviceID, pending[0].ID, false))
+	_, err = authService.CheckDeviceApproval(&DeviceApprovalRequest{ApprovalToken: denied.ApprovalToken})
+	assert.Error(t, err)
+
+	// 其他账号不能处理
+	retry := tabletLogin()
+	pending, err = authService.ListDeviceApprovals(userID)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Error(t, authService.DecideDeviceApproval(userID+1, 0, pending[0].ID, true))
+
+	// 重新登录后旧的审批token作废
+	_, err = authService.CheckDeviceApproval(&DeviceApprovalRequest{ApprovalToken: denied.ApprovalToken})
+	assert.Error(t, err)
+
+	// 超时后改用验证码
+	require.NoError(t, repository.GetDB().Model(&model.DeviceApproval{}).
+		Where("id = ?", pending[0].ID).
+		Update("fallback_at", time.Now().Add(-time.Second)).Error)
+
+	timedOut, err := authService.CheckDeviceApproval(&DeviceApprovalRequest{ApprovalToken: retry.ApprovalToken})
+	require.NoError(t, err)
+	require.NotNil(t, timedOut.DeviceApproval)
+	assert.Equal(t, deviceApprovalStatusCodeReady, timedOut.DeviceApproval.Status)
+	assert.Zero(t, timedOut.DeviceApproval.FallbackIn)
+
+	_, err = authService.VerifyDeviceApprovalCode(&DeviceApprovalRequest{ApprovalToken: retry.ApprovalToken, Code: "000000"})
+	assert.Error(t, err)
+
+	_, err = authService.CheckDeviceApproval(&DeviceApprovalRequest{ApprovalToken: "invalid"})
+	assert.ErrorIs(t, err, errInvalidDeviceApproval)
+}
+
+func TestAuthService_DeviceApprovalWithoutTrustedDevice(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithDeviceApproval(DeviceApprovalPolicy{Enabled: true}))
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+
+	claims, err := authService.ParseToken(registered.Token.AccessToken)
+	require.NoError(t, err)
+	require.NoError(t, authService.Logout(claims, "", ""))
+
+	// 没有可审批的设备时直接可以使用验证码
+	resp, err := authService.Login(&LoginRequest{Username: "alice", Password: "password123", DeviceToken: "laptop-1", DeviceType: "web"})
+	require.NoError(t, err)
+	require.NotNil(t, resp.DeviceApproval)
+	assert.Equal(t, deviceApprovalStatusCodeReady, resp.DeviceApproval.Status)
+}
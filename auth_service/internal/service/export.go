@@ -0,0 +1,100 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+// UserDataExportVersion 导出数据格式版本，字段有不兼容变更时递增
+const UserDataExportVersion = 1
+
+// UserDataExport 用户在认证服务中的全部数据 (个人数据导出)
+type UserDataExport struct {
+	FormatVersion int                  `json:"format_version"`
+	GeneratedAt   time.Time            `json:"generated_at"`
+	Account       *model.User          `json:"account"`
+	Devices       []ExportedDevice     `json:"devices"`
+	LoginHistory  []model.LoginHistory `json:"login_history"`
+	Security      ExportedSecurity     `json:"security"`
+}
+
+// ExportedDevice 导出的设备信息
+type ExportedDevice struct {
+	ID          uint       `json:"id"`
+	DeviceToken string     `json:"device_token"`
+	DeviceType  string     `json:"device_type"`
+	DeviceName  string     `json:"device_name"`
+	PushToken   string     `json:"push_token,omitempty"`
+	IsOnline    bool       `json:"is_online"`
+	LastIP      string     `json:"last_ip"`
+	LastSeenAt  *time.Time `json:"last_seen_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ExportedSecurity 导出的安全设置 (不包含密码哈希、密钥等凭证)
+type ExportedSecurity struct {
+	PasswordSet            bool       `json:"password_set"`
+	TwoFactorEnabled       bool       `json:"two_factor_enabled"`
+	TwoFactorEnabledAt     *time.Time `json:"two_factor_enabled_at,omitempty"`
+	RecoveryCodesRemaining int64      `json:"recovery_codes_remaining"`
+}
+
+// ExportUserData 收集用户在认证服务中的全部数据，包含已停用的账号
+func (s *AuthService) ExportUserData(userID uint) (*UserDataExport, error) {
+	user, err := s.userRepo.GetUserByIDIncludingInactive(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("用户不存在")
+	}
+
+	devices, err := s.deviceRepo.GetUserDevices(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	logins, err := s.loginHistoryRepo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	security := ExportedSecurity{PasswordSet: user.PasswordHash != ""}
+	twoFactor, err := s.twoFactorRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor.Enabled() {
+		security.TwoFactorEnabled = true
+		security.TwoFactorEnabledAt = twoFactor.EnabledAt
+		security.RecoveryCodesRemaining, err = s.twoFactorRepo.CountUnusedRecoveryCodes(userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	export := &UserDataExport{
+		FormatVersion: UserDataExportVersion,
+		GeneratedAt:   time.Now(),
+		Account:       user,
+		Devices:       make([]ExportedDevice, 0, len(devices)),
+		LoginHistory:  logins,
+		Security:      security,
+	}
+	for _, device := range devices {
+		export.Devices = append(export.Devices, ExportedDevice{
+			ID:          device.ID,
+			DeviceToken: device.DeviceToken,
+			DeviceType:  device.DeviceType,
+			DeviceName:  device.DeviceName,
+			PushToken:   device.PushToken,
+			IsOnline:    device.IsOnline,
+			LastIP:      device.LastIP,
+			LastSeenAt:  device.LastSeenAt,
+			CreatedAt:   device.CreatedAt,
+		})
+	}
+	return export, nil
+}
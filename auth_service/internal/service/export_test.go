@@ -0,0 +1,55 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+func TestAuthService_ExportUserData(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour))
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+		DeviceName:  "iPhone",
+	})
+	require.NoError(t, err)
+
+	_, err = authService.Login(&LoginRequest{
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "desktop-1",
+		DeviceType:  "desktop",
+		ClientIP:    "10.0.0.2",
+	})
+	require.NoError(t, err)
+
+	export, err := authService.ExportUserData(registered.User.ID)
+	require.NoError(t, err)
+	assert.Equal(t, UserDataExportVersion, export.FormatVersion)
+	assert.Equal(t, "alice", export.Account.Username)
+	assert.Len(t, export.Devices, 2)
+	require.NotEmpty(t, export.LoginHistory)
+	assert.Equal(t, "10.0.0.2", export.LoginHistory[0].IP)
+	assert.True(t, export.Security.PasswordSet)
+	assert.False(t, export.Security.TwoFactorEnabled)
+
+	// 导出内容不包含密码哈希
+	data, err := json.Marshal(export)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "password_hash")
+	assert.NotContains(t, string(data), "$argon2id$")
+
+	_, err = authService.ExportUserData(registered.User.ID + 100)
+	assert.Error(t, err)
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+// KeyService 签名密钥轮换服务
+// 密钥存储在数据库中供多个实例共享；当前签名密钥超过轮换周期后生成新密钥，
+// 旧密钥在重叠窗口内继续用于验证，窗口应不短于刷新token有效期
+type KeyService struct {
+	keyRepo          *repository.SigningKeyRepository
+	keySet           *pkg.KeySet
+	algorithm        string
+	rotationInterval time.Duration
+	overlap          time.Duration
+}
+
+// NewKeyService 创建签名密钥轮换服务
+func NewKeyService(keySet *pkg.KeySet, algorithm string, rotationInterval, overlap time.Duration) *KeyService {
+	return &KeyService{
+		keyRepo:          repository.NewSigningKeyRepository(),
+		keySet:           keySet,
+		algorithm:        algorithm,
+		rotationInterval: rotationInterval,
+		overlap:          overlap,
+	}
+}
+
+// Load 从数据库加载密钥到密钥集合，必要时先轮换
+func (s *KeyService) Load() error {
+	now := time.Now()
+
+	records, err := s.keyRepo.ListValidSigningKeys(now)
+	if err != nil {
+		return err
+	}
+
+	if s.needsRotation(records, now) {
+		record, err := s.rotate(now)
+		if err != nil {
+			return err
+		}
+		records = append([]*model.SigningKey{record}, records...)
+	}
+
+	keys := make([]*pkg.SigningKey, 0, len(records))
+	for _, record := range records {
+		key, err := pkg.ParsePrivateSigningKey(record.KeyID, record.Algorithm, []byte(record.PrivateKey))
+		if err != nil {
+			return err
+		}
+		key.CreatedAt = record.CreatedAt
+		key.ExpiresAt = record.ExpiresAt
+		keys = append(keys, key)
+	}
+
+	s.keySet.SetKeys(keys, records[0].KeyID)
+
+	return s.keyRepo.DeleteExpiredSigningKeys(now)
+}
+
+// Run 定期重新加载密钥，以执行计划轮换并同步其他实例生成的密钥
+func (s *KeyService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Load(); err != nil {
+				if log := applogger.GetDefault(); log != nil {
+					log.Error("Failed to reload signing keys", applogger.Fields{"error": err.Error()})
+				}
+			}
+		}
+	}
+}
+
+// needsRotation 没有可用密钥、最新密钥超过轮换周期或算法已变更时需要轮换
+func (s *KeyService) needsRotation(records []*model.SigningKey, now time.Time) bool {
+	if len(records) == 0 {
+		return true
+	}
+	latest := records[0]
+	return latest.Algorithm != s.algorithm || now.Sub(latest.CreatedAt) >= s.rotationInterval
+}
+
+// rotate 生成并保存新的签名密钥
+func (s *KeyService) rotate(now time.Time) (*model.SigningKey, error) {
+	key, err := pkg.GenerateSigningKey(s.algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	privatePEM, err := key.MarshalPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	record := &model.SigningKey{
+		KeyID:      key.ID,
+		Algorithm:  key.Algorithm,
+		PrivateKey: string(privatePEM),
+		ExpiresAt:  now.Add(s.rotationInterval + s.overlap),
+		CreatedAt:  now,
+	}
+	if err := s.keyRepo.CreateSigningKey(record); err != nil {
+		return nil, err
+	}
+
+	if log := applogger.GetDefault(); log != nil {
+		log.Info("Signing key rotated", applogger.Fields{
+			"kid":        record.KeyID,
+			"algorithm":  record.Algorithm,
+			"expires_at": record.ExpiresAt,
+		})
+	}
+
+	return record, nil
+}
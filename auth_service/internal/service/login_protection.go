@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+// 登录失败计数维度
+const (
+	loginScopeAccount = "account"
+	loginScopeIP      = "ip"
+	loginScopeDevice  = "device"
+)
+
+var (
+	// errInvalidCredentials 不区分账号不存在和密码错误，避免泄露账号是否存在
+	errInvalidCredentials = errors.New("账号或密码错误")
+	errLoginLocked        = errors.New("登录尝试次数过多，请稍后再试")
+)
+
+// LoginProtectionPolicy 登录防暴力破解策略
+// 某一维度连续失败达到阈值后锁定，之后每次失败锁定时长翻倍直至上限
+type LoginProtectionPolicy struct {
+	Window           time.Duration // 失败计数的有效期, 期间无失败则清零
+	AccountThreshold int           // 每个账号允许的连续失败次数
+	IPThreshold      int           // 每个IP允许的连续失败次数
+	DeviceThreshold  int           // 每个设备token允许的连续失败次数
+	BaseLockout      time.Duration // 首次锁定时长
+	MaxLockout       time.Duration // 最长锁定时长
+}
+
+// defaultLoginProtectionPolicy 默认登录防暴力破解策略
+func defaultLoginProtectionPolicy() LoginProtectionPolicy {
+	return LoginProtectionPolicy{
+		Window:           15 * time.Minute,
+		AccountThreshold: 5,
+		IPThreshold:      20,
+		DeviceThreshold:  10,
+		BaseLockout:      time.Minute,
+		MaxLockout:       time.Hour,
+	}
+}
+
+// lockoutDuration 第failures次失败后的锁定时长，未达到阈值时返回0
+func (p LoginProtectionPolicy) lockoutDuration(failures int64, threshold int) time.Duration {
+	if threshold <= 0 || failures < int64(threshold) {
+		return 0
+	}
+	lockout := p.BaseLockout
+	for i := int64(threshold); i < failures && lockout < p.MaxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > p.MaxLockout {
+		lockout = p.MaxLockout
+	}
+	return lockout
+}
+
+// loginSubject 登录失败计数的一个维度
+type loginSubject struct {
+	scope     string
+	id        string
+	threshold int
+}
+
+// loginSubjects 本次登录尝试涉及的计数维度
+// 账号存在时按用户ID计数，否则按输入的标识计数，使不存在的账号同样会被锁定
+func (s *AuthService) loginSubjects(user *model.User, req *LoginRequest) []loginSubject {
+	account := "id:" + s.loginIdentifier(req)
+	if user != nil {
+		account = fmt.Sprintf("user:%d", user.ID)
+	}
+
+	subjects := []loginSubject{
+		{scope: loginScopeAccount, id: account, threshold: s.loginPolicy.AccountThreshold},
+	}
+	if req.ClientIP != "" {
+		subjects = append(subjects, loginSubject{scope: loginScopeIP, id: req.ClientIP, threshold: s.loginPolicy.IPThreshold})
+	}
+	if req.DeviceToken != "" {
+		subjects = append(subjects, loginSubject{scope: loginScopeDevice, id: req.DeviceToken, threshold: s.loginPolicy.DeviceThreshold})
+	}
+	return subjects
+}
+
+// checkLoginLock 任一维度处于锁定期时拒绝登录
+func (s *AuthService) checkLoginLock(userID uint, subjects []loginSubject, req *LoginRequest) error {
+	if s.loginAttemptRepo == nil {
+		return nil
+	}
+
+	pairs := make([][2]string, 0, len(subjects))
+	for _, subject := range subjects {
+		pairs = append(pairs, [2]string{subject.scope, subject.id})
+	}
+
+	remaining, err := s.loginAttemptRepo.LockRemaining(context.Background(), pairs)
+	if err != nil {
+		return err
+	}
+	if remaining <= 0 {
+		return nil
+	}
+
+	s.audit(AuditLoginBlocked, auditEntry{
+		UserID:    userID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+		Reason:    errLoginLocked.Error(),
+		Details: applogger.Fields{
+			"account":      subjects[0].id,
+			"device_token": req.DeviceToken,
+			"retry_after":  remaining.Round(time.Second).String(),
+		},
+	})
+	return errLoginLocked
+}
+
+// recordLoginFailure 记录各维度的失败次数，达到阈值时锁定；账号不存在时userID为0
+// 密码、验证码和两步验证的失败共用同一组计数，任一方式都不能绕过锁定
+func (s *AuthService) recordLoginFailure(userID uint, subjects []loginSubject, req *LoginRequest, reason string) {
+	s.audit(AuditLoginFailed, auditEntry{
+		UserID:    userID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+		Reason:    reason,
+		Details: applogger.Fields{
+			"account":      subjects[0].id,
+			"device_token": req.DeviceToken,
+		},
+	})
+
+	if s.loginAttemptRepo == nil {
+		return
+	}
+
+	ctx := context.Background()
+	for _, subject := range subjects {
+		// 计数有效期覆盖最长锁定时长，避免锁定期间计数过期导致退避重置
+		failures, err := s.loginAttemptRepo.RecordFailure(ctx, subject.scope, subject.id, s.loginPolicy.Window+s.loginPolicy.MaxLockout)
+		if err != nil {
+			s.logLoginProtectionError(err)
+			continue
+		}
+
+		lockout := s.loginPolicy.lockoutDuration(failures, subject.threshold)
+		if lockout == 0 {
+			continue
+		}
+		if err := s.loginAttemptRepo.Lock(ctx, subject.scope, subject.id, lockout); err != nil {
+			s.logLoginProtectionError(err)
+			continue
+		}
+
+		s.audit(AuditLoginLocked, auditEntry{
+			UserID:    userID,
+			ClientIP:  req.ClientIP,
+			UserAgent: req.UserAgent,
+			Details: applogger.Fields{
+				"scope":    subject.scope,
+				"subject":  subject.id,
+				"failures": failures,
+				"lockout":  lockout.String(),
+			},
+		})
+	}
+}
+
+// resetLoginFailures 登录成功后清除账号和设备的失败计数 (IP可能被多个用户共享, 不清除)
+func (s *AuthService) resetLoginFailures(subjects []loginSubject) {
+	if s.loginAttemptRepo == nil {
+		return
+	}
+
+	ctx := context.Background()
+	for _, subject := range subjects {
+		if subject.scope == loginScopeIP {
+			continue
+		}
+		if err := s.loginAttemptRepo.Reset(ctx, subject.scope, subject.id); err != nil {
+			s.logLoginProtectionError(err)
+		}
+	}
+}
+
+// logLoginProtectionError 记录失败计数的存储错误，不影响登录结果
+func (s *AuthService) logLoginProtectionError(err error) {
+	if log := applogger.GetDefault(); log != nil {
+		log.Error("Failed to update login attempt counters", applogger.Fields{"error": err.Error()})
+	}
+}
+
+// loginIdentifier 规范化后的登录标识，用于不存在账号的失败计数
+func (s *AuthService) loginIdentifier(req *LoginRequest) string {
+	switch {
+	case req.Phone != "":
+		if phone, err := s.normalizePhone(req.Phone); err == nil {
+			return phone
+		}
+		return strings.TrimSpace(req.Phone)
+	case req.Email != "":
+		return pkg.NormalizeEmail(req.Email)
+	default:
+		return strings.ToLower(pkg.NormalizeUsername(req.Username))
+	}
+}
+
+var (
+	dummyPasswordHash     string
+	dummyPasswordHashOnce sync.Once
+)
+
+// verifyDummyPassword 账号不存在时执行一次等价的哈希校验，使响应时间与密码错误一致
+func (s *AuthService) verifyDummyPassword(password string) {
+	dummyPasswordHashOnce.Do(func() {
+		dummyPasswordHash, _ = s.passwordManager.HashPassword("dummy-password-for-timing")
+	})
+	s.passwordManager.VerifyPassword(dummyPasswordHash, password)
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+func TestLoginProtectionPolicy_LockoutDuration(t *testing.T) {
+	policy := LoginProtectionPolicy{BaseLockout: time.Minute, MaxLockout: 10 * time.Minute}
+
+	assert.Equal(t, time.Duration(0), policy.lockoutDuration(4, 5))
+	assert.Equal(t, time.Minute, policy.lockoutDuration(5, 5))
+	assert.Equal(t, 2*time.Minute, policy.lockoutDuration(6, 5))
+	assert.Equal(t, 8*time.Minute, policy.lockoutDuration(8, 5))
+	// 达到上限后不再增长
+	assert.Equal(t, 10*time.Minute, policy.lockoutDuration(9, 5))
+	assert.Equal(t, 10*time.Minute, policy.lockoutDuration(100, 5))
+	// 阈值为0表示不限制该维度
+	assert.Equal(t, time.Duration(0), policy.lockoutDuration(100, 0))
+}
+
+func TestAuthService_LoginGenericError(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour))
+
+	_, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+
+	// 账号不存在和密码错误返回相同的错误
+	_, unknownErr := authService.Login(&LoginRequest{
+		Username:    "nobody",
+		Password:    "password123",
+		DeviceToken: "desktop-1",
+		DeviceType:  "desktop",
+	})
+	_, wrongErr := authService.Login(&LoginRequest{
+		Username:    "alice",
+		Password:    "wrong-password",
+		DeviceToken: "desktop-1",
+		DeviceType:  "desktop",
+	})
+	require.Error(t, unknownErr)
+	require.Error(t, wrongErr)
+	assert.Equal(t, unknownErr.Error(), wrongErr.Error())
+}
+
+func TestAuthService_LoginSubjects(t *testing.T) {
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour))
+
+	// 不存在的账号按规范化后的标识计数，不同输入格式共享计数
+	first := authService.loginSubjects(nil, &LoginRequest{Phone: "138 0013 8000", ClientIP: "10.0.0.1", DeviceToken: "d1"})
+	second := authService.loginSubjects(nil, &LoginRequest{Phone: "+86 13800138000"})
+	require.Len(t, first, 3)
+	require.Len(t, second, 1)
+	assert.Equal(t, first[0].id, second[0].id)
+	assert.Equal(t, loginScopeIP, first[1].scope)
+	assert.Equal(t, loginScopeDevice, first[2].scope)
+
+	username := authService.loginSubjects(nil, &LoginRequest{Username: "@Alice"})
+	assert.Equal(t, "id:alice", username[0].id)
+}
+
+func TestAuthService_LoginLockoutCoversCodesAndSecondFactor(t *testing.T) {
+	setupTestDB(t)
+	redisServer := setupTestRedis(t)
+	sms := &stubSender{}
+	authService := NewAuthService(
+		pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithSenders(sms, &stubSender{}),
+		WithLoginProtection(LoginProtectionPolicy{
+			Window:           15 * time.Minute,
+			AccountThreshold: 3,
+			IPThreshold:      100,
+			DeviceThreshold:  100,
+			BaseLockout:      time.Minute,
+			MaxLockout:       time.Hour,
+		}),
+	)
+
+	// 验证码连续错误后锁定，锁定期间正确的验证码也被拒绝
+	_, err := authService.SendCode(&SendCodeRequest{Phone: "+8613800000001"})
+	require.NoError(t, err)
+	verify := func(code string) (*AuthResponse, error) {
+		return authService.VerifyCode(&VerifyCodeRequest{
+			Phone:       "+8613800000001",
+			Code:        code,
+			DeviceToken: "phone-1",
+			DeviceType:  "ios",
+			ClientIP:    "203.0.113.7",
+		})
+	}
+	for i := 0; i < 3; i++ {
+		_, err = verify("000000")
+		require.Error(t, err)
+		assert.NotEqual(t, errLoginLocked, err)
+	}
+	_, err = verify(sms.lastCode())
+	assert.Equal(t, errLoginLocked, err)
+
+	redisServer.FastForward(2 * time.Minute)
+	resp, err := verify(sms.lastCode())
+	require.NoError(t, err)
+	assert.True(t, resp.IsNewUser)
+
+	// 两步验证的错误与密码登录共用计数，重新登录获取新挑战也不能绕过
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000002",
+		Username:    "bob",
+		Password:    "password123",
+		DeviceToken: "phone-2",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	enrollment, err := authService.EnrollTwoFactor(registered.User.ID)
+	require.NoError(t, err)
+	totp, err := pkg.TOTPCode(enrollment.Secret, pkg.TOTPStep(time.Now()))
+	require.NoError(t, err)
+	require.NoError(t, authService.ConfirmTwoFactor(registered.User.ID, totp, "", ""))
+
+	login := func() (*AuthResponse, error) {
+		return authService.Login(&LoginRequest{
+			Username:    "bob",
+			Password:    "password123",
+			DeviceToken: "desktop-2",
+			DeviceType:  "desktop",
+		})
+	}
+	resp, err = login()
+	require.NoError(t, err)
+	require.NotNil(t, resp.TwoFactor)
+	challenge := resp.TwoFactor.ChallengeToken
+
+	for i := 0; i < 3; i++ {
+		_, err = authService.VerifySecondFactor(&VerifySecondFactorRequest{ChallengeToken: challenge, Code: "not-a-code"})
+		require.Error(t, err)
+	}
+	_, err = authService.VerifySecondFactor(&VerifySecondFactorRequest{ChallengeToken: challenge, Code: enrollment.RecoveryCodes[0]})
+	assert.Equal(t, errLoginLocked, err)
+	_, err = login()
+	assert.Equal(t, errLoginLocked, err)
+
+	redisServer.FastForward(2 * time.Minute)
+	resp, err = login()
+	require.NoError(t, err)
+	require.NotNil(t, resp.TwoFactor)
+	resp, err = authService.VerifySecondFactor(&VerifySecondFactorRequest{ChallengeToken: resp.TwoFactor.ChallengeToken, Code: enrollment.RecoveryCodes[0]})
+	require.NoError(t, err)
+	assert.NotNil(t, resp.Token)
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+)
+
+const (
+	loginTokenTTL          = time.Minute
+	loginTokenPollInterval = 500 * time.Millisecond
+	maxLoginTokenWait      = 30 * time.Second // 单次长轮询的最长等待时间
+	loginTokenQRPrefix     = "telegramlite://login?token="
+)
+
+var errInvalidLoginToken = errors.New("二维码已失效，请刷新后重新扫码")
+
+// ExportLoginTokenRequest 网页/桌面端申请二维码登录token
+type ExportLoginTokenRequest struct {
+	DeviceToken string `json:"device_token" binding:"required"`
+	DeviceType  string `json:"device_type" binding:"required"`
+	DeviceName  string `json:"device_name"`
+	ClientIP    string `json:"-"`
+}
+
+// LoginTokenResponse 二维码登录token
+type LoginTokenResponse struct {
+	Token     string `json:"token,omitempty"`      // 只在导出时返回
+	QRPayload string `json:"qr_payload,omitempty"` // 二维码内容
+	ExpiresIn int64  `json:"expires_in"`
+}
+
+// ImportLoginTokenRequest 网页/桌面端等待扫码确认并领取token
+type ImportLoginTokenRequest struct {
+	Token       string        `json:"token" binding:"required"`
+	DeviceToken string        `json:"device_token" binding:"required"` // 必须与导出时一致
+	Wait        time.Duration `json:"-"`                               // 未确认时的最长等待时间, 0表示立即返回
+	ClientIP    string        `json:"-"`
+	UserAgent   string        `json:"-"`
+}
+
+// ExportLoginToken 为网页/桌面端生成短期有效的二维码登录token
+func (s *AuthService) ExportLoginToken(req *ExportLoginTokenRequest) (*LoginTokenResponse, error) {
+	if s.loginTokenRepo == nil {
+		return nil, errors.New("二维码登录服务不可用")
+	}
+	if req.DeviceType != model.DeviceTypeWeb && req.DeviceType != model.DeviceTypeDesktop {
+		return nil, errors.New("仅网页版和桌面版支持扫码登录")
+	}
+
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(loginTokenTTL)
+	err = s.loginTokenRepo.Save(context.Background(), hashCode(token), &repository.LoginToken{
+		Status:      repository.LoginTokenPending,
+		DeviceToken: req.DeviceToken,
+		DeviceType:  req.DeviceType,
+		DeviceName:  req.DeviceName,
+		IP:          req.ClientIP,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginTokenResponse{
+		Token:     token,
+		QRPayload: loginTokenQRPrefix + token,
+		ExpiresIn: int64(loginTokenTTL.Seconds()),
+	}, nil
+}
+
+// AcceptLoginToken 已登录的手机扫码确认，授权网页/桌面端登录当前账号
+func (s *AuthService) AcceptLoginToken(userID, deviceID uint, token string) error {
+	if s.loginTokenRepo == nil {
+		return errors.New("二维码登录服务不可用")
+	}
+
+	device, err := s.deviceRepo.GetDeviceByID(deviceID)
+	if err != nil {
+		return err
+	}
+	if device == nil || device.UserID != userID {
+		return errors.New("设备不存在")
+	}
+	if device.DeviceType != model.DeviceTypeIOS && device.DeviceType != model.DeviceTypeAndroid {
+		return errors.New("请使用手机扫码确认")
+	}
+
+	ctx := context.Background()
+	tokenHash := hashCode(token)
+	loginToken, err := s.loginTokenRepo.Get(ctx, tokenHash)
+	if err != nil {
+		return err
+	}
+	if loginToken == nil {
+		return errInvalidLoginToken
+	}
+
+	accepted, err := s.loginTokenRepo.Accept(ctx, tokenHash, userID, deviceID)
+	if err != nil {
+		return err
+	}
+	if !accepted {
+		return errInvalidLoginToken
+	}
+
+	s.audit(AuditLoginTokenAccepted, auditEntry{
+		UserID:   userID,
+		DeviceID: deviceID,
+		ClientIP: loginToken.IP,
+		Details: applogger.Fields{
+			"device_type": loginToken.DeviceType,
+			"device_name": loginToken.DeviceName,
+		},
+	})
+	return nil
+}
+
+// ImportLoginToken 网页/桌面端长轮询扫码结果，确认后签发token并绑定设备
+// 等待期间token仍未确认时返回LoginToken，客户端应继续轮询；账号启用两步验证时返回挑战
+func (s *AuthService) ImportLoginToken(ctx context.Context, req *ImportLoginTokenRequest) (*AuthResponse, error) {
+	if s.loginTokenRepo == nil {
+		return nil, errors.New("二维码登录服务不可用")
+	}
+
+	wait := req.Wait
+	if wait > maxLoginTokenWait {
+		wait = maxLoginTokenWait
+	}
+	deadline := time.NewTimer(wait)
+	defer deadline.Stop()
+	ticker := time.NewTicker(loginTokenPollInterval)
+	defer ticker.Stop()
+
+	tokenHash := hashCode(req.Token)
+	for {
+		loginToken, err := s.loginTokenRepo.Get(ctx, tokenHash)
+		if err != nil {
+			return nil, err
+		}
+		if loginToken == nil || loginToken.DeviceToken != req.DeviceToken {
+			return nil, errInvalidLoginToken
+		}
+		if loginToken.Status == repository.LoginTokenAccepted {
+			return s.redeemLoginToken(ctx, tokenHash, loginToken, req)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline.C:
+			return &AuthResponse{LoginToken: &LoginTokenResponse{
+				ExpiresIn: int64(time.Until(loginToken.ExpiresAt).Seconds()),
+			}}, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// redeemLoginToken 领取已确认的token并继续登录，token只能领取一次
+// 登录成功后才删除token，失败时恢复为已确认，网页/桌面端可在有效期内重试
+func (s *AuthService) redeemLoginToken(ctx context.Context, tokenHash string, loginToken *repository.LoginToken, req *ImportLoginTokenRequest) (*AuthResponse, error) {
+	claimed, err := s.loginTokenRepo.Claim(ctx, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, errInvalidLoginToken
+	}
+
+	user, err := s.userRepo.GetUserByID(loginToken.UserID)
+	if err != nil {
+		s.releaseLoginToken(ctx, tokenHash)
+		return nil, err
+	}
+	if user == nil {
+		s.deleteLoginToken(ctx, tokenHash)
+		return nil, errInvalidLoginToken
+	}
+
+	resp, err := s.beginLogin(user, &LoginRequest{
+		DeviceToken: loginToken.DeviceToken,
+		DeviceType:  loginToken.DeviceType,
+		DeviceName:  loginToken.DeviceName,
+		ClientIP:    req.ClientIP,
+		UserAgent:   req.UserAgent,
+	})
+	if err != nil {
+		s.releaseLoginToken(ctx, tokenHash)
+		return nil, err
+	}
+
+	s.deleteLoginToken(ctx, tokenHash)
+	return resp, nil
+}
+
+// releaseLoginToken 登录失败后恢复token，失败时token在有效期后自动失效
+func (s *AuthService) releaseLoginToken(ctx context.Context, tokenHash string) {
+	if _, err := s.loginTokenRepo.Release(context.WithoutCancel(ctx), tokenHash); err != nil {
+		if log := applogger.GetDefault(); log != nil {
+			log.Error("Failed to release login token", applogger.Fields{"error": err.Error()})
+		}
+	}
+}
+
+// deleteLoginToken 删除已领取的token，失败时token保持领取中状态直到过期，不会被再次领取
+func (s *AuthService) deleteLoginToken(ctx context.Context, tokenHash string) {
+	if _, err := s.loginTokenRepo.Delete(context.WithoutCancel(ctx), tokenHash); err != nil {
+		if log := applogger.GetDefault(); log != nil {
+			log.Error("Failed to delete login token", applogger.Fields{"error": err.Error()})
+		}
+	}
+}
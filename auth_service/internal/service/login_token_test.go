@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+func TestAuthService_LoginTokenWithoutRedis(t *testing.T) {
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour))
+
+	_, err := authService.ExportLoginToken(&ExportLoginTokenRequest{DeviceToken: "web-1", DeviceType: "web"})
+	assert.EqualError(t, err, "二维码登录服务不可用")
+
+	err = authService.AcceptLoginToken(1, 1, "token")
+	assert.EqualError(t, err, "二维码登录服务不可用")
+
+	_, err = authService.ImportLoginToken(context.Background(), &ImportLoginTokenRequest{Token: "token", DeviceToken: "web-1"})
+	assert.EqualError(t, err, "二维码登录服务不可用")
+}
+
+func TestAuthService_LoginTokenFlow(t *testing.T) {
+	setupTestDB(t)
+	setupTestRedis(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour))
+
+	phone, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+
+	// 另一个账号占用了网页端的设备token，领取时登录失败
+	_, err = authService.Register(&RegisterRequest{
+		Phone:       "+8613800000001",
+		Username:    "bob",
+		Password:    "password123",
+		DeviceToken: "web-1",
+		DeviceType:  "web",
+	})
+	require.NoError(t, err)
+
+	exported, err := authService.ExportLoginToken(&ExportLoginTokenRequest{DeviceToken: "web-1", DeviceType: "web"})
+	require.NoError(t, err)
+	assert.Equal(t, loginTokenQRPrefix+exported.Token, exported.QRPayload)
+
+	importReq := &ImportLoginTokenRequest{Token: exported.Token, DeviceToken: "web-1"}
+	pending, err := authService.ImportLoginToken(context.Background(), importReq)
+	require.NoError(t, err)
+	require.NotNil(t, pending.LoginToken)
+	assert.Nil(t, pending.Token)
+
+	require.NoError(t, authService.AcceptLoginToken(phone.User.ID, phone.Device.ID, exported.Token))
+	assert.Equal(t, errInvalidLoginToken, authService.AcceptLoginToken(phone.User.ID, phone.Device.ID, exported.Token))
+
+	// 登录失败时token恢复为已确认，可以重试
+	_, err = authService.ImportLoginToken(context.Background(), importReq)
+	assert.EqualError(t, err, "设备已被其他用户使用")
+	require.NoError(t, repository.DB.Unscoped().Where("device_token = ?", "web-1").Delete(&model.Device{}).Error)
+
+	resp, err := authService.ImportLoginToken(context.Background(), importReq)
+	require.NoError(t, err)
+	require.NotNil(t, resp.Token)
+	assert.Equal(t, phone.User.ID, resp.User.ID)
+	assert.Equal(t, "web-1", resp.Device.DeviceToken)
+
+	// 登录成功后token只能领取一次
+	_, err = authService.ImportLoginToken(context.Background(), importReq)
+	assert.Equal(t, errInvalidLoginToken, err)
+}
@@ -0,0 +1,963 @@
+package service
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+const (
+	defaultOAuthIssuer     = "http://localhost:8080"
+	defaultOAuthCodeTTL    = time.Minute
+	defaultOAuthRefreshTTL = 30 * 24 * time.Hour
+	maxOAuthRedirectURIs   = 10
+	maxOAuthClientName     = 100
+	pkceMethodS256         = "S256"
+)
+
+// OAuth授权类型
+const (
+	OAuthGrantAuthorizationCode = "authorization_code"
+	OAuthGrantRefreshToken      = "refresh_token"
+)
+
+// OAuth错误码 (RFC 6749, RFC 6750, OpenID Connect Core)
+const (
+	OAuthErrInvalidRequest          = "invalid_request"
+	OAuthErrInvalidClient           = "invalid_client"
+	OAuthErrInvalidGrant            = "invalid_grant"
+	OAuthErrUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthErrUnsupportedResponseType = "unsupported_response_type"
+	OAuthErrInvalidScope            = "invalid_scope"
+	OAuthErrAccessDenied            = "access_denied"
+	OAuthErrConsentRequired         = "consent_required"
+	OAuthErrInvalidToken            = "invalid_token"
+	OAuthErrInsufficientScope       = "insufficient_scope"
+)
+
+// privateURISchemePattern 原生应用的私有scheme须为反向域名形式 (RFC 8252 7.1)
+var privateURISchemePattern = regexp.MustCompile(`^[a-z][a-z0-9+-]*(\.[a-z0-9+-]+)+$`)
+
+// supportedOAuthScopes 支持的授权范围及其在授权确认页面的说明
+var supportedOAuthScopes = []OAuthScope{
+	{Name: model.OAuthScopeOpenID, Description: "使用TelegramLite账号登录"},
+	{Name: model.OAuthScopeProfile, Description: "读取你的用户名和头像"},
+	{Name: model.OAuthScopeEmail, Description: "读取你的邮箱"},
+	{Name: model.OAuthScopePhone, Description: "读取你的手机号"},
+	{Name: model.OAuthScopeOfflineAccess, Description: "在你不使用应用时保持访问"},
+}
+
+// OAuthPolicy OAuth2/OpenID Connect配置
+type OAuthPolicy struct {
+	Issuer           string        // 对外的issuer URL, token端点等地址以此为前缀
+	AuthorizationURL string        // 授权确认页面, 由网页客户端提供
+	CodeTTL          time.Duration // 授权码有效期
+	RefreshTTL       time.Duration // 刷新token有效期
+}
+
+func defaultOAuthPolicy() OAuthPolicy {
+	return OAuthPolicy{
+		Issuer:     defaultOAuthIssuer,
+		CodeTTL:    defaultOAuthCodeTTL,
+		RefreshTTL: defaultOAuthRefreshTTL,
+	}
+}
+
+// authorizationURL 授权确认页面地址，未配置时为 {issuer}/oauth/authorize
+func (p OAuthPolicy) authorizationURL() string {
+	if p.AuthorizationURL != "" {
+		return p.AuthorizationURL
+	}
+	return p.Issuer + "/oauth/authorize"
+}
+
+// OAuthError 返回给第三方应用的错误 (RFC 6749 5.2)
+// error_description只能包含ASCII字符，因此使用英文
+type OAuthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *OAuthError) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+func newOAuthError(code, description string) *OAuthError {
+	return &OAuthError{Code: code, Description: description}
+}
+
+// OAuthScope 授权范围
+type OAuthScope struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// OAuthClientInfo 授权确认页面和授权列表中展示的应用信息
+type OAuthClientInfo struct {
+	ClientID string `json:"client_id"`
+	Name     string `json:"name"`
+}
+
+// RegisterOAuthClientRequest 注册第三方应用请求
+type RegisterOAuthClientRequest struct {
+	Name         string   `json:"name" binding:"required"`
+	RedirectURIs []string `json:"redirect_uris" binding:"required"`
+	Scopes       []string `json:"scopes"` // 允许申请的授权范围, 为空时允许全部
+	Public       bool     `json:"public"` // 单页应用和原生应用无法保存密钥, 应注册为公开客户端
+}
+
+// OAuthClientResponse 第三方应用
+type OAuthClientResponse struct {
+	ClientID     string    `json:"client_id"`
+	ClientSecret string    `json:"client_secret,omitempty"` // 只在注册时返回一次
+	Name         string    `json:"name"`
+	RedirectURIs []string  `json:"redirect_uris"`
+	Scopes       []string  `json:"scopes"`
+	Public       bool      `json:"public"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// OAuthAuthorizeRequest 授权请求，参数与第三方应用跳转到授权页面时携带的一致
+type OAuthAuthorizeRequest struct {
+	ResponseType        string `form:"response_type" json:"response_type"`
+	ClientID            string `form:"client_id" json:"client_id"`
+	RedirectURI         string `form:"redirect_uri" json:"redirect_uri"`
+	Scope               string `form:"scope" json:"scope"`
+	State               string `form:"state" json:"state"`
+	Nonce               string `form:"nonce" json:"nonce"`
+	CodeChallenge       string `form:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string `form:"code_challenge_method" json:"code_challenge_method"`
+	Prompt              string `form:"prompt" json:"prompt"` // none: 不展示确认页面; consent: 总是展示
+	ClientIP            string `form:"-" json:"-"`
+	UserAgent           string `form:"-" json:"-"`
+}
+
+// OAuthAuthorizeResult 授权结果，RedirectTo和Consent只有一个非空
+type OAuthAuthorizeResult struct {
+	RedirectTo string              `json:"redirect_to,omitempty"` // 授权完成或出错，网页客户端跳转到该地址
+	Consent    *OAuthConsentPrompt `json:"consent,omitempty"`     // 需要用户确认授权
+}
+
+// OAuthConsentPrompt 授权确认页面内容
+type OAuthConsentPrompt struct {
+	Client OAuthClientInfo `json:"client"`
+	Scopes []OAuthScope    `json:"scopes"`
+}
+
+// OAuthTokenRequest token端点请求 (application/x-www-form-urlencoded)
+// 机密客户端的凭证也可通过HTTP Basic认证传递
+type OAuthTokenRequest struct {
+	GrantType    string `form:"grant_type"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	RefreshToken string `form:"refresh_token"`
+	Scope        string `form:"scope"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+	ClientIP     string `form:"-"`
+}
+
+// OAuthTokenResponse token端点响应 (RFC 6749 5.1)
+type OAuthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"` // 授权了offline_access时返回
+	IDToken      string `json:"id_token,omitempty"`      // 授权了openid时返回
+	Scope        string `json:"scope"`
+}
+
+// OAuthUserInfo userinfo端点响应，按授权范围返回字段
+type OAuthUserInfo struct {
+	Subject           string `json:"sub"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Picture           string `json:"picture,omitempty"`
+	Email             string `json:"email,omitempty"`
+	PhoneNumber       string `json:"phone_number,omitempty"`
+}
+
+// OAuthConsentResponse 用户授权过的应用
+type OAuthConsentResponse struct {
+	Client    OAuthClientInfo `json:"client"`
+	Scopes    []string        `json:"scopes"`
+	GrantedAt time.Time       `json:"granted_at"`
+}
+
+// OpenIDConfiguration OpenID Connect发现文档 (/.well-known/openid-configuration)
+type OpenIDConfiguration struct {
+	Issuer                                     string   `json:"issuer"`
+	AuthorizationEndpoint                      string   `json:"authorization_endpoint"`
+	TokenEndpoint                              string   `json:"token_endpoint"`
+	UserinfoEndpoint                           string   `json:"userinfo_endpoint"`
+	RevocationEndpoint                         string   `json:"revocation_endpoint"`
+	JWKSURI                                    string   `json:"jwks_uri"`
+	ScopesSupported                            []string `json:"scopes_supported"`
+	ResponseTypesSupported                     []string `json:"response_types_supported"`
+	GrantTypesSupported                        []string `json:"grant_types_supported"`
+	SubjectTypesSupported                      []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported           []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported          []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported              []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                            []string `json:"claims_supported"`
+	AuthorizationResponseIssParameterSupported bool     `json:"authorization_response_iss_parameter_supported"`
+}
+
+// OpenIDConfiguration 生成发现文档
+func (s *AuthService) OpenIDConfiguration() *OpenIDConfiguration {
+	issuer := s.oauthPolicy.Issuer
+	scopes := make([]string, 0, len(supportedOAuthScopes))
+	for _, scope := range supportedOAuthScopes {
+		scopes = append(scopes, scope.Name)
+	}
+
+	return &OpenIDConfiguration{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             s.oauthPolicy.authorizationURL(),
+		TokenEndpoint:                     issuer + "/oauth/token",
+		UserinfoEndpoint:                  issuer + "/oauth/userinfo",
+		RevocationEndpoint:                issuer + "/oauth/revoke",
+		JWKSURI:                           issuer + "/.well-known/jwks.json",
+		ScopesSupported:                   scopes,
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{OAuthGrantAuthorizationCode, OAuthGrantRefreshToken},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{s.jwtManager.SigningAlgorithm()},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{pkceMethodS256},
+		ClaimsSupported: []string{"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce",
+			"preferred_username", "picture", "email", "phone_number"},
+		AuthorizationResponseIssParameterSupported: true,
+	}
+}
+
+// RegisterOAuthClient 注册第三方应用，机密客户端的密钥只在此时返回
+func (s *AuthService) RegisterOAuthClient(ownerID uint, req *RegisterOAuthClientRequest) (*OAuthClientResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len([]rune(name)) > maxOAuthClientName {
+		return nil, errors.New("应用名称不能为空且不能超过100个字符")
+	}
+	if len(req.RedirectURIs) == 0 || len(req.RedirectURIs) > maxOAuthRedirectURIs {
+		return nil, errors.New("回调地址数量必须为1到10个")
+	}
+	for _, redirectURI := range req.RedirectURIs {
+		if err := validateRedirectURI(redirectURI, req.Public); err != nil {
+			return nil, err
+		}
+	}
+
+	scopes := parseScopes(strings.Join(req.Scopes, " "))
+	if len(scopes) == 0 {
+		for _, scope := range supportedOAuthScopes {
+			scopes = append(scopes, scope.Name)
+		}
+	}
+	for _, scope := range scopes {
+		if !isSupportedOAuthScope(scope) {
+			return nil, errors.New("不支持的授权范围: " + scope)
+		}
+	}
+
+	clientID, err := pkg.NewTokenID()
+	if err != nil {
+		return nil, err
+	}
+	client := &model.OAuthClient{
+		ClientID:     clientID,
+		Name:         name,
+		OwnerID:      ownerID,
+		RedirectURIs: strings.Join(req.RedirectURIs, " "),
+		Scopes:       strings.Join(scopes, " "),
+		Public:       req.Public,
+	}
+
+	var secret string
+	if !req.Public {
+		if secret, err = generateOpaqueToken(); err != nil {
+			return nil, err
+		}
+		client.SecretHash = hashCode(secret)
+	}
+
+	if err := s.oauthRepo.CreateClient(client); err != nil {
+		return nil, err
+	}
+
+	response := oauthClientResponse(client)
+	response.ClientSecret = secret
+	return response, nil
+}
+
+// ListOAuthClients 获取用户注册的第三方应用
+func (s *AuthService) ListOAuthClients(ownerID uint) ([]OAuthClientResponse, error) {
+	clients, err := s.oauthRepo.ListClientsByOwner(ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]OAuthClientResponse, 0, len(clients))
+	for i := range clients {
+		result = append(result, *oauthClientResponse(&clients[i]))
+	}
+	return result, nil
+}
+
+// DeleteOAuthClient 删除第三方应用，所有用户对其的授权和刷新token随之失效
+func (s *AuthService) DeleteOAuthClient(ownerID uint, clientID string) error {
+	deleted, err := s.oauthRepo.DeleteClient(ownerID, clientID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.New("应用不存在")
+	}
+	return nil
+}
+
+// Authorize 处理授权请求
+// 用户此前已授权全部范围时直接签发授权码，否则返回确认页面内容；
+// client_id或redirect_uri无效时返回错误(不能跳转回应用)，其他错误通过回调地址告知应用
+func (s *AuthService) Authorize(userID uint, req *OAuthAuthorizeRequest) (*OAuthAuthorizeResult, error) {
+	client, err := s.getAuthorizeClient(req)
+	if err != nil {
+		return nil, err
+	}
+	scopes, oauthErr := validateAuthorizeRequest(client, req)
+	if oauthErr != nil {
+		return s.authorizeError(req, oauthErr), nil
+	}
+
+	if req.Prompt != "consent" {
+		consent, err := s.oauthRepo.GetConsent(userID, client.ClientID)
+		if err != nil {
+			return nil, err
+		}
+		if consent != nil && containsScopes(parseScopes(consent.Scopes), scopes) {
+			return s.issueAuthorizationCode(userID, client, req, scopes)
+		}
+	}
+	if req.Prompt == "none" {
+		return s.authorizeError(req, newOAuthError(OAuthErrConsentRequired, "user consent is required")), nil
+	}
+
+	prompt := &OAuthConsentPrompt{
+		Client: OAuthClientInfo{ClientID: client.ClientID, Name: client.Name},
+		Scopes: make([]OAuthScope, 0, len(scopes)),
+	}
+	for _, scope := range supportedOAuthScopes {
+		if containsScopes(scopes, []string{scope.Name}) {
+			prompt.Scopes = append(prompt.Scopes, scope)
+		}
+	}
+	return &OAuthAuthorizeResult{Consent: prompt}, nil
+}
+
+// DecideAuthorization 用户在确认页面同意或拒绝授权
+func (s *AuthService) DecideAuthorization(userID, deviceID uint, req *OAuthAuthorizeRequest, approve bool) (*OAuthAuthorizeResult, error) {
+	client, err := s.getAuthorizeClient(req)
+	if err != nil {
+		return nil, err
+	}
+	scopes, oauthErr := validateAuthorizeRequest(client, req)
+	if oauthErr != nil {
+		return s.authorizeError(req, oauthErr), nil
+	}
+	if !approve {
+		return s.authorizeError(req, newOAuthError(OAuthErrAccessDenied, "the user denied the request")), nil
+	}
+
+	// 保留此前授权过的范围
+	granted := scopes
+	consent, err := s.oauthRepo.GetConsent(userID, client.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if consent != nil {
+		granted = parseScopes(consent.Scopes + " " + strings.Join(scopes, " "))
+	}
+	err = s.oauthRepo.SaveConsent(&model.OAuthConsent{
+		UserID:   userID,
+		ClientID: client.ClientID,
+		Scopes:   strings.Join(granted, " "),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(AuditOAuthConsentGranted, auditEntry{
+		UserID:    userID,
+		DeviceID:  deviceID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+		Details: applogger.Fields{
+			"client_id": client.ClientID,
+			"scopes":    strings.Join(scopes, " "),
+		},
+	})
+
+	return s.issueAuthorizationCode(userID, client, req, scopes)
+}
+
+// ExchangeOAuthToken token端点：使用授权码或刷新token换取访问token
+func (s *AuthService) ExchangeOAuthToken(req *OAuthTokenRequest) (*OAuthTokenResponse, error) {
+	client, err := s.authenticateOAuthClient(req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.GrantType {
+	case OAuthGrantAuthorizationCode:
+		return s.exchangeAuthorizationCode(client, req)
+	case OAuthGrantRefreshToken:
+		return s.refreshOAuthToken(client, req)
+	case "":
+		return nil, newOAuthError(OAuthErrInvalidRequest, "grant_type is required")
+	default:
+		return nil, newOAuthError(OAuthErrUnsupportedGrantType, "unsupported grant_type")
+	}
+}
+
+// OAuthUserInfo userinfo端点：返回访问token授权范围内的用户信息
+// 用户撤销授权后，尚未过期的访问token也不能再读取用户信息
+func (s *AuthService) OAuthUserInfo(accessToken string) (*OAuthUserInfo, error) {
+	claims, err := s.jwtManager.VerifyOAuthAccessToken(accessToken)
+	if err != nil {
+		return nil, newOAuthError(OAuthErrInvalidToken, "invalid or expired access token")
+	}
+	scopes := parseScopes(claims.Scope)
+	if !containsScopes(scopes, []string{model.OAuthScopeOpenID}) {
+		return nil, newOAuthError(OAuthErrInsufficientScope, "the openid scope is required")
+	}
+
+	consent, err := s.oauthRepo.GetConsent(claims.UserID, claims.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if consent == nil {
+		return nil, newOAuthError(OAuthErrInvalidToken, "access has been revoked")
+	}
+	user, err := s.userRepo.GetUserByID(claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, newOAuthError(OAuthErrInvalidToken, "user not found")
+	}
+
+	userClaims := oauthUserClaims(user, scopes)
+	return &OAuthUserInfo{
+		Subject:           oauthSubject(user.ID),
+		PreferredUsername: userClaims.PreferredUsername,
+		Picture:           userClaims.Picture,
+		Email:             userClaims.Email,
+		PhoneNumber:       userClaims.PhoneNumber,
+	}, nil
+}
+
+// RevokeOAuthToken 吊销刷新token及其令牌族 (RFC 7009)
+// token不存在或不属于该应用时同样视为成功
+func (s *AuthService) RevokeOAuthToken(clientID, clientSecret, token string) error {
+	client, err := s.authenticateOAuthClient(clientID, clientSecret)
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return newOAuthError(OAuthErrInvalidRequest, "token is required")
+	}
+
+	record, err := s.oauthRepo.GetRefreshToken(hashCode(token))
+	if err != nil {
+		return err
+	}
+	if record == nil || record.ClientID != client.ClientID {
+		return nil
+	}
+	return s.oauthRepo.RevokeFamily(record.FamilyID)
+}
+
+// ListOAuthConsents 获取用户授权过的第三方应用
+func (s *AuthService) ListOAuthConsents(userID uint) ([]OAuthConsentResponse, error) {
+	consents, err := s.oauthRepo.ListConsents(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	clientIDs := make([]string, 0, len(consents))
+	for _, consent := range consents {
+		clientIDs = append(clientIDs, consent.ClientID)
+	}
+	clients, err := s.oauthRepo.GetClients(clientIDs)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(clients))
+	for _, client := range clients {
+		names[client.ClientID] = client.Name
+	}
+
+	result := make([]OAuthConsentResponse, 0, len(consents))
+	for _, consent := range consents {
+		name, ok := names[consent.ClientID]
+		if !ok {
+			continue
+		}
+		result = append(result, OAuthConsentResponse{
+			Client:    OAuthClientInfo{ClientID: consent.ClientID, Name: name},
+			Scopes:    parseScopes(consent.Scopes),
+			GrantedAt: consent.UpdatedAt,
+		})
+	}
+	return result, nil
+}
+
+// RevokeOAuthConsent 撤销对第三方应用的授权，应用持有的刷新token同时失效
+func (s *AuthService) RevokeOAuthConsent(userID, deviceID uint, clientID string) error {
+	deleted, err := s.oauthRepo.DeleteConsent(userID, clientID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.New("授权不存在")
+	}
+
+	s.audit(AuditOAuthConsentRevoked, auditEntry{
+		UserID:   userID,
+		DeviceID: deviceID,
+		Details:  applogger.Fields{"client_id": clientID},
+	})
+	return nil
+}
+
+// getAuthorizeClient 获取授权请求中的应用并校验回调地址
+func (s *AuthService) getAuthorizeClient(req *OAuthAuthorizeRequest) (*model.OAuthClient, error) {
+	if req.ClientID == "" {
+		return nil, newOAuthError(OAuthErrInvalidRequest, "client_id is required")
+	}
+	client, err := s.oauthRepo.GetClient(req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, newOAuthError(OAuthErrInvalidClient, "unknown client_id")
+	}
+
+	// redirect_uri必须与注册的地址完全一致
+	for _, redirectURI := range strings.Fields(client.RedirectURIs) {
+		if redirectURI == req.RedirectURI {
+			return client, nil
+		}
+	}
+	return nil, newOAuthError(OAuthErrInvalidRequest, "redirect_uri is not registered for this client")
+}
+
+// validateAuthorizeRequest 校验授权请求参数，返回申请的授权范围
+func validateAuthorizeRequest(client *model.OAuthClient, req *OAuthAuthorizeRequest) ([]string, *OAuthError) {
+	if req.ResponseType != "code" {
+		return nil, newOAuthError(OAuthErrUnsupportedResponseType, "only response_type=code is supported")
+	}
+	if req.CodeChallenge == "" {
+		return nil, newOAuthError(OAuthErrInvalidRequest, "code_challenge is required")
+	}
+	if req.CodeChallengeMethod != pkceMethodS256 {
+		return nil, newOAuthError(OAuthErrInvalidRequest, "code_challenge_method must be S256")
+	}
+	if len(req.CodeChallenge) != 43 {
+		return nil, newOAuthError(OAuthErrInvalidRequest, "invalid code_challenge")
+	}
+
+	scopes := parseScopes(req.Scope)
+	if len(scopes) == 0 {
+		return nil, newOAuthError(OAuthErrInvalidScope, "scope is required")
+	}
+	if !containsScopes(parseScopes(client.Scopes), scopes) {
+		return nil, newOAuthError(OAuthErrInvalidScope, "requested scope is not allowed for this client")
+	}
+	return scopes, nil
+}
+
+// issueAuthorizationCode 签发授权码并生成回调地址
+func (s *AuthService) issueAuthorizationCode(userID uint, client *model.OAuthClient, req *OAuthAuthorizeRequest, scopes []string) (*OAuthAuthorizeResult, error) {
+	code, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = s.oauthRepo.CreateCode(&model.OAuthAuthorizationCode{
+		CodeHash:      hashCode(code),
+		ClientID:      client.ClientID,
+		UserID:        userID,
+		RedirectURI:   req.RedirectURI,
+		Scopes:        strings.Join(scopes, " "),
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		AuthTime:      now,
+		ExpiresAt:     now.Add(s.oauthPolicy.CodeTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{"code": {code}}
+	return &OAuthAuthorizeResult{RedirectTo: s.authorizeRedirect(req, params)}, nil
+}
+
+// authorizeError 通过回调地址把错误告知应用
+func (s *AuthService) authorizeError(req *OAuthAuthorizeRequest, oauthErr *OAuthError) *OAuthAuthorizeResult {
+	params := url.Values{"error": {oauthErr.Code}}
+	if oauthErr.Description != "" {
+		params.Set("error_description", oauthErr.Description)
+	}
+	return &OAuthAuthorizeResult{RedirectTo: s.authorizeRedirect(req, params)}
+}
+
+// authorizeRedirect 在回调地址上附加参数，同时返回state和iss (RFC 9207)
+func (s *AuthService) authorizeRedirect(req *OAuthAuthorizeRequest, params url.Values) string {
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	params.Set("iss", s.oauthPolicy.Issuer)
+
+	// 回调地址在注册时已校验
+	redirectURI, _ := url.Parse(req.RedirectURI)
+	query := redirectURI.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	redirectURI.RawQuery = query.Encode()
+	return redirectURI.String()
+}
+
+// authenticateOAuthClient 校验应用凭证，公开客户端只需要client_id
+func (s *AuthService) authenticateOAuthClient(clientID, clientSecret string) (*model.OAuthClient, error) {
+	if clientID == "" {
+		return nil, newOAuthError(OAuthErrInvalidClient, "client authentication failed")
+	}
+	client, err := s.oauthRepo.GetClient(clientID)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, newOAuthError(OAuthErrInvalidClient, "client authentication failed")
+	}
+	if !client.Public && subtle.ConstantTimeCompare([]byte(hashCode(clientSecret)), []byte(client.SecretHash)) != 1 {
+		return nil, newOAuthError(OAuthErrInvalidClient, "client authentication failed")
+	}
+	return client, nil
+}
+
+// exchangeAuthorizationCode 使用授权码换取token
+// 授权码重复使用时吊销该授权签发过的刷新token (RFC 6749 4.1.2)
+func (s *AuthService) exchangeAuthorizationCode(client *model.OAuthClient, req *OAuthTokenRequest) (*OAuthTokenResponse, error) {
+	if req.Code == "" || req.CodeVerifier == "" {
+		return nil, newOAuthError(OAuthErrInvalidRequest, "code and code_verifier are required")
+	}
+
+	code, err := s.oauthRepo.GetCodeByHash(hashCode(req.Code))
+	if err != nil {
+		return nil, err
+	}
+	if code == nil || code.ClientID != client.ClientID {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "invalid authorization code")
+	}
+
+	now := time.Now()
+	if code.UsedAt != nil {
+		return nil, s.revokeReusedOAuthGrant(code.UserID, client.ClientID, req.ClientIP)
+	}
+	if !now.Before(code.ExpiresAt) {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "authorization code has expired")
+	}
+	if req.RedirectURI != code.RedirectURI {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "redirect_uri does not match the authorization request")
+	}
+	if !verifyPKCE(code.CodeChallenge, req.CodeVerifier) {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "code_verifier does not match code_challenge")
+	}
+
+	used, err := s.oauthRepo.MarkCodeUsed(code.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, s.revokeReusedOAuthGrant(code.UserID, client.ClientID, req.ClientIP)
+	}
+
+	user, err := s.userRepo.GetUserByID(code.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "user not found")
+	}
+
+	scopes := parseScopes(code.Scopes)
+	response, err := s.newOAuthTokens(user, client.ClientID, scopes, code.Nonce, code.AuthTime)
+	if err != nil {
+		return nil, err
+	}
+
+	if containsScopes(scopes, []string{model.OAuthScopeOfflineAccess}) {
+		familyID, err := pkg.NewTokenID()
+		if err != nil {
+			return nil, err
+		}
+		refreshToken, record, err := s.newOAuthRefreshToken(user.ID, client.ClientID, scopes, code.AuthTime, familyID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.oauthRepo.CreateRefreshToken(record); err != nil {
+			return nil, err
+		}
+		response.RefreshToken = refreshToken
+	}
+	return response, nil
+}
+
+// refreshOAuthToken 使用刷新token换取新的访问token，刷新token同时轮换
+// scope参数可缩小新访问token的范围，刷新token保留原授权范围
+func (s *AuthService) refreshOAuthToken(client *model.OAuthClient, req *OAuthTokenRequest) (*OAuthTokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, newOAuthError(OAuthErrInvalidRequest, "refresh_token is required")
+	}
+
+	record, err := s.oauthRepo.GetRefreshToken(hashCode(req.RefreshToken))
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record.ClientID != client.ClientID || record.RevokedAt != nil {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "invalid refresh token")
+	}
+	if record.UsedAt != nil {
+		return nil, s.revokeReusedOAuthFamily(record, req.ClientIP)
+	}
+	if !time.Now().Before(record.ExpiresAt) {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "refresh token has expired")
+	}
+
+	granted := parseScopes(record.Scopes)
+	scopes := granted
+	if req.Scope != "" {
+		scopes = parseScopes(req.Scope)
+		if !containsScopes(granted, scopes) {
+			return nil, newOAuthError(OAuthErrInvalidScope, "requested scope exceeds the original grant")
+		}
+	}
+
+	user, err := s.userRepo.GetUserByID(record.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "user not found")
+	}
+
+	refreshToken, next, err := s.newOAuthRefreshToken(user.ID, client.ClientID, granted, record.AuthTime, record.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	rotated, err := s.oauthRepo.RotateRefreshToken(record.ID, next)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		return nil, s.revokeReusedOAuthFamily(record, req.ClientIP)
+	}
+
+	response, err := s.newOAuthTokens(user, client.ClientID, scopes, "", record.AuthTime)
+	if err != nil {
+		return nil, err
+	}
+	response.RefreshToken = refreshToken
+	return response, nil
+}
+
+// newOAuthTokens 签发访问token，授权了openid时同时签发ID Token
+func (s *AuthService) newOAuthTokens(user *model.User, clientID string, scopes []string, nonce string, authTime time.Time) (*OAuthTokenResponse, error) {
+	scope := strings.Join(scopes, " ")
+	accessToken, err := s.jwtManager.GenerateOAuthAccessToken(user.ID, clientID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &OAuthTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(s.jwtManager.TokenDuration().Seconds()),
+		Scope:       scope,
+	}
+
+	if containsScopes(scopes, []string{model.OAuthScopeOpenID}) {
+		claims := oauthUserClaims(user, scopes)
+		claims.Nonce = nonce
+		claims.AuthTime = authTime.Unix()
+		idToken, err := s.jwtManager.GenerateIDToken(s.oauthPolicy.Issuer, user.ID, clientID, claims)
+		if err != nil {
+			return nil, err
+		}
+		response.IDToken = idToken
+	}
+	return response, nil
+}
+
+// newOAuthRefreshToken 生成刷新token及其记录，由调用方保存
+func (s *AuthService) newOAuthRefreshToken(userID uint, clientID string, scopes []string, authTime time.Time, familyID string) (string, *model.OAuthRefreshToken, error) {
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return "", nil, err
+	}
+	return token, &model.OAuthRefreshToken{
+		TokenHash: hashCode(token),
+		FamilyID:  familyID,
+		ClientID:  clientID,
+		UserID:    userID,
+		Scopes:    strings.Join(scopes, " "),
+		AuthTime:  authTime,
+		ExpiresAt: time.Now().Add(s.oauthPolicy.RefreshTTL),
+	}, nil
+}
+
+// revokeReusedOAuthGrant 授权码被重复使用，吊销应用持有的该用户的所有刷新token
+func (s *AuthService) revokeReusedOAuthGrant(userID uint, clientID, clientIP string) error {
+	s.audit(AuditOAuthTokenReused, auditEntry{
+		UserID:   userID,
+		ClientIP: clientIP,
+		Details:  applogger.Fields{"client_id": clientID, "grant_type": OAuthGrantAuthorizationCode},
+	})
+
+	if err := s.oauthRepo.RevokeTokens(userID, clientID); err != nil {
+		return err
+	}
+	return newOAuthError(OAuthErrInvalidGrant, "authorization code has already been used")
+}
+
+// revokeReusedOAuthFamily 刷新token被重复使用，吊销整个令牌族
+func (s *AuthService) revokeReusedOAuthFamily(record *model.OAuthRefreshToken, clientIP string) error {
+	s.audit(AuditOAuthTokenReused, auditEntry{
+		UserID:   record.UserID,
+		ClientIP: clientIP,
+		Details:  applogger.Fields{"client_id": record.ClientID, "grant_type": OAuthGrantRefreshToken},
+	})
+
+	if err := s.oauthRepo.RevokeFamily(record.FamilyID); err != nil {
+		return err
+	}
+	return newOAuthError(OAuthErrInvalidGrant, "refresh token has already been used")
+}
+
+// oauthUserClaims 按授权范围生成用户信息声明
+func oauthUserClaims(user *model.User, scopes []string) *pkg.IDTokenClaims {
+	claims := &pkg.IDTokenClaims{}
+	if containsScopes(scopes, []string{model.OAuthScopeProfile}) {
+		claims.PreferredUsername = user.Username
+		claims.Picture = user.AvatarURL
+	}
+	if containsScopes(scopes, []string{model.OAuthScopeEmail}) {
+		claims.Email = user.Email
+	}
+	if containsScopes(scopes, []string{model.OAuthScopePhone}) {
+		claims.PhoneNumber = user.Phone
+	}
+	return claims
+}
+
+func oauthSubject(userID uint) string {
+	return strconv.FormatUint(uint64(userID), 10)
+}
+
+func oauthClientResponse(client *model.OAuthClient) *OAuthClientResponse {
+	return &OAuthClientResponse{
+		ClientID:     client.ClientID,
+		Name:         client.Name,
+		RedirectURIs: strings.Fields(client.RedirectURIs),
+		Scopes:       strings.Fields(client.Scopes),
+		Public:       client.Public,
+		CreatedAt:    client.CreatedAt,
+	}
+}
+
+// verifyPKCE 校验code_verifier: BASE64URL(SHA256(code_verifier)) == code_challenge (RFC 7636)
+func verifyPKCE(challenge, verifier string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+// validateRedirectURI 回调地址必须是不含片段的绝对地址：https、本机http (原生应用的回环回调)，
+// 或公开客户端使用的反向域名私有scheme (如 com.example.app:/oauth)；其他scheme一律拒绝
+func validateRedirectURI(redirectURI string, public bool) error {
+	u, err := url.Parse(redirectURI)
+	if err != nil || !u.IsAbs() || u.Fragment != "" || len(redirectURI) > 500 {
+		return errors.New("无效的回调地址: " + redirectURI)
+	}
+	switch scheme := strings.ToLower(u.Scheme); scheme {
+	case "https":
+		if u.Host == "" {
+			return errors.New("无效的回调地址: " + redirectURI)
+		}
+	case "http":
+		host := u.Hostname()
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return errors.New("回调地址必须使用https: " + redirectURI)
+		}
+	default:
+		if !public || !privateURISchemePattern.MatchString(scheme) {
+			return errors.New("不支持的回调地址scheme: " + redirectURI)
+		}
+	}
+	return nil
+}
+
+// parseScopes 解析空格分隔的授权范围，去除重复项
+func parseScopes(scope string) []string {
+	fields := strings.Fields(scope)
+	scopes := make([]string, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if !seen[field] {
+			seen[field] = true
+			scopes = append(scopes, field)
+		}
+	}
+	return scopes
+}
+
+// containsScopes granted是否包含requested中的全部范围
+func containsScopes(granted, requested []string) bool {
+	for _, scope := range requested {
+		found := false
+		for _, g := range granted {
+			if g == scope {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func isSupportedOAuthScope(scope string) bool {
+	for _, supported := range supportedOAuthScopes {
+		if supported.Name == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/sender"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+// Option AuthService配置项
+type Option func(*AuthService)
+
+// WithDefaultRegion 设置解析国内格式手机号时使用的默认地区
+func WithDefaultRegion(region string) Option {
+	return func(s *AuthService) {
+		s.defaultRegion = region
+	}
+}
+
+// WithSenders 设置短信和邮件发送器 (验证码、密码重置消息)，未设置时拒绝发送
+func WithSenders(smsSender, emailSender sender.Sender) Option {
+	return func(s *AuthService) {
+		if smsSender != nil {
+			s.smsSender = smsSender
+		}
+		if emailSender != nil {
+			s.emailSender = emailSender
+		}
+	}
+}
+
+// WithCodePolicy 设置验证码策略，未设置(零值)的字段保留默认值
+func WithCodePolicy(policy CodePolicy) Option {
+	return func(s *AuthService) {
+		if policy.Length > 0 {
+			s.codePolicy.Length = policy.Length
+		}
+		if policy.TTL > 0 {
+			s.codePolicy.TTL = policy.TTL
+		}
+		if policy.MaxAttempts > 0 {
+			s.codePolicy.MaxAttempts = policy.MaxAttempts
+		}
+		if policy.ResendCooldown > 0 {
+			s.codePolicy.ResendCooldown = policy.ResendCooldown
+		}
+	}
+}
+
+// WithTOTPIssuer 设置验证器应用中显示的服务名
+func WithTOTPIssuer(issuer string) Option {
+	return func(s *AuthService) {
+		if issuer != "" {
+			s.totpIssuer = issuer
+		}
+	}
+}
+
+// WithLoginProtection 设置登录防暴力破解策略，未设置(零值)的字段保留默认值
+func WithLoginProtection(policy LoginProtectionPolicy) Option {
+	return func(s *AuthService) {
+		if policy.Window > 0 {
+			s.loginPolicy.Window = policy.Window
+		}
+		if policy.AccountThreshold > 0 {
+			s.loginPolicy.AccountThreshold = policy.AccountThreshold
+		}
+		if policy.IPThreshold > 0 {
+			s.loginPolicy.IPThreshold = policy.IPThreshold
+		}
+		if policy.DeviceThreshold > 0 {
+			s.loginPolicy.DeviceThreshold = policy.DeviceThreshold
+		}
+		if policy.BaseLockout > 0 {
+			s.loginPolicy.BaseLockout = policy.BaseLockout
+		}
+		if policy.MaxLockout > 0 {
+			s.loginPolicy.MaxLockout = policy.MaxLockout
+		}
+	}
+}
+
+// WithPasswordReset 设置密码重置token有效期和重置链接模板 ({token}替换为重置token)
+func WithPasswordReset(ttl time.Duration, urlTemplate string) Option {
+	return func(s *AuthService) {
+		if ttl > 0 {
+			s.passwordResetTTL = ttl
+		}
+		s.passwordResetURL = urlTemplate
+	}
+}
+
+// WithPasswordManager 设置密码哈希算法和参数
+func WithPasswordManager(manager *pkg.PasswordManager) Option {
+	return func(s *AuthService) {
+		if manager != nil {
+			s.passwordManager = manager
+		}
+	}
+}
+
+// WithPasswordPolicy 设置密码策略
+func WithPasswordPolicy(policy *pkg.PasswordPolicy) Option {
+	return func(s *AuthService) {
+		if policy != nil {
+			s.passwordPolicy = policy
+		}
+	}
+}
+
+// WithDeletionGracePeriod 设置删除账号的宽限期
+func WithDeletionGracePeriod(grace time.Duration) Option {
+	return func(s *AuthService) {
+		if grace > 0 {
+			s.deletionGracePeriod = grace
+		}
+	}
+}
+
+// WithAuditRetention 设置安全事件保留时长
+func WithAuditRetention(retention time.Duration) Option {
+	return func(s *AuthService) {
+		if retention > 0 {
+			s.auditRetention = retention
+		}
+	}
+}
+
+// WithDeviceApproval 设置新设备登录审批策略
+func WithDeviceApproval(policy DeviceApprovalPolicy) Option {
+	return func(s *AuthService) {
+		s.deviceApproval.Enabled = policy.Enabled
+		if policy.Timeout > 0 {
+			s.deviceApproval.Timeout = policy.Timeout
+		}
+	}
+}
+
+// WithOAuth 设置OAuth2/OpenID Connect的issuer、授权页面和token有效期，未设置(零值)的字段保留默认值
+func WithOAuth(policy OAuthPolicy) Option {
+	return func(s *AuthService) {
+		if policy.Issuer != "" {
+			s.oauthPolicy.Issuer = strings.TrimRight(policy.Issuer, "/")
+		}
+		if policy.AuthorizationURL != "" {
+			s.oauthPolicy.AuthorizationURL = policy.AuthorizationURL
+		}
+		if policy.CodeTTL > 0 {
+			s.oauthPolicy.CodeTTL = policy.CodeTTL
+		}
+		if policy.RefreshTTL > 0 {
+			s.oauthPolicy.RefreshTTL = policy.RefreshTTL
+		}
+	}
+}
+
+// WithBots 设置机器人数量上限和请求频率限制，未设置(零值)的字段保留默认值
+func WithBots(policy BotPolicy) Option {
+	return func(s *AuthService) {
+		if policy.MaxPerOwner > 0 {
+			s.botPolicy.MaxPerOwner = policy.MaxPerOwner
+		}
+		if policy.RateLimit > 0 {
+			s.botPolicy.RateLimit = policy.RateLimit
+		}
+		if policy.RateWindow > 0 {
+			s.botPolicy.RateWindow = policy.RateWindow
+		}
+	}
+}
+
+// WithPersonalAccessTokens 设置个人访问token的最长有效期和数量上限
+func WithPersonalAccessTokens(policy PersonalAccessTokenPolicy) Option {
+	return func(s *AuthService) {
+		if policy.MaxTTL > 0 {
+			s.patPolicy.MaxTTL = policy.MaxTTL
+		}
+		if policy.MaxPerUser > 0 {
+			s.patPolicy.MaxPerUser = policy.MaxPerUser
+		}
+	}
+}
+
+// WithUsernamePolicy 设置修改用户名的间隔、旧用户名保留时长和额外的保留用户名
+func WithUsernamePolicy(policy UsernamePolicy) Option {
+	return func(s *AuthService) {
+		if policy.ChangeCooldown > 0 {
+			s.usernamePolicy.ChangeCooldown = policy.ChangeCooldown
+		}
+		if policy.HoldPeriod > 0 {
+			s.usernamePolicy.HoldPeriod = policy.HoldPeriod
+		}
+		s.usernamePolicy.Reserved = policy.Reserved
+	}
+}
+
+// WithDevicePolicy 设置设备离线超时和闲置设备的删除期限，StaleAfter为负数时不删除闲置设备
+func WithDevicePolicy(policy DevicePolicy) Option {
+	return func(s *AuthService) {
+		if policy.IdleTimeout > 0 {
+			s.devicePolicy.IdleTimeout = policy.IdleTimeout
+		}
+		if policy.StaleAfter > 0 {
+			s.devicePolicy.StaleAfter = policy.StaleAfter
+		} else if policy.StaleAfter < 0 {
+			s.devicePolicy.StaleAfter = 0
+		}
+	}
+}
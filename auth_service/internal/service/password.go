@@ -0,0 +1,169 @@
+package service
+
+import (
+	"errors"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+// ChangePasswordRequest 修改密码请求
+type ChangePasswordRequest struct {
+	OldPassword         string `json:"old_password"`
+	Code                string `json:"code"` // 未设置密码的账号使用发送到手机号/邮箱的登录验证码设置密码
+	NewPassword         string `json:"new_password" binding:"required"`
+	RevokeOtherSessions bool   `json:"revoke_other_sessions"` // 同时终止除当前设备外的所有会话
+	ClientIP            string `json:"-"`
+	UserAgent           string `json:"-"`
+}
+
+// ChangePasswordResponse 修改密码响应
+type ChangePasswordResponse struct {
+	RevokedSessions int `json:"revoked_sessions"`
+}
+
+// ChangePassword 验证原密码后修改密码，未设置密码的账号验证登录验证码后设置密码
+// 验证失败与登录失败共用计数，不能通过修改密码接口绕过登录锁定猜测密码
+func (s *AuthService) ChangePassword(userID, deviceID uint, req *ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("用户不存在")
+	}
+
+	loginReq := &LoginRequest{ClientIP: req.ClientIP, UserAgent: req.UserAgent}
+	subjects := s.loginSubjects(user, loginReq)
+	if err := s.checkLoginLock(user.ID, subjects, loginReq); err != nil {
+		return nil, err
+	}
+	if err := s.verifyCurrentPassword(user, req); err != nil {
+		s.recordLoginFailure(user.ID, subjects, loginReq, err.Error())
+		return nil, err
+	}
+	s.resetLoginFailures(subjects)
+
+	if err := s.checkPassword(user, req.NewPassword); err != nil {
+		return nil, err
+	}
+
+	if err := s.setPassword(user.ID, req.NewPassword); err != nil {
+		return nil, err
+	}
+
+	resp := &ChangePasswordResponse{}
+	if req.RevokeOtherSessions {
+		resp.RevokedSessions, err = s.RevokeOtherSessions(user.ID, deviceID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.audit(AuditPasswordChanged, auditEntry{
+		UserID:    user.ID,
+		DeviceID:  deviceID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+		Details:   applogger.Fields{"revoked_sessions": resp.RevokedSessions},
+	})
+	return resp, nil
+}
+
+// verifyCurrentPassword 验证原密码，未设置密码的账号验证登录验证码
+func (s *AuthService) verifyCurrentPassword(user *model.User, req *ChangePasswordRequest) error {
+	if user.PasswordHash != "" {
+		if err := s.passwordManager.VerifyPassword(user.PasswordHash, req.OldPassword); err != nil {
+			return errors.New("原密码错误")
+		}
+		return nil
+	}
+
+	if req.Code == "" {
+		return errors.New("账号未设置密码，请使用验证码设置密码")
+	}
+	target := user.Phone
+	if target == "" {
+		target = user.Email
+	}
+	return s.checkCode(CodePurposeLogin, target, req.Code)
+}
+
+// checkPassword 按密码策略检查新密码，user非空时同时检查是否与最近使用的密码重复
+func (s *AuthService) checkPassword(user *model.User, password string) error {
+	violations := s.passwordPolicy.Validate(password)
+
+	if user != nil && s.passwordPolicy.HistorySize > 0 {
+		reused, err := s.isRecentPassword(user, password)
+		if err != nil {
+			return err
+		}
+		if reused {
+			violations = append(violations, s.passwordPolicy.ReusedViolation())
+		}
+	}
+
+	if len(violations) > 0 {
+		return &pkg.PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// isRecentPassword 密码是否与当前密码或最近的历史密码相同
+func (s *AuthService) isRecentPassword(user *model.User, password string) (bool, error) {
+	hashes, err := s.userRepo.GetPasswordHistory(user.ID, s.passwordPolicy.HistorySize)
+	if err != nil {
+		return false, err
+	}
+	// 启用历史记录前设置的密码不在历史表中
+	if user.PasswordHash != "" {
+		hashes = append(hashes, user.PasswordHash)
+	}
+
+	checked := make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		if checked[hash] {
+			continue
+		}
+		checked[hash] = true
+		if s.passwordManager.VerifyPassword(hash, password) == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// setPassword 保存新密码哈希并记录历史密码
+func (s *AuthService) setPassword(userID uint, password string) error {
+	hashedPassword, err := s.passwordManager.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	return s.userRepo.UpdatePassword(userID, hashedPassword, s.passwordPolicy.HistorySize)
+}
+
+// rehashPassword 哈希使用过时的算法或参数时按当前配置重新计算并保存，失败不影响登录
+func (s *AuthService) rehashPassword(user *model.User, password string) {
+	if !s.passwordManager.NeedsRehash(user.PasswordHash) {
+		return
+	}
+
+	hashedPassword, err := s.passwordManager.HashPassword(password)
+	if err == nil {
+		// 只在密码未被并发修改时替换
+		var replaced bool
+		replaced, err = s.userRepo.ReplacePasswordHash(user.ID, user.PasswordHash, hashedPassword)
+		if replaced {
+			user.PasswordHash = hashedPassword
+		}
+	}
+	if err != nil {
+		if log := applogger.GetDefault(); log != nil {
+			log.Warn("Failed to rehash password", applogger.Fields{
+				"user_id": user.ID,
+				"error":   err.Error(),
+			})
+		}
+	}
+}
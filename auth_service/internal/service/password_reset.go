@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+const (
+	codePurposePasswordReset = "reset"
+	defaultPasswordResetTTL  = 30 * time.Minute
+)
+
+var errInvalidResetToken = errors.New("重置链接无效或已过期")
+
+// RequestPasswordResetRequest 申请重置密码请求
+type RequestPasswordResetRequest struct {
+	Phone     string `json:"phone"`
+	Email     string `json:"email"`
+	ClientIP  string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// ResetPasswordRequest 重置密码请求
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+	ClientIP    string `json:"-"`
+	UserAgent   string `json:"-"`
+}
+
+// RequestPasswordReset 向账号绑定的手机号或邮箱发送重置链接
+// 限流通过后立即返回成功，查找账号和发送消息在后台完成，账号是否存在、发送是否成功
+// 都不影响响应内容和耗时；未配置Redis时无法限流，拒绝请求
+func (s *AuthService) RequestPasswordReset(req *RequestPasswordResetRequest) error {
+	if s.codeRepo == nil {
+		return errors.New("密码重置服务不可用")
+	}
+
+	target, err := s.resolveCodeTarget(req.Phone, req.Email)
+	if err != nil {
+		return err
+	}
+
+	acquired, err := s.codeRepo.AcquireCooldown(context.Background(), codePurposePasswordReset, target.value(), s.codePolicy.ResendCooldown)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return errors.New("请求过于频繁，请稍后再试")
+	}
+
+	clientIP, userAgent := req.ClientIP, req.UserAgent
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		s.sendPasswordReset(target, clientIP, userAgent)
+	}()
+	return nil
+}
+
+// sendPasswordReset 为目标账号创建重置token并发送，账号不存在时只记录审计事件，失败时记录日志
+func (s *AuthService) sendPasswordReset(target codeTarget, clientIP, userAgent string) {
+	user, err := s.resolveUser(target.phone, target.email, "")
+	if err != nil {
+		logPasswordResetError("Failed to resolve password reset account", 0, err)
+		return
+	}
+	if user == nil {
+		s.audit(AuditPasswordResetRequested, auditEntry{
+			ClientIP:  clientIP,
+			UserAgent: userAgent,
+			Details:   applogger.Fields{"target": target.value(), "found": false},
+		})
+		return
+	}
+
+	token, err := generateOpaqueToken()
+	if err != nil {
+		logPasswordResetError("Failed to generate password reset token", user.ID, err)
+		return
+	}
+
+	record := &model.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashCode(token),
+		RequestIP: clientIP,
+		ExpiresAt: time.Now().Add(s.passwordResetTTL),
+	}
+	if err := s.passwordResetRepo.CreateToken(record); err != nil {
+		logPasswordResetError("Failed to save password reset token", user.ID, err)
+		return
+	}
+
+	resetSender := s.emailSender
+	if target.phone != "" {
+		resetSender = s.smsSender
+	}
+	if err := resetSender.SendPasswordReset(context.Background(), target.value(), s.passwordResetLink(token), s.passwordResetTTL); err != nil {
+		logPasswordResetError("Failed to send password reset message", user.ID, err)
+		return
+	}
+
+	s.audit(AuditPasswordResetRequested, auditEntry{
+		UserID:    user.ID,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+		Details:   applogger.Fields{"target": target.value(), "found": true},
+	})
+}
+
+// logPasswordResetError 记录后台发送密码重置消息的错误
+func logPasswordResetError(msg string, userID uint, err error) {
+	if log := applogger.GetDefault(); log != nil {
+		log.Error(msg, applogger.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		})
+	}
+}
+
+// ResetPassword 使用重置token设置新密码，成功后该用户所有会话和设备token立即失效
+func (s *AuthService) ResetPassword(req *ResetPasswordRequest) error {
+	token := strings.TrimSpace(req.Token)
+	if token == "" {
+		return errInvalidResetToken
+	}
+
+	record, err := s.passwordResetRepo.GetValidToken(hashCode(token))
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return errInvalidResetToken
+	}
+
+	// 停用的账号也可以重置密码，之后登录即可恢复
+	user, err := s.userRepo.GetUserByIDIncludingInactive(record.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errInvalidResetToken
+	}
+
+	// 新密码不符合策略时不消耗token，用户可以换个密码重试
+	if err := s.checkPassword(user, req.NewPassword); err != nil {
+		return err
+	}
+
+	record, err = s.passwordResetRepo.ConsumeToken(hashCode(token))
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return errInvalidResetToken
+	}
+
+	if err := s.setPassword(user.ID, req.NewPassword); err != nil {
+		return err
+	}
+
+	if err := s.logoutAllDevices(user.ID); err != nil {
+		return err
+	}
+
+	// 重置成功后解除账号的登录锁定计数
+	s.resetLoginFailures([]loginSubject{{scope: loginScopeAccount, id: fmt.Sprintf("user:%d", user.ID)}})
+
+	s.audit(AuditPasswordReset, auditEntry{
+		UserID:    user.ID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+	})
+	return nil
+}
+
+// logoutAllDevices 吊销用户所有token并将所有设备标记为离线
+func (s *AuthService) logoutAllDevices(userID uint) error {
+	if err := s.RevokeUserTokens(userID); err != nil {
+		return err
+	}
+	return s.deviceRepo.SetUserDevicesOffline(userID)
+}
+
+// passwordResetLink 根据链接模板生成重置链接，未配置模板时直接返回token
+func (s *AuthService) passwordResetLink(token string) string {
+	if s.passwordResetURL == "" {
+		return token
+	}
+	return strings.ReplaceAll(s.passwordResetURL, "{token}", token)
+}
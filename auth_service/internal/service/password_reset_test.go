@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+// stubSender 记录发送内容的测试发送器
+type stubSender struct {
+	targets []string
+	codes   []string
+	links   []string
+}
+
+func (s *stubSender) SendCode(ctx context.Context, target, code string, ttl time.Duration) error {
+	s.targets = append(s.targets, target)
+	s.codes = append(s.codes, code)
+	return nil
+}
+
+// lastCode 最近发送的验证码
+func (s *stubSender) lastCode() string {
+	if len(s.codes) == 0 {
+		return ""
+	}
+	return s.codes[len(s.codes)-1]
+}
+
+func (s *stubSender) SendPasswordReset(ctx context.Context, target, link string, ttl time.Duration) error {
+	s.targets = append(s.targets, target)
+	s.links = append(s.links, link)
+	return nil
+}
+
+func TestAuthService_PasswordReset(t *testing.T) {
+	setupTestDB(t)
+	redisServer := setupTestRedis(t)
+	emailSender := &stubSender{}
+	authService := NewAuthService(
+		pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithSenders(&stubSender{}, emailSender),
+		WithPasswordReset(10*time.Minute, "https://example.com/reset?token={token}"),
+	)
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Email:       "alice@example.com",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+
+	// 不存在的账号同样返回成功，但不发送消息
+	require.NoError(t, authService.RequestPasswordReset(&RequestPasswordResetRequest{Email: "nobody@example.com"}))
+	authService.WaitBackground()
+	assert.Empty(t, emailSender.links)
+
+	require.NoError(t, authService.RequestPasswordReset(&RequestPasswordResetRequest{Email: " Alice@Example.com "}))
+	authService.WaitBackground()
+	require.Len(t, emailSender.links, 1)
+	assert.Equal(t, "alice@example.com", emailSender.targets[0])
+	firstToken := strings.TrimPrefix(emailSender.links[0], "https://example.com/reset?token=")
+
+	// 冷却期内不能重复申请
+	assert.EqualError(t, authService.RequestPasswordReset(&RequestPasswordResetRequest{Email: "alice@example.com"}), "请求过于频繁，请稍后再试")
+
+	// 重新申请后之前的token失效
+	redisServer.FastForward(authService.codePolicy.ResendCooldown)
+	require.NoError(t, authService.RequestPasswordReset(&RequestPasswordResetRequest{Email: "alice@example.com"}))
+	authService.WaitBackground()
+	require.Len(t, emailSender.links, 2)
+	token := strings.TrimPrefix(emailSender.links[1], "https://example.com/reset?token=")
+	assert.Error(t, authService.ResetPassword(&ResetPasswordRequest{Token: firstToken, NewPassword: "new-password"}))
+
+	// 新密码不符合要求时不消耗token
+	assert.Error(t, authService.ResetPassword(&ResetPasswordRequest{Token: token, NewPassword: "123"}))
+	require.NoError(t, authService.ResetPassword(&ResetPasswordRequest{Token: token, NewPassword: "new-password"}))
+
+	// token只能使用一次
+	assert.Error(t, authService.ResetPassword(&ResetPasswordRequest{Token: token, NewPassword: "another-password"}))
+
+	// 原有会话全部失效
+	sessions, err := authService.ListSessions(registered.User.ID, 0)
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+	_, err = authService.RefreshToken(registered.Token.RefreshToken, "", "")
+	assert.Error(t, err)
+
+	// 旧密码不可用，新密码可以登录
+	_, err = authService.Login(&LoginRequest{Username: "alice", Password: "password123", DeviceToken: "phone-1", DeviceType: "ios"})
+	assert.Error(t, err)
+	_, err = authService.Login(&LoginRequest{Username: "alice", Password: "new-password", DeviceToken: "phone-1", DeviceType: "ios"})
+	assert.NoError(t, err)
+}
+
+func TestAuthService_PasswordResetExpired(t *testing.T) {
+	setupTestDB(t)
+	setupTestRedis(t)
+	emailSender := &stubSender{}
+	authService := NewAuthService(
+		pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithSenders(&stubSender{}, emailSender),
+		WithPasswordReset(time.Nanosecond, ""),
+	)
+
+	_, err := authService.Register(&RegisterRequest{
+		Email:       "alice@example.com",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, authService.RequestPasswordReset(&RequestPasswordResetRequest{Email: "alice@example.com"}))
+	authService.WaitBackground()
+	require.Len(t, emailSender.links, 1)
+
+	time.Sleep(time.Millisecond)
+	err = authService.ResetPassword(&ResetPasswordRequest{Token: emailSender.links[0], NewPassword: "new-password"})
+	assert.ErrorIs(t, err, errInvalidResetToken)
+}
+
+func TestAuthService_PasswordResetDoesNotRevealAccounts(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(
+		pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithSenders(failingSender{}, failingSender{}),
+	)
+
+	// 未配置Redis时无法限流，拒绝请求
+	err := authService.RequestPasswordReset(&RequestPasswordResetRequest{Email: "alice@example.com"})
+	assert.EqualError(t, err, "密码重置服务不可用")
+
+	setupTestRedis(t)
+	authService = NewAuthService(
+		pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithSenders(failingSender{}, failingSender{}),
+	)
+	registered, err := authService.Register(&RegisterRequest{
+		Email:       "alice@example.com",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+
+	// 发送失败和账号不存在时的响应一致
+	require.NoError(t, authService.RequestPasswordReset(&RequestPasswordResetRequest{Email: "alice@example.com"}))
+	require.NoError(t, authService.RequestPasswordReset(&RequestPasswordResetRequest{Email: "nobody@example.com"}))
+	authService.WaitBackground()
+
+	// 发送失败时不记录申请成功的事件
+	events, _, err := authService.ListSecurityEvents(registered.User.ID, "", 20)
+	require.NoError(t, err)
+	for _, event := range events {
+		assert.NotEqual(t, AuditPasswordResetRequested, event.Event)
+	}
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	setupTestDB(t)
+	policy := pkg.DefaultPasswordPolicy()
+	policy.MinLength = 8
+	policy.RequireDigit = true
+	policy.HistorySize = 2
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour), WithPasswordPolicy(policy))
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password1",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	userID, deviceID := registered.User.ID, registered.Device.ID
+
+	_, err = authService.Login(&LoginRequest{Username: "alice", Password: "password1", DeviceToken: "desktop-1", DeviceType: "desktop"})
+	require.NoError(t, err)
+
+	change := func(oldPassword, newPassword string, revokeOthers bool) (*ChangePasswordResponse, error) {
+		return authService.ChangePassword(userID, deviceID, &ChangePasswordRequest{
+			OldPassword:         oldPassword,
+			NewPassword:         newPassword,
+			RevokeOtherSessions: revokeOthers,
+		})
+	}
+
+	_, err = change("wrong-password", "password2", false)
+	assert.EqualError(t, err, "原密码错误")
+
+	// 违规项以结构化形式返回
+	_, err = change("password1", "short", false)
+	var policyErr *pkg.PasswordPolicyError
+	require.True(t, errors.As(err, &policyErr))
+	assert.Equal(t, []string{pkg.ViolationTooShort, pkg.ViolationMissingDigit}, violationCodes(policyErr.Violations))
+
+	// 不能重复使用当前密码
+	_, err = change("password1", "password1", false)
+	require.True(t, errors.As(err, &policyErr))
+	assert.Equal(t, []string{pkg.ViolationReused}, violationCodes(policyErr.Violations))
+
+	resp, err := change("password1", "password2", false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, resp.RevokedSessions)
+
+	resp, err = change("password2", "password3", true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.RevokedSessions)
+
+	sessions, err := authService.ListSessions(userID, deviceID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.True(t, sessions[0].IsCurrent)
+
+	// 只保留最近2个密码，更早的密码可以再次使用
+	_, err = change("password3", "password2", false)
+	assert.Error(t, err)
+	_, err = change("password3", "password1", false)
+	assert.NoError(t, err)
+}
+
+func TestAuthService_ChangePasswordLockoutAndCodeUsers(t *testing.T) {
+	setupTestDB(t)
+	redisServer := setupTestRedis(t)
+	sms := &stubSender{}
+	authService := NewAuthService(
+		pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithSenders(sms, &stubSender{}),
+		WithLoginProtection(LoginProtectionPolicy{
+			Window:           15 * time.Minute,
+			AccountThreshold: 3,
+			IPThreshold:      100,
+			DeviceThreshold:  100,
+			BaseLockout:      time.Minute,
+			MaxLockout:       time.Hour,
+		}),
+	)
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password1",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	change := func(oldPassword string) error {
+		_, err := authService.ChangePassword(registered.User.ID, registered.Device.ID, &ChangePasswordRequest{
+			OldPassword: oldPassword,
+			NewPassword: "password2",
+			ClientIP:    "203.0.113.7",
+		})
+		return err
+	}
+
+	// 原密码连续错误后锁定，修改密码和登录都被拒绝
+	for i := 0; i < 3; i++ {
+		assert.EqualError(t, change("wrong-password"), "原密码错误")
+	}
+	assert.Equal(t, errLoginLocked, change("password1"))
+	_, err = authService.Login(&LoginRequest{Username: "alice", Password: "password1", DeviceToken: "phone-1", DeviceType: "ios"})
+	assert.Equal(t, errLoginLocked, err)
+
+	redisServer.FastForward(2 * time.Minute)
+	require.NoError(t, change("password1"))
+
+	// 验证码注册的账号没有原密码，验证登录验证码后设置密码
+	_, err = authService.SendCode(&SendCodeRequest{Phone: "+8613800000001"})
+	require.NoError(t, err)
+	created, err := authService.VerifyCode(&VerifyCodeRequest{
+		Phone:       "+8613800000001",
+		Code:        sms.lastCode(),
+		DeviceToken: "phone-2",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	require.True(t, created.IsNewUser)
+
+	setPassword := func(code string) error {
+		_, err := authService.ChangePassword(created.User.ID, created.Device.ID, &ChangePasswordRequest{
+			Code:        code,
+			NewPassword: "password3",
+		})
+		return err
+	}
+	assert.EqualError(t, setPassword(""), "账号未设置密码，请使用验证码设置密码")
+
+	redisServer.FastForward(authService.codePolicy.ResendCooldown)
+	_, err = authService.SendCode(&SendCodeRequest{Phone: "+8613800000001"})
+	require.NoError(t, err)
+	assert.Error(t, setPassword("000000"))
+	require.NoError(t, setPassword(sms.lastCode()))
+
+	_, err = authService.Login(&LoginRequest{Phone: "+8613800000001", Password: "password3", DeviceToken: "phone-2", DeviceType: "ios"})
+	assert.NoError(t, err)
+}
+
+func violationCodes(violations []pkg.PasswordViolation) []string {
+	codes := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		codes = append(codes, violation.Code)
+	}
+	return codes
+}
+
+func TestAuthService_LoginRehashesLegacyPassword(t *testing.T) {
+	setupTestDB(t)
+	jwtManager := pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour)
+
+	legacyManager, err := pkg.NewPasswordManagerWithConfig(pkg.PasswordHashConfig{Algorithm: pkg.HashAlgorithmBcrypt, BcryptCost: bcrypt.MinCost})
+	require.NoError(t, err)
+	legacyService := NewAuthService(jwtManager, WithPasswordManager(legacyManager))
+	registered, err := legacyService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password1",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+
+	storedHash := func() string {
+		user, err := repository.NewUserRepository().GetUserByID(registered.User.ID)
+		require.NoError(t, err)
+		return user.PasswordHash
+	}
+	legacyHash := storedHash()
+	require.True(t, strings.HasPrefix(legacyHash, "$2a$"))
+
+	currentManager, err := pkg.NewPasswordManagerWithConfig(pkg.PasswordHashConfig{Memory: 1024, Time: 1, Threads: 1})
+	require.NoError(t, err)
+	authService := NewAuthService(jwtManager, WithPasswordManager(currentManager))
+
+	// 密码错误时不重新计算
+	_, err = authService.Login(&LoginRequest{Username: "alice", Password: "wrong-password", DeviceToken: "phone-1", DeviceType: "ios"})
+	require.Error(t, err)
+	assert.Equal(t, legacyHash, storedHash())
+
+	// 登录成功后透明升级为argon2id，旧密码仍可登录
+	_, err = authService.Login(&LoginRequest{Username: "alice", Password: "password1", DeviceToken: "phone-1", DeviceType: "ios"})
+	require.NoError(t, err)
+	upgradedHash := storedHash()
+	assert.True(t, strings.HasPrefix(upgradedHash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+	assert.False(t, currentManager.NeedsRehash(upgradedHash))
+
+	_, err = authService.Login(&LoginRequest{Username: "alice", Password: "password1", DeviceToken: "phone-1", DeviceType: "ios"})
+	require.NoError(t, err)
+	assert.Equal(t, upgradedHash, storedHash())
+}
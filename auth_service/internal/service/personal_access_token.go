@@ -0,0 +1,205 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+const patHintLength = 4
+
+var errInvalidPersonalAccessToken = errors.New("个人访问token无效")
+
+// PersonalAccessTokenPolicy 个人访问token策略
+type PersonalAccessTokenPolicy struct {
+	MaxTTL     time.Duration // 最长有效期
+	MaxPerUser int           // 每个用户同时有效的token数
+}
+
+// defaultPersonalAccessTokenPolicy 默认个人访问token策略
+func defaultPersonalAccessTokenPolicy() PersonalAccessTokenPolicy {
+	return PersonalAccessTokenPolicy{
+		MaxTTL:     365 * 24 * time.Hour,
+		MaxPerUser: 50,
+	}
+}
+
+// CreatePersonalAccessTokenRequest 创建个人访问token请求
+type CreatePersonalAccessTokenRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1"`
+	ExpiresInDays int      `json:"expires_in_days" binding:"required,min=1"`
+	ClientIP      string   `json:"-"`
+	UserAgent     string   `json:"-"`
+}
+
+// PersonalAccessTokenInfo 个人访问token信息 (不含明文)
+type PersonalAccessTokenInfo struct {
+	model.PersonalAccessToken
+	Scopes []string `json:"scopes"`
+}
+
+// PersonalAccessTokenResponse 新创建的个人访问token，明文只在创建时返回一次
+type PersonalAccessTokenResponse struct {
+	PersonalAccessTokenInfo
+	Token string `json:"token"`
+}
+
+// PersonalAccessTokenIdentity 个人访问token验证结果
+type PersonalAccessTokenIdentity struct {
+	UserID    uint
+	Scopes    []string
+	ExpiresAt time.Time
+}
+
+// CreatePersonalAccessToken 创建带授权范围和有效期的个人访问token
+func (s *AuthService) CreatePersonalAccessToken(userID uint, req *CreatePersonalAccessTokenRequest) (*PersonalAccessTokenResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("token名称不能为空")
+	}
+
+	scopes, err := normalizeTokenScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	if ttl <= 0 || ttl > s.patPolicy.MaxTTL {
+		return nil, errors.New("有效期超出允许范围")
+	}
+
+	now := time.Now()
+	count, err := s.patRepo.CountActive(userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if count >= int64(s.patPolicy.MaxPerUser) {
+		return nil, errors.New("有效token数量已达上限，请先吊销不用的token")
+	}
+
+	value, err := pkg.NewPersonalAccessToken()
+	if err != nil {
+		return nil, err
+	}
+	record := &model.PersonalAccessToken{
+		UserID:    userID,
+		Name:      name,
+		TokenHash: hashCode(value),
+		Hint:      value[len(value)-patHintLength:],
+		Scopes:    strings.Join(scopes, " "),
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := s.patRepo.Create(record); err != nil {
+		return nil, err
+	}
+
+	s.audit(AuditPersonalAccessTokenCreated, auditEntry{
+		UserID:    userID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+		Details: applogger.Fields{
+			"token_id":   record.ID,
+			"scopes":     record.Scopes,
+			"expires_at": record.ExpiresAt,
+		},
+	})
+
+	return &PersonalAccessTokenResponse{
+		PersonalAccessTokenInfo: PersonalAccessTokenInfo{PersonalAccessToken: *record, Scopes: scopes},
+		Token:                   value,
+	}, nil
+}
+
+// ListPersonalAccessTokens 获取用户的个人访问token
+func (s *AuthService) ListPersonalAccessTokens(userID uint) ([]PersonalAccessTokenInfo, error) {
+	tokens, err := s.patRepo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]PersonalAccessTokenInfo, 0, len(tokens))
+	for _, token := range tokens {
+		infos = append(infos, PersonalAccessTokenInfo{PersonalAccessToken: token, Scopes: strings.Fields(token.Scopes)})
+	}
+	return infos, nil
+}
+
+// RevokePersonalAccessToken 吊销个人访问token，立即生效
+func (s *AuthService) RevokePersonalAccessToken(userID, tokenID uint, clientIP, userAgent string) error {
+	revoked, err := s.patRepo.Revoke(userID, tokenID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.New("token不存在或已吊销")
+	}
+
+	s.audit(AuditPersonalAccessTokenRevoked, auditEntry{
+		UserID:    userID,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+		Details:   applogger.Fields{"token_id": tokenID},
+	})
+	return nil
+}
+
+// VerifyPersonalAccessToken 验证个人访问token，返回用户和授权范围
+// 用户停用时token失效
+func (s *AuthService) VerifyPersonalAccessToken(token string) (*PersonalAccessTokenIdentity, error) {
+	if !pkg.IsPersonalAccessToken(token) {
+		return nil, errInvalidPersonalAccessToken
+	}
+
+	now := time.Now()
+	record, err := s.patRepo.GetActive(hashCode(token), now)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, errInvalidPersonalAccessToken
+	}
+
+	user, err := s.userRepo.GetUserByID(record.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errInvalidPersonalAccessToken
+	}
+
+	if err := s.patRepo.Touch(record, now); err != nil {
+		s.logTokenBookkeepingError(err)
+	}
+
+	return &PersonalAccessTokenIdentity{
+		UserID:    user.ID,
+		Scopes:    strings.Fields(record.Scopes),
+		ExpiresAt: record.ExpiresAt,
+	}, nil
+}
+
+// normalizeTokenScopes 校验、去重并排序授权范围
+func normalizeTokenScopes(requested []string) ([]string, error) {
+	seen := make(map[string]bool, len(requested))
+	scopes := make([]string, 0, len(requested))
+	for _, scope := range requested {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !pkg.IsValidScope(scope) {
+			return nil, errors.New("无效的授权范围: " + scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, errors.New("至少需要一个授权范围")
+	}
+	sort.Strings(scopes)
+	return scopes, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+func TestAuthService_PersonalAccessTokens(t *testing.T) {
+	setupTestDB(t)
+	jwtManager := pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour)
+	authService := NewAuthService(jwtManager, WithPersonalAccessTokens(PersonalAccessTokenPolicy{MaxTTL: 30 * 24 * time.Hour}))
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	userID := registered.User.ID
+
+	// 范围必须有效 (没有admin范围)，有效期不能超过上限
+	for _, req := range []*CreatePersonalAccessTokenRequest{
+		{Name: "ci", Scopes: []string{"messages:read"}, ExpiresInDays: 7},
+		{Name: "ci", Scopes: []string{"admin:*"}, ExpiresInDays: 7},
+		{Name: "ci", Scopes: []string{"profile:read"}, ExpiresInDays: 31},
+		{Name: " ", Scopes: []string{"profile:read"}, ExpiresInDays: 7},
+	} {
+		_, err := authService.CreatePersonalAccessToken(userID, req)
+		assert.Error(t, err, req)
+	}
+
+	created, err := authService.CreatePersonalAccessToken(userID, &CreatePersonalAccessTokenRequest{
+		Name:          "ci",
+		Scopes:        []string{"Profile:Read", "friends:*", "profile:read"},
+		ExpiresInDays: 7,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"friends:*", "profile:read"}, created.Scopes)
+	assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), created.ExpiresAt, time.Minute)
+
+	var stored model.PersonalAccessToken
+	require.NoError(t, repository.GetDB().First(&stored, created.ID).Error)
+	assert.NotEqual(t, created.Token, stored.TokenHash)
+
+	identity, err := authService.VerifyPersonalAccessToken(created.Token)
+	require.NoError(t, err)
+	assert.Equal(t, userID, identity.UserID)
+	assert.Equal(t, []string{"friends:*", "profile:read"}, identity.Scopes)
+
+	// 个人访问token不是JWT，不能调用需要会话token的接口
+	_, err = authService.ParseToken(created.Token)
+	assert.Error(t, err)
+
+	// 过期后失效
+	require.NoError(t, repository.GetDB().Model(&model.PersonalAccessToken{}).Where("id = ?", created.ID).
+		Update("expires_at", time.Now().Add(-time.Minute)).Error)
+	_, err = authService.VerifyPersonalAccessToken(created.Token)
+	assert.Error(t, err)
+
+	// 吊销后立即失效
+	second, err := authService.CreatePersonalAccessToken(userID, &CreatePersonalAccessTokenRequest{Name: "backup", Scopes: []string{"exports:read"}, ExpiresInDays: 1})
+	require.NoError(t, err)
+	assert.Error(t, authService.RevokePersonalAccessToken(userID+1, second.ID, "", ""))
+	require.NoError(t, authService.RevokePersonalAccessToken(userID, second.ID, "", ""))
+	_, err = authService.VerifyPersonalAccessToken(second.Token)
+	assert.Error(t, err)
+
+	tokens, err := authService.ListPersonalAccessTokens(userID)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	assert.Equal(t, "backup", tokens[0].Name)
+	assert.NotNil(t, tokens[0].RevokedAt)
+}
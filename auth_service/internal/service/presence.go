@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+)
+
+// deviceReapBatch 每批写入心跳或删除闲置设备的条数
+const deviceReapBatch = 500
+
+// DevicePolicy 设备在线状态与闲置设备清理策略
+type DevicePolicy struct {
+	IdleTimeout time.Duration // 超过该时长没有心跳的设备标记为离线
+	StaleAfter  time.Duration // 超过该时长未使用的设备被删除并吊销token，0表示不删除
+}
+
+// defaultDevicePolicy 默认设备策略
+func defaultDevicePolicy() DevicePolicy {
+	return DevicePolicy{
+		IdleTimeout: 2 * time.Minute,
+		StaleAfter:  180 * 24 * time.Hour,
+	}
+}
+
+// HeartbeatResult 心跳结果
+type HeartbeatResult struct {
+	Interval int `json:"interval"` // 建议的下次心跳间隔(秒)
+}
+
+// DeviceReapResult 一轮设备清理的结果
+type DeviceReapResult struct {
+	Flushed int   // 写入数据库的心跳数
+	Offline int64 // 标记为离线的设备数
+	Removed int   // 删除的闲置设备数
+}
+
+// Heartbeat 记录设备心跳，配置Redis时先写入Redis，由RunDeviceReaper批量写入数据库
+func (s *AuthService) Heartbeat(userID, deviceID uint) (*HeartbeatResult, error) {
+	if deviceID == 0 {
+		return nil, errors.New("缺少设备信息")
+	}
+
+	now := time.Now()
+	if s.presenceRepo != nil {
+		if err := s.presenceRepo.Touch(context.Background(), deviceID, now); err != nil {
+			return nil, err
+		}
+	} else {
+		device, err := s.deviceRepo.GetDeviceByID(deviceID)
+		if err != nil {
+			return nil, err
+		}
+		if device == nil || device.UserID != userID {
+			return nil, errors.New("设备不存在")
+		}
+		if err := s.deviceRepo.UpdateLastSeen(map[uint]time.Time{deviceID: now}, now.Add(-s.devicePolicy.IdleTimeout)); err != nil {
+			return nil, err
+		}
+	}
+
+	return &HeartbeatResult{Interval: int(s.heartbeatInterval().Seconds())}, nil
+}
+
+// ReapDevices 写入缓冲的心跳，将超时没有心跳的设备标记为离线，并删除长期未使用的设备
+func (s *AuthService) ReapDevices(now time.Time) (*DeviceReapResult, error) {
+	result := &DeviceReapResult{}
+	onlineAfter := now.Add(-s.devicePolicy.IdleTimeout)
+
+	flushed, err := s.flushHeartbeats(now, onlineAfter)
+	result.Flushed = flushed
+	if err != nil {
+		return result, err
+	}
+
+	offline, err := s.deviceRepo.MarkIdleOffline(onlineAfter)
+	result.Offline = offline
+	if err != nil {
+		return result, err
+	}
+
+	if s.devicePolicy.StaleAfter > 0 {
+		removed, err := s.removeStaleDevices(now.Add(-s.devicePolicy.StaleAfter))
+		result.Removed = removed
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// RunDeviceReaper 定期清理设备在线状态和闲置设备，直到ctx取消
+// 配置Redis时每轮先获取锁，多个实例中只有一个执行清理
+func (s *AuthService) RunDeviceReaper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, ran, err := s.reapDevicesLocked(time.Now(), interval)
+			log := applogger.GetDefault()
+			if log == nil || !ran {
+				continue
+			}
+			if err != nil {
+				log.Error("Failed to reap devices", applogger.Fields{"error": err.Error()})
+			} else if result.Offline > 0 || result.Removed > 0 {
+				log.Info("Idle devices reaped", applogger.Fields{
+					"offline": result.Offline,
+					"removed": result.Removed,
+				})
+			}
+		}
+	}
+}
+
+// reapDevicesLocked 持有设备清理任务锁时执行一轮清理，其他实例持有锁时跳过并返回false
+// 锁在ttl后自动过期，实例在清理中途退出也不会阻塞后续的清理
+func (s *AuthService) reapDevicesLocked(now time.Time, ttl time.Duration) (*DeviceReapResult, bool, error) {
+	if s.presenceRepo == nil {
+		result, err := s.ReapDevices(now)
+		return result, true, err
+	}
+
+	owner, err := generateOpaqueToken()
+	if err != nil {
+		return nil, true, err
+	}
+	ctx := context.Background()
+	acquired, err := s.presenceRepo.AcquireReaperLock(ctx, owner, ttl)
+	if err != nil {
+		return nil, true, err
+	}
+	if !acquired {
+		return nil, false, nil
+	}
+	defer func() {
+		if err := s.presenceRepo.ReleaseReaperLock(ctx, owner); err != nil {
+			if log := applogger.GetDefault(); log != nil {
+				log.Error("Failed to release device reaper lock", applogger.Fields{"error": err.Error()})
+			}
+		}
+	}()
+
+	result, err := s.ReapDevices(now)
+	return result, true, err
+}
+
+// flushHeartbeats 将Redis中now之前的心跳分批写入数据库，返回写入条数
+func (s *AuthService) flushHeartbeats(now, onlineAfter time.Time) (int, error) {
+	if s.presenceRepo == nil {
+		return 0, nil
+	}
+
+	ctx := context.Background()
+	total := 0
+	for {
+		seen, err := s.presenceRepo.Oldest(ctx, now, deviceReapBatch)
+		if err != nil {
+			return total, err
+		}
+		if err := s.deviceRepo.UpdateLastSeen(seen, onlineAfter); err != nil {
+			return total, err
+		}
+		if err := s.presenceRepo.Remove(ctx, seen); err != nil {
+			return total, err
+		}
+		total += len(seen)
+		if len(seen) < deviceReapBatch {
+			return total, nil
+		}
+	}
+}
+
+// removeStaleDevices 删除before之后未使用过的设备，先吊销设备的token，返回删除的设备数
+func (s *AuthService) removeStaleDevices(before time.Time) (int, error) {
+	total := 0
+	for {
+		devices, err := s.deviceRepo.ListStaleDevices(before, deviceReapBatch)
+		if err != nil {
+			return total, err
+		}
+
+		deviceIDs := make([]uint, 0, len(devices))
+		for _, device := range devices {
+			if err := s.RevokeDeviceTokens(device.ID); err != nil {
+				return total, err
+			}
+			lastSeen := device.CreatedAt
+			if device.LastSeenAt != nil {
+				lastSeen = *device.LastSeenAt
+			}
+			s.audit(AuditStaleDeviceRemoved, auditEntry{
+				UserID:   device.UserID,
+				DeviceID: device.ID,
+				Reason:   "长期未使用",
+				Details: applogger.Fields{
+					"device_name":  device.DeviceName,
+					"device_type":  device.DeviceType,
+					"last_seen_at": lastSeen,
+				},
+			})
+			deviceIDs = append(deviceIDs, device.ID)
+		}
+
+		if err := s.deviceRepo.DeleteDevices(deviceIDs); err != nil {
+			return total, err
+		}
+		total += len(deviceIDs)
+		if len(devices) < deviceReapBatch {
+			return total, nil
+		}
+	}
+}
+
+// heartbeatInterval 建议客户端的心跳间隔，为离线超时的三分之一
+func (s *AuthService) heartbeatInterval() time.Duration {
+	interval := s.devicePolicy.IdleTimeout / 3
+	if interval < time.Second {
+		return time.Second
+	}
+	return interval
+}
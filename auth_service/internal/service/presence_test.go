@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+func TestAuthService_DeviceHeartbeatAndReaper(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(
+		pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithDevicePolicy(DevicePolicy{IdleTimeout: 90 * time.Second, StaleAfter: 30 * 24 * time.Hour}),
+	)
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+		ClientIP:    "10.0.0.1",
+	})
+	require.NoError(t, err)
+	userID, deviceID := registered.User.ID, registered.Device.ID
+
+	getDevice := func() *model.Device {
+		device, err := repository.NewDeviceRepository().GetDeviceByID(deviceID)
+		require.NoError(t, err)
+		return device
+	}
+
+	result, err := authService.Heartbeat(userID, deviceID)
+	require.NoError(t, err)
+	assert.Equal(t, 30, result.Interval)
+
+	// 不能为其他用户的设备发送心跳
+	_, err = authService.Heartbeat(userID+1, deviceID)
+	assert.Error(t, err)
+
+	// 超时前保持在线
+	reaped, err := authService.ReapDevices(time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, reaped.Offline)
+	assert.True(t, getDevice().IsOnline)
+
+	// 超时没有心跳的设备标记为离线，再次心跳后恢复在线
+	reaped, err = authService.ReapDevices(time.Now().Add(2 * time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), reaped.Offline)
+	assert.False(t, getDevice().IsOnline)
+
+	_, err = authService.Heartbeat(userID, deviceID)
+	require.NoError(t, err)
+	assert.True(t, getDevice().IsOnline)
+
+	// 长期未使用的设备被彻底删除，刷新token失效
+	reaped, err = authService.ReapDevices(time.Now().Add(31 * 24 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, reaped.Removed)
+	assert.Nil(t, getDevice())
+
+	var count int64
+	require.NoError(t, repository.DB.Unscoped().Model(&model.Device{}).Where("id = ?", deviceID).Count(&count).Error)
+	assert.Zero(t, count)
+
+	_, err = authService.RefreshToken(registered.Token.RefreshToken, "", "")
+	assert.Error(t, err)
+
+	events, _, err := authService.ListSecurityEvents(userID, "", 20)
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+	assert.Equal(t, AuditStaleDeviceRemoved, events[0].Event)
+	assert.Equal(t, deviceID, events[0].DeviceID)
+
+	// 同一设备token再次登录时按新设备创建
+	_, err = authService.Login(&LoginRequest{
+		Phone:       "+8613800000000",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+}
+
+func TestAuthService_DeviceHeartbeatWithRedis(t *testing.T) {
+	setupTestDB(t)
+	redisServer := setupTestRedis(t)
+	authService := NewAuthService(
+		pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithDevicePolicy(DevicePolicy{IdleTimeout: 90 * time.Second}),
+	)
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	userID, deviceID := registered.User.ID, registered.Device.ID
+	member := strconv.FormatUint(uint64(deviceID), 10)
+
+	getDevice := func() *model.Device {
+		device, err := repository.NewDeviceRepository().GetDeviceByID(deviceID)
+		require.NoError(t, err)
+		return device
+	}
+	before := getDevice().LastSeenAt
+
+	// 心跳只写入Redis有序集合，等待批量写入数据库
+	_, err = authService.Heartbeat(userID, deviceID)
+	require.NoError(t, err)
+	score, err := redisServer.ZScore(repository.DeviceLastSeenKey, member)
+	require.NoError(t, err)
+	assert.Equal(t, before, getDevice().LastSeenAt)
+
+	reaped, err := authService.ReapDevices(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, reaped.Flushed)
+	assert.Zero(t, reaped.Offline)
+	assert.False(t, redisServer.Exists(repository.DeviceLastSeenKey))
+	device := getDevice()
+	require.NotNil(t, device.LastSeenAt)
+	assert.Equal(t, int64(score), device.LastSeenAt.UnixMilli())
+	assert.True(t, device.IsOnline)
+
+	// 写库期间又有新心跳的设备不会被移除，留到下一轮写入
+	ctx := context.Background()
+	heartbeat := time.Now()
+	require.NoError(t, authService.presenceRepo.Touch(ctx, deviceID, heartbeat))
+	seen, err := authService.presenceRepo.Oldest(ctx, heartbeat, deviceReapBatch)
+	require.NoError(t, err)
+	require.Len(t, seen, 1)
+	require.NoError(t, authService.presenceRepo.Touch(ctx, deviceID, heartbeat.Add(time.Second)))
+	require.NoError(t, authService.presenceRepo.Remove(ctx, seen))
+	score, err = redisServer.ZScore(repository.DeviceLastSeenKey, member)
+	require.NoError(t, err)
+	assert.Equal(t, float64(heartbeat.Add(time.Second).UnixMilli()), score)
+
+	require.NoError(t, authService.presenceRepo.Remove(ctx, map[uint]time.Time{deviceID: heartbeat.Add(time.Second)}))
+	assert.False(t, redisServer.Exists(repository.DeviceLastSeenKey))
+}
+
+func TestAuthService_DeviceReaperLock(t *testing.T) {
+	setupTestDB(t)
+	redisServer := setupTestRedis(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour))
+
+	// 其他实例持有锁时跳过本轮清理
+	ctx := context.Background()
+	acquired, err := authService.presenceRepo.AcquireReaperLock(ctx, "other", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	_, ran, err := authService.reapDevicesLocked(time.Now(), time.Minute)
+	require.NoError(t, err)
+	assert.False(t, ran)
+
+	// 不能释放其他实例持有的锁
+	require.NoError(t, authService.presenceRepo.ReleaseReaperLock(ctx, "mine"))
+	assert.True(t, redisServer.Exists(repository.DeviceReaperLockKey))
+
+	// 锁过期后由本实例执行，执行完成后释放锁
+	redisServer.FastForward(time.Minute)
+	_, ran, err = authService.reapDevicesLocked(time.Now(), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ran)
+	assert.False(t, redisServer.Exists(repository.DeviceReaperLockKey))
+}
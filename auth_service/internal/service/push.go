@@ -0,0 +1,128 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+)
+
+const (
+	maxPushTokenLength = 512
+	// pushTokenMaxAge 超过该时长未重新注册的推送token视为失效，客户端应在每次启动时注册
+	pushTokenMaxAge = 60 * 24 * time.Hour
+)
+
+// pushProviders 每种设备类型支持的推送通道
+var pushProviders = map[string][]string{
+	model.DeviceTypeIOS:     {model.PushProviderAPNs, model.PushProviderFCM},
+	model.DeviceTypeAndroid: {model.PushProviderFCM},
+	model.DeviceTypeWeb:     {model.PushProviderWebPush},
+	model.DeviceTypeDesktop: {model.PushProviderWebPush, model.PushProviderFCM},
+}
+
+// PushTarget 推送目标
+type PushTarget struct {
+	DeviceID   uint   `json:"device_id"`
+	DeviceType string `json:"device_type"`
+	Provider   string `json:"provider"`
+	Token      string `json:"token"`
+}
+
+// RegisterPushTokenRequest 注册推送token请求
+type RegisterPushTokenRequest struct {
+	Provider string `json:"provider" binding:"required"`
+	Token    string `json:"token" binding:"required"`
+}
+
+// RegisterPushToken 为当前设备注册推送token，覆盖之前的token
+func (s *AuthService) RegisterPushToken(userID, deviceID uint, req *RegisterPushTokenRequest) error {
+	device, err := s.deviceRepo.GetDeviceByID(deviceID)
+	if err != nil {
+		return err
+	}
+	if device == nil || device.UserID != userID {
+		return errors.New("设备不存在")
+	}
+
+	provider := strings.ToLower(strings.TrimSpace(req.Provider))
+	if !isValidPushProvider(device.DeviceType, provider) {
+		return errors.New("该设备不支持此推送通道")
+	}
+	token := strings.TrimSpace(req.Token)
+	if token == "" || len(token) > maxPushTokenLength {
+		return errors.New("无效的推送token")
+	}
+
+	return s.deviceRepo.UpdatePushToken(deviceID, provider, token)
+}
+
+// UnregisterPushToken 清除当前设备的推送token
+func (s *AuthService) UnregisterPushToken(userID, deviceID uint) error {
+	device, err := s.deviceRepo.GetDeviceByID(deviceID)
+	if err != nil {
+		return err
+	}
+	if device == nil || device.UserID != userID {
+		return errors.New("设备不存在")
+	}
+	return s.deviceRepo.ClearPushTokens([]uint{deviceID})
+}
+
+// GetPushTargets 获取用户所有可推送的设备 (供推送服务调用)
+// 已退出登录、会话被吊销或长期未重新注册的设备token在此时清除，不再返回
+func (s *AuthService) GetPushTargets(userID uint) ([]PushTarget, error) {
+	devices, err := s.deviceRepo.GetPushDevices(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(devices) == 0 {
+		return []PushTarget{}, nil
+	}
+
+	activeDevices, err := s.deviceRepo.GetActiveSessionDevices(userID)
+	if err != nil {
+		return nil, err
+	}
+	active := make(map[uint]bool, len(activeDevices))
+	for _, device := range activeDevices {
+		active[device.ID] = true
+	}
+
+	staleBefore := time.Now().Add(-pushTokenMaxAge)
+	targets := make([]PushTarget, 0, len(devices))
+	var stale []uint
+	for _, device := range devices {
+		if !active[device.ID] || device.PushUpdatedAt == nil || device.PushUpdatedAt.Before(staleBefore) {
+			stale = append(stale, device.ID)
+			continue
+		}
+		targets = append(targets, PushTarget{
+			DeviceID:   device.ID,
+			DeviceType: device.DeviceType,
+			Provider:   device.PushProvider,
+			Token:      device.PushToken,
+		})
+	}
+
+	if err := s.deviceRepo.ClearPushTokens(stale); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+// InvalidatePushTokens 推送通道报告token已失效时清除 (如APNs返回Unregistered)，返回清除的设备数
+func (s *AuthService) InvalidatePushTokens(provider string, tokens []string) (int64, error) {
+	return s.deviceRepo.ClearPushTokensByValue(strings.ToLower(provider), tokens)
+}
+
+// isValidPushProvider 推送通道是否适用于该设备类型
+func isValidPushProvider(deviceType, provider string) bool {
+	for _, p := range pushProviders[deviceType] {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+func TestAuthService_PushTokens(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour))
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	userID := registered.User.ID
+	phoneID := registered.Device.ID
+
+	laptop, err := authService.Login(&LoginRequest{Username: "alice", Password: "password123", DeviceToken: "laptop-1", DeviceType: "web"})
+	require.NoError(t, err)
+	laptopID := laptop.Device.ID
+
+	// 推送通道需与设备类型匹配
+	err = authService.RegisterPushToken(userID, phoneID, &RegisterPushTokenRequest{Provider: "webpush", Token: "apns-1"})
+	assert.Error(t, err)
+	err = authService.RegisterPushToken(userID+1, phoneID, &RegisterPushTokenRequest{Provider: "apns", Token: "apns-1"})
+	assert.Error(t, err)
+
+	require.NoError(t, authService.RegisterPushToken(userID, phoneID, &RegisterPushTokenRequest{Provider: "APNs", Token: "apns-1"}))
+	require.NoError(t, authService.RegisterPushToken(userID, laptopID, &RegisterPushTokenRequest{Provider: "webpush", Token: "https://push.example.com/sub/1"}))
+
+	targets, err := authService.GetPushTargets(userID)
+	require.NoError(t, err)
+	require.Len(t, targets, 2)
+	assert.Equal(t, PushTarget{DeviceID: phoneID, DeviceType: "ios", Provider: model.PushProviderAPNs, Token: "apns-1"}, targets[0])
+
+	// 退出登录的设备在查询时被清除
+	claims, err := authService.ParseToken(laptop.Token.AccessToken)
+	require.NoError(t, err)
+	require.NoError(t, authService.Logout(claims, "", ""))
+
+	targets, err = authService.GetPushTargets(userID)
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	assert.Equal(t, phoneID, targets[0].DeviceID)
+
+	device, err := repository.NewDeviceRepository().GetDeviceByID(laptopID)
+	require.NoError(t, err)
+	assert.Empty(t, device.PushToken)
+
+	// 长期未重新注册的token视为失效
+	require.NoError(t, repository.GetDB().Model(&model.Device{}).Where("id = ?", phoneID).
+		Update("push_updated_at", time.Now().Add(-pushTokenMaxAge-time.Hour)).Error)
+	targets, err = authService.GetPushTargets(userID)
+	require.NoError(t, err)
+	assert.Empty(t, targets)
+
+	// 推送通道报告失效
+	require.NoError(t, authService.RegisterPushToken(userID, phoneID, &RegisterPushTokenRequest{Provider: "apns", Token: "apns-2"}))
+	invalidated, err := authService.InvalidatePushTokens("apns", []string{"apns-2", "unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), invalidated)
+
+	// 注销
+	require.NoError(t, authService.RegisterPushToken(userID, phoneID, &RegisterPushTokenRequest{Provider: "apns", Token: "apns-3"}))
+	require.NoError(t, authService.UnregisterPushToken(userID, phoneID))
+	targets, err = authService.GetPushTargets(userID)
+	require.NoError(t, err)
+	assert.Empty(t, targets)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+	"time"
+)
+
+// Session 活跃会话 (一个已登录的设备)
+type Session struct {
+	DeviceID   uint       `json:"device_id"`
+	DeviceType string     `json:"device_type"`
+	DeviceName string     `json:"device_name"`
+	IP         string     `json:"ip"`
+	IsOnline   bool       `json:"is_online"`
+	IsCurrent  bool       `json:"is_current"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ListSessions 获取用户的活跃会话
+func (s *AuthService) ListSessions(userID, currentDeviceID uint) ([]*Session, error) {
+	devices, err := s.deviceRepo.GetActiveSessionDevices(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*Session, 0, len(devices))
+	for _, device := range devices {
+		sessions = append(sessions, &Session{
+			DeviceID:   device.ID,
+			DeviceType: device.DeviceType,
+			DeviceName: device.DeviceName,
+			IP:         device.LastIP,
+			IsOnline:   device.IsOnline,
+			IsCurrent:  device.ID == currentDeviceID,
+			LastSeenAt: device.LastSeenAt,
+			CreatedAt:  device.CreatedAt,
+		})
+	}
+	return sessions, nil
+}
+
+// RevokeSession 终止用户的指定会话，该设备的所有token立即失效
+func (s *AuthService) RevokeSession(userID, deviceID uint) error {
+	device, err := s.deviceRepo.GetDeviceByID(deviceID)
+	if err != nil {
+		return err
+	}
+	if device == nil || device.UserID != userID {
+		return errors.New("会话不存在")
+	}
+	return s.logoutDevice(deviceID)
+}
+
+// RevokeOtherSessions 终止除当前设备外的所有会话，返回终止的会话数
+func (s *AuthService) RevokeOtherSessions(userID, currentDeviceID uint) (int, error) {
+	devices, err := s.deviceRepo.GetActiveSessionDevices(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	for _, device := range devices {
+		if device.ID == currentDeviceID {
+			continue
+		}
+		if err := s.logoutDevice(device.ID); err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+	return revoked, nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+// setupTestDB 使用内存SQLite替换全局DB实例
+func setupTestDB(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	originalDB := repository.DB
+	repository.DB = db
+	t.Cleanup(func() {
+		repository.DB = originalDB
+	})
+
+	require.NoError(t, repository.AutoMigrate())
+}
+
+// setupTestRedis 使用内存Redis替换全局Redis客户端，需在NewAuthService之前调用
+func setupTestRedis(t *testing.T) *miniredis.Miniredis {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	originalClient := repository.RedisClient
+	repository.RedisClient = client
+	t.Cleanup(func() {
+		repository.RedisClient = originalClient
+		client.Close()
+	})
+	return server
+}
+
+func TestAuthService_Sessions(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour))
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+		DeviceName:  "iPhone",
+		ClientIP:    "10.0.0.1",
+	})
+	require.NoError(t, err)
+
+	for _, deviceToken := range []string{"desktop-1", "web-1"} {
+		_, err := authService.Login(&LoginRequest{
+			Phone:       "+8613800000000",
+			Password:    "password123",
+			DeviceToken: deviceToken,
+			DeviceType:  "desktop",
+			ClientIP:    "10.0.0.2",
+		})
+		require.NoError(t, err)
+	}
+
+	current := registered.Device.ID
+	sessions, err := authService.ListSessions(registered.User.ID, current)
+	require.NoError(t, err)
+	require.Len(t, sessions, 3)
+	for _, session := range sessions {
+		assert.Equal(t, session.DeviceID == current, session.IsCurrent)
+		assert.NotEmpty(t, session.IP)
+		assert.NotNil(t, session.LastSeenAt)
+	}
+
+	// 不能终止其他用户的会话
+	assert.Error(t, authService.RevokeSession(registered.User.ID+1, sessions[0].DeviceID))
+
+	count, err := authService.RevokeOtherSessions(registered.User.ID, current)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	sessions, err = authService.ListSessions(registered.User.ID, current)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, current, sessions[0].DeviceID)
+
+	// 被终止设备的刷新token失效
+	require.NoError(t, authService.RevokeSession(registered.User.ID, current))
+	_, err = authService.RefreshToken(registered.Token.RefreshToken, "", "")
+	assert.Error(t, err)
+}
+
+func TestAuthService_DeviceRevocationPrecision(t *testing.T) {
+	setupTestDB(t)
+	setupTestRedis(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour))
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	claims, err := authService.ParseToken(registered.Token.AccessToken)
+	require.NoError(t, err)
+	issuedAt := claims.IssuedAtTime()
+	assert.Equal(t, issuedAt.UnixMilli(), claims.IssuedAtMs)
+
+	// 与签发时间在同一秒内、但晚于签发时间的吊销同样生效
+	ctx := context.Background()
+	require.NoError(t, authService.revocationRepo.RevokeDevice(ctx, claims.DeviceID, issuedAt.Add(time.Millisecond), time.Hour))
+	_, err = authService.ParseToken(registered.Token.AccessToken)
+	assert.EqualError(t, err, "token已被吊销")
+
+	// 吊销之后签发的token不受影响
+	revoked, err := authService.revocationRepo.IsRevoked(ctx, "", claims.UserID, claims.DeviceID, issuedAt.Add(2*time.Millisecond))
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	events, err := authService.revocationRepo.ListRevocations(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, issuedAt.Add(time.Millisecond).UnixMilli(), events[0].RevokedAt.UnixMilli())
+}
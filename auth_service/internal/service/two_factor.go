@@ -0,0 +1,389 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+const (
+	defaultTOTPIssuer    = "TelegramLite"
+	totpSkew             = 1               // 允许前后各一个周期的时钟偏差
+	challengeDuration    = 5 * time.Minute // 两步验证挑战token有效期
+	maxChallengeAttempts = 5               // 单个挑战允许的验证次数
+	recoveryCodeCount    = 10
+	recoveryCodeHalfLen  = 5
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789" // 去除易混淆的字符
+)
+
+var (
+	// errTwoFactorUnavailable 未配置Redis时无法限制挑战的尝试次数，不允许启用或完成两步验证
+	errTwoFactorUnavailable = errors.New("两步验证服务不可用")
+	errChallengeUsed        = errors.New("两步验证已完成，请重新登录")
+)
+
+// TwoFactorChallenge 登录需要两步验证时返回的挑战
+type TwoFactorChallenge struct {
+	ChallengeToken string `json:"challenge_token"`
+	ExpiresIn      int64  `json:"expires_in"`
+}
+
+// TwoFactorEnrollment 两步验证注册信息，恢复码只在此时返回一次
+type TwoFactorEnrollment struct {
+	Secret          string   `json:"secret"`
+	ProvisioningURI string   `json:"provisioning_uri"`
+	RecoveryCodes   []string `json:"recovery_codes"`
+}
+
+// TwoFactorStatus 两步验证状态
+type TwoFactorStatus struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int64      `json:"recovery_codes_remaining"`
+}
+
+// VerifySecondFactorRequest 完成两步验证登录请求
+type VerifySecondFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"` // TOTP验证码或恢复码
+	ClientIP       string `json:"-"`
+	UserAgent      string `json:"-"`
+}
+
+// DisableTwoFactorRequest 关闭两步验证请求
+type DisableTwoFactorRequest struct {
+	Password  string `json:"password" binding:"required"`
+	Code      string `json:"code" binding:"required"` // TOTP验证码或恢复码
+	ClientIP  string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// GetTwoFactorStatus 获取用户的两步验证状态
+func (s *AuthService) GetTwoFactorStatus(userID uint) (*TwoFactorStatus, error) {
+	twoFactor, err := s.twoFactorRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !twoFactor.Enabled() {
+		return &TwoFactorStatus{}, nil
+	}
+
+	remaining, err := s.twoFactorRepo.CountUnusedRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &TwoFactorStatus{
+		Enabled:                true,
+		EnabledAt:              twoFactor.EnabledAt,
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
+
+// EnrollTwoFactor 生成TOTP密钥和恢复码，需调用ConfirmTwoFactor确认后才生效
+func (s *AuthService) EnrollTwoFactor(userID uint) (*TwoFactorEnrollment, error) {
+	if s.challengeRepo == nil {
+		return nil, errTwoFactorUnavailable
+	}
+
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("用户不存在")
+	}
+
+	twoFactor, err := s.twoFactorRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor.Enabled() {
+		return nil, errors.New("已启用两步验证")
+	}
+
+	secret, err := pkg.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.twoFactorRepo.SavePending(userID, secret, hashes); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: pkg.TOTPProvisioningURI(s.totpIssuer, totpAccountName(user), secret),
+		RecoveryCodes:   recoveryCodes,
+	}, nil
+}
+
+// ConfirmTwoFactor 使用验证器应用生成的验证码确认并启用两步验证
+func (s *AuthService) ConfirmTwoFactor(userID uint, code, clientIP, userAgent string) error {
+	if s.challengeRepo == nil {
+		return errTwoFactorUnavailable
+	}
+
+	twoFactor, err := s.twoFactorRepo.GetByUserID(userID)
+	if err != nil {
+		return err
+	}
+	if twoFactor == nil {
+		return errors.New("请先生成两步验证密钥")
+	}
+	if twoFactor.Enabled() {
+		return errors.New("已启用两步验证")
+	}
+
+	step, ok := pkg.ValidateTOTP(twoFactor.Secret, code, time.Now(), totpSkew)
+	if !ok {
+		return errors.New("验证码错误")
+	}
+
+	if err := s.twoFactorRepo.Enable(userID, step); err != nil {
+		return err
+	}
+
+	s.audit(AuditTwoFactorEnabled, auditEntry{
+		UserID:    userID,
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+	})
+	return nil
+}
+
+// DisableTwoFactor 关闭两步验证，需要同时验证密码和验证码(或恢复码)
+// 验证失败与登录失败共用计数，不能通过关闭接口绕过登录锁定猜测密码或恢复码
+func (s *AuthService) DisableTwoFactor(userID uint, req *DisableTwoFactorRequest) error {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("用户不存在")
+	}
+
+	loginReq := &LoginRequest{ClientIP: req.ClientIP, UserAgent: req.UserAgent}
+	subjects := s.loginSubjects(user, loginReq)
+	if err := s.checkLoginLock(user.ID, subjects, loginReq); err != nil {
+		return err
+	}
+	if err := s.passwordManager.VerifyPassword(user.PasswordHash, req.Password); err != nil {
+		s.recordLoginFailure(user.ID, subjects, loginReq, "密码错误")
+		return errors.New("密码错误")
+	}
+
+	twoFactor, err := s.twoFactorRepo.GetByUserID(userID)
+	if err != nil {
+		return err
+	}
+	if !twoFactor.Enabled() {
+		return errors.New("未启用两步验证")
+	}
+
+	if err := s.checkSecondFactor(twoFactor, req.Code, loginReq); err != nil {
+		s.recordLoginFailure(user.ID, subjects, loginReq, err.Error())
+		return err
+	}
+	s.resetLoginFailures(subjects)
+
+	if err := s.twoFactorRepo.Delete(userID); err != nil {
+		return err
+	}
+
+	s.audit(AuditTwoFactorDisabled, auditEntry{
+		UserID:    userID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+	})
+	return nil
+}
+
+// VerifySecondFactor 校验挑战token和第二因素后完成登录
+func (s *AuthService) VerifySecondFactor(req *VerifySecondFactorRequest) (*AuthResponse, error) {
+	claims, err := s.jwtManager.VerifyChallengeToken(req.ChallengeToken)
+	if err != nil {
+		return nil, errors.New("两步验证已过期，请重新登录")
+	}
+
+	if s.challengeRepo == nil {
+		return nil, errTwoFactorUnavailable
+	}
+	ctx := context.Background()
+	attempts, err := s.challengeRepo.Incr(ctx, claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		return nil, err
+	}
+	if attempts < 0 {
+		return nil, errChallengeUsed
+	}
+	if attempts > maxChallengeAttempts {
+		return nil, errors.New("验证次数过多，请重新登录")
+	}
+
+	// 停用的账号完成两步验证后恢复
+	user, err := s.userRepo.GetUserByIDIncludingInactive(claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("用户不存在")
+	}
+
+	loginReq := &LoginRequest{
+		DeviceToken: claims.DeviceToken,
+		DeviceType:  claims.DeviceType,
+		DeviceName:  claims.DeviceName,
+		ClientIP:    req.ClientIP,
+		UserAgent:   req.UserAgent,
+	}
+
+	// 与密码登录共用失败计数，重新获取挑战token也不能绕过锁定
+	subjects := s.loginSubjects(user, loginReq)
+	if err := s.checkLoginLock(user.ID, subjects, loginReq); err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := s.twoFactorRepo.GetByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor.Enabled() {
+		if err := s.checkSecondFactor(twoFactor, req.Code, loginReq); err != nil {
+			s.recordLoginFailure(user.ID, subjects, loginReq, err.Error())
+			return nil, err
+		}
+	}
+
+	// 挑战只能完成一次登录，并发提交时只有一个请求成功
+	consumed, err := s.challengeRepo.Consume(ctx, claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, errChallengeUsed
+	}
+	s.resetLoginFailures(subjects)
+
+	return s.completeLogin(user, loginReq)
+}
+
+// beginLogin 第一因素验证通过后，启用了两步验证的账号返回挑战，否则直接完成登录
+func (s *AuthService) beginLogin(user *model.User, req *LoginRequest) (*AuthResponse, error) {
+	twoFactor, err := s.twoFactorRepo.GetByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !twoFactor.Enabled() {
+		return s.completeLogin(user, req)
+	}
+
+	challenge, _, err := s.jwtManager.GenerateChallengeToken(user.ID, req.DeviceToken, req.DeviceType, req.DeviceName, challengeDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	user.PasswordHash = ""
+
+	return &AuthResponse{
+		User: user,
+		TwoFactor: &TwoFactorChallenge{
+			ChallengeToken: challenge,
+			ExpiresIn:      int64(challengeDuration.Seconds()),
+		},
+	}, nil
+}
+
+// checkSecondFactor 校验TOTP验证码或恢复码，两者都只能使用一次
+func (s *AuthService) checkSecondFactor(twoFactor *model.TwoFactor, code string, req *LoginRequest) error {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return errors.New("验证码不能为空")
+	}
+
+	if step, ok := pkg.ValidateTOTP(twoFactor.Secret, code, time.Now(), totpSkew); ok {
+		used, err := s.twoFactorRepo.UseStep(twoFactor.UserID, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return errors.New("验证码已使用，请等待下一个验证码")
+		}
+		return nil
+	}
+
+	used, err := s.twoFactorRepo.UseRecoveryCode(twoFactor.UserID, hashCode(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return errors.New("验证码错误")
+	}
+
+	// 恢复码已作废，统计剩余数量失败不影响本次验证
+	details := applogger.Fields{}
+	if remaining, err := s.twoFactorRepo.CountUnusedRecoveryCodes(twoFactor.UserID); err == nil {
+		details["recovery_codes_remaining"] = remaining
+	}
+	s.audit(AuditRecoveryCodeUsed, auditEntry{
+		UserID:    twoFactor.UserID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+		Details:   details,
+	})
+	return nil
+}
+
+// totpAccountName 验证器应用中显示的账号名
+func totpAccountName(user *model.User) string {
+	switch {
+	case user.Username != "":
+		return user.Username
+	case user.Email != "":
+		return user.Email
+	default:
+		return user.Phone
+	}
+}
+
+// generateRecoveryCodes 生成恢复码及其哈希 (格式 xxxxx-xxxxx)
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	alphabetSize := big.NewInt(int64(len(recoveryCodeAlphabet)))
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		var code strings.Builder
+		for j := 0; j < recoveryCodeHalfLen*2; j++ {
+			if j == recoveryCodeHalfLen {
+				code.WriteByte('-')
+			}
+			n, err := rand.Int(rand.Reader, alphabetSize)
+			if err != nil {
+				return nil, nil, err
+			}
+			code.WriteByte(recoveryCodeAlphabet[n.Int64()])
+		}
+		codes = append(codes, code.String())
+		hashes = append(hashes, hashCode(normalizeRecoveryCode(code.String())))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode 忽略恢复码输入中的大小写、空格和连字符
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+func TestAuthService_TwoFactor(t *testing.T) {
+	setupTestDB(t)
+	setupTestRedis(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour))
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	userID := registered.User.ID
+
+	login := func() (*AuthResponse, error) {
+		return authService.Login(&LoginRequest{
+			Username:    "alice",
+			Password:    "password123",
+			DeviceToken: "desktop-1",
+			DeviceType:  "desktop",
+		})
+	}
+
+	enrollment, err := authService.EnrollTwoFactor(userID)
+	require.NoError(t, err)
+	assert.Contains(t, enrollment.ProvisioningURI, "otpauth://totp/")
+	assert.Len(t, enrollment.RecoveryCodes, recoveryCodeCount)
+
+	// 确认前登录不要求两步验证
+	resp, err := login()
+	require.NoError(t, err)
+	assert.Nil(t, resp.TwoFactor)
+	assert.NotNil(t, resp.Token)
+
+	assert.Error(t, authService.ConfirmTwoFactor(userID, "000000", "", ""))
+	code, err := pkg.TOTPCode(enrollment.Secret, pkg.TOTPStep(time.Now()))
+	require.NoError(t, err)
+	require.NoError(t, authService.ConfirmTwoFactor(userID, code, "", ""))
+
+	_, err = authService.EnrollTwoFactor(userID)
+	assert.Error(t, err)
+
+	// 启用后登录返回挑战而不是token
+	resp, err = login()
+	require.NoError(t, err)
+	require.NotNil(t, resp.TwoFactor)
+	assert.Nil(t, resp.Token)
+
+	// 确认时已使用的验证码不能重放
+	_, err = authService.VerifySecondFactor(&VerifySecondFactorRequest{ChallengeToken: resp.TwoFactor.ChallengeToken, Code: code})
+	assert.Error(t, err)
+
+	// 访问token不能作为挑战token
+	_, err = authService.VerifySecondFactor(&VerifySecondFactorRequest{ChallengeToken: registered.Token.AccessToken, Code: enrollment.RecoveryCodes[0]})
+	assert.Error(t, err)
+
+	// 恢复码可完成登录，且只能使用一次 (输入不区分大小写)
+	completed, err := authService.VerifySecondFactor(&VerifySecondFactorRequest{
+		ChallengeToken: resp.TwoFactor.ChallengeToken,
+		Code:           " " + enrollment.RecoveryCodes[0] + " ",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, completed.Token)
+	assert.Equal(t, "desktop-1", completed.Device.DeviceToken)
+
+	// 挑战只能完成一次登录，即使提供另一个有效的恢复码
+	_, err = authService.VerifySecondFactor(&VerifySecondFactorRequest{ChallengeToken: resp.TwoFactor.ChallengeToken, Code: enrollment.RecoveryCodes[0]})
+	assert.Error(t, err)
+	_, err = authService.VerifySecondFactor(&VerifySecondFactorRequest{ChallengeToken: resp.TwoFactor.ChallengeToken, Code: enrollment.RecoveryCodes[2]})
+	assert.Equal(t, errChallengeUsed, err)
+
+	status, err := authService.GetTwoFactorStatus(userID)
+	require.NoError(t, err)
+	assert.True(t, status.Enabled)
+	assert.Equal(t, int64(recoveryCodeCount-1), status.RecoveryCodesRemaining)
+
+	// 关闭需要正确的密码和验证码
+	assert.Error(t, authService.DisableTwoFactor(userID, &DisableTwoFactorRequest{Password: "wrong-password", Code: enrollment.RecoveryCodes[1]}))
+	assert.Error(t, authService.DisableTwoFactor(userID, &DisableTwoFactorRequest{Password: "password123", Code: "000000"}))
+	require.NoError(t, authService.DisableTwoFactor(userID, &DisableTwoFactorRequest{Password: "password123", Code: enrollment.RecoveryCodes[1], ClientIP: "10.0.0.3"}))
+
+	resp, err = login()
+	require.NoError(t, err)
+	assert.Nil(t, resp.TwoFactor)
+	assert.NotNil(t, resp.Token)
+
+	// 开启、关闭两步验证和使用恢复码都记录到审计事件
+	events, _, err := authService.ListSecurityEvents(userID, "", maxAuthEventPageSize)
+	require.NoError(t, err)
+	var twoFactorEvents []model.AuthEvent
+	for _, event := range events {
+		switch event.Event {
+		case AuditTwoFactorEnabled, AuditTwoFactorDisabled, AuditRecoveryCodeUsed:
+			twoFactorEvents = append(twoFactorEvents, event)
+		}
+	}
+	require.Len(t, twoFactorEvents, 4)
+	assert.Equal(t, AuditTwoFactorDisabled, twoFactorEvents[0].Event)
+	assert.Equal(t, "10.0.0.3", twoFactorEvents[0].IP)
+	assert.Equal(t, AuditRecoveryCodeUsed, twoFactorEvents[1].Event)
+	assert.Contains(t, twoFactorEvents[1].Details, fmt.Sprintf(`"recovery_codes_remaining":%d`, recoveryCodeCount-2))
+	assert.Equal(t, AuditRecoveryCodeUsed, twoFactorEvents[2].Event)
+	assert.Contains(t, twoFactorEvents[2].Details, fmt.Sprintf(`"recovery_codes_remaining":%d`, recoveryCodeCount-1))
+	assert.Equal(t, AuditTwoFactorEnabled, twoFactorEvents[3].Event)
+}
+
+func TestAuthService_DisableTwoFactorLockout(t *testing.T) {
+	setupTestDB(t)
+	redisServer := setupTestRedis(t)
+	authService := NewAuthService(
+		pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithLoginProtection(LoginProtectionPolicy{
+			Window:           15 * time.Minute,
+			AccountThreshold: 3,
+			IPThreshold:      100,
+			DeviceThreshold:  100,
+			BaseLockout:      time.Minute,
+			MaxLockout:       time.Hour,
+		}),
+	)
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	userID := registered.User.ID
+	enrollment, err := authService.EnrollTwoFactor(userID)
+	require.NoError(t, err)
+	totp, err := pkg.TOTPCode(enrollment.Secret, pkg.TOTPStep(time.Now()))
+	require.NoError(t, err)
+	require.NoError(t, authService.ConfirmTwoFactor(userID, totp, "", ""))
+
+	disable := func(password, code string) error {
+		return authService.DisableTwoFactor(userID, &DisableTwoFactorRequest{Password: password, Code: code, ClientIP: "203.0.113.7"})
+	}
+
+	// 密码错误和恢复码错误都计入登录失败，达到阈值后关闭和登录都被拒绝
+	assert.EqualError(t, disable("wrong-password", enrollment.RecoveryCodes[0]), "密码错误")
+	assert.EqualError(t, disable("password123", "aaaaa-aaaaa"), "验证码错误")
+	assert.EqualError(t, disable("password123", "bbbbb-bbbbb"), "验证码错误")
+	assert.Equal(t, errLoginLocked, disable("password123", enrollment.RecoveryCodes[0]))
+	_, err = authService.Login(&LoginRequest{Username: "alice", Password: "password123", DeviceToken: "phone-1", DeviceType: "ios"})
+	assert.Equal(t, errLoginLocked, err)
+
+	redisServer.FastForward(2 * time.Minute)
+	require.NoError(t, disable("password123", enrollment.RecoveryCodes[0]))
+	status, err := authService.GetTwoFactorStatus(userID)
+	require.NoError(t, err)
+	assert.False(t, status.Enabled)
+}
+
+func TestAuthService_TwoFactorRequiresChallengeStore(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour))
+
+	registered, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+
+	// 未配置Redis时无法限制验证次数，不允许启用两步验证
+	_, err = authService.EnrollTwoFactor(registered.User.ID)
+	assert.Equal(t, errTwoFactorUnavailable, err)
+	assert.Equal(t, errTwoFactorUnavailable, authService.ConfirmTwoFactor(registered.User.ID, "000000", "", ""))
+
+	challenge, _, err := authService.jwtManager.GenerateChallengeToken(registered.User.ID, "desktop-1", "desktop", "", challengeDuration)
+	require.NoError(t, err)
+	_, err = authService.VerifySecondFactor(&VerifySecondFactorRequest{ChallengeToken: challenge, Code: "000000"})
+	assert.Equal(t, errTwoFactorUnavailable, err)
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, hashes, err := generateRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodeCount)
+	require.Len(t, hashes, recoveryCodeCount)
+
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		assert.Len(t, code, recoveryCodeHalfLen*2+1)
+		assert.Equal(t, hashes[i], hashCode(normalizeRecoveryCode(code)))
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+}
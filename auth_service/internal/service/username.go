@@ -0,0 +1,257 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+// usernamePattern 用户名: 3-32位字母、数字或下划线，以字母开头，不以下划线结尾
+var usernamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{1,30}[a-zA-Z0-9]$`)
+
+// defaultReservedUsernames 保留的用户名，不区分大小写，普通用户和机器人都不能使用
+var defaultReservedUsernames = []string{
+	"admin", "administrator", "root", "system", "support", "help", "security",
+	"official", "staff", "moderator", "telegram", "telegramlite", "bot", "api",
+	"www", "null", "undefined", "me", "settings", "login", "register",
+}
+
+// maxUsernameHistory 返回的用户名变更记录条数
+const maxUsernameHistory = 20
+
+// 用户名不可用的原因
+const (
+	UsernameInvalid  = "invalid"  // 格式不符
+	UsernameReserved = "reserved" // 保留词
+	UsernameTaken    = "taken"    // 已被其他账号使用
+	UsernameHeld     = "held"     // 其他账号刚改掉的旧用户名，保留期内不能使用
+)
+
+var errUsernameNotFound = errors.New("用户不存在")
+
+// UsernamePolicy 用户名策略
+type UsernamePolicy struct {
+	ChangeCooldown time.Duration // 两次修改用户名的最短间隔
+	HoldPeriod     time.Duration // 旧用户名为原用户保留的时长
+	Reserved       []string      // 额外的保留用户名
+}
+
+// defaultUsernamePolicy 默认用户名策略
+func defaultUsernamePolicy() UsernamePolicy {
+	return UsernamePolicy{
+		ChangeCooldown: 7 * 24 * time.Hour,
+		HoldPeriod:     14 * 24 * time.Hour,
+	}
+}
+
+// UsernameAvailability 用户名检查结果
+type UsernameAvailability struct {
+	Username  string `json:"username"`
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"` // invalid/reserved/taken/held
+	Message   string `json:"message,omitempty"`
+}
+
+// PublicUser 通过用户名解析到的公开信息
+type PublicUser struct {
+	ID          uint   `json:"id"`
+	Username    string `json:"username"`
+	AvatarURL   string `json:"avatar_url"`
+	AccountType string `json:"account_type"`
+}
+
+// ChangeUsernameRequest 修改用户名请求
+type ChangeUsernameRequest struct {
+	Username  string `json:"username" binding:"required"`
+	ClientIP  string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// CheckUsername 检查用户名是否可用，userID为当前用户 (未登录为0)，自己的用户名和保留中的旧用户名视为可用
+func (s *AuthService) CheckUsername(userID uint, username string) (*UsernameAvailability, error) {
+	username = pkg.NormalizeUsername(username)
+	result := &UsernameAvailability{Username: username}
+
+	reason, err := s.usernameUnavailable(userID, username, false)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		result.Reason = reason
+		result.Message = usernameMessage(reason)
+		return result, nil
+	}
+	result.Available = true
+	return result, nil
+}
+
+// ChangeUsername 修改用户名，受修改间隔限制；旧用户名保留一段时间，期间其他人不能使用
+func (s *AuthService) ChangeUsername(userID uint, req *ChangeUsernameRequest) (*model.User, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errUsernameNotFound
+	}
+
+	username := pkg.NormalizeUsername(req.Username)
+	if username == user.Username {
+		return nil, errors.New("新用户名与当前用户名相同")
+	}
+
+	reason, err := s.usernameUnavailable(userID, username, user.AccountType == pkg.AccountTypeBot)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		return nil, errors.New(usernameMessage(reason))
+	}
+
+	now := time.Now()
+	// 只修改大小写不受间隔限制，也不保留旧用户名
+	caseOnly := strings.EqualFold(username, user.Username)
+	if !caseOnly {
+		history, err := s.userRepo.GetUsernameHistory(userID, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(history) > 0 {
+			if next := history[0].ChangedAt.Add(s.usernamePolicy.ChangeCooldown); now.Before(next) {
+				hours := int(math.Ceil(next.Sub(now).Hours()))
+				return nil, fmt.Errorf("修改用户名过于频繁，请在%d小时后再试", hours)
+			}
+		}
+	}
+
+	oldUsername := user.Username
+	if caseOnly {
+		oldUsername = ""
+	}
+	if err := s.userRepo.ChangeUsername(userID, oldUsername, username, now, now.Add(s.usernamePolicy.HoldPeriod)); err != nil {
+		return nil, err
+	}
+
+	s.audit(AuditUsernameChanged, auditEntry{
+		UserID:    userID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+		Details:   applogger.Fields{"old_username": user.Username, "new_username": username},
+	})
+
+	user.Username = username
+	user.PasswordHash = ""
+	return user, nil
+}
+
+// ResolveUsername 将@用户名解析为用户的公开信息，已停用的账号和旧用户名不解析
+func (s *AuthService) ResolveUsername(username string) (*PublicUser, error) {
+	username = pkg.NormalizeUsername(username)
+	if username == "" {
+		return nil, errUsernameNotFound
+	}
+
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.IsActive {
+		return nil, errUsernameNotFound
+	}
+
+	return &PublicUser{
+		ID:          user.ID,
+		Username:    user.Username,
+		AvatarURL:   user.AvatarURL,
+		AccountType: user.AccountType,
+	}, nil
+}
+
+// ListUsernameHistory 获取用户最近的用户名变更
+func (s *AuthService) ListUsernameHistory(userID uint) ([]model.UsernameHistory, error) {
+	return s.userRepo.GetUsernameHistory(userID, maxUsernameHistory)
+}
+
+// usernameUnavailable 返回用户名不可用的原因，可用时返回空字符串
+// userID为0表示新账号；bot决定使用机器人还是普通用户的格式规则
+func (s *AuthService) usernameUnavailable(userID uint, username string, bot bool) (string, error) {
+	if !isValidUsername(username, bot) {
+		return UsernameInvalid, nil
+	}
+	if s.isReservedUsername(username) {
+		return UsernameReserved, nil
+	}
+
+	existing, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil && existing.ID != userID {
+		return UsernameTaken, nil
+	}
+
+	hold, err := s.userRepo.GetUsernameHold(username, time.Now())
+	if err != nil {
+		return "", err
+	}
+	if hold != nil && hold.UserID != userID {
+		return UsernameHeld, nil
+	}
+	return "", nil
+}
+
+// checkNewUsername 注册和创建机器人时检查用户名，不可用时返回对应的错误
+func (s *AuthService) checkNewUsername(username string, bot bool) error {
+	reason, err := s.usernameUnavailable(0, username, bot)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		return errors.New(usernameMessage(reason))
+	}
+	return nil
+}
+
+// isReservedUsername 是否为保留用户名
+func (s *AuthService) isReservedUsername(username string) bool {
+	for _, reserved := range defaultReservedUsernames {
+		if strings.EqualFold(username, reserved) {
+			return true
+		}
+	}
+	for _, reserved := range s.usernamePolicy.Reserved {
+		if strings.EqualFold(username, reserved) {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidUsername 普通用户名不能以bot结尾，机器人用户名必须以bot结尾
+func isValidUsername(username string, bot bool) bool {
+	if bot {
+		return botUsernamePattern.MatchString(username)
+	}
+	return usernamePattern.MatchString(username) && !strings.HasSuffix(strings.ToLower(username), "bot")
+}
+
+// usernameMessage 用户名不可用的提示
+func usernameMessage(reason string) string {
+	switch reason {
+	case UsernameInvalid:
+		return "用户名须为3-32位字母、数字或下划线，以字母开头，不能以下划线或bot结尾"
+	case UsernameReserved:
+		return "该用户名为保留用户名"
+	case UsernameHeld:
+		return "该用户名刚被释放，暂时不能使用"
+	default:
+		return "用户名已被占用"
+	}
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/repository"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+func TestAuthService_CheckUsername(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithUsernamePolicy(UsernamePolicy{Reserved: []string{"ceo"}}))
+
+	_, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+
+	for username, reason := range map[string]string{
+		"ab":         UsernameInvalid,
+		"1alice":     UsernameInvalid,
+		"alice_":     UsernameInvalid,
+		"weatherbot": UsernameInvalid,
+		"Admin":      UsernameReserved,
+		"CEO":        UsernameReserved,
+		"@ALICE":     UsernameTaken,
+	} {
+		result, err := authService.CheckUsername(0, username)
+		require.NoError(t, err)
+		assert.False(t, result.Available, username)
+		assert.Equal(t, reason, result.Reason, username)
+	}
+
+	result, err := authService.CheckUsername(0, "@bob_2")
+	require.NoError(t, err)
+	assert.True(t, result.Available)
+	assert.Equal(t, "bob_2", result.Username)
+
+	// 注册时先检查用户名，直接返回原因
+	_, err = authService.Register(&RegisterRequest{
+		Phone:       "+8613800000001",
+		Username:    "support",
+		Password:    "password123",
+		DeviceToken: "phone-2",
+		DeviceType:  "ios",
+	})
+	assert.EqualError(t, err, usernameMessage(UsernameReserved))
+}
+
+func TestAuthService_ChangeUsername(t *testing.T) {
+	setupTestDB(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour))
+
+	alice, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000000",
+		Username:    "alice",
+		Password:    "password123",
+		DeviceToken: "phone-1",
+		DeviceType:  "ios",
+	})
+	require.NoError(t, err)
+	aliceID := alice.User.ID
+
+	bob, err := authService.Register(&RegisterRequest{
+		Phone:       "+8613800000001",
+		Username:    "bob",
+		Password:    "password123",
+		DeviceToken: "phone-2",
+		DeviceType:  "android",
+	})
+	require.NoError(t, err)
+	bobID := bob.User.ID
+
+	_, err = authService.ChangeUsername(aliceID, &ChangeUsernameRequest{Username: "bob"})
+	assert.Error(t, err)
+
+	user, err := authService.ChangeUsername(aliceID, &ChangeUsernameRequest{Username: "@alice_w"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice_w", user.Username)
+
+	// 旧用户名保留期内其他人不能使用，也不再解析到原用户
+	result, err := authService.CheckUsername(bobID, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, UsernameHeld, result.Reason)
+	_, err = authService.ChangeUsername(bobID, &ChangeUsernameRequest{Username: "Alice"})
+	assert.Error(t, err)
+	_, err = authService.ResolveUsername("alice")
+	assert.Error(t, err)
+
+	resolved, err := authService.ResolveUsername("@ALICE_W")
+	require.NoError(t, err)
+	assert.Equal(t, aliceID, resolved.ID)
+	assert.Equal(t, pkg.AccountTypeUser, resolved.AccountType)
+
+	// 修改间隔内不能再次修改，只改大小写不受限制
+	_, err = authService.ChangeUsername(aliceID, &ChangeUsernameRequest{Username: "alice_x"})
+	assert.Error(t, err)
+	user, err = authService.ChangeUsername(aliceID, &ChangeUsernameRequest{Username: "Alice_W"})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice_W", user.Username)
+
+	history, err := authService.ListUsernameHistory(aliceID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "alice", history[0].Username)
+
+	// 间隔过后原用户可以改回保留的旧用户名，保留随之释放
+	require.NoError(t, repository.GetDB().Model(&model.UsernameHistory{}).Where("user_id = ?", aliceID).
+		Update("changed_at", time.Now().Add(-8*24*time.Hour)).Error)
+	result, err = authService.CheckUsername(aliceID, "alice")
+	require.NoError(t, err)
+	assert.True(t, result.Available)
+	_, err = authService.ChangeUsername(aliceID, &ChangeUsernameRequest{Username: "alice"})
+	require.NoError(t, err)
+
+	hold, err := repository.NewUserRepository().GetUsernameHold("alice", time.Now())
+	require.NoError(t, err)
+	assert.Nil(t, hold)
+	hold, err = repository.NewUserRepository().GetUsernameHold("alice_w", time.Now())
+	require.NoError(t, err)
+	require.NotNil(t, hold)
+	assert.Equal(t, aliceID, hold.UserID)
+}
+
+func TestUserRepository_UsernameUniqueIgnoresCase(t *testing.T) {
+	setupTestDB(t)
+	userRepo := repository.NewUserRepository()
+
+	// 绕过服务层的检查直接写入，模拟并发注册或改名时的竞争
+	require.NoError(t, userRepo.CreateUser(&model.User{Username: "Alice", IsActive: true, AccountType: pkg.AccountTypeUser}))
+	err := userRepo.CreateUser(&model.User{Username: "alice", IsActive: true, AccountType: pkg.AccountTypeUser})
+	assert.ErrorIs(t, err, repository.ErrUsernameTaken)
+	assert.EqualError(t, err, "用户名已被占用")
+
+	bob := &model.User{Username: "bob", IsActive: true, AccountType: pkg.AccountTypeUser}
+	require.NoError(t, userRepo.CreateUser(bob))
+	err = userRepo.ChangeUsername(bob.ID, "bob", "ALICE", time.Now(), time.Now().Add(time.Hour))
+	assert.ErrorIs(t, err, repository.ErrUsernameTaken)
+
+	stored, err := userRepo.GetUserByID(bob.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "bob", stored.Username)
+}
+
+func TestAutoMigrate_ReportsCaseInsensitiveUsernameConflicts(t *testing.T) {
+	setupTestDB(t)
+	db := repository.GetDB()
+
+	// 模拟早期版本允许的只有大小写不同的用户名
+	require.NoError(t, db.Exec("DROP INDEX idx_users_username_lower").Error)
+	for _, username := range []string{"Alice", "bob", "alice", "ALICE"} {
+		require.NoError(t, db.Create(&model.User{Username: username, IsActive: true, AccountType: pkg.AccountTypeUser}).Error)
+	}
+
+	err := repository.AutoMigrate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "alice: 1, 3, 4")
+	assert.NotContains(t, err.Error(), "bob")
+
+	// 改名消除冲突后创建索引
+	require.NoError(t, db.Model(&model.User{}).Where("id IN ?", []uint{3, 4}).Update("username", gorm.Expr("'alice_' || id")).Error)
+	require.NoError(t, repository.AutoMigrate())
+	assert.Error(t, db.Create(&model.User{Username: "BOB", IsActive: true, AccountType: pkg.AccountTypeUser}).Error)
+}
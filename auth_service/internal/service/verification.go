@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/auth_service/internal/model"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+// 验证码用途
+const (
+	CodePurposeLogin = "login" // 验证码登录/注册
+)
+
+// CodePolicy 验证码策略
+type CodePolicy struct {
+	Length         int           // 验证码位数
+	TTL            time.Duration // 有效期
+	MaxAttempts    int           // 最大验证尝试次数
+	ResendCooldown time.Duration // 重发冷却时间
+}
+
+// defaultCodePolicy 默认验证码策略
+func defaultCodePolicy() CodePolicy {
+	return CodePolicy{
+		Length:         6,
+		TTL:            5 * time.Minute,
+		MaxAttempts:    5,
+		ResendCooldown: time.Minute,
+	}
+}
+
+// SendCodeRequest 发送验证码请求
+type SendCodeRequest struct {
+	Phone string `json:"phone"`
+	Email string `json:"email"`
+}
+
+// SendCodeResponse 发送验证码响应
+type SendCodeResponse struct {
+	ExpiresIn   int64 `json:"expires_in"`   // 验证码有效期(秒)
+	ResendAfter int64 `json:"resend_after"` // 可重新发送的等待时间(秒)
+}
+
+// VerifyCodeRequest 验证码登录请求，账号不存在时自动注册
+type VerifyCodeRequest struct {
+	Phone       string `json:"phone"`
+	Email       string `json:"email"`
+	Code        string `json:"code" binding:"required"`
+	Username    string `json:"username"` // 注册时使用，为空则自动生成
+	DeviceToken string `json:"device_token" binding:"required"`
+	DeviceType  string `json:"device_type" binding:"required"`
+	DeviceName  string `json:"device_name"`
+	ClientIP    string `json:"-"`
+	UserAgent   string `json:"-"`
+}
+
+// codeTarget 规范化后的验证码接收方
+type codeTarget struct {
+	phone string
+	email string
+}
+
+// value 接收方标识
+func (t codeTarget) value() string {
+	if t.phone != "" {
+		return t.phone
+	}
+	return t.email
+}
+
+// SendCode 发送登录验证码
+func (s *AuthService) SendCode(req *SendCodeRequest) (*SendCodeResponse, error) {
+	target, err := s.resolveCodeTarget(req.Phone, req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.issueCode(CodePurposeLogin, target); err != nil {
+		return nil, err
+	}
+
+	return &SendCodeResponse{
+		ExpiresIn:   int64(s.codePolicy.TTL.Seconds()),
+		ResendAfter: int64(s.codePolicy.ResendCooldown.Seconds()),
+	}, nil
+}
+
+// VerifyCode 使用验证码登录，账号不存在时自动注册
+func (s *AuthService) VerifyCode(req *VerifyCodeRequest) (*AuthResponse, error) {
+	if !isValidDeviceType(req.DeviceType) {
+		return nil, errors.New("无效的设备类型")
+	}
+
+	target, err := s.resolveCodeTarget(req.Phone, req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.codeRepo == nil {
+		return nil, errors.New("验证码服务不可用")
+	}
+
+	user, err := s.resolveUser(target.phone, target.email, "")
+	if err != nil {
+		return nil, err
+	}
+
+	loginReq := &LoginRequest{
+		Phone:       target.phone,
+		Email:       target.email,
+		DeviceToken: req.DeviceToken,
+		DeviceType:  req.DeviceType,
+		DeviceName:  req.DeviceName,
+		ClientIP:    req.ClientIP,
+		UserAgent:   req.UserAgent,
+	}
+
+	// 与密码登录共用失败计数，防止通过验证码暴力破解
+	subjects := s.loginSubjects(user, loginReq)
+	var userID uint
+	if user != nil {
+		userID = user.ID
+	}
+	if err := s.checkLoginLock(userID, subjects, loginReq); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCode(CodePurposeLogin, target.value(), req.Code); err != nil {
+		s.recordLoginFailure(userID, subjects, loginReq, err.Error())
+		return nil, err
+	}
+	s.resetLoginFailures(subjects)
+
+	if user != nil {
+		return s.beginLogin(user, loginReq)
+	}
+
+	user, err = s.createCodeUser(target, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(AuditRegistered, auditEntry{
+		UserID:    user.ID,
+		ClientIP:  req.ClientIP,
+		UserAgent: req.UserAgent,
+		Details:   applogger.Fields{"method": "code"},
+	})
+
+	resp, err := s.completeLogin(user, loginReq)
+	if err != nil {
+		return nil, err
+	}
+	resp.IsNewUser = true
+	return resp, nil
+}
+
+// resolveCodeTarget 规范化验证码接收方
+func (s *AuthService) resolveCodeTarget(phone, email string) (codeTarget, error) {
+	switch {
+	case phone != "":
+		normalized, err := s.normalizePhone(phone)
+		if err != nil {
+			return codeTarget{}, err
+		}
+		return codeTarget{phone: normalized}, nil
+	case email != "":
+		normalized := pkg.NormalizeEmail(email)
+		if !strings.Contains(normalized, "@") {
+			return codeTarget{}, errors.New("邮箱格式无效")
+		}
+		return codeTarget{email: normalized}, nil
+	default:
+		return codeTarget{}, errors.New("手机号或邮箱必须提供一个")
+	}
+}
+
+// createCodeUser 为验证码登录的新账号创建用户
+func (s *AuthService) createCodeUser(target codeTarget, username string) (*model.User, error) {
+	username = pkg.NormalizeUsername(username)
+	if username == "" {
+		suffix, err := pkg.NewTokenID()
+		if err != nil {
+			return nil, err
+		}
+		username = "user_" + suffix[:10]
+	} else if err := s.checkNewUsername(username, false); err != nil {
+		return nil, err
+	}
+
+	user := &model.User{
+		Phone:       target.phone,
+		Email:       target.email,
+		Username:    username,
+		IsActive:    true,
+		AccountType: pkg.AccountTypeUser,
+	}
+	if err := s.userRepo.CreateUser(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// issueCode 生成验证码并发送，冷却期内拒绝重发
+func (s *AuthService) issueCode(purpose string, target codeTarget) error {
+	if s.codeRepo == nil {
+		return errors.New("验证码服务不可用")
+	}
+
+	ctx := context.Background()
+	acquired, err := s.codeRepo.AcquireCooldown(ctx, purpose, target.value(), s.codePolicy.ResendCooldown)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return errors.New("验证码发送过于频繁，请稍后再试")
+	}
+
+	code, err := generateCode(s.codePolicy.Length)
+	if err != nil {
+		return err
+	}
+
+	if err := s.codeRepo.SaveCode(ctx, purpose, target.value(), hashCode(code), s.codePolicy.TTL); err != nil {
+		return err
+	}
+
+	codeSender := s.emailSender
+	if target.phone != "" {
+		codeSender = s.smsSender
+	}
+
+	if err := codeSender.SendCode(ctx, target.value(), code, s.codePolicy.TTL); err != nil {
+		s.logCodeError("Failed to send verification code", purpose, err)
+		// 作废未送达的验证码并允许立即重发
+		if _, err := s.codeRepo.DeleteCode(ctx, purpose, target.value()); err != nil {
+			s.logCodeError("Failed to delete unsent verification code", purpose, err)
+		}
+		if err := s.codeRepo.ReleaseCooldown(ctx, purpose, target.value()); err != nil {
+			s.logCodeError("Failed to release verification code cooldown", purpose, err)
+		}
+		return errors.New("验证码发送失败，请稍后再试")
+	}
+
+	return nil
+}
+
+// checkCode 校验验证码，成功后验证码立即失效
+func (s *AuthService) checkCode(purpose, target, code string) error {
+	if s.codeRepo == nil {
+		return errors.New("验证码服务不可用")
+	}
+	if code == "" {
+		return errors.New("验证码不能为空")
+	}
+
+	ctx := context.Background()
+	attempts, codeHash, found, err := s.codeRepo.IncrAttempts(ctx, purpose, target)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.New("验证码无效或已过期")
+	}
+
+	if attempts > int64(s.codePolicy.MaxAttempts) {
+		if _, err := s.codeRepo.DeleteCode(ctx, purpose, target); err != nil {
+			return err
+		}
+		return errors.New("验证码错误次数过多，请重新获取")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashCode(code)), []byte(codeHash)) != 1 {
+		return errors.New("验证码错误")
+	}
+
+	// 并发验证同一验证码时只有一个请求能成功
+	deleted, err := s.codeRepo.DeleteCode(ctx, purpose, target)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.New("验证码无效或已过期")
+	}
+	return nil
+}
+
+// logCodeError 记录验证码发送和清理的错误
+func (s *AuthService) logCodeError(msg, purpose string, err error) {
+	if log := applogger.GetDefault(); log != nil {
+		log.Error(msg, applogger.Fields{
+			"purpose": purpose,
+			"error":   err.Error(),
+		})
+	}
+}
+
+// generateCode 生成指定位数的数字验证码
+func generateCode(length int) (string, error) {
+	var code strings.Builder
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		code.WriteByte(byte('0' + n.Int64()))
+	}
+	return code.String(), nil
+}
+
+// hashCode 验证码哈希，Redis中不保存明文
+func hashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// opaqueTokenBytes 不透明token的随机字节数
+const opaqueTokenBytes = 32
+
+// generateOpaqueToken 生成URL安全的随机token (重置链接、授权码、客户端密钥、刷新token等)，只保存其哈希
+func generateOpaqueToken() (string, error) {
+	b := make([]byte, opaqueTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+func TestGenerateCode(t *testing.T) {
+	for _, length := range []int{4, 6, 8} {
+		code, err := generateCode(length)
+		require.NoError(t, err)
+		assert.Len(t, code, length)
+		for _, r := range code {
+			assert.True(t, r >= '0' && r <= '9')
+		}
+	}
+}
+
+func TestHashCode(t *testing.T) {
+	assert.Equal(t, hashCode("123456"), hashCode("123456"))
+	assert.NotEqual(t, hashCode("123456"), hashCode("654321"))
+	assert.NotContains(t, hashCode("123456"), "123456")
+}
+
+func TestWithCodePolicy(t *testing.T) {
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", 3600, 7*24*3600),
+		WithCodePolicy(CodePolicy{Length: 8, TTL: 10 * time.Minute}))
+
+	assert.Equal(t, 8, authService.codePolicy.Length)
+	assert.Equal(t, 10*time.Minute, authService.codePolicy.TTL)
+	// 未设置的字段保留默认值
+	assert.Equal(t, defaultCodePolicy().MaxAttempts, authService.codePolicy.MaxAttempts)
+	assert.Equal(t, defaultCodePolicy().ResendCooldown, authService.codePolicy.ResendCooldown)
+}
+
+func TestAuthService_SendCode(t *testing.T) {
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", 3600, 7*24*3600))
+
+	tests := []struct {
+		name   string
+		req    *SendCodeRequest
+		errMsg string
+	}{
+		{
+			name:   "missing phone and email",
+			req:    &SendCodeRequest{},
+			errMsg: "手机号或邮箱必须提供一个",
+		},
+		{
+			name:   "invalid phone",
+			req:    &SendCodeRequest{Phone: "abc"},
+			errMsg: "手机号格式无效",
+		},
+		{
+			name:   "invalid email",
+			req:    &SendCodeRequest{Email: "not-an-email"},
+			errMsg: "邮箱格式无效",
+		},
+		{
+			name:   "redis unavailable",
+			req:    &SendCodeRequest{Phone: "13800138000"},
+			errMsg: "验证码服务不可用",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := authService.SendCode(tt.req)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestAuthService_VerifyCode(t *testing.T) {
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", 3600, 7*24*3600))
+
+	tests := []struct {
+		name   string
+		req    *VerifyCodeRequest
+		errMsg string
+	}{
+		{
+			name: "invalid device type",
+			req: &VerifyCodeRequest{
+				Phone:       "13800138000",
+				Code:        "123456",
+				DeviceToken: "device123",
+				DeviceType:  "invalid_type",
+			},
+			errMsg: "无效的设备类型",
+		},
+		{
+			name: "missing phone and email",
+			req: &VerifyCodeRequest{
+				Code:        "123456",
+				DeviceToken: "device123",
+				DeviceType:  "ios",
+			},
+			errMsg: "手机号或邮箱必须提供一个",
+		},
+		{
+			name: "redis unavailable",
+			req: &VerifyCodeRequest{
+				Email:       "user@example.com",
+				Code:        "123456",
+				DeviceToken: "device123",
+				DeviceType:  "ios",
+			},
+			errMsg: "验证码服务不可用",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := authService.VerifyCode(tt.req)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+// failingSender 总是发送失败的测试发送器
+type failingSender struct{}
+
+func (failingSender) SendCode(ctx context.Context, target, code string, ttl time.Duration) error {
+	return errors.New("gateway unavailable")
+}
+
+func (failingSender) SendPasswordReset(ctx context.Context, target, link string, ttl time.Duration) error {
+	return errors.New("gateway unavailable")
+}
+
+func TestAuthService_VerifyCodeWithRedis(t *testing.T) {
+	setupTestDB(t)
+	redisServer := setupTestRedis(t)
+	sms := &stubSender{}
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithSenders(sms, &stubSender{}),
+		WithCodePolicy(CodePolicy{MaxAttempts: 3}))
+
+	verify := func(code string) (*AuthResponse, error) {
+		return authService.VerifyCode(&VerifyCodeRequest{
+			Phone:       "+8613800138000",
+			Code:        code,
+			DeviceToken: "phone-1",
+			DeviceType:  "ios",
+		})
+	}
+
+	resp, err := authService.SendCode(&SendCodeRequest{Phone: "+8613800138000"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), resp.ExpiresIn)
+	assert.Equal(t, []string{"+8613800138000"}, sms.targets)
+
+	// Redis中只保存验证码哈希
+	key := fmt.Sprintf("auth:code:%s:%s", CodePurposeLogin, "+8613800138000")
+	assert.Equal(t, hashCode(sms.lastCode()), redisServer.HGet(key, "hash"))
+
+	// 冷却期内不能重发
+	_, err = authService.SendCode(&SendCodeRequest{Phone: "+8613800138000"})
+	assert.Error(t, err)
+
+	// 超过最大错误次数后验证码作废
+	for i := 0; i < 3; i++ {
+		_, err = verify("000000")
+		assert.EqualError(t, err, "验证码错误")
+	}
+	_, err = verify(sms.lastCode())
+	assert.EqualError(t, err, "验证码错误次数过多，请重新获取")
+	assert.False(t, redisServer.Exists(key))
+
+	// 冷却期结束后重新获取，正确的验证码完成注册且只能使用一次
+	redisServer.FastForward(time.Minute)
+	_, err = authService.SendCode(&SendCodeRequest{Phone: "+8613800138000"})
+	require.NoError(t, err)
+	login, err := verify(sms.lastCode())
+	require.NoError(t, err)
+	assert.True(t, login.IsNewUser)
+	assert.NotNil(t, login.Token)
+
+	_, err = verify(sms.lastCode())
+	assert.EqualError(t, err, "验证码无效或已过期")
+
+	// 验证码过期后失效
+	redisServer.FastForward(time.Minute)
+	_, err = authService.SendCode(&SendCodeRequest{Phone: "+8613800138000"})
+	require.NoError(t, err)
+	redisServer.FastForward(6 * time.Minute)
+	_, err = verify(sms.lastCode())
+	assert.EqualError(t, err, "验证码无效或已过期")
+}
+
+func TestAuthService_SendCodeFailure(t *testing.T) {
+	redisServer := setupTestRedis(t)
+	authService := NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour),
+		WithSenders(failingSender{}, failingSender{}))
+
+	// 发送失败时作废验证码并释放冷却期，可以立即重试
+	_, err := authService.SendCode(&SendCodeRequest{Email: "user@example.com"})
+	assert.EqualError(t, err, "验证码发送失败，请稍后再试")
+	assert.Empty(t, redisServer.Keys())
+
+	_, err = authService.SendCode(&SendCodeRequest{Email: "user@example.com"})
+	assert.EqualError(t, err, "验证码发送失败，请稍后再试")
+
+	// 未配置发送渠道时默认拒绝发送
+	_, err = NewAuthService(pkg.NewJWTManager("test-secret", time.Hour, 7*24*time.Hour)).
+		SendCode(&SendCodeRequest{Email: "user@example.com"})
+	assert.EqualError(t, err, "验证码发送失败，请稍后再试")
+}
@@ -0,0 +1,46 @@
+package pkg
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strconv"
+	"strings"
+)
+
+// 账号类型
+const (
+	AccountTypeUser = "user" // 普通用户
+	AccountTypeBot  = "bot"  // 机器人，由普通用户创建和管理
+)
+
+// botSecretBytes 机器人token随机部分的字节数
+const botSecretBytes = 32
+
+// NewBotToken 生成机器人token，格式为 <机器人ID>:<随机串>
+// 与访问token不同，机器人token不是JWT，长期有效直到被吊销
+func NewBotToken(botID uint) (string, error) {
+	b := make([]byte, botSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return strconv.FormatUint(uint64(botID), 10) + ":" + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// ParseBotToken 解析机器人token中的机器人ID，格式不符时返回false
+func ParseBotToken(token string) (uint, bool) {
+	id, secret, ok := strings.Cut(token, ":")
+	if !ok || len(secret) != base64.RawURLEncoding.EncodedLen(botSecretBytes) {
+		return 0, false
+	}
+	botID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil || botID == 0 {
+		return 0, false
+	}
+	return uint(botID), true
+}
+
+// IsBotToken 是否为机器人token格式 (JWT不包含冒号)
+func IsBotToken(token string) bool {
+	_, ok := ParseBotToken(token)
+	return ok
+}
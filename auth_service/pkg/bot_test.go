@@ -0,0 +1,37 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBotToken(t *testing.T) {
+	token, err := NewBotToken(42)
+	require.NoError(t, err)
+
+	botID, ok := ParseBotToken(token)
+	require.True(t, ok)
+	assert.Equal(t, uint(42), botID)
+	other, err := NewBotToken(42)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+
+	jwtManager := NewJWTManager("test-secret", time.Hour, time.Hour)
+	accessToken, err := jwtManager.GenerateToken(1, 1, "device")
+	require.NoError(t, err)
+
+	for _, invalid := range []string{
+		"",
+		accessToken,
+		"42",
+		"42:short",
+		"0:" + token[3:],
+		"abc:" + token[3:],
+		"-1:" + token[3:],
+	} {
+		assert.False(t, IsBotToken(invalid), invalid)
+	}
+}
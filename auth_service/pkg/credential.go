@@ -0,0 +1,93 @@
+package pkg
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidPhone 手机号无法规范化为E.164
+var ErrInvalidPhone = errors.New("invalid phone number")
+
+// regionInfo 地区的国际区号和国内长途前缀
+type regionInfo struct {
+	callingCode string
+	trunkPrefix string
+}
+
+// regions 支持作为默认地区的国家/地区 (ISO 3166-1 alpha-2)
+var regions = map[string]regionInfo{
+	"CN": {callingCode: "86", trunkPrefix: "0"},
+	"HK": {callingCode: "852"},
+	"MO": {callingCode: "853"},
+	"TW": {callingCode: "886", trunkPrefix: "0"},
+	"SG": {callingCode: "65"},
+	"JP": {callingCode: "81", trunkPrefix: "0"},
+	"KR": {callingCode: "82", trunkPrefix: "0"},
+	"IN": {callingCode: "91", trunkPrefix: "0"},
+	"US": {callingCode: "1", trunkPrefix: "1"},
+	"CA": {callingCode: "1", trunkPrefix: "1"},
+	"GB": {callingCode: "44", trunkPrefix: "0"},
+	"DE": {callingCode: "49", trunkPrefix: "0"},
+	"FR": {callingCode: "33", trunkPrefix: "0"},
+	"RU": {callingCode: "7", trunkPrefix: "8"},
+	"AU": {callingCode: "61", trunkPrefix: "0"},
+}
+
+// IsSupportedRegion 是否支持该默认地区
+func IsSupportedRegion(region string) bool {
+	_, ok := regions[strings.ToUpper(region)]
+	return ok
+}
+
+// NormalizePhone 将手机号规范化为E.164格式 (+8613800138000)
+// 以+或00开头的号码视为国际格式，否则按defaultRegion的国内格式处理
+func NormalizePhone(raw, defaultRegion string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	international := strings.HasPrefix(raw, "+")
+
+	var digits strings.Builder
+	for i, r := range raw {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", ErrInvalidPhone
+		}
+	}
+
+	number := digits.String()
+	if !international && strings.HasPrefix(number, "00") {
+		number = number[2:]
+		international = true
+	}
+
+	if !international {
+		region, ok := regions[strings.ToUpper(defaultRegion)]
+		if !ok {
+			return "", ErrInvalidPhone
+		}
+		if region.trunkPrefix != "" {
+			number = strings.TrimPrefix(number, region.trunkPrefix)
+		}
+		number = region.callingCode + number
+	}
+
+	// E.164最长15位，首位不能为0
+	if len(number) < 7 || len(number) > 15 || number[0] == '0' {
+		return "", ErrInvalidPhone
+	}
+
+	return "+" + number, nil
+}
+
+// NormalizeEmail 规范化邮箱 (去除首尾空白并转为小写)
+func NormalizeEmail(raw string) string {
+	return strings.ToLower(strings.TrimSpace(raw))
+}
+
+// NormalizeUsername 规范化用户名输入 (去除首尾空白和@前缀)
+func NormalizeUsername(raw string) string {
+	return strings.TrimPrefix(strings.TrimSpace(raw), "@")
+}
@@ -0,0 +1,44 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		region  string
+		want    string
+		wantErr bool
+	}{
+		{name: "national CN", raw: "138 0013 8000", region: "CN", want: "+8613800138000"},
+		{name: "international with spaces", raw: "+86 138-0013-8000", region: "US", want: "+8613800138000"},
+		{name: "00 prefix", raw: "0086 13800138000", region: "CN", want: "+8613800138000"},
+		{name: "national US with trunk", raw: "1 (415) 555-2671", region: "US", want: "+14155552671"},
+		{name: "national GB with trunk", raw: "020 7946 0018", region: "gb", want: "+442079460018"},
+		{name: "letters", raw: "+86 138abc", region: "CN", wantErr: true},
+		{name: "too long", raw: "+1234567890123456", region: "CN", wantErr: true},
+		{name: "too short", raw: "12", region: "CN", wantErr: true},
+		{name: "unknown region", raw: "13800138000", region: "ZZ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizePhone(tt.raw, tt.region)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPhone)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalizeEmailAndUsername(t *testing.T) {
+	assert.Equal(t, "alice@example.com", NormalizeEmail("  Alice@Example.COM "))
+	assert.Equal(t, "alice", NormalizeUsername(" @alice"))
+}
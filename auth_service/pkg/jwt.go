@@ -1,6 +1,8 @@
 package pkg
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -8,28 +10,64 @@ import (
 )
 
 // JWTManager JWT管理器
+// 配置密钥集合时使用非对称算法签发并在header中携带kid；
+// secretKey仅在未配置密钥集合时用于HS256签发，否则只在legacyRetireAt之前用于验证迁移前签发的旧token
 type JWTManager struct {
 	secretKey       string
+	keySet          *KeySet
 	tokenDuration   time.Duration
 	refreshDuration time.Duration
+	legacyRetireAt  time.Time
+	onLegacyUse     func(subject string)
 }
 
+// errLegacySecretRetired 旧HS256密钥已停用
+var errLegacySecretRetired = errors.New("legacy signing secret retired")
+
 // Claims JWT声明
 type Claims struct {
 	UserID      uint   `json:"user_id"`
 	DeviceID    uint   `json:"device_id"`
 	DeviceToken string `json:"device_token"`
+	IssuedAtMs  int64  `json:"iat_ms,omitempty"` // 毫秒精度的签发时间, 用于与吊销时间比较 (iat只精确到秒)
 	jwt.RegisteredClaims
 }
 
+// IssuedAtTime 签发时间，旧token没有iat_ms时使用秒精度的iat
+func (c *Claims) IssuedAtTime() time.Time {
+	if c.IssuedAtMs > 0 {
+		return time.UnixMilli(c.IssuedAtMs)
+	}
+	if c.IssuedAt != nil {
+		return c.IssuedAt.Time
+	}
+	return time.Time{}
+}
+
 // RefreshClaims 刷新token声明
 type RefreshClaims struct {
-	UserID   uint `json:"user_id"`
-	DeviceID uint `json:"device_id"`
+	UserID   uint   `json:"user_id"`
+	DeviceID uint   `json:"device_id"`
+	FamilyID string `json:"family_id"` // 令牌族ID, 同一设备登录后的每次轮换共享
 	jwt.RegisteredClaims
 }
 
-// NewJWTManager 创建JWT管理器
+// ChallengeClaims 两步验证挑战token声明, 携带完成登录所需的设备信息
+type ChallengeClaims struct {
+	UserID      uint   `json:"user_id"`
+	DeviceToken string `json:"device_token"`
+	DeviceType  string `json:"device_type"`
+	DeviceName  string `json:"device_name"`
+	jwt.RegisteredClaims
+}
+
+const (
+	accessTokenSubject    = "access-token"
+	refreshTokenSubject   = "refresh-token"
+	challengeTokenSubject = "2fa-challenge"
+)
+
+// NewJWTManager 创建JWT管理器 (HS256)
 func NewJWTManager(secretKey string, tokenDuration, refreshDuration time.Duration) *JWTManager {
 	return &JWTManager{
 		secretKey:       secretKey,
@@ -38,57 +76,95 @@ func NewJWTManager(secretKey string, tokenDuration, refreshDuration time.Duratio
 	}
 }
 
+// NewJWTManagerWithKeys 创建使用非对称密钥集合的JWT管理器
+// legacySecret非空且通过SetLegacySecretRetireAt设置了停用时间时，停用前仍接受该密钥签发的HS256旧token
+func NewJWTManagerWithKeys(keySet *KeySet, legacySecret string, tokenDuration, refreshDuration time.Duration) *JWTManager {
+	return &JWTManager{
+		secretKey:       legacySecret,
+		keySet:          keySet,
+		tokenDuration:   tokenDuration,
+		refreshDuration: refreshDuration,
+	}
+}
+
+// SetLegacySecretRetireAt 设置HS256旧密钥的停用时间，未设置时不接受旧token
+// onUse在每次接受旧token后调用，用于观察迁移进度，需在开始验证token前设置
+func (manager *JWTManager) SetLegacySecretRetireAt(retireAt time.Time, onUse func(subject string)) {
+	manager.legacyRetireAt = retireAt
+	manager.onLegacyUse = onUse
+}
+
+// SigningKeys 当前有效的签名公钥, HS256模式下为空
+func (manager *JWTManager) SigningKeys() []*SigningKey {
+	if manager.keySet == nil {
+		return nil
+	}
+	return manager.keySet.Keys()
+}
+
+// TokenDuration 访问token有效期
+func (manager *JWTManager) TokenDuration() time.Duration {
+	return manager.tokenDuration
+}
+
 // GenerateToken 生成访问token
 func (manager *JWTManager) GenerateToken(userID, deviceID uint, deviceToken string) (string, error) {
+	tokenID, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
 	claims := &Claims{
 		UserID:      userID,
 		DeviceID:    deviceID,
 		DeviceToken: deviceToken,
+		IssuedAtMs:  now.UnixMilli(),
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(manager.tokenDuration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			ID:        tokenID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(manager.tokenDuration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "telegramlite-auth",
-			Subject:   "access-token",
+			Subject:   accessTokenSubject,
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(manager.secretKey))
+	return manager.sign(claims)
 }
 
 // GenerateRefreshToken 生成刷新token
-func (manager *JWTManager) GenerateRefreshToken(userID, deviceID uint) (string, error) {
+func (manager *JWTManager) GenerateRefreshToken(userID, deviceID uint, familyID string) (string, *RefreshClaims, error) {
+	tokenID, err := NewTokenID()
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now()
 	claims := &RefreshClaims{
 		UserID:   userID,
 		DeviceID: deviceID,
+		FamilyID: familyID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(manager.refreshDuration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			ID:        tokenID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(manager.refreshDuration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "telegramlite-auth",
-			Subject:   "refresh-token",
+			Subject:   refreshTokenSubject,
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(manager.secretKey))
+	signed, err := manager.sign(claims)
+	if err != nil {
+		return "", nil, err
+	}
+	return signed, claims, nil
 }
 
 // VerifyToken 验证访问token
 func (manager *JWTManager) VerifyToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(
-		tokenString,
-		&Claims{},
-		func(token *jwt.Token) (interface{}, error) {
-			_, ok := token.Method.(*jwt.SigningMethodHMAC)
-			if !ok {
-				return nil, errors.New("unexpected token signing method")
-			}
-			return []byte(manager.secretKey), nil
-		},
-	)
-
+	token, err := manager.parse(tokenString, &Claims{}, accessTokenSubject)
 	if err != nil {
 		return nil, err
 	}
@@ -103,18 +179,7 @@ func (manager *JWTManager) VerifyToken(tokenString string) (*Claims, error) {
 
 // VerifyRefreshToken 验证刷新token
 func (manager *JWTManager) VerifyRefreshToken(tokenString string) (*RefreshClaims, error) {
-	token, err := jwt.ParseWithClaims(
-		tokenString,
-		&RefreshClaims{},
-		func(token *jwt.Token) (interface{}, error) {
-			_, ok := token.Method.(*jwt.SigningMethodHMAC)
-			if !ok {
-				return nil, errors.New("unexpected token signing method")
-			}
-			return []byte(manager.secretKey), nil
-		},
-	)
-
+	token, err := manager.parse(tokenString, &RefreshClaims{}, refreshTokenSubject)
 	if err != nil {
 		return nil, err
 	}
@@ -127,30 +192,145 @@ func (manager *JWTManager) VerifyRefreshToken(tokenString string) (*RefreshClaim
 	return claims, nil
 }
 
+// GenerateChallengeToken 生成两步验证挑战token
+func (manager *JWTManager) GenerateChallengeToken(userID uint, deviceToken, deviceType, deviceName string, duration time.Duration) (string, *ChallengeClaims, error) {
+	tokenID, err := NewTokenID()
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now()
+	claims := &ChallengeClaims{
+		UserID:      userID,
+		DeviceToken: deviceToken,
+		DeviceType:  deviceType,
+		DeviceName:  deviceName,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "telegramlite-auth",
+			Subject:   challengeTokenSubject,
+		},
+	}
+
+	signed, err := manager.sign(claims)
+	if err != nil {
+		return "", nil, err
+	}
+	return signed, claims, nil
+}
+
+// VerifyChallengeToken 验证两步验证挑战token
+func (manager *JWTManager) VerifyChallengeToken(tokenString string) (*ChallengeClaims, error) {
+	token, err := manager.parse(tokenString, &ChallengeClaims{}, challengeTokenSubject)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*ChallengeClaims)
+	if !ok {
+		return nil, errors.New("invalid challenge token claims")
+	}
+
+	return claims, nil
+}
+
+// sign 使用当前签名密钥签发token
+func (manager *JWTManager) sign(claims jwt.Claims) (string, error) {
+	if manager.keySet == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(manager.secretKey))
+	}
+
+	key := manager.keySet.Active()
+	if key == nil || key.PrivateKey == nil {
+		return "", errors.New("no active signing key")
+	}
+
+	token := jwt.NewWithClaims(key.SigningMethod(), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.PrivateKey)
+}
+
+// parse 验证token签名和subject，接受旧HS256 token时通知onLegacyUse
+func (manager *JWTManager) parse(tokenString string, claims jwt.Claims, subject string) (*jwt.Token, error) {
+	token, err := jwt.ParseWithClaims(tokenString, claims, manager.keyFunc, jwt.WithSubject(subject))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok && manager.keySet != nil && manager.onLegacyUse != nil {
+		manager.onLegacyUse(subject)
+	}
+	return token, nil
+}
+
+// keyFunc 根据token header选择验证密钥
+func (manager *JWTManager) keyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if manager.secretKey == "" {
+			return nil, errors.New("unexpected token signing method")
+		}
+		// 迁移到非对称算法后，旧密钥只在停用时间之前用于验证
+		if manager.keySet != nil && (manager.legacyRetireAt.IsZero() || !time.Now().Before(manager.legacyRetireAt)) {
+			return nil, errLegacySecretRetired
+		}
+		return []byte(manager.secretKey), nil
+	}
+
+	if manager.keySet == nil {
+		return nil, errors.New("unexpected token signing method")
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key := manager.keySet.Get(kid)
+	if key == nil {
+		return nil, ErrUnknownKeyID
+	}
+	if token.Method.Alg() != key.Algorithm {
+		return nil, errors.New("unexpected token signing method")
+	}
+	return key.PublicKey, nil
+}
+
 // TokenResponse token响应结构
 type TokenResponse struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
 	TokenType    string `json:"token_type"`
 	ExpiresIn    int64  `json:"expires_in"`
+
+	// 刷新token的服务端元数据, 用于持久化令牌族, 不返回给客户端
+	RefreshClaims *RefreshClaims `json:"-"`
 }
 
-// GenerateTokenPair 生成token对
-func (manager *JWTManager) GenerateTokenPair(userID, deviceID uint, deviceToken string) (*TokenResponse, error) {
+// GenerateTokenPair 生成token对, 刷新token归属于familyID指定的令牌族
+func (manager *JWTManager) GenerateTokenPair(userID, deviceID uint, deviceToken, familyID string) (*TokenResponse, error) {
 	accessToken, err := manager.GenerateToken(userID, deviceID, deviceToken)
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := manager.GenerateRefreshToken(userID, deviceID)
+	refreshToken, refreshClaims, err := manager.GenerateRefreshToken(userID, deviceID, familyID)
 	if err != nil {
 		return nil, err
 	}
 
 	return &TokenResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		TokenType:    "Bearer",
-		ExpiresIn:    int64(manager.tokenDuration.Seconds()),
+		AccessToken:   accessToken,
+		RefreshToken:  refreshToken,
+		TokenType:     "Bearer",
+		ExpiresIn:     int64(manager.tokenDuration.Seconds()),
+		RefreshClaims: refreshClaims,
 	}, nil
 }
+
+// NewTokenID 生成随机的token唯一标识
+func NewTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,113 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTManager_GenerateTokenPair(t *testing.T) {
+	jwtManager := NewJWTManager("test-secret", time.Hour, 7*24*time.Hour)
+
+	first, err := jwtManager.GenerateTokenPair(1, 2, "device123", "family-a")
+	require.NoError(t, err)
+	second, err := jwtManager.GenerateTokenPair(1, 2, "device123", "family-a")
+	require.NoError(t, err)
+
+	claims, err := jwtManager.VerifyRefreshToken(first.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), claims.UserID)
+	assert.Equal(t, uint(2), claims.DeviceID)
+	assert.Equal(t, "family-a", claims.FamilyID)
+	assert.Equal(t, first.RefreshClaims.ID, claims.ID)
+
+	// 同一令牌族内每次签发的刷新token都有独立的jti
+	assert.NotEmpty(t, claims.ID)
+	assert.NotEqual(t, first.RefreshClaims.ID, second.RefreshClaims.ID)
+}
+
+func TestJWTManager_TokenTypeSeparation(t *testing.T) {
+	jwtManager := NewJWTManager("test-secret", time.Hour, 7*24*time.Hour)
+
+	pair, err := jwtManager.GenerateTokenPair(1, 2, "device123", "family-a")
+	require.NoError(t, err)
+
+	// 刷新token不能当作访问token使用
+	_, err = jwtManager.VerifyToken(pair.RefreshToken)
+	assert.Error(t, err)
+
+	// 访问token不能用于刷新
+	_, err = jwtManager.VerifyRefreshToken(pair.AccessToken)
+	assert.Error(t, err)
+}
+
+func TestJWTManager_ChallengeToken(t *testing.T) {
+	jwtManager := NewJWTManager("test-secret", time.Hour, 7*24*time.Hour)
+
+	challenge, _, err := jwtManager.GenerateChallengeToken(1, "device123", "ios", "iPhone", 5*time.Minute)
+	require.NoError(t, err)
+
+	claims, err := jwtManager.VerifyChallengeToken(challenge)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), claims.UserID)
+	assert.Equal(t, "device123", claims.DeviceToken)
+	assert.Equal(t, "ios", claims.DeviceType)
+
+	// 挑战token不能当作访问token使用，反之亦然
+	_, err = jwtManager.VerifyToken(challenge)
+	assert.Error(t, err)
+
+	access, err := jwtManager.GenerateToken(1, 2, "device123")
+	require.NoError(t, err)
+	_, err = jwtManager.VerifyChallengeToken(access)
+	assert.Error(t, err)
+}
+
+func TestJWTManager_AccessTokenID(t *testing.T) {
+	jwtManager := NewJWTManager("test-secret", time.Hour, 7*24*time.Hour)
+
+	first, err := jwtManager.GenerateToken(1, 2, "device123")
+	require.NoError(t, err)
+	second, err := jwtManager.GenerateToken(1, 2, "device123")
+	require.NoError(t, err)
+
+	firstClaims, err := jwtManager.VerifyToken(first)
+	require.NoError(t, err)
+	secondClaims, err := jwtManager.VerifyToken(second)
+	require.NoError(t, err)
+
+	// 访问token携带独立的jti, 用于单独吊销
+	assert.NotEmpty(t, firstClaims.ID)
+	assert.NotEqual(t, firstClaims.ID, secondClaims.ID)
+}
+
+func TestJWTManager_OAuthTokens(t *testing.T) {
+	jwtManager := NewJWTManager("test-secret", time.Hour, 7*24*time.Hour)
+
+	oauthToken, err := jwtManager.GenerateOAuthAccessToken(1, "client-a", "openid profile")
+	require.NoError(t, err)
+
+	claims, err := jwtManager.VerifyOAuthAccessToken(oauthToken)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), claims.UserID)
+	assert.Equal(t, "client-a", claims.ClientID)
+	assert.Equal(t, "openid profile", claims.Scope)
+
+	// 第三方应用的token不能当作客户端访问token使用，反之亦然
+	_, err = jwtManager.VerifyToken(oauthToken)
+	assert.Error(t, err)
+	access, err := jwtManager.GenerateToken(1, 2, "device123")
+	require.NoError(t, err)
+	_, err = jwtManager.VerifyOAuthAccessToken(access)
+	assert.Error(t, err)
+
+	// ID Token的sub为用户ID，不能当作任何访问token使用
+	idToken, err := jwtManager.GenerateIDToken("https://auth.example.com", 1, "client-a", &IDTokenClaims{Nonce: "n-1"})
+	require.NoError(t, err)
+	_, err = jwtManager.VerifyToken(idToken)
+	assert.Error(t, err)
+	_, err = jwtManager.VerifyOAuthAccessToken(idToken)
+	assert.Error(t, err)
+}
@@ -0,0 +1,252 @@
+package pkg
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 支持的签名算法
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+const rsaKeyBits = 2048
+
+// ErrUnknownKeyID token的kid不在当前密钥集中
+var ErrUnknownKeyID = errors.New("unknown signing key id")
+
+// SigningKey 签名密钥
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.Signer // 仅签发方持有, 验证方为nil
+	PublicKey  crypto.PublicKey
+	CreatedAt  time.Time
+	ExpiresAt  time.Time // 过期后不再接受该密钥签发的token, 零值表示不过期
+}
+
+// GenerateSigningKey 生成新的签名密钥
+func GenerateSigningKey(algorithm string) (*SigningKey, error) {
+	var signer crypto.Signer
+	switch algorithm {
+	case AlgorithmRS256:
+		key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, err
+		}
+		signer = key
+	case AlgorithmEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		signer = key
+	default:
+		return nil, errors.New("unsupported signing algorithm: " + algorithm)
+	}
+
+	kid := make([]byte, 8)
+	if _, err := rand.Read(kid); err != nil {
+		return nil, err
+	}
+
+	return &SigningKey{
+		ID:         hex.EncodeToString(kid),
+		Algorithm:  algorithm,
+		PrivateKey: signer,
+		PublicKey:  signer.Public(),
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// ParsePrivateSigningKey 从PKCS#8 PEM解析签名密钥
+func ParsePrivateSigningKey(id, algorithm string, privatePEM []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(privatePEM)
+	if block == nil {
+		return nil, errors.New("invalid private key PEM")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok || !matchesAlgorithm(algorithm, signer.Public()) {
+		return nil, errors.New("private key does not match algorithm " + algorithm)
+	}
+	return &SigningKey{
+		ID:         id,
+		Algorithm:  algorithm,
+		PrivateKey: signer,
+		PublicKey:  signer.Public(),
+	}, nil
+}
+
+// ParsePublicSigningKey 从PKIX DER解析验证用公钥
+func ParsePublicSigningKey(id, algorithm string, publicDER []byte) (*SigningKey, error) {
+	key, err := x509.ParsePKIXPublicKey(publicDER)
+	if err != nil {
+		return nil, err
+	}
+	if !matchesAlgorithm(algorithm, key) {
+		return nil, errors.New("public key does not match algorithm " + algorithm)
+	}
+	return &SigningKey{
+		ID:        id,
+		Algorithm: algorithm,
+		PublicKey: key,
+	}, nil
+}
+
+// MarshalPrivateKey 导出PKCS#8 PEM格式私钥
+func (k *SigningKey) MarshalPrivateKey() ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(k.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// MarshalPublicKey 导出PKIX DER格式公钥
+func (k *SigningKey) MarshalPublicKey() ([]byte, error) {
+	return x509.MarshalPKIXPublicKey(k.PublicKey)
+}
+
+// SigningMethod 密钥对应的JWT签名方法
+func (k *SigningKey) SigningMethod() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
+
+// Expired 密钥是否已过期
+func (k *SigningKey) Expired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
+}
+
+// JWK 公钥的JWK表示 (RFC 7517)
+func (k *SigningKey) JWK() JWK {
+	jwk := JWK{
+		Use: "sig",
+		Kid: k.ID,
+		Alg: k.Algorithm,
+	}
+	switch pub := k.PublicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+	return jwk
+}
+
+// JWK JSON Web Key
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// matchesAlgorithm 检查公钥类型与算法是否匹配
+func matchesAlgorithm(algorithm string, key crypto.PublicKey) bool {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return algorithm == AlgorithmRS256
+	case ed25519.PublicKey:
+		return algorithm == AlgorithmEdDSA
+	default:
+		return false
+	}
+}
+
+// KeySet 并发安全的签名密钥集合
+// 最新的密钥用于签发，其余未过期的密钥仅用于验证轮换前签发的token
+type KeySet struct {
+	mu     sync.RWMutex
+	keys   map[string]*SigningKey
+	active *SigningKey
+}
+
+// NewKeySet 创建密钥集合
+func NewKeySet() *KeySet {
+	return &KeySet{
+		keys: make(map[string]*SigningKey),
+	}
+}
+
+// SetKeys 替换全部密钥，activeID指定签发用的密钥
+func (s *KeySet) SetKeys(keys []*SigningKey, activeID string) {
+	keyMap := make(map[string]*SigningKey, len(keys))
+	for _, key := range keys {
+		keyMap[key.ID] = key
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keyMap
+	s.active = keyMap[activeID]
+}
+
+// Active 当前签发用的密钥
+func (s *KeySet) Active() *SigningKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.active
+}
+
+// Get 根据kid获取未过期的密钥
+func (s *KeySet) Get(kid string) *SigningKey {
+	s.mu.RLock()
+	key := s.keys[kid]
+	s.mu.RUnlock()
+
+	if key == nil || key.Expired(time.Now()) {
+		return nil
+	}
+	return key
+}
+
+// Keys 所有未过期的密钥，按创建时间倒序
+func (s *KeySet) Keys() []*SigningKey {
+	now := time.Now()
+
+	s.mu.RLock()
+	keys := make([]*SigningKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		if !key.Expired(now) {
+			keys = append(keys, key)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+	return keys
+}
@@ -0,0 +1,134 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTManager_AsymmetricSigning(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmRS256, AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			key, err := GenerateSigningKey(algorithm)
+			require.NoError(t, err)
+
+			keySet := NewKeySet()
+			keySet.SetKeys([]*SigningKey{key}, key.ID)
+			jwtManager := NewJWTManagerWithKeys(keySet, "", time.Hour, 7*24*time.Hour)
+
+			tokenString, err := jwtManager.GenerateToken(1, 2, "device123")
+			require.NoError(t, err)
+
+			token, _, err := jwt.NewParser().ParseUnverified(tokenString, &Claims{})
+			require.NoError(t, err)
+			assert.Equal(t, key.ID, token.Header["kid"])
+			assert.Equal(t, algorithm, token.Method.Alg())
+
+			claims, err := jwtManager.VerifyToken(tokenString)
+			require.NoError(t, err)
+			assert.Equal(t, uint(1), claims.UserID)
+
+			// 验证方只持有公钥
+			publicDER, err := key.MarshalPublicKey()
+			require.NoError(t, err)
+			publicKey, err := ParsePublicSigningKey(key.ID, algorithm, publicDER)
+			require.NoError(t, err)
+
+			verifierKeys := NewKeySet()
+			verifierKeys.SetKeys([]*SigningKey{publicKey}, "")
+			verifier := NewJWTManagerWithKeys(verifierKeys, "", 0, 0)
+
+			_, err = verifier.VerifyToken(tokenString)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestJWTManager_KeyRotation(t *testing.T) {
+	oldKey, err := GenerateSigningKey(AlgorithmEdDSA)
+	require.NoError(t, err)
+	newKey, err := GenerateSigningKey(AlgorithmEdDSA)
+	require.NoError(t, err)
+
+	keySet := NewKeySet()
+	keySet.SetKeys([]*SigningKey{oldKey}, oldKey.ID)
+	jwtManager := NewJWTManagerWithKeys(keySet, "", time.Hour, 7*24*time.Hour)
+
+	oldToken, err := jwtManager.GenerateToken(1, 2, "device123")
+	require.NoError(t, err)
+
+	// 轮换后旧密钥仍在重叠窗口内
+	keySet.SetKeys([]*SigningKey{newKey, oldKey}, newKey.ID)
+	_, err = jwtManager.VerifyToken(oldToken)
+	assert.NoError(t, err)
+
+	newToken, err := jwtManager.GenerateToken(1, 2, "device123")
+	require.NoError(t, err)
+	token, _, err := jwt.NewParser().ParseUnverified(newToken, &Claims{})
+	require.NoError(t, err)
+	assert.Equal(t, newKey.ID, token.Header["kid"])
+
+	// 旧密钥过期后不再接受
+	oldKey.ExpiresAt = time.Now().Add(-time.Second)
+	_, err = jwtManager.VerifyToken(oldToken)
+	assert.ErrorIs(t, err, ErrUnknownKeyID)
+}
+
+func TestJWTManager_LegacySecret(t *testing.T) {
+	legacyToken, err := NewJWTManager("test-secret", time.Hour, time.Hour).GenerateToken(1, 2, "device123")
+	require.NoError(t, err)
+
+	key, err := GenerateSigningKey(AlgorithmEdDSA)
+	require.NoError(t, err)
+	keySet := NewKeySet()
+	keySet.SetKeys([]*SigningKey{key}, key.ID)
+
+	// 配置旧密钥时在停用时间之前继续接受迁移前的HS256 token，并通知调用方
+	jwtManager := NewJWTManagerWithKeys(keySet, "test-secret", time.Hour, time.Hour)
+	var used []string
+	jwtManager.SetLegacySecretRetireAt(time.Now().Add(time.Hour), func(subject string) { used = append(used, subject) })
+	_, err = jwtManager.VerifyToken(legacyToken)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{accessTokenSubject}, used)
+
+	// 新签发的token不使用旧密钥
+	newToken, err := jwtManager.GenerateToken(1, 2, "device123")
+	require.NoError(t, err)
+	_, err = jwtManager.VerifyToken(newToken)
+	require.NoError(t, err)
+	assert.Len(t, used, 1)
+
+	// 超过停用时间或未设置停用时间时不再接受
+	jwtManager.SetLegacySecretRetireAt(time.Now().Add(-time.Second), nil)
+	_, err = jwtManager.VerifyToken(legacyToken)
+	assert.ErrorIs(t, err, errLegacySecretRetired)
+
+	_, err = NewJWTManagerWithKeys(keySet, "test-secret", time.Hour, time.Hour).VerifyToken(legacyToken)
+	assert.ErrorIs(t, err, errLegacySecretRetired)
+
+	_, err = NewJWTManagerWithKeys(keySet, "", time.Hour, time.Hour).VerifyToken(legacyToken)
+	assert.Error(t, err)
+}
+
+func TestSigningKey_PrivateKeyRoundTrip(t *testing.T) {
+	key, err := GenerateSigningKey(AlgorithmRS256)
+	require.NoError(t, err)
+
+	privatePEM, err := key.MarshalPrivateKey()
+	require.NoError(t, err)
+
+	parsed, err := ParsePrivateSigningKey(key.ID, AlgorithmRS256, privatePEM)
+	require.NoError(t, err)
+	assert.Equal(t, key.JWK(), parsed.JWK())
+
+	_, err = ParsePrivateSigningKey(key.ID, AlgorithmEdDSA, privatePEM)
+	assert.Error(t, err)
+
+	jwk := parsed.JWK()
+	assert.Equal(t, "RSA", jwk.Kty)
+	assert.Equal(t, "AQAB", jwk.E)
+	assert.Equal(t, "sig", jwk.Use)
+}
@@ -0,0 +1,132 @@
+package pkg
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LocalCA 本地CA，用于测试和开发环境签发服务证书
+type LocalCA struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+}
+
+// NewLocalCA 生成自签名CA
+func NewLocalCA(commonName string, validFor time.Duration) (*LocalCA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(validFor),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocalCA{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}, nil
+}
+
+// CertPEM CA证书
+func (ca *LocalCA) CertPEM() []byte {
+	return ca.certPEM
+}
+
+// Issue 为服务签发同时可用于服务端和客户端的证书
+// 证书包含 spiffe://telegramlite/<service> URI SAN，dnsNames为服务端证书校验的主机名
+func (ca *LocalCA) Issue(service string, validFor time.Duration, dnsNames ...string) (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: service},
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(validFor),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     dnsNames,
+		URIs:         []*url.URL{{Scheme: "spiffe", Host: ServiceTrustDomain, Path: "/" + service}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// WriteFiles 签发服务证书并写入dir，返回证书、私钥和CA证书的路径
+func (ca *LocalCA) WriteFiles(dir, service string, validFor time.Duration, dnsNames ...string) (certFile, keyFile, caFile string, err error) {
+	certPEM, keyPEM, err := ca.Issue(service, validFor, dnsNames...)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	certFile = filepath.Join(dir, service+".crt")
+	keyFile = filepath.Join(dir, service+".key")
+	caFile = filepath.Join(dir, "ca.crt")
+	if err := os.WriteFile(certFile, certPEM, 0o644); err != nil {
+		return "", "", "", err
+	}
+	if err := os.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		return "", "", "", err
+	}
+	if err := os.WriteFile(caFile, ca.certPEM, 0o644); err != nil {
+		return "", "", "", err
+	}
+	return certFile, keyFile, caFile, nil
+}
+
+func randomSerial() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("generate serial: %w", err)
+	}
+	return serial, nil
+}
@@ -0,0 +1,266 @@
+package pkg
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ServiceTrustDomain 服务证书URI SAN使用的信任域, 服务身份为 spiffe://telegramlite/<服务名>
+const ServiceTrustDomain = "telegramlite"
+
+// CertReloader 从文件加载服务证书、私钥和CA证书，文件变化时自动重新加载
+// 握手时读取当前证书，轮换证书不需要重启服务
+type CertReloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	caPool  *x509.CertPool
+	modTime time.Time // 三个文件中最新的修改时间
+}
+
+// NewCertReloader 创建证书加载器并立即加载一次
+func NewCertReloader(certFile, keyFile, caFile string) (*CertReloader, error) {
+	r := &CertReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload 重新加载证书文件，失败时保留之前的证书
+func (r *CertReloader) Reload() error {
+	modTime, err := latestModTime(r.certFile, r.keyFile, r.caFile)
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load certificate: %w", err)
+	}
+	caPEM, err := os.ReadFile(r.caFile)
+	if err != nil {
+		return fmt.Errorf("read ca file: %w", err)
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caPEM) {
+		return errors.New("ca file contains no certificates")
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.caPool = caPool
+	r.modTime = modTime
+	r.mu.Unlock()
+	return nil
+}
+
+// Run 定期检查证书文件，修改后重新加载，直到ctx取消
+func (r *CertReloader) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			modTime, err := latestModTime(r.certFile, r.keyFile, r.caFile)
+			if err == nil {
+				r.mu.RLock()
+				changed := modTime.After(r.modTime)
+				r.mu.RUnlock()
+				if !changed {
+					continue
+				}
+				err = r.Reload()
+			}
+			if err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// ServerTLSConfig 服务端配置：要求客户端提供由CA签发的证书
+func (r *CertReloader) ServerTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, caPool := r.current()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+				ClientCAs:    caPool,
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				NextProtos:   []string{"h2"},
+			}, nil
+		},
+	}
+}
+
+// ClientTLSConfig 客户端配置：出示本服务证书，并按当前CA校验服务端证书的serverName
+// CA可能被热更新，因此在VerifyConnection中校验证书链而不是使用固定的RootCAs
+func (r *CertReloader) ClientTLSConfig(serverName string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := r.current()
+			return cert, nil
+		},
+		InsecureSkipVerify: true, // 由VerifyConnection完成校验
+		VerifyConnection: func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 {
+				return errors.New("server presented no certificate")
+			}
+			_, caPool := r.current()
+			intermediates := x509.NewCertPool()
+			for _, cert := range state.PeerCertificates[1:] {
+				intermediates.AddCert(cert)
+			}
+			_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+				DNSName:       serverName,
+				Roots:         caPool,
+				Intermediates: intermediates,
+				KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			})
+			return err
+		},
+	}
+}
+
+func (r *CertReloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, r.caPool
+}
+
+func latestModTime(files ...string) (time.Time, error) {
+	var latest time.Time
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// ServiceIdentity 证书中的服务名：优先使用 spiffe://telegramlite/<服务名> URI SAN，否则使用CN
+func ServiceIdentity(cert *x509.Certificate) string {
+	for _, uri := range cert.URIs {
+		if uri.Scheme == "spiffe" && uri.Host == ServiceTrustDomain {
+			return strings.TrimPrefix(uri.Path, "/")
+		}
+	}
+	return cert.Subject.CommonName
+}
+
+// PeerServiceIdentity 获取gRPC调用方证书中的服务名，未使用mTLS时返回false
+func PeerServiceIdentity(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return "", false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+	return ServiceIdentity(tlsInfo.State.VerifiedChains[0][0]), true
+}
+
+// ServiceACL 按RPC方法限制调用方服务
+// 键为方法名 (如 RevokeTokens, 不区分大小写), 值为允许调用的服务名;
+// 未列出的方法使用 "*" 的规则, 没有 "*" 时不限制调用方 (启用mTLS时仍须持有有效证书)
+type ServiceACL map[string][]string
+
+// ServiceACLDefault 未单独列出的方法使用的规则
+const ServiceACLDefault = "*"
+
+// Authorize 检查调用方是否允许调用该方法
+// 受限方法要求调用方出示服务证书，未启用mTLS时一律拒绝；不受限的方法不检查调用方
+func (acl ServiceACL) Authorize(ctx context.Context, fullMethod string) error {
+	allowed, restricted := acl.lookup(path.Base(fullMethod))
+	if !restricted {
+		return nil
+	}
+
+	identity, ok := PeerServiceIdentity(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "client certificate required")
+	}
+	for _, service := range allowed {
+		if service == identity {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "service %q is not allowed to call %s", identity, fullMethod)
+}
+
+// lookup 查找方法的规则 (配置文件中的键会被转为小写)
+func (acl ServiceACL) lookup(method string) ([]string, bool) {
+	for name, services := range acl {
+		if strings.EqualFold(name, method) {
+			return services, true
+		}
+	}
+	services, ok := acl[ServiceACLDefault]
+	return services, ok
+}
+
+// UnaryServerInterceptor 在调用前检查调用方服务身份
+func (acl ServiceACL) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := acl.Authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor 在建立流前检查调用方服务身份
+func (acl ServiceACL) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := acl.Authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// Merge 返回合并后的ACL，overrides中的方法覆盖默认规则
+func (acl ServiceACL) Merge(overrides map[string][]string) ServiceACL {
+	merged := make(ServiceACL, len(acl)+len(overrides))
+	for method, services := range acl {
+		merged[strings.ToLower(method)] = services
+	}
+	for method, services := range overrides {
+		merged[strings.ToLower(method)] = services
+	}
+	return merged
+}
@@ -0,0 +1,129 @@
+package pkg
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// startTestServer 启动要求mTLS的健康检查服务，Check只允许user-service调用
+func startTestServer(t *testing.T, reloader *CertReloader) string {
+	acl := ServiceACL{"Check": {"user-service"}}
+	server := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(reloader.ServerTLSConfig())),
+		grpc.ChainUnaryInterceptor(acl.UnaryServerInterceptor()),
+	)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+func checkHealth(t *testing.T, addr string, reloader *CertReloader) error {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(reloader.ClientTLSConfig("auth-service"))))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca, err := NewLocalCA("test-ca", time.Hour)
+	require.NoError(t, err)
+
+	serverDir, userDir, pushDir := t.TempDir(), t.TempDir(), t.TempDir()
+	certFile, keyFile, caFile, err := ca.WriteFiles(serverDir, "auth-service", time.Hour, "auth-service")
+	require.NoError(t, err)
+	serverCerts, err := NewCertReloader(certFile, keyFile, caFile)
+	require.NoError(t, err)
+	addr := startTestServer(t, serverCerts)
+
+	certFile, keyFile, caFile, err = ca.WriteFiles(userDir, "user-service", time.Hour)
+	require.NoError(t, err)
+	userCerts, err := NewCertReloader(certFile, keyFile, caFile)
+	require.NoError(t, err)
+
+	certFile, keyFile, caFile, err = ca.WriteFiles(pushDir, "push-service", time.Hour)
+	require.NoError(t, err)
+	pushCerts, err := NewCertReloader(certFile, keyFile, caFile)
+	require.NoError(t, err)
+
+	// 允许的服务可以调用
+	require.NoError(t, checkHealth(t, addr, userCerts))
+
+	// 有效证书但不在允许列表中
+	err = checkHealth(t, addr, pushCerts)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// 其他CA签发的证书无法建立连接
+	otherCA, err := NewLocalCA("other-ca", time.Hour)
+	require.NoError(t, err)
+	certFile, keyFile, caFile, err = otherCA.WriteFiles(t.TempDir(), "user-service", time.Hour)
+	require.NoError(t, err)
+	untrusted, err := NewCertReloader(certFile, keyFile, caFile)
+	require.NoError(t, err)
+	assert.Error(t, checkHealth(t, addr, untrusted))
+
+	// 服务端轮换到新CA后热加载，旧证书失效
+	certPEM, keyPEM, err := otherCA.Issue("auth-service", time.Hour, "auth-service")
+	require.NoError(t, err)
+	serverCertFile, serverKeyFile, serverCAFile := serverCerts.certFile, serverCerts.keyFile, serverCerts.caFile
+	require.NoError(t, os.WriteFile(serverCertFile, certPEM, 0o644))
+	require.NoError(t, os.WriteFile(serverKeyFile, keyPEM, 0o600))
+	require.NoError(t, os.WriteFile(serverCAFile, otherCA.CertPEM(), 0o644))
+	require.NoError(t, serverCerts.Reload())
+
+	assert.Error(t, checkHealth(t, addr, userCerts))
+	assert.NoError(t, checkHealth(t, addr, untrusted))
+}
+
+func TestServiceIdentity(t *testing.T) {
+	ca, err := NewLocalCA("test-ca", time.Hour)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	certFile, keyFile, caFile, err := ca.WriteFiles(dir, "user-service", time.Hour)
+	require.NoError(t, err)
+
+	reloader, err := NewCertReloader(certFile, keyFile, caFile)
+	require.NoError(t, err)
+	cert, _ := reloader.current()
+	require.NotNil(t, cert.Leaf)
+	assert.Equal(t, "user-service", ServiceIdentity(cert.Leaf))
+
+	acl := ServiceACL{"RevokeTokens": {"admin"}}.Merge(map[string][]string{"RevokeTokens": {"user-service"}})
+	assert.Equal(t, []string{"user-service"}, acl["revoketokens"])
+
+	services, restricted := acl.lookup("RevokeTokens")
+	assert.True(t, restricted)
+	assert.Equal(t, []string{"user-service"}, services)
+	_, restricted = acl.lookup("VerifyToken")
+	assert.False(t, restricted)
+	services, _ = ServiceACL{ServiceACLDefault: {"gateway"}}.lookup("VerifyToken")
+	assert.Equal(t, []string{"gateway"}, services)
+
+	// 未使用mTLS的调用被拒绝
+	err = acl.Authorize(context.Background(), "/auth.AuthService/RevokeTokens")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// 不受限的方法不要求证书
+	assert.NoError(t, acl.Authorize(context.Background(), "/auth.AuthService/VerifyToken"))
+	err = ServiceACL{ServiceACLDefault: {"gateway"}}.Authorize(context.Background(), "/auth.AuthService/VerifyToken")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,103 @@
+package pkg
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const oauthAccessTokenSubject = "oauth-access-token"
+
+// OAuthClaims 第三方应用访问token声明
+// 与客户端自身的访问token使用不同的Subject，不能互相替代
+type OAuthClaims struct {
+	UserID   uint   `json:"user_id"`
+	ClientID string `json:"client_id"`
+	Scope    string `json:"scope"` // 空格分隔的授权范围
+	jwt.RegisteredClaims
+}
+
+// IDTokenClaims OpenID Connect ID Token声明，sub为用户ID，aud为client_id
+type IDTokenClaims struct {
+	Nonce             string `json:"nonce,omitempty"`
+	AuthTime          int64  `json:"auth_time,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Picture           string `json:"picture,omitempty"`
+	Email             string `json:"email,omitempty"`
+	PhoneNumber       string `json:"phone_number,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// SigningAlgorithm 当前签发token使用的算法
+func (manager *JWTManager) SigningAlgorithm() string {
+	if manager.keySet == nil {
+		return AlgorithmHS256
+	}
+	if key := manager.keySet.Active(); key != nil {
+		return key.Algorithm
+	}
+	return ""
+}
+
+// GenerateOAuthAccessToken 为第三方应用生成访问token
+func (manager *JWTManager) GenerateOAuthAccessToken(userID uint, clientID, scope string) (string, error) {
+	tokenID, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := &OAuthClaims{
+		UserID:   userID,
+		ClientID: clientID,
+		Scope:    scope,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(manager.tokenDuration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "telegramlite-auth",
+			Subject:   oauthAccessTokenSubject,
+		},
+	}
+
+	return manager.sign(claims)
+}
+
+// VerifyOAuthAccessToken 验证第三方应用访问token
+func (manager *JWTManager) VerifyOAuthAccessToken(tokenString string) (*OAuthClaims, error) {
+	token, err := manager.parse(tokenString, &OAuthClaims{}, oauthAccessTokenSubject)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*OAuthClaims)
+	if !ok {
+		return nil, errors.New("invalid oauth token claims")
+	}
+
+	return claims, nil
+}
+
+// GenerateIDToken 生成OpenID Connect ID Token
+// issuer必须与发现文档中的issuer一致，有效期与访问token相同
+func (manager *JWTManager) GenerateIDToken(issuer string, userID uint, clientID string, claims *IDTokenClaims) (string, error) {
+	tokenID, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        tokenID,
+		ExpiresAt: jwt.NewNumericDate(now.Add(manager.tokenDuration)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		Issuer:    issuer,
+		Subject:   strconv.FormatUint(uint64(userID), 10),
+		Audience:  jwt.ClaimStrings{clientID},
+	}
+
+	return manager.sign(claims)
+}
@@ -1,33 +1,170 @@
 package pkg
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// 密码哈希算法
+const (
+	HashAlgorithmArgon2id = "argon2id"
+	HashAlgorithmBcrypt   = "bcrypt"
+)
+
+var (
+	// ErrPasswordMismatch 密码与哈希不匹配
+	ErrPasswordMismatch = errors.New("密码不匹配")
+	// ErrUnsupportedHash 无法识别的密码哈希格式
+	ErrUnsupportedHash = errors.New("不支持的密码哈希格式")
+)
+
+// PasswordHashConfig 密码哈希参数
+type PasswordHashConfig struct {
+	Algorithm  string // argon2id, bcrypt
+	Memory     uint32 // argon2id 内存开销(KiB)
+	Time       uint32 // argon2id 迭代次数
+	Threads    uint8  // argon2id 并行度
+	SaltLength uint32 // argon2id 盐长度(字节)
+	KeyLength  uint32 // argon2id 输出长度(字节)
+	BcryptCost int    // bcrypt 成本
+}
+
+// DefaultPasswordHashConfig 默认参数 (argon2id, RFC 9106 推荐的64MiB配置)
+func DefaultPasswordHashConfig() PasswordHashConfig {
+	return PasswordHashConfig{
+		Algorithm:  HashAlgorithmArgon2id,
+		Memory:     64 * 1024,
+		Time:       3,
+		Threads:    4,
+		SaltLength: 16,
+		KeyLength:  32,
+		BcryptCost: bcrypt.DefaultCost,
+	}
+}
+
 // PasswordManager 密码管理器
 type PasswordManager struct {
-	cost int
+	config PasswordHashConfig
 }
 
-// NewPasswordManager 创建密码管理器
+// NewPasswordManager 使用默认参数创建密码管理器
 func NewPasswordManager() *PasswordManager {
 	return &PasswordManager{
-		cost: bcrypt.DefaultCost,
+		config: DefaultPasswordHashConfig(),
 	}
 }
 
-// HashPassword 加密密码
+// NewPasswordManagerWithConfig 使用指定参数创建密码管理器，未设置的参数使用默认值
+func NewPasswordManagerWithConfig(config PasswordHashConfig) (*PasswordManager, error) {
+	defaults := DefaultPasswordHashConfig()
+	if config.Algorithm == "" {
+		config.Algorithm = defaults.Algorithm
+	}
+	if config.Memory == 0 {
+		config.Memory = defaults.Memory
+	}
+	if config.Time == 0 {
+		config.Time = defaults.Time
+	}
+	if config.Threads == 0 {
+		config.Threads = defaults.Threads
+	}
+	if config.SaltLength == 0 {
+		config.SaltLength = defaults.SaltLength
+	}
+	if config.KeyLength == 0 {
+		config.KeyLength = defaults.KeyLength
+	}
+	if config.BcryptCost == 0 {
+		config.BcryptCost = defaults.BcryptCost
+	}
+
+	switch config.Algorithm {
+	case HashAlgorithmArgon2id:
+	case HashAlgorithmBcrypt:
+		if config.BcryptCost < bcrypt.MinCost || config.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("invalid bcrypt cost: %d", config.BcryptCost)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported password hash algorithm: %s", config.Algorithm)
+	}
+
+	return &PasswordManager{config: config}, nil
+}
+
+// HashPassword 使用当前配置的算法和参数加密密码
 func (pm *PasswordManager) HashPassword(password string) (string, error) {
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), pm.cost)
-	if err != nil {
+	if pm.config.Algorithm == HashAlgorithmBcrypt {
+		hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), pm.config.BcryptCost)
+		if err != nil {
+			return "", err
+		}
+		return string(hashedBytes), nil
+	}
+
+	salt := make([]byte, pm.config.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
 		return "", err
 	}
-	return string(hashedBytes), nil
+	key := argon2.IDKey([]byte(password), salt, pm.config.Time, pm.config.Memory, pm.config.Threads, pm.config.KeyLength)
+
+	// PHC 字符串格式: $argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, pm.config.Memory, pm.config.Time, pm.config.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
 }
 
-// VerifyPassword 验证密码
+// VerifyPassword 验证密码，支持argon2id和bcrypt格式的哈希
 func (pm *PasswordManager) VerifyPassword(hashedPassword, password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	if isBcryptHash(hashedPassword) {
+		err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return ErrPasswordMismatch
+		}
+		return err
+	}
+
+	params, salt, key, err := decodeArgon2idHash(hashedPassword)
+	if err != nil {
+		return err
+	}
+	computed := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(computed, key) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+// NeedsRehash 判断哈希是否使用了过时的算法或参数，需要用当前配置重新计算
+func (pm *PasswordManager) NeedsRehash(hashedPassword string) bool {
+	if isBcryptHash(hashedPassword) {
+		if pm.config.Algorithm != HashAlgorithmBcrypt {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(hashedPassword))
+		return err != nil || cost != pm.config.BcryptCost
+	}
+
+	if pm.config.Algorithm != HashAlgorithmArgon2id {
+		return true
+	}
+	params, salt, key, err := decodeArgon2idHash(hashedPassword)
+	if err != nil {
+		return true
+	}
+	return params.Memory != pm.config.Memory ||
+		params.Time != pm.config.Time ||
+		params.Threads != pm.config.Threads ||
+		uint32(len(salt)) != pm.config.SaltLength ||
+		uint32(len(key)) != pm.config.KeyLength
 }
 
 // IsValidPassword 检查密码强度
@@ -41,3 +178,43 @@ func (pm *PasswordManager) IsValidPassword(password string) bool {
 	}
 	return true
 }
+
+// isBcryptHash 判断是否为bcrypt格式的哈希 ($2a$, $2b$, $2y$)
+func isBcryptHash(hashedPassword string) bool {
+	return strings.HasPrefix(hashedPassword, "$2a$") ||
+		strings.HasPrefix(hashedPassword, "$2b$") ||
+		strings.HasPrefix(hashedPassword, "$2y$")
+}
+
+// decodeArgon2idHash 解析PHC格式的argon2id哈希
+func decodeArgon2idHash(hashedPassword string) (PasswordHashConfig, []byte, []byte, error) {
+	var params PasswordHashConfig
+
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 || parts[1] != HashAlgorithmArgon2id {
+		return params, nil, nil, ErrUnsupportedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrUnsupportedHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads); err != nil {
+		return params, nil, nil, ErrUnsupportedHash
+	}
+	if params.Memory == 0 || params.Time == 0 || params.Threads == 0 {
+		return params, nil, nil, ErrUnsupportedHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrUnsupportedHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrUnsupportedHash
+	}
+
+	params.Algorithm = HashAlgorithmArgon2id
+	return params, salt, key, nil
+}
@@ -0,0 +1,151 @@
+package pkg
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 密码策略违规代码, 客户端可据此本地化提示
+const (
+	ViolationTooShort         = "too_short"
+	ViolationTooLong          = "too_long"
+	ViolationMissingLowercase = "missing_lowercase"
+	ViolationMissingUppercase = "missing_uppercase"
+	ViolationMissingDigit     = "missing_digit"
+	ViolationMissingSymbol    = "missing_symbol"
+	ViolationBlocklisted      = "blocklisted"
+	ViolationReused           = "reused"
+)
+
+// PasswordViolation 一条密码策略违规
+type PasswordViolation struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// PasswordPolicyError 密码不符合策略，包含所有违规项
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Message)
+	}
+	return strings.Join(messages, "；")
+}
+
+// PasswordPolicy 密码策略
+type PasswordPolicy struct {
+	MinLength        int
+	MaxLength        int
+	RequireLowercase bool
+	RequireUppercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	HistorySize      int // 不允许与最近N个密码重复, 0表示不限制
+
+	blocklist map[string]struct{}
+}
+
+// DefaultPasswordPolicy 默认密码策略 (6-128位)
+func DefaultPasswordPolicy() *PasswordPolicy {
+	return &PasswordPolicy{
+		MinLength: 6,
+		MaxLength: 128,
+	}
+}
+
+// LoadBlocklist 从文件加载常见/已泄露密码列表，每行一个，#开头为注释，比较时不区分大小写
+func (p *PasswordPolicy) LoadBlocklist(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	blocklist := make(map[string]struct{})
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		blocklist[strings.ToLower(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	p.blocklist = blocklist
+	return nil
+}
+
+// BlocklistSize 已加载的黑名单条目数
+func (p *PasswordPolicy) BlocklistSize() int {
+	return len(p.blocklist)
+}
+
+// Validate 检查密码是否符合策略，返回全部违规项 (不包含历史密码检查)
+func (p *PasswordPolicy) Validate(password string) []PasswordViolation {
+	var violations []PasswordViolation
+
+	length := utf8.RuneCountInString(password)
+	if p.MinLength > 0 && length < p.MinLength {
+		violations = append(violations, PasswordViolation{
+			Code:    ViolationTooShort,
+			Message: fmt.Sprintf("密码长度至少%d位", p.MinLength),
+		})
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violations = append(violations, PasswordViolation{
+			Code:    ViolationTooLong,
+			Message: fmt.Sprintf("密码长度不能超过%d位", p.MaxLength),
+		})
+	}
+
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireLowercase && !hasLower {
+		violations = append(violations, PasswordViolation{Code: ViolationMissingLowercase, Message: "密码需包含小写字母"})
+	}
+	if p.RequireUppercase && !hasUpper {
+		violations = append(violations, PasswordViolation{Code: ViolationMissingUppercase, Message: "密码需包含大写字母"})
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, PasswordViolation{Code: ViolationMissingDigit, Message: "密码需包含数字"})
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, PasswordViolation{Code: ViolationMissingSymbol, Message: "密码需包含特殊字符"})
+	}
+
+	if _, ok := p.blocklist[strings.ToLower(password)]; ok {
+		violations = append(violations, PasswordViolation{Code: ViolationBlocklisted, Message: "密码过于常见，请更换"})
+	}
+
+	return violations
+}
+
+// ReusedViolation 与历史密码重复的违规项
+func (p *PasswordPolicy) ReusedViolation() PasswordViolation {
+	return PasswordViolation{
+		Code:    ViolationReused,
+		Message: fmt.Sprintf("不能使用最近%d次用过的密码", p.HistorySize),
+	}
+}
@@ -0,0 +1,68 @@
+package pkg
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func violationCodes(violations []PasswordViolation) []string {
+	codes := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		codes = append(codes, violation.Code)
+	}
+	return codes
+}
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := &PasswordPolicy{
+		MinLength:        8,
+		MaxLength:        64,
+		RequireLowercase: true,
+		RequireUppercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+	}
+
+	assert.Empty(t, policy.Validate("Str0ng-Pass"))
+	assert.ElementsMatch(t,
+		[]string{ViolationTooShort, ViolationMissingUppercase, ViolationMissingDigit, ViolationMissingSymbol},
+		violationCodes(policy.Validate("short")))
+	assert.Equal(t, []string{ViolationMissingLowercase}, violationCodes(policy.Validate("STR0NG-PASS")))
+
+	// 默认策略与原有规则一致
+	assert.Empty(t, DefaultPasswordPolicy().Validate("123456"))
+	assert.Equal(t, []string{ViolationTooShort}, violationCodes(DefaultPasswordPolicy().Validate("12345")))
+}
+
+func TestPasswordPolicy_Blocklist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocklist.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# 常见密码\npassword123\n\nQwerty123\n"), 0o600))
+
+	policy := DefaultPasswordPolicy()
+	require.NoError(t, policy.LoadBlocklist(path))
+	assert.Equal(t, 2, policy.BlocklistSize())
+
+	assert.Equal(t, []string{ViolationBlocklisted}, violationCodes(policy.Validate("PASSWORD123")))
+	assert.Equal(t, []string{ViolationBlocklisted}, violationCodes(policy.Validate("qwerty123")))
+	assert.Empty(t, policy.Validate("correct horse battery"))
+
+	assert.Error(t, policy.LoadBlocklist(filepath.Join(t.TempDir(), "missing.txt")))
+}
+
+func TestPasswordPolicyError(t *testing.T) {
+	var err error = &PasswordPolicyError{Violations: []PasswordViolation{
+		{Code: ViolationTooShort, Message: "密码长度至少8位"},
+		{Code: ViolationMissingDigit, Message: "密码需包含数字"},
+	}}
+
+	var policyErr *PasswordPolicyError
+	require.True(t, errors.As(err, &policyErr))
+	assert.Len(t, policyErr.Violations, 2)
+	assert.Contains(t, err.Error(), "密码长度至少8位")
+	assert.Contains(t, err.Error(), "密码需包含数字")
+}
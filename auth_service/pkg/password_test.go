@@ -0,0 +1,88 @@
+package pkg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newTestPasswordManager(t *testing.T, config PasswordHashConfig) *PasswordManager {
+	t.Helper()
+	pm, err := NewPasswordManagerWithConfig(config)
+	require.NoError(t, err)
+	return pm
+}
+
+func TestPasswordManager_Argon2id(t *testing.T) {
+	pm := newTestPasswordManager(t, PasswordHashConfig{Memory: 1024, Time: 1, Threads: 1})
+
+	hash, err := pm.HashPassword("correct horse")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	assert.NoError(t, pm.VerifyPassword(hash, "correct horse"))
+	assert.ErrorIs(t, pm.VerifyPassword(hash, "wrong horse"), ErrPasswordMismatch)
+	assert.False(t, pm.NeedsRehash(hash))
+
+	// 相同密码每次使用不同的盐
+	other, err := pm.HashPassword("correct horse")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+}
+
+func TestPasswordManager_LegacyBcrypt(t *testing.T) {
+	legacy, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	pm := newTestPasswordManager(t, PasswordHashConfig{Memory: 1024, Time: 1, Threads: 1})
+	assert.NoError(t, pm.VerifyPassword(string(legacy), "correct horse"))
+	assert.ErrorIs(t, pm.VerifyPassword(string(legacy), "wrong horse"), ErrPasswordMismatch)
+	assert.True(t, pm.NeedsRehash(string(legacy)))
+
+	// 仍使用bcrypt时只有成本不一致才需要重新计算
+	bcryptManager := newTestPasswordManager(t, PasswordHashConfig{Algorithm: HashAlgorithmBcrypt, BcryptCost: bcrypt.MinCost})
+	assert.False(t, bcryptManager.NeedsRehash(string(legacy)))
+	higherCost := newTestPasswordManager(t, PasswordHashConfig{Algorithm: HashAlgorithmBcrypt, BcryptCost: bcrypt.MinCost + 1})
+	assert.True(t, higherCost.NeedsRehash(string(legacy)))
+}
+
+func TestPasswordManager_NeedsRehashOnParamChange(t *testing.T) {
+	old := newTestPasswordManager(t, PasswordHashConfig{Memory: 1024, Time: 1, Threads: 1})
+	hash, err := old.HashPassword("correct horse")
+	require.NoError(t, err)
+
+	current := newTestPasswordManager(t, PasswordHashConfig{Memory: 2048, Time: 1, Threads: 1})
+	assert.True(t, current.NeedsRehash(hash))
+	// 旧参数生成的哈希仍可验证
+	assert.NoError(t, current.VerifyPassword(hash, "correct horse"))
+
+	bcryptManager := newTestPasswordManager(t, PasswordHashConfig{Algorithm: HashAlgorithmBcrypt, BcryptCost: bcrypt.MinCost})
+	assert.True(t, bcryptManager.NeedsRehash(hash))
+}
+
+func TestPasswordManager_InvalidHash(t *testing.T) {
+	pm := newTestPasswordManager(t, PasswordHashConfig{Memory: 1024, Time: 1, Threads: 1})
+
+	for _, hash := range []string{
+		"",
+		"plaintext",
+		"$argon2i$v=19$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=18$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=0,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=1$!!!$a2V5",
+	} {
+		assert.ErrorIs(t, pm.VerifyPassword(hash, "password"), ErrUnsupportedHash, hash)
+		assert.True(t, pm.NeedsRehash(hash), hash)
+	}
+}
+
+func TestNewPasswordManagerWithConfig_Invalid(t *testing.T) {
+	_, err := NewPasswordManagerWithConfig(PasswordHashConfig{Algorithm: "md5"})
+	assert.Error(t, err)
+
+	_, err = NewPasswordManagerWithConfig(PasswordHashConfig{Algorithm: HashAlgorithmBcrypt, BcryptCost: 64})
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+package pkg
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+)
+
+// 个人访问token的授权范围，格式为 <资源>:<操作>
+// <资源>:* 包含该资源的所有操作
+const (
+	ScopeProfileRead  = "profile:read"  // 读取资料和设置
+	ScopeProfileWrite = "profile:write" // 修改资料、状态和设置
+	ScopeFriendsRead  = "friends:read"  // 读取好友和好友请求
+	ScopeFriendsWrite = "friends:write" // 发送、处理好友请求和删除好友
+	ScopeBlocksRead   = "blocks:read"   // 读取屏蔽列表
+	ScopeBlocksWrite  = "blocks:write"  // 屏蔽和取消屏蔽
+	ScopeExportsRead  = "exports:read"  // 查询和下载数据导出
+	ScopeExportsWrite = "exports:write" // 创建数据导出
+
+	scopeWildcard = "*"
+)
+
+// token类型，VerifyToken返回给其他服务，用于决定是否校验授权范围
+const (
+	TokenKindSession             = "session"               // 登录会话的访问token，不限制范围
+	TokenKindPersonalAccessToken = "personal_access_token" // 个人访问token，只能访问授权范围内的接口
+	TokenKindBot                 = "bot"                   // 机器人token，不限制范围
+)
+
+// personalAccessTokenPrefix 个人访问token前缀，便于识别和密钥扫描
+const personalAccessTokenPrefix = "tlpat_"
+
+// knownScopes 所有可授予的授权范围
+var knownScopes = map[string]bool{
+	ScopeProfileRead:  true,
+	ScopeProfileWrite: true,
+	ScopeFriendsRead:  true,
+	ScopeFriendsWrite: true,
+	ScopeBlocksRead:   true,
+	ScopeBlocksWrite:  true,
+	ScopeExportsRead:  true,
+	ScopeExportsWrite: true,
+}
+
+// IsValidScope 是否为可授予的授权范围 (含 <资源>:* 形式)
+func IsValidScope(scope string) bool {
+	if knownScopes[scope] {
+		return true
+	}
+	resource, action, ok := strings.Cut(scope, ":")
+	if !ok || action != scopeWildcard {
+		return false
+	}
+	for known := range knownScopes {
+		if strings.HasPrefix(known, resource+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeAllows 已授予的范围是否包含required
+func ScopeAllows(granted []string, required string) bool {
+	resource, _, _ := strings.Cut(required, ":")
+	for _, scope := range granted {
+		if scope == required || scope == resource+":"+scopeWildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// NewPersonalAccessToken 生成个人访问token (不是JWT，只能由Auth Service验证)
+func NewPersonalAccessToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return personalAccessTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// IsPersonalAccessToken 是否为个人访问token格式
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, personalAccessTokenPrefix) &&
+		len(token) == len(personalAccessTokenPrefix)+base64.RawURLEncoding.EncodedLen(32)
+}
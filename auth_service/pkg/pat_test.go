@@ -0,0 +1,38 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopes(t *testing.T) {
+	assert.True(t, IsValidScope("profile:read"))
+	assert.True(t, IsValidScope("exports:*"))
+	assert.False(t, IsValidScope("admin:*"))
+	assert.False(t, IsValidScope("profile:delete"))
+	assert.False(t, IsValidScope("messages:*"))
+	assert.False(t, IsValidScope("*"))
+
+	granted := []string{"profile:read", "friends:*"}
+	assert.True(t, ScopeAllows(granted, ScopeProfileRead))
+	assert.False(t, ScopeAllows(granted, ScopeProfileWrite))
+	assert.True(t, ScopeAllows(granted, ScopeFriendsWrite))
+	assert.False(t, ScopeAllows(nil, ScopeProfileRead))
+}
+
+func TestPersonalAccessToken(t *testing.T) {
+	token, err := NewPersonalAccessToken()
+	require.NoError(t, err)
+	assert.True(t, IsPersonalAccessToken(token))
+	other, err := NewPersonalAccessToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+
+	botToken, err := NewBotToken(1)
+	require.NoError(t, err)
+	assert.False(t, IsPersonalAccessToken("tlpat_short"))
+	assert.False(t, IsPersonalAccessToken(botToken))
+	assert.False(t, IsBotToken(token))
+}
@@ -0,0 +1,71 @@
+package pkg
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	// RFC 6238 附录B的SHA1测试向量 (取后6位)
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, want := range vectors {
+		code, err := TOTPCode(secret, TOTPStep(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, want, code, "time %d", unix)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	code, err := TOTPCode(secret, TOTPStep(now))
+	require.NoError(t, err)
+
+	step, ok := ValidateTOTP(secret, code, now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, TOTPStep(now), step)
+
+	// 上一个周期的验证码在允许偏差内有效
+	previous, err := TOTPCode(secret, TOTPStep(now)-1)
+	require.NoError(t, err)
+	_, ok = ValidateTOTP(secret, previous, now, 1)
+	assert.True(t, ok)
+
+	// 超出偏差范围无效
+	old, err := TOTPCode(secret, TOTPStep(now)-3)
+	require.NoError(t, err)
+	_, ok = ValidateTOTP(secret, old, now, 1)
+	assert.False(t, ok)
+
+	_, ok = ValidateTOTP(secret, "12345", now, 1)
+	assert.False(t, ok)
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := TOTPProvisioningURI("TelegramLite", "alice", "JBSWY3DPEHPK3PXP")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/TelegramLite:alice?"))
+
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", parsed.Query().Get("secret"))
+	assert.Equal(t, "TelegramLite", parsed.Query().Get("issuer"))
+	assert.Equal(t, "30", parsed.Query().Get("period"))
+}
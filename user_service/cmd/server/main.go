@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -14,9 +15,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/jacl-coder/TelegramLite/common/go/logger"
+	authpkg "github.com/jacl-coder/telegramlite/auth_service/pkg"
 	pb "github.com/jacl-coder/telegramlite/user_service/api/proto"
 	"github.com/jacl-coder/telegramlite/user_service/internal/client"
 	"github.com/jacl-coder/telegramlite/user_service/internal/config"
@@ -69,8 +72,22 @@ func main() {
 		log.Fatalf("Failed to initialize Redis: %v", err)
 	}
 
+	// 加载服务间mTLS证书
+	var certs *authpkg.CertReloader
+	var authCreds credentials.TransportCredentials
+	if cfg.GRPCTLS.Enabled {
+		certs, err = authpkg.NewCertReloader(cfg.GRPCTLS.CertFile, cfg.GRPCTLS.KeyFile, cfg.GRPCTLS.CAFile)
+		if err != nil {
+			appLogger.Error("Failed to load gRPC TLS certificates", logger.Fields{"error": err.Error()})
+			log.Fatalf("Failed to load gRPC TLS certificates: %v", err)
+		}
+		authCreds = credentials.NewTLS(certs.ClientTLSConfig(cfg.GRPCTLS.AuthServiceName()))
+	} else {
+		appLogger.Warn("gRPC mTLS disabled, calling auth service without a client certificate")
+	}
+
 	// 初始化Auth Service客户端
-	authClient, err := client.NewAuthClient(cfg.Auth.AuthServiceURL)
+	authClient, err := client.NewAuthClient(cfg.Auth.AuthServiceURL, authCreds)
 	if err != nil {
 		appLogger.Error("Failed to connect to auth service", logger.Fields{
 			"error": err.Error(),
@@ -80,8 +97,37 @@ func main() {
 	}
 	defer authClient.Close()
 
+	// 创建等待组和上下文
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// 内部RPC被拒绝时本地验证无法同步吊销列表，账号清除和数据导出也会失败，直接退出而不是静默降级
+	if err := authClient.CheckInternalAccess(ctx); err != nil {
+		if errors.Is(err, client.ErrInternalRPCDenied) {
+			appLogger.Error("Auth service rejects internal RPCs from this service, enable grpc_tls on both services or allow_insecure_internal_rpc on auth service for local development", logger.Fields{"error": err.Error()})
+			log.Fatalf("Auth service rejects internal RPCs: %v", err)
+		}
+		appLogger.Warn("Failed to check auth service internal RPC access", logger.Fields{"error": err.Error()})
+	}
+
+	if certs != nil {
+		go certs.Run(ctx, cfg.GRPCTLS.ReloadInterval(), func(err error) {
+			appLogger.Error("Failed to reload gRPC TLS certificates", logger.Fields{"error": err.Error()})
+		})
+	}
+
 	// 创建身份验证中间件
-	authMiddleware := middleware.NewAuthMiddleware(authClient)
+	var tokenVerifier client.TokenVerifier = authClient
+	if cfg.Auth.VerifyMode == config.VerifyModeLocal {
+		localVerifier := client.NewLocalVerifier(authClient, client.LocalVerifierConfig{
+			KeyRefreshInterval:     cfg.Auth.KeyRefreshInterval(),
+			RevocationPollInterval: cfg.Auth.RevocationPollInterval(),
+			MaxStaleness:           cfg.Auth.MaxStaleness(),
+		})
+		localVerifier.Start(ctx)
+		tokenVerifier = localVerifier
+	}
+	authMiddleware := middleware.NewAuthMiddleware(tokenVerifier)
 
 	// 自动迁移数据库
 	if err := repository.AutoMigrate(); err != nil {
@@ -95,26 +141,31 @@ func main() {
 	userService := service.NewUserService()
 	friendshipService := service.NewFriendshipService()
 
+	// 个人数据导出
+	exportService := service.NewExportService(authClient, cfg.Export.Dir(), cfg.Export.TTL())
+	exportService.Start(ctx)
+
+	// 同步已删除的账号并清理其数据
+	accountPurger := service.NewAccountPurger(authClient, cfg.Auth.PurgePollInterval(), exportService)
+	accountPurger.Start(ctx)
+
 	// 初始化处理器
 	userHandler := handler.NewUserHandler(userService)
 	friendshipHandler := handler.NewFriendshipHandler(friendshipService)
-
-	// 创建等待组和上下文
-	var wg sync.WaitGroup
-	ctx, cancel := context.WithCancel(context.Background())
+	exportHandler := handler.NewExportHandler(exportService)
 
 	// 启动 HTTP 服务器
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		startHTTPServer(ctx, cfg, userHandler, friendshipHandler, authMiddleware, appLogger)
+		startHTTPServer(ctx, cfg, userHandler, friendshipHandler, exportHandler, authMiddleware, appLogger)
 	}()
 
 	// 启动 gRPC 服务器
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		startGRPCServer(ctx, cfg, userService, friendshipService, appLogger)
+		startGRPCServer(ctx, cfg, certs, userService, friendshipService, appLogger)
 	}()
 
 	// 等待中断信号
@@ -146,7 +197,7 @@ func main() {
 }
 
 // startHTTPServer 启动 HTTP 服务器
-func startHTTPServer(ctx context.Context, cfg *config.Config, userHandler *handler.UserHandler, friendshipHandler *handler.FriendshipHandler, authMiddleware *middleware.AuthMiddleware, appLogger logger.Logger) {
+func startHTTPServer(ctx context.Context, cfg *config.Config, userHandler *handler.UserHandler, friendshipHandler *handler.FriendshipHandler, exportHandler *handler.ExportHandler, authMiddleware *middleware.AuthMiddleware, appLogger logger.Logger) {
 	// 设置 Gin 模式
 	if cfg.Server.Mode == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -166,36 +217,45 @@ func startHTTPServer(ctx context.Context, cfg *config.Config, userHandler *handl
 	users := v1.Group("/users")
 	users.Use(authMiddleware.RequireAuth()) // 应用身份验证中间件
 	{
-		users.GET("/:user_id/profile", userHandler.GetProfile)
-		users.PUT("/:user_id/profile", userHandler.UpdateProfile)
-		users.PUT("/:user_id/status", userHandler.UpdateStatus)
-		users.GET("/:user_id/settings", userHandler.GetSettings)
-		users.PUT("/:user_id/settings", userHandler.UpdateSettings)
+		users.GET("/:user_id/profile", authMiddleware.RequireScope(authpkg.ScopeProfileRead), userHandler.GetProfile)
+		users.PUT("/:user_id/profile", authMiddleware.RequireScope(authpkg.ScopeProfileWrite), userHandler.UpdateProfile)
+		users.PUT("/:user_id/status", authMiddleware.RequireScope(authpkg.ScopeProfileWrite), userHandler.UpdateStatus)
+		users.GET("/:user_id/settings", authMiddleware.RequireScope(authpkg.ScopeProfileRead), userHandler.GetSettings)
+		users.PUT("/:user_id/settings", authMiddleware.RequireScope(authpkg.ScopeProfileWrite), userHandler.UpdateSettings)
 	}
 
 	// 用户搜索（可选身份验证，用于隐私检查）
-	v1.GET("/users/search", authMiddleware.OptionalAuth(), userHandler.SearchUsers)
+	v1.GET("/users/search", authMiddleware.OptionalAuth(), authMiddleware.RequireScope(authpkg.ScopeProfileRead), userHandler.SearchUsers)
 
 	// 好友关系路由（需要身份验证）
 	friends := v1.Group("/users/:user_id/friends")
 	friends.Use(authMiddleware.RequireAuth())
 	{
-		friends.POST("/requests", friendshipHandler.SendFriendRequest)
-		friends.GET("/requests", friendshipHandler.GetPendingRequests)
-		friends.PUT("/requests/:request_id/accept", friendshipHandler.AcceptFriendRequest)
-		friends.PUT("/requests/:request_id/reject", friendshipHandler.RejectFriendRequest)
-		friends.GET("", friendshipHandler.GetFriendsList)
-		friends.DELETE("/:friend_id", friendshipHandler.DeleteFriend)
-		friends.GET("/mutual/:other_user_id", friendshipHandler.GetMutualFriends)
+		friends.POST("/requests", authMiddleware.RequireScope(authpkg.ScopeFriendsWrite), friendshipHandler.SendFriendRequest)
+		friends.GET("/requests", authMiddleware.RequireScope(authpkg.ScopeFriendsRead), friendshipHandler.GetPendingRequests)
+		friends.PUT("/requests/:request_id/accept", authMiddleware.RequireScope(authpkg.ScopeFriendsWrite), friendshipHandler.AcceptFriendRequest)
+		friends.PUT("/requests/:request_id/reject", authMiddleware.RequireScope(authpkg.ScopeFriendsWrite), friendshipHandler.RejectFriendRequest)
+		friends.GET("", authMiddleware.RequireScope(authpkg.ScopeFriendsRead), friendshipHandler.GetFriendsList)
+		friends.DELETE("/:friend_id", authMiddleware.RequireScope(authpkg.ScopeFriendsWrite), friendshipHandler.DeleteFriend)
+		friends.GET("/mutual/:other_user_id", authMiddleware.RequireScope(authpkg.ScopeFriendsRead), friendshipHandler.GetMutualFriends)
 	}
 
 	// 用户屏蔽路由（需要身份验证）
 	blocks := v1.Group("/users/:user_id/blocked")
 	blocks.Use(authMiddleware.RequireAuth())
 	{
-		blocks.POST("/:blocked_id", userHandler.BlockUser)     // 屏蔽用户
-		blocks.DELETE("/:blocked_id", userHandler.UnblockUser) // 取消屏蔽
-		blocks.GET("", userHandler.GetBlockedUsers)            // 获取屏蔽列表
+		blocks.POST("/:blocked_id", authMiddleware.RequireScope(authpkg.ScopeBlocksWrite), userHandler.BlockUser)     // 屏蔽用户
+		blocks.DELETE("/:blocked_id", authMiddleware.RequireScope(authpkg.ScopeBlocksWrite), userHandler.UnblockUser) // 取消屏蔽
+		blocks.GET("", authMiddleware.RequireScope(authpkg.ScopeBlocksRead), userHandler.GetBlockedUsers)             // 获取屏蔽列表
+	}
+
+	// 个人数据导出路由（需要身份验证，只能导出自己的数据）
+	exports := v1.Group("/users/:user_id/exports")
+	exports.Use(authMiddleware.RequireAuth())
+	{
+		exports.POST("", authMiddleware.RequireScope(authpkg.ScopeExportsWrite), exportHandler.RequestExport)                    // 创建导出任务
+		exports.GET("/:export_id", authMiddleware.RequireScope(authpkg.ScopeExportsRead), exportHandler.GetExport)               // 查询任务状态
+		exports.GET("/:export_id/download", authMiddleware.RequireScope(authpkg.ScopeExportsRead), exportHandler.DownloadExport) // 下载归档
 	}
 
 	// 健康检查
@@ -245,7 +305,7 @@ func startHTTPServer(ctx context.Context, cfg *config.Config, userHandler *handl
 }
 
 // startGRPCServer 启动 gRPC 服务器
-func startGRPCServer(ctx context.Context, cfg *config.Config, userService *service.UserService, friendshipService *service.FriendshipService, appLogger logger.Logger) {
+func startGRPCServer(ctx context.Context, cfg *config.Config, certs *authpkg.CertReloader, userService *service.UserService, friendshipService *service.FriendshipService, appLogger logger.Logger) {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Server.GRPCPort))
 	if err != nil {
 		appLogger.Error("Failed to listen on gRPC port", logger.Fields{
@@ -255,8 +315,25 @@ func startGRPCServer(ctx context.Context, cfg *config.Config, userService *servi
 		return
 	}
 
-	// 创建 gRPC 服务器
-	grpcServer := grpc.NewServer()
+	// 创建 gRPC 服务器，启用mTLS时要求调用方出示服务证书并按服务身份限制调用
+	// 未启用mTLS时拒绝受限的调用，除非显式开启allow_insecure_internal_rpc
+	var opts []grpc.ServerOption
+	acl := handler.GRPCCallers.Merge(cfg.GRPCTLS.ACL)
+	interceptors := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(acl.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(acl.StreamServerInterceptor()),
+	}
+	switch {
+	case certs != nil:
+		opts = append(opts, grpc.Creds(credentials.NewTLS(certs.ServerTLSConfig())))
+		opts = append(opts, interceptors...)
+	case cfg.GRPCTLS.AllowInsecureInternalRPC:
+		appLogger.Warn("gRPC mTLS disabled and allow_insecure_internal_rpc set, gRPC accepts any caller")
+	default:
+		appLogger.Warn("gRPC mTLS disabled, restricted gRPC calls are rejected")
+		opts = append(opts, interceptors...)
+	}
+	grpcServer := grpc.NewServer(opts...)
 
 	// 创建 gRPC handler
 	grpcHandler := handler.NewUserGRPCHandler(userService, friendshipService)
@@ -4,6 +4,7 @@ go 1.24.7
 
 require (
 	github.com/gin-gonic/gin v1.10.1
+	github.com/golang-jwt/jwt/v5 v5.3.0
 	github.com/jacl-coder/TelegramLite/common/go/logger v0.0.0-00010101000000-000000000000
 	github.com/redis/go-redis/v9 v9.13.0
 	github.com/spf13/viper v1.20.1
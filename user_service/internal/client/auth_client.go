@@ -2,26 +2,48 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	authpb "github.com/jacl-coder/telegramlite/auth_service/api/proto"
 )
 
+// ErrTokenRejected Auth Service明确拒绝了token (区别于网络错误)
+var ErrTokenRejected = errors.New("token verification failed")
+
+// ErrRateLimited 机器人token超过了请求频率限制
+var ErrRateLimited = errors.New("too many requests")
+
+// ErrInternalRPCDenied Auth Service拒绝本服务调用内部RPC (未启用mTLS或证书身份不在允许列表中)
+var ErrInternalRPCDenied = errors.New("auth service denied internal RPC")
+
+// TokenVerifier 访问token验证器
+type TokenVerifier interface {
+	VerifyToken(ctx context.Context, token string) (*authpb.VerifyTokenData, error)
+}
+
 // AuthClient Auth Service客户端
 type AuthClient struct {
 	client authpb.AuthServiceClient
 	conn   *grpc.ClientConn
 }
 
-// NewAuthClient 创建Auth Service客户端
-func NewAuthClient(authServiceURL string) (*AuthClient, error) {
+// NewAuthClient 创建Auth Service客户端，creds为nil时使用明文连接
+func NewAuthClient(authServiceURL string, creds credentials.TransportCredentials) (*AuthClient, error) {
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+
 	// 建立gRPC连接
 	conn, err := grpc.NewClient(authServiceURL,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(4*1024*1024)), // 4MB
 	)
 	if err != nil {
@@ -51,8 +73,11 @@ func (c *AuthClient) VerifyToken(ctx context.Context, token string) (*authpb.Ver
 	}
 
 	// 检查响应
+	if resp.Response.Code == 429 {
+		return nil, fmt.Errorf("%w: %s", ErrRateLimited, resp.Response.Message)
+	}
 	if resp.Response.Code != 0 {
-		return nil, fmt.Errorf("token verification failed: %s", resp.Response.Message)
+		return nil, fmt.Errorf("%w: %s", ErrTokenRejected, resp.Response.Message)
 	}
 
 	return resp.Data, nil
@@ -77,6 +102,93 @@ func (c *AuthClient) GetUserInfo(ctx context.Context, token string) (*authpb.Use
 	return resp.User, nil
 }
 
+// GetSigningKeys 获取签名公钥
+func (c *AuthClient) GetSigningKeys(ctx context.Context) ([]*authpb.SigningKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetSigningKeys(ctx, &authpb.GetSigningKeysRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signing keys: %w", err)
+	}
+
+	if resp.Response.Code != 0 {
+		return nil, fmt.Errorf("get signing keys failed: %s", resp.Response.Message)
+	}
+
+	return resp.Keys, nil
+}
+
+// GetRevocations 同步游标之后的token吊销事件
+func (c *AuthClient) GetRevocations(ctx context.Context, cursor string, limit int32) ([]*authpb.RevocationEvent, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetRevocations(ctx, &authpb.GetRevocationsRequest{
+		Cursor: cursor,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, cursor, fmt.Errorf("failed to get revocations: %w", err)
+	}
+
+	if resp.Response.Code != 0 {
+		return nil, cursor, fmt.Errorf("get revocations failed: %s", resp.Response.Message)
+	}
+
+	return resp.Events, resp.Cursor, nil
+}
+
+// CheckInternalAccess 调用一次内部RPC，确认本服务有权同步吊销列表、已清除账号和导出用户数据
+// 被拒绝时返回ErrInternalRPCDenied，Auth Service暂时不可用等其他错误原样返回
+func (c *AuthClient) CheckInternalAccess(ctx context.Context) error {
+	_, _, err := c.GetRevocations(ctx, "", 1)
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return fmt.Errorf("%w: %v", ErrInternalRPCDenied, err)
+	}
+	return err
+}
+
+// GetAccountPurges 同步游标之后已清除的账号
+func (c *AuthClient) GetAccountPurges(ctx context.Context, cursor string, limit int32) ([]*authpb.AccountPurge, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetAccountPurges(ctx, &authpb.GetAccountPurgesRequest{
+		Cursor: cursor,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, cursor, fmt.Errorf("failed to get account purges: %w", err)
+	}
+
+	if resp.Response.Code != 0 {
+		return nil, cursor, fmt.Errorf("get account purges failed: %s", resp.Response.Message)
+	}
+
+	return resp.Purges, resp.Cursor, nil
+}
+
+// ExportUserData 获取用户在Auth Service中的数据 (JSON)
+func (c *AuthClient) ExportUserData(ctx context.Context, userID uint) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	resp, err := c.client.ExportUserData(ctx, &authpb.ExportUserDataRequest{
+		UserId: uint64(userID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export user data: %w", err)
+	}
+
+	if resp.Response.Code != 0 {
+		return nil, fmt.Errorf("export user data failed: %s", resp.Response.Message)
+	}
+
+	return resp.Data, nil
+}
+
 // Close 关闭连接
 func (c *AuthClient) Close() error {
 	if c.conn != nil {
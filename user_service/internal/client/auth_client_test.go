@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authpb "github.com/jacl-coder/telegramlite/auth_service/api/proto"
+)
+
+// revocationServer 只实现GetRevocations的Auth Service
+type revocationServer struct {
+	authpb.UnimplementedAuthServiceServer
+	err error
+}
+
+func (s *revocationServer) GetRevocations(ctx context.Context, req *authpb.GetRevocationsRequest) (*authpb.GetRevocationsResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &authpb.GetRevocationsResponse{Response: &authpb.Response{Code: 0}}, nil
+}
+
+func startAuthServer(t *testing.T, srv authpb.AuthServiceServer) *AuthClient {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	authpb.RegisterAuthServiceServer(server, srv)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	authClient, err := NewAuthClient(listener.Addr().String(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { authClient.Close() })
+	return authClient
+}
+
+func TestAuthClient_CheckInternalAccess(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, startAuthServer(t, &revocationServer{}).CheckInternalAccess(ctx))
+
+	// 未启用mTLS或证书身份不符时Auth Service拒绝内部RPC
+	for _, code := range []codes.Code{codes.Unauthenticated, codes.PermissionDenied} {
+		err := startAuthServer(t, &revocationServer{err: status.Error(code, "denied")}).CheckInternalAccess(ctx)
+		assert.ErrorIs(t, err, ErrInternalRPCDenied)
+	}
+
+	// 其他错误不视为被拒绝
+	err := startAuthServer(t, &revocationServer{err: status.Error(codes.Unavailable, "down")}).CheckInternalAccess(ctx)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInternalRPCDenied)
+}
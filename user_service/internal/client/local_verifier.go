@@ -0,0 +1,315 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	authpb "github.com/jacl-coder/telegramlite/auth_service/api/proto"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+const (
+	revocationPageSize    = 1000
+	minKeyRefreshInterval = 10 * time.Second
+)
+
+// ErrTokenRevoked token已被吊销
+var ErrTokenRevoked = errors.New("token has been revoked")
+
+// authSource 本地验证依赖的Auth Service接口
+type authSource interface {
+	TokenVerifier
+	GetSigningKeys(ctx context.Context) ([]*authpb.SigningKey, error)
+	GetRevocations(ctx context.Context, cursor string, limit int32) ([]*authpb.RevocationEvent, string, error)
+}
+
+// LocalVerifierConfig 本地验证配置
+type LocalVerifierConfig struct {
+	KeyRefreshInterval     time.Duration // 签名公钥刷新周期
+	RevocationPollInterval time.Duration // 吊销事件同步周期
+	MaxStaleness           time.Duration // 吊销列表超过该时长未同步时以远程验证为准
+}
+
+// revocation 设备/用户级吊销: notBefore之前签发的token无效
+type revocation struct {
+	notBefore time.Time
+	expiresAt time.Time
+}
+
+// LocalVerifier 使用缓存的签名公钥在本地验证token
+// 吊销列表通过轮询Auth Service的吊销事件同步；遇到未知kid、旧HS256 token
+// 或吊销列表过期时改为调用远程VerifyToken，Auth Service不可达时沿用本地结果
+type LocalVerifier struct {
+	source     authSource
+	config     LocalVerifierConfig
+	keySet     *pkg.KeySet
+	jwtManager *pkg.JWTManager
+	keyRefresh chan struct{}
+
+	mu             sync.RWMutex
+	cursor         string
+	lastSync       time.Time
+	lastKeyRefresh time.Time
+	revokedTokens  map[string]time.Time // jti -> 条目过期时间
+	revokedDevices map[uint]revocation
+	revokedUsers   map[uint]revocation
+}
+
+// NewLocalVerifier 创建本地token验证器
+func NewLocalVerifier(authClient *AuthClient, config LocalVerifierConfig) *LocalVerifier {
+	return newLocalVerifier(authClient, config)
+}
+
+func newLocalVerifier(source authSource, config LocalVerifierConfig) *LocalVerifier {
+	keySet := pkg.NewKeySet()
+	return &LocalVerifier{
+		source:         source,
+		config:         config,
+		keySet:         keySet,
+		jwtManager:     pkg.NewJWTManagerWithKeys(keySet, "", 0, 0),
+		keyRefresh:     make(chan struct{}, 1),
+		revokedTokens:  make(map[string]time.Time),
+		revokedDevices: make(map[uint]revocation),
+		revokedUsers:   make(map[uint]revocation),
+	}
+}
+
+// Start 加载签名公钥和吊销列表并启动后台同步
+func (v *LocalVerifier) Start(ctx context.Context) {
+	v.refreshKeys(ctx)
+	v.syncRevocations(ctx)
+	go v.run(ctx)
+}
+
+// run 后台定期同步
+func (v *LocalVerifier) run(ctx context.Context) {
+	keyTicker := time.NewTicker(v.config.KeyRefreshInterval)
+	defer keyTicker.Stop()
+	pollTicker := time.NewTicker(v.config.RevocationPollInterval)
+	defer pollTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keyTicker.C:
+			v.refreshKeys(ctx)
+		case <-v.keyRefresh:
+			v.mu.RLock()
+			recent := time.Since(v.lastKeyRefresh) < minKeyRefreshInterval
+			v.mu.RUnlock()
+			if !recent {
+				v.refreshKeys(ctx)
+			}
+		case <-pollTicker.C:
+			v.syncRevocations(ctx)
+			v.prune(time.Now())
+		}
+	}
+}
+
+// VerifyToken 验证token
+// 机器人token和个人访问token不是JWT，每次都由Auth Service验证 (机器人计入请求频率，个人访问token吊销立即生效)
+func (v *LocalVerifier) VerifyToken(ctx context.Context, token string) (*authpb.VerifyTokenData, error) {
+	if pkg.IsBotToken(token) || pkg.IsPersonalAccessToken(token) {
+		return v.source.VerifyToken(ctx, token)
+	}
+
+	claims, err := v.jwtManager.VerifyToken(token)
+	if err != nil {
+		if !errors.Is(err, jwt.ErrTokenUnverifiable) {
+			// 过期、签名错误等本地即可确定
+			return nil, err
+		}
+		if errors.Is(err, pkg.ErrUnknownKeyID) {
+			v.requestKeyRefresh()
+		}
+		return v.source.VerifyToken(ctx, token)
+	}
+
+	if v.isRevoked(claims) {
+		return nil, ErrTokenRevoked
+	}
+
+	data := &authpb.VerifyTokenData{
+		Valid:       true,
+		UserId:      uint64(claims.UserID),
+		DeviceId:    uint64(claims.DeviceID),
+		DeviceToken: claims.DeviceToken,
+		ExpiresAt:   timestamppb.New(claims.ExpiresAt.Time),
+		AccountType: pkg.AccountTypeUser,
+		TokenKind:   pkg.TokenKindSession,
+	}
+
+	if !v.synced(time.Now()) {
+		// 吊销列表可能已过期，以Auth Service结果为准
+		remote, err := v.source.VerifyToken(ctx, token)
+		if err == nil || errors.Is(err, ErrTokenRejected) {
+			return remote, err
+		}
+		if log := applogger.GetDefault(); log != nil {
+			log.Warn("Auth service unavailable, using local token verification", applogger.Fields{
+				"user_id": claims.UserID,
+				"error":   err.Error(),
+			})
+		}
+	}
+
+	return data, nil
+}
+
+// synced 吊销列表是否在容忍范围内
+func (v *LocalVerifier) synced(now time.Time) bool {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return !v.lastSync.IsZero() && now.Sub(v.lastSync) <= v.config.MaxStaleness
+}
+
+// isRevoked 根据本地吊销列表检查token
+func (v *LocalVerifier) isRevoked(claims *pkg.Claims) bool {
+	issuedAt := claims.IssuedAtTime()
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if _, ok := v.revokedTokens[claims.ID]; ok && claims.ID != "" {
+		return true
+	}
+	if r, ok := v.revokedDevices[claims.DeviceID]; ok && issuedAt.UnixMilli() < r.notBefore.UnixMilli() {
+		return true
+	}
+	if r, ok := v.revokedUsers[claims.UserID]; ok && issuedAt.UnixMilli() < r.notBefore.UnixMilli() {
+		return true
+	}
+	return false
+}
+
+// requestKeyRefresh 请求尽快刷新签名公钥
+func (v *LocalVerifier) requestKeyRefresh() {
+	select {
+	case v.keyRefresh <- struct{}{}:
+	default:
+	}
+}
+
+// refreshKeys 从Auth Service拉取签名公钥
+func (v *LocalVerifier) refreshKeys(ctx context.Context) {
+	v.mu.Lock()
+	v.lastKeyRefresh = time.Now()
+	v.mu.Unlock()
+
+	pbKeys, err := v.source.GetSigningKeys(ctx)
+	if err != nil {
+		if log := applogger.GetDefault(); log != nil {
+			log.Warn("Failed to refresh signing keys", applogger.Fields{"error": err.Error()})
+		}
+		return
+	}
+
+	keys := make([]*pkg.SigningKey, 0, len(pbKeys))
+	for _, pbKey := range pbKeys {
+		key, err := pkg.ParsePublicSigningKey(pbKey.Kid, pbKey.Algorithm, pbKey.PublicKey)
+		if err != nil {
+			if log := applogger.GetDefault(); log != nil {
+				log.Warn("Skipping invalid signing key", applogger.Fields{"kid": pbKey.Kid, "error": err.Error()})
+			}
+			continue
+		}
+		key.CreatedAt = pbKey.CreatedAt.AsTime()
+		key.ExpiresAt = pbKey.ExpiresAt.AsTime()
+		keys = append(keys, key)
+	}
+
+	v.keySet.SetKeys(keys, "")
+}
+
+// syncRevocations 拉取游标之后的所有吊销事件
+func (v *LocalVerifier) syncRevocations(ctx context.Context) {
+	v.mu.RLock()
+	cursor := v.cursor
+	v.mu.RUnlock()
+
+	for {
+		events, next, err := v.source.GetRevocations(ctx, cursor, revocationPageSize)
+		if err != nil {
+			if log := applogger.GetDefault(); log != nil {
+				log.Warn("Failed to sync token revocations", applogger.Fields{"error": err.Error()})
+			}
+			return
+		}
+
+		v.applyRevocations(events, next)
+		cursor = next
+
+		if len(events) < revocationPageSize {
+			return
+		}
+	}
+}
+
+// applyRevocations 合并吊销事件到本地列表
+func (v *LocalVerifier) applyRevocations(events []*authpb.RevocationEvent, cursor string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for _, event := range events {
+		revokedAt := event.RevokedAt.AsTime()
+		expiresAt := event.ExpiresAt.AsTime()
+
+		switch target := event.Target.(type) {
+		case *authpb.RevocationEvent_TokenId:
+			v.revokedTokens[target.TokenId] = expiresAt
+		case *authpb.RevocationEvent_DeviceId:
+			mergeRevocation(v.revokedDevices, uint(target.DeviceId), revokedAt, expiresAt)
+		case *authpb.RevocationEvent_UserId:
+			mergeRevocation(v.revokedUsers, uint(target.UserId), revokedAt, expiresAt)
+		}
+	}
+
+	v.cursor = cursor
+	v.lastSync = time.Now()
+}
+
+// prune 清理已失效的吊销条目
+func (v *LocalVerifier) prune(now time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for id, expiresAt := range v.revokedTokens {
+		if now.After(expiresAt) {
+			delete(v.revokedTokens, id)
+		}
+	}
+	for id, r := range v.revokedDevices {
+		if now.After(r.expiresAt) {
+			delete(v.revokedDevices, id)
+		}
+	}
+	for id, r := range v.revokedUsers {
+		if now.After(r.expiresAt) {
+			delete(v.revokedUsers, id)
+		}
+	}
+}
+
+// mergeRevocation 合并设备/用户级吊销，保留最晚的吊销时间
+func mergeRevocation(revocations map[uint]revocation, id uint, revokedAt, expiresAt time.Time) {
+	current, ok := revocations[id]
+	if !ok {
+		revocations[id] = revocation{notBefore: revokedAt, expiresAt: expiresAt}
+		return
+	}
+	if revokedAt.After(current.notBefore) {
+		current.notBefore = revokedAt
+	}
+	if expiresAt.After(current.expiresAt) {
+		current.expiresAt = expiresAt
+	}
+	revocations[id] = current
+}
@@ -0,0 +1,219 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	authpb "github.com/jacl-coder/telegramlite/auth_service/api/proto"
+	"github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+// fakeAuthSource 模拟Auth Service
+type fakeAuthSource struct {
+	keys        []*authpb.SigningKey
+	events      []*authpb.RevocationEvent
+	unavailable bool
+	remoteCalls int
+}
+
+func (f *fakeAuthSource) VerifyToken(ctx context.Context, token string) (*authpb.VerifyTokenData, error) {
+	f.remoteCalls++
+	if f.unavailable {
+		return nil, errors.New("connection refused")
+	}
+	return &authpb.VerifyTokenData{Valid: true, UserId: 99}, nil
+}
+
+func (f *fakeAuthSource) GetSigningKeys(ctx context.Context) ([]*authpb.SigningKey, error) {
+	if f.unavailable {
+		return nil, errors.New("connection refused")
+	}
+	return f.keys, nil
+}
+
+func (f *fakeAuthSource) GetRevocations(ctx context.Context, cursor string, limit int32) ([]*authpb.RevocationEvent, string, error) {
+	if f.unavailable {
+		return nil, cursor, errors.New("connection refused")
+	}
+	var events []*authpb.RevocationEvent
+	next := cursor
+	for _, event := range f.events {
+		if event.Id > cursor {
+			events = append(events, event)
+			next = event.Id
+		}
+	}
+	return events, next, nil
+}
+
+func newTestIssuer(t *testing.T) (*pkg.JWTManager, *authpb.SigningKey) {
+	key, err := pkg.GenerateSigningKey(pkg.AlgorithmEdDSA)
+	require.NoError(t, err)
+
+	keySet := pkg.NewKeySet()
+	keySet.SetKeys([]*pkg.SigningKey{key}, key.ID)
+
+	publicKey, err := key.MarshalPublicKey()
+	require.NoError(t, err)
+
+	return pkg.NewJWTManagerWithKeys(keySet, "", time.Hour, time.Hour), &authpb.SigningKey{
+		Kid:       key.ID,
+		Algorithm: key.Algorithm,
+		PublicKey: publicKey,
+		CreatedAt: timestamppb.Now(),
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+	}
+}
+
+func newTestVerifier(source *fakeAuthSource) *LocalVerifier {
+	verifier := newLocalVerifier(source, LocalVerifierConfig{
+		KeyRefreshInterval:     time.Minute,
+		RevocationPollInterval: time.Second,
+		MaxStaleness:           time.Minute,
+	})
+	verifier.refreshKeys(context.Background())
+	verifier.syncRevocations(context.Background())
+	return verifier
+}
+
+func TestLocalVerifier_VerifiesLocally(t *testing.T) {
+	issuer, signingKey := newTestIssuer(t)
+	source := &fakeAuthSource{keys: []*authpb.SigningKey{signingKey}}
+	verifier := newTestVerifier(source)
+
+	token, err := issuer.GenerateToken(1, 2, "device123")
+	require.NoError(t, err)
+
+	data, err := verifier.VerifyToken(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), data.UserId)
+	assert.Equal(t, uint64(2), data.DeviceId)
+	assert.Equal(t, pkg.AccountTypeUser, data.AccountType)
+	assert.Equal(t, pkg.TokenKindSession, data.TokenKind)
+	assert.Equal(t, 0, source.remoteCalls)
+
+	// Auth Service短暂不可用时继续本地验证
+	source.unavailable = true
+	_, err = verifier.VerifyToken(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, source.remoteCalls)
+}
+
+func TestLocalVerifier_Revocations(t *testing.T) {
+	issuer, signingKey := newTestIssuer(t)
+	source := &fakeAuthSource{keys: []*authpb.SigningKey{signingKey}}
+	verifier := newTestVerifier(source)
+
+	token, err := issuer.GenerateToken(1, 2, "device123")
+	require.NoError(t, err)
+	claims, err := issuer.VerifyToken(token)
+	require.NoError(t, err)
+
+	source.events = []*authpb.RevocationEvent{{
+		Id:        "1-0",
+		Target:    &authpb.RevocationEvent_TokenId{TokenId: claims.ID},
+		RevokedAt: timestamppb.Now(),
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+	}}
+	verifier.syncRevocations(context.Background())
+
+	_, err = verifier.VerifyToken(context.Background(), token)
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+
+	// 设备级吊销只影响吊销时间之前签发的token
+	other, err := issuer.GenerateToken(1, 3, "device456")
+	require.NoError(t, err)
+	source.events = append(source.events, &authpb.RevocationEvent{
+		Id:        "2-0",
+		Target:    &authpb.RevocationEvent_DeviceId{DeviceId: 3},
+		RevokedAt: timestamppb.New(time.Now().Add(time.Second)),
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	verifier.syncRevocations(context.Background())
+
+	_, err = verifier.VerifyToken(context.Background(), other)
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+
+	// 同一秒内的签发和吊销按毫秒比较
+	before, err := issuer.GenerateToken(1, 4, "device789")
+	require.NoError(t, err)
+	after, err := issuer.GenerateToken(1, 5, "device000")
+	require.NoError(t, err)
+	beforeClaims, err := issuer.VerifyToken(before)
+	require.NoError(t, err)
+	afterClaims, err := issuer.VerifyToken(after)
+	require.NoError(t, err)
+	source.events = append(source.events, &authpb.RevocationEvent{
+		Id:        "3-0",
+		Target:    &authpb.RevocationEvent_DeviceId{DeviceId: 4},
+		RevokedAt: timestamppb.New(beforeClaims.IssuedAtTime().Add(time.Millisecond)),
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+	}, &authpb.RevocationEvent{
+		Id:        "4-0",
+		Target:    &authpb.RevocationEvent_DeviceId{DeviceId: 5},
+		RevokedAt: timestamppb.New(afterClaims.IssuedAtTime().Add(-time.Millisecond)),
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	verifier.syncRevocations(context.Background())
+
+	_, err = verifier.VerifyToken(context.Background(), before)
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+	_, err = verifier.VerifyToken(context.Background(), after)
+	assert.NoError(t, err)
+}
+
+func TestLocalVerifier_FallsBackToRemote(t *testing.T) {
+	issuer, signingKey := newTestIssuer(t)
+	source := &fakeAuthSource{}
+	verifier := newTestVerifier(source)
+
+	// 未知kid交给Auth Service
+	token, err := issuer.GenerateToken(1, 2, "device123")
+	require.NoError(t, err)
+	data, err := verifier.VerifyToken(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(99), data.UserId)
+	assert.Equal(t, 1, source.remoteCalls)
+
+	// 吊销列表过期时以远程结果为准
+	source.keys = []*authpb.SigningKey{signingKey}
+	verifier.refreshKeys(context.Background())
+	verifier.lastSync = time.Now().Add(-time.Hour)
+
+	data, err = verifier.VerifyToken(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(99), data.UserId)
+	assert.Equal(t, 2, source.remoteCalls)
+
+	// 伪造签名的token本地直接拒绝
+	_, err = verifier.VerifyToken(context.Background(), token[:len(token)-4]+"AAAA")
+	assert.Error(t, err)
+	assert.Equal(t, 2, source.remoteCalls)
+}
+
+func TestLocalVerifier_OpaqueTokensVerifiedRemotely(t *testing.T) {
+	_, signingKey := newTestIssuer(t)
+	source := &fakeAuthSource{keys: []*authpb.SigningKey{signingKey}}
+	verifier := newTestVerifier(source)
+
+	// 机器人token和个人访问token每次都交给Auth Service验证
+	patToken, err := pkg.NewPersonalAccessToken()
+	require.NoError(t, err)
+	botToken, err := pkg.NewBotToken(7)
+	require.NoError(t, err)
+	calls := 0
+	for _, token := range []string{botToken, patToken} {
+		for i := 0; i < 2; i++ {
+			_, err := verifier.VerifyToken(context.Background(), token)
+			require.NoError(t, err)
+			calls++
+			assert.Equal(t, calls, source.remoteCalls)
+		}
+	}
+}
@@ -25,6 +25,8 @@ type Config struct {
 	Redis    RedisConfig    `mapstructure:"redis"`
 	Auth     AuthConfig     `mapstructure:"auth"`
 	JWT      JWTConfig      `mapstructure:"jwt"`
+	Export   ExportConfig   `mapstructure:"export"`
+	GRPCTLS  GRPCTLSConfig  `mapstructure:"grpc_tls"`
 	Log      LogConfig      `mapstructure:"log"`
 }
 
@@ -49,8 +51,78 @@ type RedisConfig struct {
 	DB       int    `mapstructure:"db"`
 }
 
+// GRPCTLSConfig gRPC服务间mTLS配置，同时用于gRPC服务端和调用Auth Service的客户端
+type GRPCTLSConfig struct {
+	Enabled        bool   `mapstructure:"enabled"`
+	CertFile       string `mapstructure:"cert_file"`        // 本服务证书
+	KeyFile        string `mapstructure:"key_file"`         // 本服务私钥
+	CAFile         string `mapstructure:"ca_file"`          // 签发服务证书的CA
+	ReloadSeconds  int    `mapstructure:"reload_seconds"`   // 检查证书文件变化的周期
+	AuthServerName string `mapstructure:"auth_server_name"` // Auth Service证书中的主机名
+
+	ACL map[string][]string `mapstructure:"acl"` // RPC方法 -> 允许调用的服务名, 覆盖默认规则; "*" 对应未列出的方法
+
+	// 未启用mTLS时仍允许任何调用方访问gRPC接口, 仅用于本地开发; 默认拒绝
+	AllowInsecureInternalRPC bool `mapstructure:"allow_insecure_internal_rpc"`
+}
+
+// ReloadInterval 检查证书文件变化的周期，默认1分钟
+func (g GRPCTLSConfig) ReloadInterval() time.Duration {
+	if g.ReloadSeconds <= 0 {
+		return time.Minute
+	}
+	return time.Duration(g.ReloadSeconds) * time.Second
+}
+
+// AuthServiceName 校验Auth Service证书使用的主机名，默认auth-service
+func (g GRPCTLSConfig) AuthServiceName() string {
+	if g.AuthServerName == "" {
+		return "auth-service"
+	}
+	return g.AuthServerName
+}
+
+// Token验证模式
+const (
+	VerifyModeRemote = "remote" // 每次请求调用Auth Service验证
+	VerifyModeLocal  = "local"  // 使用缓存的签名公钥本地验证, 必要时回退远程验证
+)
+
 type AuthConfig struct {
-	AuthServiceURL string `mapstructure:"auth_service_url"`
+	AuthServiceURL        string `mapstructure:"auth_service_url"`
+	VerifyMode            string `mapstructure:"verify_mode"`             // remote, local
+	KeyRefreshSeconds     int    `mapstructure:"key_refresh_seconds"`     // 签名公钥刷新周期
+	RevocationPollSeconds int    `mapstructure:"revocation_poll_seconds"` // 吊销事件同步周期
+	MaxStalenessSeconds   int    `mapstructure:"max_staleness_seconds"`   // 吊销列表允许的最长未同步时间
+	PurgePollSeconds      int    `mapstructure:"purge_poll_seconds"`      // 已清除账号同步周期
+}
+
+func (a AuthConfig) KeyRefreshInterval() time.Duration {
+	if a.KeyRefreshSeconds <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(a.KeyRefreshSeconds) * time.Second
+}
+
+func (a AuthConfig) RevocationPollInterval() time.Duration {
+	if a.RevocationPollSeconds <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(a.RevocationPollSeconds) * time.Second
+}
+
+func (a AuthConfig) PurgePollInterval() time.Duration {
+	if a.PurgePollSeconds <= 0 {
+		return time.Minute
+	}
+	return time.Duration(a.PurgePollSeconds) * time.Second
+}
+
+func (a AuthConfig) MaxStaleness() time.Duration {
+	if a.MaxStalenessSeconds <= 0 {
+		return time.Minute
+	}
+	return time.Duration(a.MaxStalenessSeconds) * time.Second
 }
 
 type JWTConfig struct {
@@ -67,6 +139,26 @@ func (j JWTConfig) RefreshExpireDuration() time.Duration {
 	return time.Duration(j.RefreshExpireHours) * time.Hour
 }
 
+// ExportConfig 个人数据导出配置
+type ExportConfig struct {
+	StorageDir string `mapstructure:"storage_dir"` // 导出归档存储目录
+	TTLHours   int    `mapstructure:"ttl_hours"`   // 归档保留时长
+}
+
+func (e ExportConfig) Dir() string {
+	if e.StorageDir == "" {
+		return "./data/exports"
+	}
+	return e.StorageDir
+}
+
+func (e ExportConfig) TTL() time.Duration {
+	if e.TTLHours <= 0 {
+		return 72 * time.Hour
+	}
+	return time.Duration(e.TTLHours) * time.Hour
+}
+
 // LoadConfig 加载配置文件
 func LoadConfig(path string) (*Config, error) {
 	viper.SetConfigName("config")
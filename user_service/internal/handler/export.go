@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jacl-coder/telegramlite/user_service/internal/middleware"
+	"github.com/jacl-coder/telegramlite/user_service/internal/service"
+)
+
+// ExportHandler 个人数据导出处理器
+type ExportHandler struct {
+	exportService *service.ExportService
+}
+
+// NewExportHandler 创建个人数据导出处理器
+func NewExportHandler(exportService *service.ExportService) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+	}
+}
+
+// RequestExport 创建导出任务
+func (h *ExportHandler) RequestExport(c *gin.Context) {
+	userID, ok := h.ownerID(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.exportService.RequestExport(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Code:    500,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, Response{
+		Code:    200,
+		Message: "export requested",
+		Data:    export,
+	})
+}
+
+// GetExport 查询导出任务状态
+func (h *ExportHandler) GetExport(c *gin.Context) {
+	userID, ok := h.ownerID(c)
+	if !ok {
+		return
+	}
+	exportID, ok := parseExportID(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.exportService.GetExport(userID, exportID)
+	if err != nil {
+		respondExportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    200,
+		Message: "success",
+		Data:    export,
+	})
+}
+
+// DownloadExport 下载导出归档
+func (h *ExportHandler) DownloadExport(c *gin.Context) {
+	userID, ok := h.ownerID(c)
+	if !ok {
+		return
+	}
+	exportID, ok := parseExportID(c)
+	if !ok {
+		return
+	}
+
+	export, filePath, err := h.exportService.OpenExport(userID, exportID)
+	if err != nil {
+		respondExportError(c, err)
+		return
+	}
+
+	c.FileAttachment(filePath, fmt.Sprintf("telegramlite-export-%d-%d.zip", export.UserID, export.ID))
+}
+
+// ownerID 解析路径中的用户ID，只允许访问自己的导出
+func (h *ExportHandler) ownerID(c *gin.Context) (uint, bool) {
+	userIDStr := c.Param("user_id")
+	userID, err := strconv.ParseUint(userIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "invalid user ID",
+		})
+		return 0, false
+	}
+
+	currentUserID, exists := middleware.GetUserID(c)
+	if !exists || currentUserID != uint(userID) {
+		c.JSON(http.StatusForbidden, Response{
+			Code:    403,
+			Message: "cannot access another user's exports",
+		})
+		return 0, false
+	}
+	return uint(userID), true
+}
+
+func parseExportID(c *gin.Context) (uint, bool) {
+	exportIDStr := c.Param("export_id")
+	exportID, err := strconv.ParseUint(exportIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "invalid export ID",
+		})
+		return 0, false
+	}
+	return uint(exportID), true
+}
+
+func respondExportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrExportNotFound):
+		c.JSON(http.StatusNotFound, Response{Code: 404, Message: err.Error()})
+	case errors.Is(err, service.ErrExportNotReady):
+		c.JSON(http.StatusConflict, Response{Code: 409, Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, Response{Code: 500, Message: err.Error()})
+	}
+}
@@ -6,10 +6,17 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	authpkg "github.com/jacl-coder/telegramlite/auth_service/pkg"
 	pb "github.com/jacl-coder/telegramlite/user_service/api/proto"
 	"github.com/jacl-coder/telegramlite/user_service/internal/service"
 )
 
+// GRPCCallers 默认允许调用gRPC接口的服务；这些接口按请求中的user_id操作，不校验终端用户身份
+// 未启用mTLS时一律拒绝
+var GRPCCallers = authpkg.ServiceACL{
+	authpkg.ServiceACLDefault: {"gateway-service", "msg-service"},
+}
+
 // UserGRPCHandler gRPC处理器
 type UserGRPCHandler struct {
 	pb.UnimplementedUserServiceServer
@@ -2,23 +2,25 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	authpkg "github.com/jacl-coder/telegramlite/auth_service/pkg"
 	"github.com/jacl-coder/telegramlite/user_service/internal/client"
 )
 
 // AuthMiddleware JWT身份验证中间件
 type AuthMiddleware struct {
-	authClient *client.AuthClient
+	verifier client.TokenVerifier
 }
 
 // NewAuthMiddleware 创建身份验证中间件
-func NewAuthMiddleware(authClient *client.AuthClient) *AuthMiddleware {
+func NewAuthMiddleware(verifier client.TokenVerifier) *AuthMiddleware {
 	return &AuthMiddleware{
-		authClient: authClient,
+		verifier: verifier,
 	}
 }
 
@@ -49,8 +51,16 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 
 		token := parts[1]
 
-		// 调用Auth Service验证token
-		tokenData, err := m.authClient.VerifyToken(context.Background(), token)
+		// 验证token
+		tokenData, err := m.verifier.VerifyToken(context.Background(), token)
+		if errors.Is(err, client.ErrRateLimited) {
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"code":    429,
+				"message": "Too many requests",
+			})
+			c.Abort()
+			return
+		}
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"code":    401,
@@ -65,12 +75,49 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		c.Set("user_id", uint(tokenData.UserId))
 		c.Set("device_id", uint(tokenData.DeviceId))
 		c.Set("device_token", tokenData.DeviceToken)
+		c.Set("account_type", tokenData.AccountType)
+		c.Set("token_kind", tokenData.TokenKind)
+		c.Set("scopes", tokenData.Scopes)
 		c.Set("token", token)
 
 		c.Next()
 	}
 }
 
+// RequireScope 要求个人访问token包含所有指定的授权范围，需在RequireAuth或OptionalAuth之后使用
+// 会话token和机器人token没有范围限制；个人访问token的范围为空时一律拒绝；未登录的请求交由后续处理
+func (m *AuthMiddleware) RequireScope(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		granted, restricted := GetScopes(c)
+		if !restricted {
+			c.Next()
+			return
+		}
+
+		if len(granted) == 0 {
+			c.JSON(http.StatusForbidden, gin.H{
+				"code":    403,
+				"message": "Insufficient scope",
+			})
+			c.Abort()
+			return
+		}
+		for _, scope := range scopes {
+			if !authpkg.ScopeAllows(granted, scope) {
+				c.JSON(http.StatusForbidden, gin.H{
+					"code":           403,
+					"message":        "Insufficient scope",
+					"required_scope": scope,
+				})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
 // OptionalAuth 可选身份验证的中间件（不强制要求登录）
 func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -89,7 +136,7 @@ func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 		}
 
 		token := parts[1]
-		tokenData, err := m.authClient.VerifyToken(context.Background(), token)
+		tokenData, err := m.verifier.VerifyToken(context.Background(), token)
 		if err != nil {
 			// token无效，继续处理但不设置用户信息
 			c.Next()
@@ -100,6 +147,9 @@ func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 		c.Set("user_id", uint(tokenData.UserId))
 		c.Set("device_id", uint(tokenData.DeviceId))
 		c.Set("device_token", tokenData.DeviceToken)
+		c.Set("account_type", tokenData.AccountType)
+		c.Set("token_kind", tokenData.TokenKind)
+		c.Set("scopes", tokenData.Scopes)
 		c.Set("token", token)
 
 		c.Next()
@@ -124,6 +174,27 @@ func GetDeviceID(c *gin.Context) (uint, bool) {
 	return deviceID.(uint), true
 }
 
+// GetAccountType 从context获取账号类型 (user或bot)
+func GetAccountType(c *gin.Context) (string, bool) {
+	accountType, exists := c.Get("account_type")
+	if !exists {
+		return "", false
+	}
+	return accountType.(string), true
+}
+
+// GetScopes 从context获取个人访问token的授权范围，会话token和机器人token返回false
+// 按token类型判断是否受限，范围为空的个人访问token同样受限
+func GetScopes(c *gin.Context) ([]string, bool) {
+	scopes, exists := c.Get("scopes")
+	if !exists {
+		return nil, false
+	}
+	granted, _ := scopes.([]string)
+	kind := c.GetString("token_kind")
+	return granted, kind == authpkg.TokenKindPersonalAccessToken || len(granted) > 0
+}
+
 // GetToken 从context获取token
 func GetToken(c *gin.Context) (string, bool) {
 	token, exists := c.Get("token")
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	authpb "github.com/jacl-coder/telegramlite/auth_service/api/proto"
+	authpkg "github.com/jacl-coder/telegramlite/auth_service/pkg"
+)
+
+// fakeVerifier 按token返回预设的验证结果
+type fakeVerifier map[string]*authpb.VerifyTokenData
+
+func (f fakeVerifier) VerifyToken(ctx context.Context, token string) (*authpb.VerifyTokenData, error) {
+	data, ok := f[token]
+	if !ok {
+		return nil, assert.AnError
+	}
+	return data, nil
+}
+
+func TestRequireScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := NewAuthMiddleware(fakeVerifier{
+		"session": {Valid: true, UserId: 1, DeviceId: 2, AccountType: authpkg.AccountTypeUser, TokenKind: authpkg.TokenKindSession},
+		"bot":     {Valid: true, UserId: 3, AccountType: authpkg.AccountTypeBot, TokenKind: authpkg.TokenKindBot},
+		"reader":  {Valid: true, UserId: 1, AccountType: authpkg.AccountTypeUser, TokenKind: authpkg.TokenKindPersonalAccessToken, Scopes: []string{authpkg.ScopeProfileRead}},
+		"friends": {Valid: true, UserId: 1, AccountType: authpkg.AccountTypeUser, TokenKind: authpkg.TokenKindPersonalAccessToken, Scopes: []string{"friends:*"}},
+		"empty":   {Valid: true, UserId: 1, AccountType: authpkg.AccountTypeUser, TokenKind: authpkg.TokenKindPersonalAccessToken},
+	})
+
+	router := gin.New()
+	router.GET("/profile", m.RequireAuth(), m.RequireScope(authpkg.ScopeProfileRead), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.PUT("/profile", m.RequireAuth(), m.RequireScope(authpkg.ScopeProfileWrite), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/friends", m.RequireAuth(), m.RequireScope(authpkg.ScopeFriendsWrite), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/search", m.OptionalAuth(), m.RequireScope(authpkg.ScopeProfileRead), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		method, path, token string
+		want                int
+	}{
+		{http.MethodPut, "/profile", "session", http.StatusOK}, // 会话token不限制范围
+		{http.MethodPut, "/profile", "bot", http.StatusOK},
+		{http.MethodGet, "/profile", "empty", http.StatusForbidden}, // 范围为空的个人访问token不能访问任何接口
+		{http.MethodGet, "/search", "empty", http.StatusForbidden},
+		{http.MethodGet, "/profile", "reader", http.StatusOK},
+		{http.MethodPut, "/profile", "reader", http.StatusForbidden},
+		{http.MethodPost, "/friends", "reader", http.StatusForbidden},
+		{http.MethodPost, "/friends", "friends", http.StatusOK},
+		{http.MethodGet, "/search", "", http.StatusOK},
+		{http.MethodGet, "/search", "friends", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if tt.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, tt.want, w.Code, "%s %s with %q", tt.method, tt.path, tt.token)
+	}
+}
@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// DataExportStatus 数据导出任务状态
+type DataExportStatus string
+
+const (
+	DataExportPending    DataExportStatus = "pending"    // 等待处理
+	DataExportProcessing DataExportStatus = "processing" // 处理中
+	DataExportCompleted  DataExportStatus = "completed"  // 已完成，可下载
+	DataExportFailed     DataExportStatus = "failed"     // 失败
+	DataExportExpired    DataExportStatus = "expired"    // 已过期，归档已删除
+)
+
+// DataExport 个人数据导出任务
+type DataExport struct {
+	ID          uint             `json:"id" gorm:"primarykey"`
+	UserID      uint             `json:"user_id" gorm:"not null;index;comment:用户ID"`
+	Status      DataExportStatus `json:"status" gorm:"type:varchar(20);default:'pending';index;comment:任务状态"`
+	FilePath    string           `json:"-" gorm:"size:500;comment:归档文件路径"`
+	FileSize    int64            `json:"file_size" gorm:"comment:归档文件大小"`
+	Error       string           `json:"error,omitempty" gorm:"size:500;comment:失败原因"`
+	CreatedAt   time.Time        `json:"created_at"`
+	CompletedAt *time.Time       `json:"completed_at" gorm:"comment:完成时间"`
+	ExpiresAt   *time.Time       `json:"expires_at" gorm:"index;comment:归档过期时间"`
+}
+
+// TableName 指定表名
+func (DataExport) TableName() string {
+	return "data_exports"
+}
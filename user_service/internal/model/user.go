@@ -9,8 +9,8 @@ import (
 // User 基础用户模型（引用Auth Service中的用户表）
 type User struct {
 	ID           uint           `json:"id" gorm:"primarykey"`
-	Phone        string         `json:"phone" gorm:"uniqueIndex;size:20;comment:手机号"`
-	Email        string         `json:"email" gorm:"uniqueIndex;size:255;comment:邮箱"`
+	Phone        string         `json:"phone" gorm:"uniqueIndex:idx_users_phone_set,where:phone <> '';size:20;comment:手机号"`
+	Email        string         `json:"email" gorm:"uniqueIndex:idx_users_email_set,where:email <> '';size:255;comment:邮箱"`
 	Username     string         `json:"username" gorm:"uniqueIndex;size:50;comment:用户名"`
 	PasswordHash string         `json:"-" gorm:"size:255;comment:密码哈希"`
 	AvatarURL    string         `json:"avatar_url" gorm:"size:500;comment:头像URL"`
@@ -71,6 +71,7 @@ func AutoMigrate() error {
 		&model.Friendship{},    // 好友关系表
 		&model.UserSetting{},   // 用户设置表
 		&model.BlockedUser{},   // 屏蔽用户表
+		&model.DataExport{},    // 数据导出任务表
 	)
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
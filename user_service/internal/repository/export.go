@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/jacl-coder/telegramlite/user_service/internal/model"
+)
+
+// UserDataSnapshot 用户在本服务中的全部数据
+type UserDataSnapshot struct {
+	Profile        *model.UserProfile
+	Settings       *model.UserSetting
+	Friendships    []model.Friendship
+	FriendRequests []model.FriendRequest
+	BlockedUsers   []model.BlockedUser
+}
+
+// ExportRepository 数据导出任务数据访问层
+type ExportRepository struct {
+	db *gorm.DB
+}
+
+// NewExportRepository 创建数据导出repository
+func NewExportRepository() *ExportRepository {
+	return &ExportRepository{
+		db: GetDB(),
+	}
+}
+
+// Create 创建导出任务
+func (r *ExportRepository) Create(export *model.DataExport) error {
+	return r.db.Create(export).Error
+}
+
+// GetByID 获取用户的导出任务
+func (r *ExportRepository) GetByID(userID, exportID uint) (*model.DataExport, error) {
+	var export model.DataExport
+	err := r.db.Where("id = ? AND user_id = ?", exportID, userID).First(&export).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+// Get 按ID获取导出任务
+func (r *ExportRepository) Get(exportID uint) (*model.DataExport, error) {
+	var export model.DataExport
+	err := r.db.First(&export, exportID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+// GetActiveByUser 获取用户未结束或未过期的最新导出任务
+func (r *ExportRepository) GetActiveByUser(userID uint) (*model.DataExport, error) {
+	var export model.DataExport
+	err := r.db.Where("user_id = ? AND status IN ?", userID, []model.DataExportStatus{
+		model.DataExportPending,
+		model.DataExportProcessing,
+		model.DataExportCompleted,
+	}).Order("id DESC").First(&export).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+// ListUnfinished 获取未完成的导出任务 (服务重启后重新处理)
+func (r *ExportRepository) ListUnfinished() ([]model.DataExport, error) {
+	var exports []model.DataExport
+	err := r.db.Where("status IN ?", []model.DataExportStatus{
+		model.DataExportPending,
+		model.DataExportProcessing,
+	}).Order("id").Find(&exports).Error
+	return exports, err
+}
+
+// MarkProcessing 将等待中的任务标记为处理中，任务已被处理时返回false
+func (r *ExportRepository) MarkProcessing(exportID uint) (bool, error) {
+	result := r.db.Model(&model.DataExport{}).
+		Where("id = ? AND status IN ?", exportID, []model.DataExportStatus{model.DataExportPending, model.DataExportProcessing}).
+		Update("status", model.DataExportProcessing)
+	return result.RowsAffected > 0, result.Error
+}
+
+// MarkCompleted 记录归档文件并标记任务完成
+func (r *ExportRepository) MarkCompleted(exportID uint, filePath string, fileSize int64, completedAt, expiresAt time.Time) error {
+	return r.db.Model(&model.DataExport{}).Where("id = ?", exportID).Updates(map[string]interface{}{
+		"status":       model.DataExportCompleted,
+		"file_path":    filePath,
+		"file_size":    fileSize,
+		"completed_at": completedAt,
+		"expires_at":   expiresAt,
+	}).Error
+}
+
+// MarkFailed 标记任务失败
+func (r *ExportRepository) MarkFailed(exportID uint, reason string) error {
+	return r.db.Model(&model.DataExport{}).Where("id = ?", exportID).Updates(map[string]interface{}{
+		"status": model.DataExportFailed,
+		"error":  reason,
+	}).Error
+}
+
+// ListExpired 获取归档已过期的任务
+func (r *ExportRepository) ListExpired(now time.Time) ([]model.DataExport, error) {
+	var exports []model.DataExport
+	err := r.db.Where("status = ? AND expires_at <= ?", model.DataExportCompleted, now).Find(&exports).Error
+	return exports, err
+}
+
+// MarkExpired 标记任务已过期
+func (r *ExportRepository) MarkExpired(exportID uint) error {
+	return r.db.Model(&model.DataExport{}).Where("id = ?", exportID).Updates(map[string]interface{}{
+		"status":    model.DataExportExpired,
+		"file_path": "",
+	}).Error
+}
+
+// ListByUser 获取用户的全部导出任务
+func (r *ExportRepository) ListByUser(userID uint) ([]model.DataExport, error) {
+	var exports []model.DataExport
+	err := r.db.Where("user_id = ?", userID).Find(&exports).Error
+	return exports, err
+}
+
+// DeleteByUser 删除用户的全部导出任务
+func (r *ExportRepository) DeleteByUser(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&model.DataExport{}).Error
+}
+
+// CollectUserData 收集用户的资料、设置、好友关系、好友请求和屏蔽列表
+func (r *ExportRepository) CollectUserData(userID uint) (*UserDataSnapshot, error) {
+	snapshot := &UserDataSnapshot{}
+
+	var profile model.UserProfile
+	err := r.db.Where("user_id = ?", userID).First(&profile).Error
+	if err == nil {
+		snapshot.Profile = &profile
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	var settings model.UserSetting
+	err = r.db.Where("user_id = ?", userID).First(&settings).Error
+	if err == nil {
+		snapshot.Settings = &settings
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if err := r.db.Where("user_id = ? OR friend_id = ?", userID, userID).Order("id").Find(&snapshot.Friendships).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("from_id = ? OR to_id = ?", userID, userID).Order("id").Find(&snapshot.FriendRequests).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).Order("id").Find(&snapshot.BlockedUsers).Error; err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
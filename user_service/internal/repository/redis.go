@@ -31,6 +31,9 @@ const (
 	// 搜索缓存键前缀
 	SearchResultKey = "search:users:%s" // search:users:keyword
 
+	// 已清除账号的同步游标
+	AccountPurgeCursorKey = "user:account_purge:cursor"
+
 	// 缓存过期时间
 	UserCacheTTL    = 30 * time.Minute // 用户信息缓存30分钟
 	OnlineCacheTTL  = 5 * time.Minute  // 在线状态缓存5分钟
@@ -381,6 +384,38 @@ func (r *UserCacheRepository) InvalidateUserCache(ctx context.Context, userID ui
 	return r.redis.Del(ctx, keys...).Err()
 }
 
+// InvalidateRelationCache 使用户的好友、好友请求和屏蔽缓存失效
+func (r *UserCacheRepository) InvalidateRelationCache(ctx context.Context, userIDs []uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(userIDs)*4)
+	for _, userID := range userIDs {
+		keys = append(keys,
+			fmt.Sprintf(FriendsListKey, userID),
+			fmt.Sprintf(FriendRequestKey, userID),
+			fmt.Sprintf(BlockedUsersKey, userID),
+			fmt.Sprintf("user:blocking:%d", userID),
+		)
+	}
+	return r.redis.Del(ctx, keys...).Err()
+}
+
+// GetAccountPurgeCursor 获取已清除账号的同步游标
+func (r *UserCacheRepository) GetAccountPurgeCursor(ctx context.Context) (string, error) {
+	cursor, err := r.redis.Get(ctx, AccountPurgeCursorKey).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return cursor, err
+}
+
+// SetAccountPurgeCursor 保存已清除账号的同步游标
+func (r *UserCacheRepository) SetAccountPurgeCursor(ctx context.Context, cursor string) error {
+	return r.redis.Set(ctx, AccountPurgeCursorKey, cursor, 0).Err()
+}
+
 // InvalidateFriendshipCache 使好友关系缓存失效
 func (r *UserCacheRepository) InvalidateFriendshipCache(ctx context.Context, userID1, userID2 uint) error {
 	keys := []string{
@@ -261,3 +261,54 @@ func (r *UserRepository) IsBlockedBy(userID, byUserID uint) (bool, error) {
 
 	return count > 0, err
 }
+
+// PurgeUserData 物理删除用户在本服务的全部数据 (资料、设置、好友关系、好友请求、屏蔽记录)
+// 返回与该用户有关联的其他用户ID，用于清理对方的缓存
+func (r *UserRepository) PurgeUserData(userID uint) ([]uint, error) {
+	relations := []struct {
+		table interface{}
+		own   string
+		other string
+	}{
+		{&model.Friendship{}, "user_id", "friend_id"},
+		{&model.FriendRequest{}, "from_id", "to_id"},
+		{&model.BlockedUser{}, "user_id", "blocked_id"},
+	}
+
+	seen := make(map[uint]bool)
+	var related []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, relation := range relations {
+			var ids []uint
+			if err := tx.Unscoped().Model(relation.table).Where(relation.own+" = ?", userID).Pluck(relation.other, &ids).Error; err != nil {
+				return err
+			}
+			var reverse []uint
+			if err := tx.Unscoped().Model(relation.table).Where(relation.other+" = ?", userID).Pluck(relation.own, &reverse).Error; err != nil {
+				return err
+			}
+			for _, id := range append(ids, reverse...) {
+				if id != userID && !seen[id] {
+					seen[id] = true
+					related = append(related, id)
+				}
+			}
+
+			err := tx.Unscoped().
+				Where(relation.own+" = ? OR "+relation.other+" = ?", userID, userID).
+				Delete(relation.table).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&model.UserProfile{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("user_id = ?", userID).Delete(&model.UserSetting{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return related, nil
+}
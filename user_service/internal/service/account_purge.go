@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	authpb "github.com/jacl-coder/telegramlite/auth_service/api/proto"
+	"github.com/jacl-coder/telegramlite/user_service/internal/repository"
+)
+
+const accountPurgePageSize = 100
+
+// AccountPurgeSource 已清除账号的来源 (Auth Service)
+type AccountPurgeSource interface {
+	GetAccountPurges(ctx context.Context, cursor string, limit int32) ([]*authpb.AccountPurge, string, error)
+}
+
+// AccountPurger 轮询Auth Service已清除的账号，删除其在本服务的全部数据
+// 同步游标保存在Redis中，未配置Redis时只保存在内存中，重启后从头同步 (清理操作是幂等的)
+type AccountPurger struct {
+	source    AccountPurgeSource
+	interval  time.Duration
+	userRepo  *repository.UserRepository
+	cacheRepo *repository.UserCacheRepository
+	exports   *ExportService
+	cursor    string
+}
+
+// NewAccountPurger 创建账号数据清理器，exports不为nil时同时删除用户的导出归档
+func NewAccountPurger(source AccountPurgeSource, interval time.Duration, exports *ExportService) *AccountPurger {
+	var cacheRepo *repository.UserCacheRepository
+	if redisClient := repository.GetRedis(); redisClient != nil {
+		cacheRepo = repository.NewUserCacheRepository(redisClient)
+	}
+
+	return &AccountPurger{
+		source:    source,
+		interval:  interval,
+		userRepo:  repository.NewUserRepository(),
+		cacheRepo: cacheRepo,
+		exports:   exports,
+	}
+}
+
+// Start 加载同步游标并启动后台同步
+func (p *AccountPurger) Start(ctx context.Context) {
+	if p.cacheRepo != nil {
+		cursor, err := p.cacheRepo.GetAccountPurgeCursor(ctx)
+		if err != nil {
+			p.logError("Failed to load account purge cursor", err)
+		}
+		p.cursor = cursor
+	}
+	go p.run(ctx)
+}
+
+// run 定期同步已清除的账号
+func (p *AccountPurger) run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Sync(ctx); err != nil {
+			p.logError("Failed to sync account purges", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync 拉取游标之后已清除的账号并删除其数据，返回处理的账号数
+func (p *AccountPurger) Sync(ctx context.Context) (int, error) {
+	processed := 0
+	for {
+		purges, _, err := p.source.GetAccountPurges(ctx, p.cursor, accountPurgePageSize)
+		if err != nil {
+			return processed, err
+		}
+
+		for _, purge := range purges {
+			if err := p.purgeUser(ctx, uint(purge.UserId)); err != nil {
+				return processed, err
+			}
+			processed++
+			p.saveCursor(ctx, purge.Id)
+		}
+
+		if len(purges) < accountPurgePageSize {
+			return processed, nil
+		}
+	}
+}
+
+// purgeUser 删除用户数据、导出归档并清理相关缓存
+func (p *AccountPurger) purgeUser(ctx context.Context, userID uint) error {
+	related, err := p.userRepo.PurgeUserData(userID)
+	if err != nil {
+		return err
+	}
+
+	if p.exports != nil {
+		if err := p.exports.DeleteUserExports(userID); err != nil {
+			return err
+		}
+	}
+
+	if p.cacheRepo != nil {
+		p.cacheRepo.InvalidateUserCache(ctx, userID)
+		p.cacheRepo.InvalidateRelationCache(ctx, append(related, userID))
+	}
+
+	if log := applogger.GetDefault(); log != nil {
+		log.Info("Purged data of deleted account", applogger.Fields{
+			"user_id":       userID,
+			"related_users": len(related),
+		})
+	}
+	return nil
+}
+
+// saveCursor 推进同步游标
+func (p *AccountPurger) saveCursor(ctx context.Context, cursor string) {
+	p.cursor = cursor
+	if p.cacheRepo == nil {
+		return
+	}
+	if err := p.cacheRepo.SetAccountPurgeCursor(ctx, cursor); err != nil {
+		p.logError("Failed to save account purge cursor", err)
+	}
+}
+
+func (p *AccountPurger) logError(message string, err error) {
+	if log := applogger.GetDefault(); log != nil {
+		log.Error(message, applogger.Fields{"error": err.Error()})
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authpb "github.com/jacl-coder/telegramlite/auth_service/api/proto"
+	"github.com/jacl-coder/telegramlite/user_service/internal/model"
+	"github.com/jacl-coder/telegramlite/user_service/internal/repository"
+)
+
+// fakePurgeSource 按游标返回预置的清除记录
+type fakePurgeSource struct {
+	purges  []*authpb.AccountPurge
+	cursors []string
+}
+
+func (f *fakePurgeSource) GetAccountPurges(ctx context.Context, cursor string, limit int32) ([]*authpb.AccountPurge, string, error) {
+	f.cursors = append(f.cursors, cursor)
+
+	var result []*authpb.AccountPurge
+	for _, purge := range f.purges {
+		if cursor == "" || purge.Id > cursor {
+			result = append(result, purge)
+		}
+		if len(result) == int(limit) {
+			break
+		}
+	}
+	if len(result) > 0 {
+		cursor = result[len(result)-1].Id
+	}
+	return result, cursor, nil
+}
+
+func TestAccountPurger_Sync(t *testing.T) {
+	testDB := setupTestDB(t)
+	originalDB := repository.DB
+	repository.DB = testDB
+	defer func() {
+		repository.DB = originalDB
+	}()
+
+	// 用户1将被清除，用户2、3与其存在好友、请求和屏蔽关系
+	testDB.Create(&model.UserProfile{UserID: 1, Nickname: "deleted"})
+	testDB.Create(&model.UserProfile{UserID: 2, Nickname: "friend"})
+	testDB.Create(&model.UserSetting{UserID: 1})
+	testDB.Create(&model.UserSetting{UserID: 2})
+	testDB.Create(&model.Friendship{UserID: 1, FriendID: 2, Status: model.FriendshipAccepted})
+	testDB.Create(&model.Friendship{UserID: 2, FriendID: 1, Status: model.FriendshipAccepted})
+	testDB.Create(&model.Friendship{UserID: 2, FriendID: 3, Status: model.FriendshipAccepted})
+	testDB.Create(&model.FriendRequest{FromID: 3, ToID: 1})
+	testDB.Create(&model.BlockedUser{UserID: 3, BlockedID: 1})
+	blocked := &model.BlockedUser{UserID: 1, BlockedID: 2}
+	testDB.Create(blocked)
+	testDB.Delete(blocked) // 软删除的记录同样清除
+
+	related, err := repository.NewUserRepository().PurgeUserData(1)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uint{2, 3}, related)
+
+	count := func(value interface{}, query string, args ...interface{}) int64 {
+		var n int64
+		testDB.Unscoped().Model(value).Where(query, args...).Count(&n)
+		return n
+	}
+	assert.Zero(t, count(&model.UserProfile{}, "user_id = ?", 1))
+	assert.Zero(t, count(&model.UserSetting{}, "user_id = ?", 1))
+	assert.Zero(t, count(&model.Friendship{}, "user_id = ? OR friend_id = ?", 1, 1))
+	assert.Zero(t, count(&model.FriendRequest{}, "from_id = ? OR to_id = ?", 1, 1))
+	assert.Zero(t, count(&model.BlockedUser{}, "user_id = ? OR blocked_id = ?", 1, 1))
+
+	// 其他用户的数据保持不变
+	assert.Equal(t, int64(1), count(&model.UserProfile{}, "user_id = ?", 2))
+	assert.Equal(t, int64(1), count(&model.Friendship{}, "user_id = ? AND friend_id = ?", 2, 3))
+
+	// 清理器按游标分页同步，重复清除是幂等的
+	source := &fakePurgeSource{}
+	for i := 1; i <= accountPurgePageSize+1; i++ {
+		source.purges = append(source.purges, &authpb.AccountPurge{
+			Id:     strconv.Itoa(100000 + i),
+			UserId: uint64(i),
+		})
+	}
+	purger := NewAccountPurger(source, 0, nil)
+
+	processed, err := purger.Sync(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, accountPurgePageSize+1, processed)
+	assert.Equal(t, []string{"", "100100"}, source.cursors)
+	assert.Equal(t, "100101", purger.cursor)
+
+	processed, err = purger.Sync(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, processed)
+}
@@ -0,0 +1,333 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	applogger "github.com/jacl-coder/TelegramLite/common/go/logger"
+	"github.com/jacl-coder/telegramlite/user_service/internal/model"
+	"github.com/jacl-coder/telegramlite/user_service/internal/repository"
+)
+
+// DataExportFormatVersion 导出归档格式版本，文件结构有不兼容变更时递增
+const DataExportFormatVersion = 1
+
+const (
+	exportQueueSize     = 100
+	exportSweepInterval = time.Minute
+)
+
+var (
+	ErrExportNotFound = errors.New("export not found")
+	ErrExportNotReady = errors.New("export is not ready")
+)
+
+// UserDataSource 用户在Auth Service中的数据来源
+type UserDataSource interface {
+	ExportUserData(ctx context.Context, userID uint) ([]byte, error)
+}
+
+// DataExportManifest 归档清单
+type DataExportManifest struct {
+	FormatVersion int       `json:"format_version"`
+	UserID        uint      `json:"user_id"`
+	GeneratedAt   time.Time `json:"generated_at"`
+	Files         []string  `json:"files"`
+}
+
+// ExportService 个人数据导出服务
+// 导出任务异步处理，生成的zip归档保存在本地存储目录中，过期后删除
+type ExportService struct {
+	exportRepo *repository.ExportRepository
+	source     UserDataSource
+	storageDir string
+	ttl        time.Duration
+	queue      chan uint
+}
+
+// NewExportService 创建数据导出服务
+func NewExportService(source UserDataSource, storageDir string, ttl time.Duration) *ExportService {
+	return &ExportService{
+		exportRepo: repository.NewExportRepository(),
+		source:     source,
+		storageDir: storageDir,
+		ttl:        ttl,
+		queue:      make(chan uint, exportQueueSize),
+	}
+}
+
+// RequestExport 创建导出任务，已有进行中或未过期的任务时直接返回该任务
+func (s *ExportService) RequestExport(userID uint) (*model.DataExport, error) {
+	if userID == 0 {
+		return nil, errors.New("user ID cannot be zero")
+	}
+
+	existing, err := s.exportRepo.GetActiveByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get export: %w", err)
+	}
+	if existing != nil && !isExportExpired(existing, time.Now()) {
+		return existing, nil
+	}
+
+	export := &model.DataExport{UserID: userID, Status: model.DataExportPending}
+	if err := s.exportRepo.Create(export); err != nil {
+		return nil, fmt.Errorf("failed to create export: %w", err)
+	}
+
+	// 队列已满时任务保持pending，由定期扫描处理
+	select {
+	case s.queue <- export.ID:
+	default:
+	}
+	return export, nil
+}
+
+// GetExport 获取导出任务状态
+func (s *ExportService) GetExport(userID, exportID uint) (*model.DataExport, error) {
+	export, err := s.exportRepo.GetByID(userID, exportID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get export: %w", err)
+	}
+	if export == nil {
+		return nil, ErrExportNotFound
+	}
+	if isExportExpired(export, time.Now()) {
+		export.Status = model.DataExportExpired
+	}
+	return export, nil
+}
+
+// OpenExport 获取可下载的归档文件路径
+func (s *ExportService) OpenExport(userID, exportID uint) (*model.DataExport, string, error) {
+	export, err := s.GetExport(userID, exportID)
+	if err != nil {
+		return nil, "", err
+	}
+	if export.Status != model.DataExportCompleted {
+		return nil, "", ErrExportNotReady
+	}
+	return export, export.FilePath, nil
+}
+
+// Start 重新处理未完成的任务并启动后台worker
+func (s *ExportService) Start(ctx context.Context) {
+	go s.run(ctx)
+}
+
+// run 处理队列中的任务，并定期扫描遗留任务和过期归档
+func (s *ExportService) run(ctx context.Context) {
+	ticker := time.NewTicker(exportSweepInterval)
+	defer ticker.Stop()
+
+	s.sweep(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case exportID := <-s.queue:
+			if err := s.Process(ctx, exportID); err != nil {
+				s.logError("Failed to process data export", err)
+			}
+		case <-ticker.C:
+			s.sweep(ctx)
+		}
+	}
+}
+
+// sweep 处理未完成的任务并清理过期归档
+func (s *ExportService) sweep(ctx context.Context) {
+	exports, err := s.exportRepo.ListUnfinished()
+	if err != nil {
+		s.logError("Failed to list unfinished data exports", err)
+	}
+	for _, export := range exports {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := s.Process(ctx, export.ID); err != nil {
+			s.logError("Failed to process data export", err)
+		}
+	}
+
+	if _, err := s.CleanupExpired(time.Now()); err != nil {
+		s.logError("Failed to clean up expired data exports", err)
+	}
+}
+
+// Process 生成导出归档，失败时记录失败原因
+func (s *ExportService) Process(ctx context.Context, exportID uint) error {
+	claimed, err := s.exportRepo.MarkProcessing(exportID)
+	if err != nil || !claimed {
+		return err
+	}
+
+	export, err := s.exportRepo.Get(exportID)
+	if err != nil || export == nil {
+		return err
+	}
+
+	filePath, size, err := s.writeArchive(ctx, export)
+	if err != nil {
+		if markErr := s.exportRepo.MarkFailed(exportID, err.Error()); markErr != nil {
+			return markErr
+		}
+		return err
+	}
+
+	now := time.Now()
+	if err := s.exportRepo.MarkCompleted(exportID, filePath, size, now, now.Add(s.ttl)); err != nil {
+		os.Remove(filePath)
+		return err
+	}
+
+	if log := applogger.GetDefault(); log != nil {
+		log.Info("Data export completed", applogger.Fields{
+			"export_id": exportID,
+			"user_id":   export.UserID,
+			"size":      size,
+		})
+	}
+	return nil
+}
+
+// writeArchive 收集用户数据并写入zip归档，先写临时文件再重命名
+func (s *ExportService) writeArchive(ctx context.Context, export *model.DataExport) (string, int64, error) {
+	authData, err := s.source.ExportUserData(ctx, export.UserID)
+	if err != nil {
+		return "", 0, err
+	}
+	snapshot, err := s.exportRepo.CollectUserData(export.UserID)
+	if err != nil {
+		return "", 0, err
+	}
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"auth.json", json.RawMessage(authData)},
+		{"profile.json", snapshot.Profile},
+		{"settings.json", snapshot.Settings},
+		{"friendships.json", snapshot.Friendships},
+		{"friend_requests.json", snapshot.FriendRequests},
+		{"blocked_users.json", snapshot.BlockedUsers},
+	}
+
+	manifest := DataExportManifest{
+		FormatVersion: DataExportFormatVersion,
+		UserID:        export.UserID,
+		GeneratedAt:   time.Now(),
+	}
+	for _, file := range files {
+		manifest.Files = append(manifest.Files, file.name)
+	}
+
+	dir := filepath.Join(s.storageDir, strconv.FormatUint(uint64(export.UserID), 10))
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", 0, err
+	}
+	tmp, err := os.CreateTemp(dir, "export-*.tmp")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	archive := zip.NewWriter(tmp)
+	if err := writeJSONFile(archive, "manifest.json", manifest); err != nil {
+		return "", 0, err
+	}
+	for _, file := range files {
+		if err := writeJSONFile(archive, file.name, file.data); err != nil {
+			return "", 0, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return "", 0, err
+	}
+
+	info, err := tmp.Stat()
+	if err != nil {
+		return "", 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", 0, err
+	}
+
+	filePath := filepath.Join(dir, fmt.Sprintf("export-%d.zip", export.ID))
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		return "", 0, err
+	}
+	return filePath, info.Size(), nil
+}
+
+// CleanupExpired 删除过期归档，返回清理的任务数
+func (s *ExportService) CleanupExpired(now time.Time) (int, error) {
+	exports, err := s.exportRepo.ListExpired(now)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, export := range exports {
+		if err := removeExportFile(export.FilePath); err != nil {
+			return i, err
+		}
+		if err := s.exportRepo.MarkExpired(export.ID); err != nil {
+			return i, err
+		}
+	}
+	return len(exports), nil
+}
+
+// DeleteUserExports 删除用户的全部导出任务和归档 (账号清除时调用)
+func (s *ExportService) DeleteUserExports(userID uint) error {
+	exports, err := s.exportRepo.ListByUser(userID)
+	if err != nil {
+		return err
+	}
+	for _, export := range exports {
+		if err := removeExportFile(export.FilePath); err != nil {
+			return err
+		}
+	}
+	return s.exportRepo.DeleteByUser(userID)
+}
+
+func (s *ExportService) logError(message string, err error) {
+	if log := applogger.GetDefault(); log != nil {
+		log.Error(message, applogger.Fields{"error": err.Error()})
+	}
+}
+
+// isExportExpired 归档是否已过期 (清理任务尚未执行时同样视为过期)
+func isExportExpired(export *model.DataExport, now time.Time) bool {
+	return export.Status == model.DataExportCompleted && export.ExpiresAt != nil && !export.ExpiresAt.After(now)
+}
+
+func writeJSONFile(archive *zip.Writer, name string, data interface{}) error {
+	w, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+func removeExportFile(path string) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jacl-coder/telegramlite/user_service/internal/model"
+	"github.com/jacl-coder/telegramlite/user_service/internal/repository"
+)
+
+// fakeUserDataSource 返回预置的Auth Service导出数据
+type fakeUserDataSource struct {
+	data []byte
+	err  error
+}
+
+func (f *fakeUserDataSource) ExportUserData(ctx context.Context, userID uint) ([]byte, error) {
+	return f.data, f.err
+}
+
+// readArchive 读取zip归档中的全部文件
+func readArchive(t *testing.T, path string) map[string][]byte {
+	reader, err := zip.OpenReader(path)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	files := make(map[string][]byte)
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+		files[file.Name] = data
+	}
+	return files
+}
+
+func TestExportService_Process(t *testing.T) {
+	testDB := setupTestDB(t)
+	originalDB := repository.DB
+	repository.DB = testDB
+	defer func() {
+		repository.DB = originalDB
+	}()
+
+	testDB.Create(&model.UserProfile{UserID: 1, Nickname: "alice"})
+	testDB.Create(&model.UserSetting{UserID: 1})
+	testDB.Create(&model.Friendship{UserID: 1, FriendID: 2, Status: model.FriendshipAccepted})
+	testDB.Create(&model.FriendRequest{FromID: 3, ToID: 1})
+	testDB.Create(&model.BlockedUser{UserID: 1, BlockedID: 4})
+	testDB.Create(&model.BlockedUser{UserID: 4, BlockedID: 1}) // 他人的屏蔽列表不导出
+
+	source := &fakeUserDataSource{data: []byte(`{"format_version":1,"account":{"id":1}}`)}
+	exportService := NewExportService(source, t.TempDir(), time.Hour)
+
+	export, err := exportService.RequestExport(1)
+	require.NoError(t, err)
+	assert.Equal(t, model.DataExportPending, export.Status)
+
+	// 已有进行中的任务时不重复创建
+	again, err := exportService.RequestExport(1)
+	require.NoError(t, err)
+	assert.Equal(t, export.ID, again.ID)
+
+	_, _, err = exportService.OpenExport(1, export.ID)
+	assert.ErrorIs(t, err, ErrExportNotReady)
+
+	require.NoError(t, exportService.Process(context.Background(), export.ID))
+
+	// 其他用户不能访问
+	_, err = exportService.GetExport(2, export.ID)
+	assert.ErrorIs(t, err, ErrExportNotFound)
+
+	completed, path, err := exportService.OpenExport(1, export.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.DataExportCompleted, completed.Status)
+	assert.NotZero(t, completed.FileSize)
+
+	files := readArchive(t, path)
+	var manifest DataExportManifest
+	require.NoError(t, json.Unmarshal(files["manifest.json"], &manifest))
+	assert.Equal(t, DataExportFormatVersion, manifest.FormatVersion)
+	assert.Equal(t, uint(1), manifest.UserID)
+	for _, name := range manifest.Files {
+		assert.Contains(t, files, name)
+	}
+	assert.JSONEq(t, string(source.data), string(files["auth.json"]))
+
+	var profile model.UserProfile
+	require.NoError(t, json.Unmarshal(files["profile.json"], &profile))
+	assert.Equal(t, "alice", profile.Nickname)
+
+	var blocked []model.BlockedUser
+	require.NoError(t, json.Unmarshal(files["blocked_users.json"], &blocked))
+	require.Len(t, blocked, 1)
+	assert.Equal(t, uint(4), blocked[0].BlockedID)
+
+	// 过期后删除归档，再次请求创建新任务
+	cleaned, err := exportService.CleanupExpired(time.Now().Add(2 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, cleaned)
+	_, err = os.Stat(path)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	expired, err := exportService.GetExport(1, export.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.DataExportExpired, expired.Status)
+
+	next, err := exportService.RequestExport(1)
+	require.NoError(t, err)
+	assert.NotEqual(t, export.ID, next.ID)
+
+	// Auth Service不可用时任务失败
+	source.err = errors.New("auth service unavailable")
+	assert.Error(t, exportService.Process(context.Background(), next.ID))
+	failed, err := exportService.GetExport(1, next.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.DataExportFailed, failed.Status)
+
+	// 账号清除时删除全部导出任务
+	require.NoError(t, exportService.DeleteUserExports(1))
+	_, err = exportService.GetExport(1, next.ID)
+	assert.ErrorIs(t, err, ErrExportNotFound)
+}
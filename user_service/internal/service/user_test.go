@@ -32,6 +32,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		&model.Friendship{},
 		&model.UserSetting{},
 		&model.BlockedUser{},
+		&model.DataExport{},
 	)
 	if err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)